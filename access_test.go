@@ -0,0 +1,51 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLevel(t *testing.T) {
+	assert.True(t, AccessInstaller.Allows(AccessUser))
+	assert.True(t, AccessAfterSales.Allows(AccessInstaller))
+	assert.False(t, AccessInstaller.Allows(AccessManufacturer))
+	assert.False(t, AccessUser.Allows(AccessInstaller))
+	assert.Equal(t, "installer", AccessInstaller.String())
+
+	l, err := ParseAccessLevel("After-Sales")
+	require.NoError(t, err)
+	assert.Equal(t, AccessAfterSales, l)
+	_, err = ParseAccessLevel("root")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestClient_Unlock(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+	require.NoError(t, c.Connect())
+
+	pm := NewParameterMap()
+	require.NoError(t, c.ReadParameters(pm))
+	assert.Equal(t, AccessInstaller, pm[ParamEinstBWSHystAkt].Access())
+
+	err := c.WriteParameter(pm, ParamEinstBWSHystAkt, 5)
+	assert.ErrorIs(t, err, ErrInsufficientAccess)
+	assert.EqualError(t, err, "WriteParameter ID_Einst_BWS_Hyst_akt needs access level installer, have user, see Client.Unlock: insufficient access level")
+	err = c.WriteParameterRaw(pm, ParamEinstBWSHystAkt, 50)
+	assert.ErrorIs(t, err, ErrInsufficientAccess)
+
+	require.NoError(t, c.Unlock(context.Background(), pm, AccessInstaller))
+	require.NoError(t, c.WriteParameter(pm, ParamEinstBWSHystAkt, 5))
+
+	hp.mu.Lock()
+	assert.Equal(t, uint32(AccessInstaller), hp.parameters[ParamAccessLevel])
+	assert.Equal(t, uint32(50), hp.parameters[ParamEinstBWSHystAkt])
+	hp.mu.Unlock()
+
+	s := &Snapshot{Calculations: NewCalculationsMap(), Parameters: pm}
+	assert.Equal(t, AccessInstaller, s.DeviceInfo().AccessLevel)
+}
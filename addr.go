@@ -0,0 +1,53 @@
+package luxtronik
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// NormalizeAddr returns s as host:port for NewClient. It accepts a bare IP
+// address or host name, which get DefaultPort, host:port and IPv6 addresses
+// with or without brackets, e.g. fe80::1, [fe80::1] or [fe80::1]:8889.
+func NormalizeAddr(s string) (string, error) {
+	return normalizeAddr(s, DefaultPort)
+}
+
+// normalizeAddr is NormalizeAddr with another default port, e.g.
+// WebSocketPort.
+func normalizeAddr(s, defaultPort string) (string, error) {
+	s = strings.TrimSpace(s)
+	host, port := s, defaultPort
+	switch {
+	case s == "":
+		return "", fmt.Errorf("NormalizeAddr: empty address: %w", ErrInvalidAddress)
+	case strings.Contains(s, "://"):
+		return "", fmt.Errorf("NormalizeAddr %q: want host[:port] without scheme: %w", s, ErrInvalidAddress)
+	case strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]"):
+		host = s[1 : len(s)-1]
+	case strings.HasPrefix(s, "["), strings.Count(s, ":") == 1:
+		var err error
+		if host, port, err = net.SplitHostPort(s); err != nil {
+			return "", fmt.Errorf("NormalizeAddr %q: %w: %w", s, ErrInvalidAddress, err)
+		}
+	}
+
+	if host == "" {
+		return "", fmt.Errorf("NormalizeAddr %q: missing host: %w", s, ErrInvalidAddress)
+	}
+	if strings.Contains(host, ":") {
+		if _, err := netip.ParseAddr(host); err != nil {
+			return "", fmt.Errorf("NormalizeAddr %q: invalid IPv6 address: %w", s, ErrInvalidAddress)
+		}
+	} else if i := strings.IndexFunc(host, func(r rune) bool {
+		return !(r == '.' || r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}); i >= 0 {
+		return "", fmt.Errorf("NormalizeAddr %q: invalid character %q in host: %w", s, host[i], ErrInvalidAddress)
+	}
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		return "", fmt.Errorf("NormalizeAddr %q: port %q is no number from 1 to 65535: %w", s, port, ErrInvalidAddress)
+	}
+	return net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,39 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAddr(t *testing.T) {
+	tests := []struct {
+		in, want, err string
+	}{
+		{in: "192.168.0.121", want: "192.168.0.121:8889"},
+		{in: " 192.168.0.121:9000 ", want: "192.168.0.121:9000"},
+		{in: "heatpump.local", want: "heatpump.local:8889"},
+		{in: "fe80::1", want: "[fe80::1]:8889"},
+		{in: "[fe80::1]", want: "[fe80::1]:8889"},
+		{in: "[fe80::1%eth0]:9000", want: "[fe80::1%eth0]:9000"},
+		{in: "", err: "empty address"},
+		{in: ":8889", err: "missing host"},
+		{in: "192.168.0.121:", err: `port "" is no number`},
+		{in: "192.168.0.121:http", err: `port "http" is no number`},
+		{in: "192.168.0.121:70000", err: `port "70000" is no number`},
+		{in: "[fe80::1", err: "missing ']'"},
+		{in: "fe80::zz", err: "invalid IPv6 address"},
+		{in: "http://192.168.0.121", err: "without scheme"},
+		{in: "heat/pump", err: "invalid character '/'"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeAddr(tt.in)
+		if tt.err != "" {
+			assert.ErrorIs(t, err, ErrInvalidAddress, tt.in)
+			assert.ErrorContains(t, err, tt.err, tt.in)
+			continue
+		}
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
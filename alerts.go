@@ -0,0 +1,394 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// AlertRule fires when a value fulfills the condition for the duration For.
+// Value is the luxtronik name or index of the value, Block restricts it to
+// one block. Op is one of <, <=, >, >=, == and !=. Firing rules of < and >
+// resolve once the value is back beyond the threshold by Hysteresis.
+// Selections and strings compare their raw value, durations their seconds.
+//
+// And adds conditions on further values, e.g. of BlockSensors, which are
+// compared with their last known value. The rule resolves as soon as one of
+// them no longer holds.
+type AlertRule struct {
+	Name       string           `yaml:"name"`
+	Block      string           `yaml:"block"`
+	Value      string           `yaml:"value"`
+	Op         string           `yaml:"op"`
+	Threshold  float64          `yaml:"threshold"`
+	For        time.Duration    `yaml:"for"`
+	Hysteresis float64          `yaml:"hysteresis"`
+	And        []AlertCondition `yaml:"and"`
+}
+
+// AlertCondition is an additional condition of an AlertRule.
+type AlertCondition struct {
+	Block     string  `yaml:"block"`
+	Value     string  `yaml:"value"`
+	Op        string  `yaml:"op"`
+	Threshold float64 `yaml:"threshold"`
+}
+
+func (c AlertCondition) String() string {
+	return fmt.Sprintf("%s %s %s", c.Value, c.Op, strconv.FormatFloat(c.Threshold, 'f', -1, 64))
+}
+
+var alertOps = map[string]func(v, t float64) bool{
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// ParseAlertRule parses rules like
+//
+//	low brine: ID_WEB_LIN_ND < 0.5 for 5m hysteresis 0.2
+//	ID_WEB_ERROR_Nr0 != 0
+//	cold room: ID_WEB_Temperatur_TVL >= 45 and Room_living_room_Temperature < 19 for 2h
+//
+// The name before the colon is optional and defaults to the condition.
+func ParseAlertRule(s string) (AlertRule, error) {
+	var r AlertRule
+	expr := s
+	if name, rest, ok := strings.Cut(s, ":"); ok {
+		r.Name, expr = strings.TrimSpace(name), rest
+	}
+	f := strings.Fields(expr)
+	if len(f) < 3 {
+		return r, fmt.Errorf("ParseAlertRule invalid rule %q, want [name:] value op threshold [and value op threshold] [for duration] [hysteresis delta]", s)
+	}
+	r.Value, r.Op = f[0], f[1]
+	var err error
+	if r.Threshold, err = strconv.ParseFloat(f[2], 64); err != nil {
+		return r, fmt.Errorf("ParseAlertRule invalid threshold in %q: %w", s, err)
+	}
+	for i := 3; i < len(f); {
+		n := 2
+		if f[i] == "and" {
+			n = 4
+		}
+		if i+n > len(f) {
+			return r, fmt.Errorf("ParseAlertRule invalid rule %q: incomplete option %q", s, f[i])
+		}
+		switch f[i] {
+		case "for":
+			r.For, err = time.ParseDuration(f[i+1])
+		case "hysteresis":
+			r.Hysteresis, err = strconv.ParseFloat(f[i+1], 64)
+		case "and":
+			c := AlertCondition{Value: f[i+1], Op: f[i+2]}
+			c.Threshold, err = strconv.ParseFloat(f[i+3], 64)
+			r.And = append(r.And, c)
+		default:
+			err = fmt.Errorf("unknown option %q", f[i])
+		}
+		if err != nil {
+			return r, fmt.Errorf("ParseAlertRule invalid rule %q: %w", s, err)
+		}
+		i += n
+	}
+	return r, r.validate()
+}
+
+// AlertConfig is the content of an alert config file like
+//
+//	rules:
+//	  - name: low brine
+//	    value: ID_WEB_LIN_ND
+//	    op: "<"
+//	    threshold: 0.5
+//	    for: 5m
+//	notifiers:
+//	  - type: telegram
+//	    token: 123456:ABC
+//	    chat_id: "42"
+//
+// A file with just a list of rules is accepted as well.
+type AlertConfig struct {
+	Rules     []AlertRule      `yaml:"rules"`
+	Notifiers []NotifierConfig `yaml:"notifiers"`
+}
+
+// LoadAlertConfig reads and validates an alert config file.
+func LoadAlertConfig(path string) (AlertConfig, error) {
+	var cfg AlertConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("LoadAlertConfig: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return cfg, fmt.Errorf("LoadAlertConfig %s: %w", path, err)
+	}
+	if len(doc.Content) > 0 {
+		if doc.Content[0].Kind == yaml.SequenceNode {
+			err = doc.Decode(&cfg.Rules)
+		} else {
+			err = doc.Decode(&cfg)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("LoadAlertConfig %s: %w", path, err)
+		}
+	}
+	for i, r := range cfg.Rules {
+		if err := r.validate(); err != nil {
+			return cfg, fmt.Errorf("LoadAlertConfig %s rule %d: %w", path, i+1, err)
+		}
+	}
+	return cfg, nil
+}
+
+func (r AlertRule) validate() error {
+	if r.Value == "" {
+		return fmt.Errorf("alert rule %q: missing value", r.Name)
+	}
+	if _, ok := alertOps[r.Op]; !ok {
+		return fmt.Errorf("alert rule %q: unknown operator %q", r.Name, r.Op)
+	}
+	if r.For < 0 || r.Hysteresis < 0 {
+		return fmt.Errorf("alert rule %q: negative duration or hysteresis", r.Name)
+	}
+	for _, c := range r.And {
+		if c.Value == "" {
+			return fmt.Errorf("alert rule %q: missing value of a condition", r.Name)
+		}
+		if _, ok := alertOps[c.Op]; !ok {
+			return fmt.Errorf("alert rule %q: unknown operator %q", r.Name, c.Op)
+		}
+	}
+	return nil
+}
+
+// conditions returns the condition of the rule followed by And.
+func (r AlertRule) conditions() []AlertCondition {
+	return append([]AlertCondition{{Block: r.Block, Value: r.Value, Op: r.Op, Threshold: r.Threshold}}, r.And...)
+}
+
+// String returns the name or the condition of the rule.
+func (r AlertRule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	s := fmt.Sprintf("%s %s %s", r.Value, r.Op, strconv.FormatFloat(r.Threshold, 'f', -1, 64))
+	for _, c := range r.And {
+		s += " and " + c.String()
+	}
+	if r.For > 0 {
+		s += " for " + r.For.String()
+	}
+	return s
+}
+
+// resolved reports whether a firing rule is no longer fulfilled, respecting
+// the hysteresis.
+func (r AlertRule) resolved(v float64) bool {
+	switch r.Op {
+	case "<", "<=":
+		return v >= r.Threshold+r.Hysteresis && !alertOps[r.Op](v, r.Threshold)
+	case ">", ">=":
+		return v <= r.Threshold-r.Hysteresis && !alertOps[r.Op](v, r.Threshold)
+	}
+	return !alertOps[r.Op](v, r.Threshold)
+}
+
+// AlertEvent reports that a rule started firing or got resolved.
+type AlertEvent struct {
+	Rule  AlertRule
+	Host  string
+	Value float64
+	// Text is the value as shown for codes and strings, e.g. the message of
+	// an ErrorCode, empty for numbers.
+	Text     string
+	Time     time.Time
+	Since    time.Time // start of the condition
+	Resolved bool
+}
+
+func (e AlertEvent) String() string {
+	state := "firing"
+	if e.Resolved {
+		state = "resolved"
+	}
+	value := e.Text
+	if value == "" {
+		value = strconv.FormatFloat(e.Value, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%s %s: %s (value %s)", e.Host, state, e.Rule, value)
+}
+
+type AlertOptions struct {
+	Rules []AlertRule
+	// Notify receives the events, they are logged in any case.
+	Notify func(AlertEvent)
+	Logger *zap.Logger
+}
+
+// AlertEngine is a Sink which evaluates the rules on every poll of every
+// heat pump. Rules are evaluated once all their values have been seen, the
+// conditions of And may refer to other blocks like BlockSensors.
+type AlertEngine struct {
+	opts AlertOptions
+
+	mu     sync.Mutex
+	states map[alertKey]*alertState
+}
+
+type alertKey struct {
+	host string
+	rule int
+}
+
+type alertState struct {
+	since  time.Time // zero while the condition is not fulfilled
+	firing bool
+	// values of the conditions, the first one is the value of the rule
+	values []float64
+	seen   []bool
+	text   string
+}
+
+// complete reports whether all values of the conditions are known.
+func (st *alertState) complete() bool {
+	return !slices.Contains(st.seen, false)
+}
+
+func NewAlertEngine(opts AlertOptions) *AlertEngine {
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	return &AlertEngine{opts: opts, states: map[alertKey]*alertState{}}
+}
+
+func (a *AlertEngine) Write(_ context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, r := range a.opts.Rules {
+		conds := r.conditions()
+		var st *alertState
+		for j, c := range conds {
+			if c.Block != "" && c.Block != block {
+				continue
+			}
+			_, b, ok := pm.Lookup(c.Value)
+			if !ok {
+				continue
+			}
+			v, text, ok := alertValue(b)
+			if !ok {
+				continue
+			}
+			key := alertKey{host, i}
+			if st = a.states[key]; st == nil {
+				st = &alertState{values: make([]float64, len(conds)), seen: make([]bool, len(conds))}
+				a.states[key] = st
+			}
+			st.values[j], st.seen[j] = v, true
+			if j == 0 {
+				st.text = text
+			}
+		}
+		if st != nil && st.complete() {
+			a.evaluate(host, ts, r, st)
+		}
+	}
+	return nil
+}
+
+func (a *AlertEngine) evaluate(host string, ts time.Time, r AlertRule, st *alertState) {
+	v := st.values[0]
+	holds := true
+	for j, c := range r.And {
+		holds = holds && alertOps[c.Op](st.values[j+1], c.Threshold)
+	}
+	if st.firing {
+		if r.resolved(v) || !holds {
+			a.emit(AlertEvent{Rule: r, Host: host, Value: v, Text: st.text, Time: ts, Since: st.since, Resolved: true})
+			st.firing, st.since = false, time.Time{}
+		}
+		return
+	}
+	if !holds || !alertOps[r.Op](v, r.Threshold) {
+		st.since = time.Time{}
+		return
+	}
+	if st.since.IsZero() {
+		st.since = ts
+	}
+	if ts.Sub(st.since) >= r.For {
+		st.firing = true
+		a.emit(AlertEvent{Rule: r, Host: host, Value: v, Text: st.text, Time: ts, Since: st.since})
+	}
+}
+
+func (a *AlertEngine) emit(e AlertEvent) {
+	if e.Resolved {
+		a.opts.Logger.Info("alert resolved", zap.String("host", e.Host), zap.Stringer("rule", e.Rule), zap.Float64("value", e.Value))
+	} else {
+		a.opts.Logger.Warn("alert firing", zap.String("host", e.Host), zap.Stringer("rule", e.Rule),
+			zap.Float64("value", e.Value), zap.Time("since", e.Since))
+	}
+	if a.opts.Notify != nil {
+		a.opts.Notify(e)
+	}
+}
+
+// Firing returns the currently firing rules, sorted by host and rule.
+func (a *AlertEngine) Firing() []AlertEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var keys []alertKey
+	for key, st := range a.states {
+		if st.firing {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].host != keys[j].host {
+			return keys[i].host < keys[j].host
+		}
+		return keys[i].rule < keys[j].rule
+	})
+	res := make([]AlertEvent, 0, len(keys))
+	for _, key := range keys {
+		st := a.states[key]
+		res = append(res, AlertEvent{Rule: a.opts.Rules[key.rule], Host: key.host, Value: st.values[0], Text: st.text, Since: st.since})
+	}
+	return res
+}
+
+// alertValue converts the value of b into a number for the comparison and
+// the text of codes and strings for the events.
+func alertValue(b *Base) (float64, string, bool) {
+	switch v := b.FromHeatPump().(type) {
+	case time.Duration:
+		return v.Seconds(), "", true
+	case string, fmt.Stringer:
+		// codes, e.g. HeatingMode or ErrorCode, and times compare by their
+		// raw value
+		return float64(b.reading.Raw), FormatValue(v), true
+	case float32:
+		return float64(v), "", true
+	default:
+		f, err := cast.ToFloat64E(v)
+		return f, "", err == nil
+	}
+}
+
+func (a *AlertEngine) Close() error { return nil }
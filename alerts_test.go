@@ -0,0 +1,173 @@
+package luxtronik
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlertRule(t *testing.T) {
+	r, err := ParseAlertRule("low brine: ID_WEB_LIN_ND < 0.5 for 5m hysteresis 0.2")
+	require.NoError(t, err)
+	assert.Equal(t, AlertRule{
+		Name:       "low brine",
+		Value:      "ID_WEB_LIN_ND",
+		Op:         "<",
+		Threshold:  0.5,
+		For:        5 * time.Minute,
+		Hysteresis: 0.2,
+	}, r)
+
+	r, err = ParseAlertRule("ID_WEB_ERROR_Nr0 != 0")
+	require.NoError(t, err)
+	assert.Equal(t, "ID_WEB_ERROR_Nr0 != 0", r.String())
+
+	r, err = ParseAlertRule("ID_WEB_Temperatur_TVL >= 45 and Room_living_room_Temperature < 19 for 2h")
+	require.NoError(t, err)
+	assert.Equal(t, []AlertCondition{{Value: "Room_living_room_Temperature", Op: "<", Threshold: 19}}, r.And)
+	assert.Equal(t, "ID_WEB_Temperatur_TVL >= 45 and Room_living_room_Temperature < 19 for 2h0m0s", r.String())
+
+	for _, s := range []string{
+		"ID_WEB_LIN_ND <",
+		"ID_WEB_LIN_ND ~ 1",
+		"ID_WEB_LIN_ND < x",
+		"ID_WEB_LIN_ND < 1 for",
+		"ID_WEB_LIN_ND < 1 for 5 minutes",
+		"ID_WEB_LIN_ND < 1 during 5m",
+		"ID_WEB_LIN_ND < 1 hysteresis -1",
+		"ID_WEB_LIN_ND < 1 and ID_WEB_LIN_HD >",
+		"ID_WEB_LIN_ND < 1 and ID_WEB_LIN_HD ~ 1",
+	} {
+		_, err := ParseAlertRule(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestLoadAlertConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+- name: low brine
+  value: ID_WEB_LIN_ND
+  op: "<"
+  threshold: 0.5
+  for: 5m
+- value: ID_WEB_ERROR_Nr0
+  op: "!="
+  threshold: 0
+`), 0o600))
+	cfg, err := LoadAlertConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Rules, 2)
+	assert.Equal(t, 5*time.Minute, cfg.Rules[0].For)
+	assert.Equal(t, "!=", cfg.Rules[1].Op)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - value: ID_WEB_ERROR_Nr0
+    op: "!="
+notifiers:
+  - type: pushover
+    token: app
+    user: me
+`), 0o600))
+	cfg, err = LoadAlertConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Rules, 1)
+	assert.Equal(t, []NotifierConfig{{Type: "pushover", Token: "app", User: "me"}}, cfg.Notifiers)
+
+	require.NoError(t, os.WriteFile(path, []byte(`- value: ID_WEB_LIN_ND`), 0o600))
+	_, err = LoadAlertConfig(path)
+	assert.ErrorContains(t, err, "unknown operator")
+}
+
+func TestAlertEngine(t *testing.T) {
+	brine, err := ParseAlertRule("low brine: ID_WEB_LIN_ND < 0.5 for 5m hysteresis 0.2")
+	require.NoError(t, err)
+	errorCode, err := ParseAlertRule("ID_WEB_ERROR_Nr0 != 0")
+	require.NoError(t, err)
+
+	var events []AlertEvent
+	a := NewAlertEngine(AlertOptions{
+		Rules:  []AlertRule{brine, errorCode},
+		Notify: func(e AlertEvent) { events = append(events, e) },
+	})
+	defer a.Close()
+
+	pm := NewCalculationsMap()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	poll := func(minute int, pressure, code uint32) {
+		pm[CalcLINND].reading.Raw = pressure
+		pm[CalcERRORNr0].reading.Raw = code
+		require.NoError(t, a.Write(context.Background(), "hp", start.Add(time.Duration(minute)*time.Minute), BlockCalculations, pm))
+	}
+
+	poll(0, 120, 0)
+	poll(1, 45, 0)
+	poll(4, 40, 0)
+	assert.Empty(t, events, "not yet below for 5m")
+	poll(6, 40, 0)
+	require.Len(t, events, 1)
+	assert.Equal(t, "low brine", events[0].Rule.Name)
+	assert.False(t, events[0].Resolved)
+	assert.Equal(t, start.Add(time.Minute), events[0].Since)
+
+	poll(7, 60, 0)
+	assert.Len(t, events, 1, "within the hysteresis")
+	poll(8, 75, 723)
+	require.Len(t, events, 3)
+	assert.True(t, events[1].Resolved)
+	assert.Equal(t, "ID_WEB_ERROR_Nr0 != 0", events[2].Rule.String())
+	assert.Equal(t, 723.0, events[2].Value)
+	assert.Equal(t, "723: temperature difference hot water", events[2].Text)
+	assert.Equal(t, "hp firing: ID_WEB_ERROR_Nr0 != 0 (value 723: temperature difference hot water)", events[2].String())
+
+	firing := a.Firing()
+	require.Len(t, firing, 1)
+	assert.Equal(t, "hp", firing[0].Host)
+
+	require.NoError(t, a.Write(context.Background(), "hp", start, BlockParameters, NewParameterMap()))
+	assert.Len(t, events, 3, "values missing in the block are ignored")
+}
+
+func TestAlertEngine_And(t *testing.T) {
+	rule, err := ParseAlertRule("cold room: ID_WEB_Temperatur_TVL >= 45 and Room_living_room_Temperature < 19 for 2h")
+	require.NoError(t, err)
+	var events []AlertEvent
+	a := NewAlertEngine(AlertOptions{
+		Rules:  []AlertRule{rule},
+		Notify: func(e AlertEvent) { events = append(events, e) },
+	})
+
+	ctx := context.Background()
+	pm := NewCalculationsMap()
+	sensors := NewRoomSensors(0)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	poll := func(hour int, flow uint32, room float64) {
+		ts := start.Add(time.Duration(hour) * time.Hour)
+		pm[CalcFlowTemperature].reading.Raw = flow
+		require.NoError(t, sensors.Update(SensorReading{Sensor: "Living Room", Temperature: &room, Time: ts}))
+		require.NoError(t, a.Write(ctx, "hp", ts, BlockCalculations, pm))
+		require.NoError(t, a.Write(ctx, "hp", ts, BlockSensors, sensors.Map()))
+	}
+
+	require.NoError(t, a.Write(ctx, "hp", start, BlockCalculations, pm))
+	assert.Empty(t, a.Firing(), "the room temperature is not yet known")
+	poll(0, 450, 18.5)
+	poll(1, 460, 18.7)
+	assert.Empty(t, events)
+	poll(2, 460, 18.9)
+	require.Len(t, events, 1)
+	assert.Equal(t, 46.0, events[0].Value)
+	assert.Equal(t, start, events[0].Since)
+
+	poll(3, 470, 19.5)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Resolved, "the room warmed up")
+	poll(4, 400, 18)
+	assert.Len(t, events, 2, "flow below 45 °C")
+}
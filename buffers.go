@@ -0,0 +1,36 @@
+package luxtronik
+
+import "sync"
+
+// The buffers of the frames are pooled, a daemon polling every few seconds
+// would otherwise allocate several kilobytes per poll for the garbage
+// collector. The pools hold pointers to slices to avoid an allocation per
+// Put.
+var (
+	bytePool   = sync.Pool{New: func() any { return new([]byte) }}
+	uint32Pool = sync.Pool{New: func() any { return new([]uint32) }}
+)
+
+// getBytes returns a pooled buffer of length n, see putBytes.
+func getBytes(n int) *[]byte {
+	p := bytePool.Get().(*[]byte)
+	if cap(*p) < n {
+		*p = make([]byte, n)
+	}
+	*p = (*p)[:n]
+	return p
+}
+
+func putBytes(p *[]byte) { bytePool.Put(p) }
+
+// getUint32s returns a pooled slice of length n, see putUint32s.
+func getUint32s(n int) *[]uint32 {
+	p := uint32Pool.Get().(*[]uint32)
+	if cap(*p) < n {
+		*p = make([]uint32, n)
+	}
+	*p = (*p)[:n]
+	return p
+}
+
+func putUint32s(p *[]uint32) { uint32Pool.Put(p) }
@@ -0,0 +1,116 @@
+package luxtronik
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Limits of a burst, so that a forgotten burst does not stress the
+// controller for long.
+const (
+	MinBurstInterval = time.Second
+	MaxBurstDuration = time.Hour
+)
+
+// BurstStatus describes the current burst of a Poller.
+type BurstStatus struct {
+	Active   bool          `json:"active"`
+	Interval time.Duration `json:"interval_ns"`
+	Until    time.Time     `json:"until,omitempty"`
+}
+
+// Burst polls every interval for the duration d and falls back to the
+// configured interval afterwards, e.g. while commissioning. The interval is
+// raised to MinBurstInterval and d capped at MaxBurstDuration. A running
+// burst gets replaced, a zero d ends it. A running Run polls at once.
+func (p *Poller) Burst(interval, d time.Duration) BurstStatus {
+	if interval < MinBurstInterval {
+		interval = MinBurstInterval
+	}
+	if d > MaxBurstDuration {
+		d = MaxBurstDuration
+	}
+	p.mu.Lock()
+	if d > 0 {
+		p.burst = BurstStatus{Active: true, Interval: interval, Until: time.Now().Add(d)}
+	} else {
+		p.burst = BurstStatus{}
+	}
+	st := p.burst
+	p.mu.Unlock()
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	p.opts.Logger.Info("burst mode", zap.Bool("active", st.Active), zap.Duration("interval", st.Interval),
+		zap.Time("until", st.Until))
+	return st
+}
+
+// BurstStatus returns the current burst, Active is false once it ended.
+func (p *Poller) BurstStatus() BurstStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.burst.Active && !time.Now().Before(p.burst.Until) {
+		p.burst = BurstStatus{}
+	}
+	return p.burst
+}
+
+// interval returns the time between two polls.
+func (p *Poller) interval() time.Duration {
+	if st := p.BurstStatus(); st.Active {
+		return st.Interval
+	}
+	return p.opts.Interval
+}
+
+// BurstHandler controls the burst of p via HTTP. GET returns the
+// BurstStatus as JSON, POST starts a burst with the query parameters
+// interval and duration, e.g. ?interval=1s&duration=10m, DELETE ends it.
+func BurstHandler(p *Poller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var st BurstStatus
+		switch r.Method {
+		case http.MethodGet:
+			st = p.BurstStatus()
+		case http.MethodPost:
+			interval, err := durationParam(r, "interval", MinBurstInterval)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			d, err := durationParam(r, "duration", 10*time.Minute)
+			if err != nil || d <= 0 {
+				http.Error(w, fmt.Sprintf("invalid duration: %v", err), http.StatusBadRequest)
+				return
+			}
+			st = p.Burst(interval, d)
+		case http.MethodDelete:
+			st = p.Burst(0, 0)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(st)
+	})
+}
+
+func durationParam(r *http.Request, name string, def time.Duration) (time.Duration, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return d, nil
+}
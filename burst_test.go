@@ -0,0 +1,63 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoller_Burst(t *testing.T) {
+	hp := newMockHeatPump(t)
+	p := NewPoller(MustNewClient(hp.addr(), Options{}), PollerOptions{
+		Interval: time.Hour,
+		Blocks:   []string{BlockVisibilities},
+	})
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = p.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	require.Eventually(t, func() bool { return p.Stats().Polls == 1 }, time.Second, 5*time.Millisecond)
+
+	srv := httptest.NewServer(BurstHandler(p))
+	defer srv.Close()
+
+	res, err := http.Post(srv.URL+"?interval=10ms&duration=2h", "", nil)
+	require.NoError(t, err)
+	var st BurstStatus
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&st))
+	res.Body.Close()
+	assert.True(t, st.Active)
+	assert.Equal(t, MinBurstInterval, st.Interval)
+	assert.WithinDuration(t, time.Now().Add(MaxBurstDuration), st.Until, time.Second)
+
+	// the burst wakes up the poller at once
+	require.Eventually(t, func() bool { return p.Stats().Polls == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, MinBurstInterval, p.interval())
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL, nil)
+	require.NoError(t, err)
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.False(t, p.BurstStatus().Active)
+	assert.Equal(t, time.Hour, p.interval())
+
+	res, err = http.Post(srv.URL+"?duration=soon", "", nil)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
@@ -1,10 +1,11 @@
+// Code generated by genmaps from data/calculations.csv; DO NOT EDIT.
+
 package luxtronik
 
-func NewCalculationsMap() DataTypeMap {
+func calculationsCatalog() DataTypeMap {
 	return DataTypeMap{
-		// the index number is really import because it assigns a value from the
-		// heat pump to the Base object. The heat pump only sends the values
-		// from 0 to 1053.
+		// the index number is really important because it assigns a value from
+		// the heat pump to the Base object.
 		0:   NewUnknown("Unknown_Calculation_0"),
 		1:   NewUnknown("Unknown_Calculation_1"),
 		2:   NewUnknown("Unknown_Calculation_2"),
@@ -15,74 +16,74 @@ func NewCalculationsMap() DataTypeMap {
 		7:   NewUnknown("Unknown_Calculation_7"),
 		8:   NewUnknown("Unknown_Calculation_8"),
 		9:   NewUnknown("Unknown_Calculation_9"),
-		10:  NewCelsius("ID_WEB_Temperatur_TVL", false), // vorlauf
-		11:  NewCelsius("ID_WEB_Temperatur_TRL", false), // ruecklauf
-		12:  NewCelsius("ID_WEB_Sollwert_TRL_HZ", false),
-		13:  NewCelsius("ID_WEB_Temperatur_TRL_ext", false),
-		14:  NewCelsius("ID_WEB_Temperatur_THG", false),
-		15:  NewCelsius("ID_WEB_Temperatur_TA", false),    // outdoor temp
-		16:  NewCelsius("ID_WEB_Mitteltemperatur", false), // avg outdoor temp
-		17:  NewCelsius("ID_WEB_Temperatur_TBW", false),   // DHW current temp
-		18:  NewCelsius("ID_WEB_Einst_BWS_akt", false),    // DHW target temp
-		19:  NewCelsius("ID_WEB_Temperatur_TWE", false),
+		10:  NewCelsius("ID_WEB_Temperatur_TVL", false).WithVisibility("ID_Visi_Temp_Vorlauf"),  // vorlauf
+		11:  NewCelsius("ID_WEB_Temperatur_TRL", false).WithVisibility("ID_Visi_Temp_Rucklauf"), // ruecklauf
+		12:  NewCelsius("ID_WEB_Sollwert_TRL_HZ", false).WithVisibility("ID_Visi_Temp_RL_Soll"),
+		13:  NewCelsius("ID_WEB_Temperatur_TRL_ext", false).WithVisibility("ID_Visi_Temp_Ruecklext"),
+		14:  NewCelsius("ID_WEB_Temperatur_THG", false).WithVisibility("ID_Visi_Temp_Heissgas"),
+		15:  NewCelsius("ID_WEB_Temperatur_TA", false).WithVisibility("ID_Visi_Temp_Aussent"), // outdoor temp
+		16:  NewCelsius("ID_WEB_Mitteltemperatur", false),                                     // avg outdoor temp
+		17:  NewCelsius("ID_WEB_Temperatur_TBW", false).WithVisibility("ID_Visi_Temp_BW_Ist"), // DHW current temp
+		18:  NewCelsius("ID_WEB_Einst_BWS_akt", false).WithVisibility("ID_Visi_Temp_BW_Soll"), // DHW target temp
+		19:  NewCelsius("ID_WEB_Temperatur_TWE", false).WithVisibility("ID_Visi_Temp_WQ_Ein"),
 		20:  NewCelsius("ID_WEB_Temperatur_TWA", false),
-		21:  NewCelsius("ID_WEB_Temperatur_TFB1", false),
-		22:  NewCelsius("ID_WEB_Sollwert_TVL_MK1", false),
-		23:  NewCelsius("ID_WEB_Temperatur_RFV", false),
-		24:  NewCelsius("ID_WEB_Temperatur_TFB2", false),
-		25:  NewCelsius("ID_WEB_Sollwert_TVL_MK2", false),
-		26:  NewCelsius("ID_WEB_Temperatur_TSK", false),
-		27:  NewCelsius("ID_WEB_Temperatur_TSS", false),
-		28:  NewCelsius("ID_WEB_Temperatur_TEE", false),
-		29:  NewBool("ID_WEB_ASDin", false),
-		30:  NewBool("ID_WEB_BWTin", false),
-		31:  NewBool("ID_WEB_EVUin", false),
-		32:  NewBool("ID_WEB_HDin", false),
-		33:  NewBool("ID_WEB_MOTin", false),
-		34:  NewBool("ID_WEB_NDin", false),
-		35:  NewBool("ID_WEB_PEXin", false),
-		36:  NewBool("ID_WEB_SWTin", false),
-		37:  NewBool("ID_WEB_AVout", false),
-		38:  NewBool("ID_WEB_BUPout", false),
-		39:  NewBool("ID_WEB_HUPout", false),
-		40:  NewBool("ID_WEB_MA1out", false),
-		41:  NewBool("ID_WEB_MZ1out", false),
-		42:  NewBool("ID_WEB_VENout", false),
-		43:  NewBool("ID_WEB_VBOout", false),
-		44:  NewBool("ID_WEB_VD1out", false),
-		45:  NewBool("ID_WEB_VD2out", false),
-		46:  NewBool("ID_WEB_ZIPout", false),
-		47:  NewBool("ID_WEB_ZUPout", false),
-		48:  NewBool("ID_WEB_ZW1out", false),
-		49:  NewBool("ID_WEB_ZW2SSTout", false),
-		50:  NewBool("ID_WEB_ZW3SSTout", false),
-		51:  NewBool("ID_WEB_FP2out", false),
-		52:  NewBool("ID_WEB_SLPout", false),
-		53:  NewBool("ID_WEB_SUPout", false),
-		54:  NewBool("ID_WEB_MZ2out", false),
-		55:  NewBool("ID_WEB_MA2out", false),
-		56:  NewSeconds("ID_WEB_Zaehler_BetrZeitVD1"),
-		57:  NewCount("ID_WEB_Zaehler_BetrZeitImpVD1"),
-		58:  NewSeconds("ID_WEB_Zaehler_BetrZeitVD2"),
-		59:  NewCount("ID_WEB_Zaehler_BetrZeitImpVD2"),
-		60:  NewSeconds("ID_WEB_Zaehler_BetrZeitZWE1"),
-		61:  NewSeconds("ID_WEB_Zaehler_BetrZeitZWE2"),
-		62:  NewSeconds("ID_WEB_Zaehler_BetrZeitZWE3"),
-		63:  NewSeconds("ID_WEB_Zaehler_BetrZeitWP"),
+		21:  NewCelsius("ID_WEB_Temperatur_TFB1", false).WithVisibility("ID_Visi_Temp_MK1_Vorlauf"),
+		22:  NewCelsius("ID_WEB_Sollwert_TVL_MK1", false).WithVisibility("ID_Visi_Temp_MK1VL_Soll"),
+		23:  NewCelsius("ID_WEB_Temperatur_RFV", false).WithVisibility("ID_Visi_Temp_Raumstation"),
+		24:  NewCelsius("ID_WEB_Temperatur_TFB2", false).WithVisibility("ID_Visi_Temp_MK2_Vorlauf"),
+		25:  NewCelsius("ID_WEB_Sollwert_TVL_MK2", false).WithVisibility("ID_Visi_Temp_MK2VL_Soll"),
+		26:  NewCelsius("ID_WEB_Temperatur_TSK", false).WithVisibility("ID_Visi_Temp_Solarkoll"),
+		27:  NewCelsius("ID_WEB_Temperatur_TSS", false).WithVisibility("ID_Visi_Temp_Solarsp"),
+		28:  NewCelsius("ID_WEB_Temperatur_TEE", false).WithVisibility("ID_Visi_Temp_Ext_Energ"),
+		29:  NewBool("ID_WEB_ASDin", false).WithVisibility("ID_Visi_IN_ASD"),
+		30:  NewBool("ID_WEB_BWTin", false).WithVisibility("ID_Visi_IN_BWT"),
+		31:  NewBool("ID_WEB_EVUin", false).WithVisibility("ID_Visi_IN_EVU"),
+		32:  NewBool("ID_WEB_HDin", false).WithVisibility("ID_Visi_IN_HD"),
+		33:  NewBool("ID_WEB_MOTin", false).WithVisibility("ID_Visi_IN_MOT"),
+		34:  NewBool("ID_WEB_NDin", false).WithVisibility("ID_Visi_IN_ND"),
+		35:  NewBool("ID_WEB_PEXin", false).WithVisibility("ID_Visi_IN_PEX"),
+		36:  NewBool("ID_WEB_SWTin", false).WithVisibility("ID_Visi_IN_SWT"),
+		37:  NewBool("ID_WEB_AVout", false).WithVisibility("ID_Visi_OUT_Abtauventil"),
+		38:  NewBool("ID_WEB_BUPout", false).WithVisibility("ID_Visi_OUT_BUP"),
+		39:  NewBool("ID_WEB_HUPout", false).WithVisibility("ID_Visi_OUT_HUP"),
+		40:  NewBool("ID_WEB_MA1out", false).WithVisibility("ID_Visi_OUT_Mischer1Auf"),
+		41:  NewBool("ID_WEB_MZ1out", false).WithVisibility("ID_Visi_OUT_Mischer1Zu"),
+		42:  NewBool("ID_WEB_VENout", false).WithVisibility("ID_Visi_OUT_Ventilation"),
+		43:  NewBool("ID_WEB_VBOout", false).WithVisibility("ID_Visi_OUT_Ventil_BOSUP"),
+		44:  NewBool("ID_WEB_VD1out", false).WithVisibility("ID_Visi_OUT_Verdichter1"),
+		45:  NewBool("ID_WEB_VD2out", false).WithVisibility("ID_Visi_OUT_Verdichter2"),
+		46:  NewBool("ID_WEB_ZIPout", false).WithVisibility("ID_Visi_OUT_ZIP"),
+		47:  NewBool("ID_WEB_ZUPout", false).WithVisibility("ID_Visi_OUT_ZUP"),
+		48:  NewBool("ID_WEB_ZW1out", false).WithVisibility("ID_Visi_OUT_ZWE1"),
+		49:  NewBool("ID_WEB_ZW2SSTout", false).WithVisibility("ID_Visi_OUT_ZWE2_SST"),
+		50:  NewBool("ID_WEB_ZW3SSTout", false).WithVisibility("ID_Visi_OUT_ZWE3"),
+		51:  NewBool("ID_WEB_FP2out", false).WithVisibility("ID_Visi_OUT_FUP2"),
+		52:  NewBool("ID_WEB_SLPout", false).WithVisibility("ID_Visi_OUT_SLP"),
+		53:  NewBool("ID_WEB_SUPout", false).WithVisibility("ID_Visi_OUT_SUP"),
+		54:  NewBool("ID_WEB_MZ2out", false).WithVisibility("ID_Visi_OUT_Mischer2Zu"),
+		55:  NewBool("ID_WEB_MA2out", false).WithVisibility("ID_Visi_OUT_Mischer2Auf"),
+		56:  NewSeconds("ID_WEB_Zaehler_BetrZeitVD1").WithVisibility("ID_Visi_Bst_BStdVD1"),
+		57:  NewCount("ID_WEB_Zaehler_BetrZeitImpVD1").WithVisibility("ID_Visi_Bst_ImpVD1"),
+		58:  NewSeconds("ID_WEB_Zaehler_BetrZeitVD2").WithVisibility("ID_Visi_Bst_BStdVD2"),
+		59:  NewCount("ID_WEB_Zaehler_BetrZeitImpVD2").WithVisibility("ID_Visi_Bst_ImpVD2"),
+		60:  NewSeconds("ID_WEB_Zaehler_BetrZeitZWE1").WithVisibility("ID_Visi_Bst_BStdZWE1"),
+		61:  NewSeconds("ID_WEB_Zaehler_BetrZeitZWE2").WithVisibility("ID_Visi_Bst_BStdZWE2"),
+		62:  NewSeconds("ID_WEB_Zaehler_BetrZeitZWE3").WithVisibility("ID_Visi_Bst_BStdZWE3"),
+		63:  NewSeconds("ID_WEB_Zaehler_BetrZeitWP").WithVisibility("ID_Visi_Bst_BStdWP"),
 		64:  NewSeconds("ID_WEB_Zaehler_BetrZeitHz"),
 		65:  NewSeconds("ID_WEB_Zaehler_BetrZeitBW"),
 		66:  NewSeconds("ID_WEB_Zaehler_BetrZeitKue"),
-		67:  NewSeconds("ID_WEB_Time_WPein_akt"),
-		68:  NewSeconds("ID_WEB_Time_ZWE1_akt"),
-		69:  NewSeconds("ID_WEB_Time_ZWE2_akt"),
+		67:  NewSeconds("ID_WEB_Time_WPein_akt").WithVisibility("ID_Visi_AblaufZ_WP_Seit"),
+		68:  NewSeconds("ID_WEB_Time_ZWE1_akt").WithVisibility("ID_Visi_AblaufZ_ZWE1_seit"),
+		69:  NewSeconds("ID_WEB_Time_ZWE2_akt").WithVisibility("ID_Visi_AblaufZ_ZWE2_seit"),
 		70:  NewSeconds("ID_WEB_Timer_EinschVerz"),
 		71:  NewSeconds("ID_WEB_Time_SSPAUS_akt"),
 		72:  NewSeconds("ID_WEB_Time_SSPEIN_akt"),
-		73:  NewSeconds("ID_WEB_Time_VDStd_akt"),
-		74:  NewSeconds("ID_WEB_Time_HRM_akt"),
-		75:  NewSeconds("ID_WEB_Time_HRW_akt"),
+		73:  NewSeconds("ID_WEB_Time_VDStd_akt").WithVisibility("ID_Visi_AblaufZ_VD_Stand"),
+		74:  NewSeconds("ID_WEB_Time_HRM_akt").WithVisibility("ID_Visi_AblaufZ_HRM_Zeit"),
+		75:  NewSeconds("ID_WEB_Time_HRW_akt").WithVisibility("ID_Visi_AblaufZ_HRW_Zeit"),
 		76:  NewSeconds("ID_WEB_Time_LGS_akt"),
-		77:  NewSeconds("ID_WEB_Time_SBW_akt"),
+		77:  NewSeconds("ID_WEB_Time_SBW_akt").WithVisibility("ID_Visi_AblaufZ_Sperre_BW"),
 		78:  NewHeatpumpCode("ID_WEB_Code_WP_akt"), // heat pump type; internal type 3
 		79:  NewBivalenceLevel("ID_WEB_BIV_Stufe_akt"),
 		80:  NewOperationMode("ID_WEB_WP_BZ_akt"),
@@ -139,7 +140,7 @@ func NewCalculationsMap() DataTypeMap {
 		131: NewUnknown("ID_WEB_StatusSlave_3"),
 		132: NewUnknown("ID_WEB_StatusSlave_4"),
 		133: NewUnknown("ID_WEB_StatusSlave_5"),
-		134: NewTime("ID_WEB_AktuelleTimeStamp"),
+		134: NewTime("ID_WEB_AktuelleTimeStamp"), // clock of the controller
 		135: NewIcon("ID_WEB_SH_MK3"),
 		136: NewCelsius("ID_WEB_Sollwert_TVL_MK3", false),
 		137: NewCelsius("ID_WEB_Temperatur_TFB3", false),
@@ -275,3 +276,242 @@ func NewCalculationsMap() DataTypeMap {
 		267: NewUnknown("Unknown_Calculation_267"), // Desired Room Temperature ?
 	}
 }
+
+// Indexes of calculationsCatalog.
+const (
+	CalcFlowTemperature              = 10  // ID_WEB_Temperatur_TVL
+	CalcReturnTemperature            = 11  // ID_WEB_Temperatur_TRL
+	CalcSollwertTRLHZ                = 12  // ID_WEB_Sollwert_TRL_HZ
+	CalcTemperaturTRLExt             = 13  // ID_WEB_Temperatur_TRL_ext
+	CalcTemperaturTHG                = 14  // ID_WEB_Temperatur_THG
+	CalcOutdoorTemperature           = 15  // ID_WEB_Temperatur_TA
+	CalcMitteltemperatur             = 16  // ID_WEB_Mitteltemperatur
+	CalcHotWaterTemperature          = 17  // ID_WEB_Temperatur_TBW
+	CalcHotWaterTarget               = 18  // ID_WEB_Einst_BWS_akt
+	CalcSourceInTemperature          = 19  // ID_WEB_Temperatur_TWE
+	CalcTemperaturTWA                = 20  // ID_WEB_Temperatur_TWA
+	CalcTemperaturTFB1               = 21  // ID_WEB_Temperatur_TFB1
+	CalcSollwertTVLMK1               = 22  // ID_WEB_Sollwert_TVL_MK1
+	CalcTemperaturRFV                = 23  // ID_WEB_Temperatur_RFV
+	CalcTemperaturTFB2               = 24  // ID_WEB_Temperatur_TFB2
+	CalcSollwertTVLMK2               = 25  // ID_WEB_Sollwert_TVL_MK2
+	CalcTemperaturTSK                = 26  // ID_WEB_Temperatur_TSK
+	CalcTemperaturTSS                = 27  // ID_WEB_Temperatur_TSS
+	CalcTemperaturTEE                = 28  // ID_WEB_Temperatur_TEE
+	CalcASDin                        = 29  // ID_WEB_ASDin
+	CalcBWTin                        = 30  // ID_WEB_BWTin
+	CalcEVUin                        = 31  // ID_WEB_EVUin
+	CalcHDin                         = 32  // ID_WEB_HDin
+	CalcMOTin                        = 33  // ID_WEB_MOTin
+	CalcNDin                         = 34  // ID_WEB_NDin
+	CalcPEXin                        = 35  // ID_WEB_PEXin
+	CalcSWTin                        = 36  // ID_WEB_SWTin
+	CalcAVout                        = 37  // ID_WEB_AVout
+	CalcBUPout                       = 38  // ID_WEB_BUPout
+	CalcHUPout                       = 39  // ID_WEB_HUPout
+	CalcMA1out                       = 40  // ID_WEB_MA1out
+	CalcMZ1out                       = 41  // ID_WEB_MZ1out
+	CalcVENout                       = 42  // ID_WEB_VENout
+	CalcVBOout                       = 43  // ID_WEB_VBOout
+	CalcVD1out                       = 44  // ID_WEB_VD1out
+	CalcVD2out                       = 45  // ID_WEB_VD2out
+	CalcZIPout                       = 46  // ID_WEB_ZIPout
+	CalcZUPout                       = 47  // ID_WEB_ZUPout
+	CalcZW1out                       = 48  // ID_WEB_ZW1out
+	CalcZW2SSTout                    = 49  // ID_WEB_ZW2SSTout
+	CalcZW3SSTout                    = 50  // ID_WEB_ZW3SSTout
+	CalcFP2out                       = 51  // ID_WEB_FP2out
+	CalcSLPout                       = 52  // ID_WEB_SLPout
+	CalcSUPout                       = 53  // ID_WEB_SUPout
+	CalcMZ2out                       = 54  // ID_WEB_MZ2out
+	CalcMA2out                       = 55  // ID_WEB_MA2out
+	CalcZaehlerBetrZeitVD1           = 56  // ID_WEB_Zaehler_BetrZeitVD1
+	CalcCompressorStarts             = 57  // ID_WEB_Zaehler_BetrZeitImpVD1
+	CalcZaehlerBetrZeitVD2           = 58  // ID_WEB_Zaehler_BetrZeitVD2
+	CalcZaehlerBetrZeitImpVD2        = 59  // ID_WEB_Zaehler_BetrZeitImpVD2
+	CalcZaehlerBetrZeitZWE1          = 60  // ID_WEB_Zaehler_BetrZeitZWE1
+	CalcZaehlerBetrZeitZWE2          = 61  // ID_WEB_Zaehler_BetrZeitZWE2
+	CalcZaehlerBetrZeitZWE3          = 62  // ID_WEB_Zaehler_BetrZeitZWE3
+	CalcZaehlerBetrZeitWP            = 63  // ID_WEB_Zaehler_BetrZeitWP
+	CalcHeatingRuntime               = 64  // ID_WEB_Zaehler_BetrZeitHz
+	CalcZaehlerBetrZeitBW            = 65  // ID_WEB_Zaehler_BetrZeitBW
+	CalcZaehlerBetrZeitKue           = 66  // ID_WEB_Zaehler_BetrZeitKue
+	CalcTimeWPeinAkt                 = 67  // ID_WEB_Time_WPein_akt
+	CalcTimeZWE1Akt                  = 68  // ID_WEB_Time_ZWE1_akt
+	CalcTimeZWE2Akt                  = 69  // ID_WEB_Time_ZWE2_akt
+	CalcTimerEinschVerz              = 70  // ID_WEB_Timer_EinschVerz
+	CalcTimeSSPAUSAkt                = 71  // ID_WEB_Time_SSPAUS_akt
+	CalcTimeSSPEINAkt                = 72  // ID_WEB_Time_SSPEIN_akt
+	CalcTimeVDStdAkt                 = 73  // ID_WEB_Time_VDStd_akt
+	CalcTimeHRMAkt                   = 74  // ID_WEB_Time_HRM_akt
+	CalcTimeHRWAkt                   = 75  // ID_WEB_Time_HRW_akt
+	CalcTimeLGSAkt                   = 76  // ID_WEB_Time_LGS_akt
+	CalcTimeSBWAkt                   = 77  // ID_WEB_Time_SBW_akt
+	CalcHeatpumpCode                 = 78  // ID_WEB_Code_WP_akt
+	CalcBIVStufeAkt                  = 79  // ID_WEB_BIV_Stufe_akt
+	CalcOperationMode                = 80  // ID_WEB_WP_BZ_akt
+	CalcSoftStand0                   = 81  // ID_WEB_SoftStand_0
+	CalcSoftStand1                   = 82  // ID_WEB_SoftStand_1
+	CalcSoftStand2                   = 83  // ID_WEB_SoftStand_2
+	CalcSoftStand3                   = 84  // ID_WEB_SoftStand_3
+	CalcSoftStand4                   = 85  // ID_WEB_SoftStand_4
+	CalcSoftStand5                   = 86  // ID_WEB_SoftStand_5
+	CalcSoftStand6                   = 87  // ID_WEB_SoftStand_6
+	CalcSoftStand7                   = 88  // ID_WEB_SoftStand_7
+	CalcSoftStand8                   = 89  // ID_WEB_SoftStand_8
+	CalcSoftStand9                   = 90  // ID_WEB_SoftStand_9
+	CalcAdresseIPAkt                 = 91  // ID_WEB_AdresseIP_akt
+	CalcSubNetMaskAkt                = 92  // ID_WEB_SubNetMask_akt
+	CalcAddBroadcast                 = 93  // ID_WEB_Add_Broadcast
+	CalcAddStdGateway                = 94  // ID_WEB_Add_StdGateway
+	CalcERRORTime0                   = 95  // ID_WEB_ERROR_Time0
+	CalcERRORTime1                   = 96  // ID_WEB_ERROR_Time1
+	CalcERRORTime2                   = 97  // ID_WEB_ERROR_Time2
+	CalcERRORTime3                   = 98  // ID_WEB_ERROR_Time3
+	CalcERRORTime4                   = 99  // ID_WEB_ERROR_Time4
+	CalcERRORNr0                     = 100 // ID_WEB_ERROR_Nr0
+	CalcERRORNr1                     = 101 // ID_WEB_ERROR_Nr1
+	CalcERRORNr2                     = 102 // ID_WEB_ERROR_Nr2
+	CalcERRORNr3                     = 103 // ID_WEB_ERROR_Nr3
+	CalcERRORNr4                     = 104 // ID_WEB_ERROR_Nr4
+	CalcAnzahlFehlerInSpeicher       = 105 // ID_WEB_AnzahlFehlerInSpeicher
+	CalcSwitchoffFileNr0             = 106 // ID_WEB_Switchoff_file_Nr0
+	CalcSwitchoffFileNr1             = 107 // ID_WEB_Switchoff_file_Nr1
+	CalcSwitchoffFileNr2             = 108 // ID_WEB_Switchoff_file_Nr2
+	CalcSwitchoffFileNr3             = 109 // ID_WEB_Switchoff_file_Nr3
+	CalcSwitchoffFileNr4             = 110 // ID_WEB_Switchoff_file_Nr4
+	CalcSwitchoffFileTime0           = 111 // ID_WEB_Switchoff_file_Time0
+	CalcSwitchoffFileTime1           = 112 // ID_WEB_Switchoff_file_Time1
+	CalcSwitchoffFileTime2           = 113 // ID_WEB_Switchoff_file_Time2
+	CalcSwitchoffFileTime3           = 114 // ID_WEB_Switchoff_file_Time3
+	CalcSwitchoffFileTime4           = 115 // ID_WEB_Switchoff_file_Time4
+	CalcComfortExists                = 116 // ID_WEB_Comfort_exists
+	CalcHauptMenuStatusZeile1        = 117 // ID_WEB_HauptMenuStatus_Zeile1
+	CalcHauptMenuStatusZeile2        = 118 // ID_WEB_HauptMenuStatus_Zeile2
+	CalcHauptMenuStatusZeile3        = 119 // ID_WEB_HauptMenuStatus_Zeile3
+	CalcHauptMenuStatusZeit          = 120 // ID_WEB_HauptMenuStatus_Zeit
+	CalcHauptMenuAHPStufe            = 121 // ID_WEB_HauptMenuAHP_Stufe
+	CalcHauptMenuAHPTemp             = 122 // ID_WEB_HauptMenuAHP_Temp
+	CalcHauptMenuAHPZeit             = 123 // ID_WEB_HauptMenuAHP_Zeit
+	CalcSHBWW                        = 124 // ID_WEB_SH_BWW
+	CalcSHHZ                         = 125 // ID_WEB_SH_HZ
+	CalcSHMK1                        = 126 // ID_WEB_SH_MK1
+	CalcSHMK2                        = 127 // ID_WEB_SH_MK2
+	CalcEinstKurzrpgramm             = 128 // ID_WEB_Einst_Kurzrpgramm
+	CalcStatusSlave1                 = 129 // ID_WEB_StatusSlave_1
+	CalcStatusSlave2                 = 130 // ID_WEB_StatusSlave_2
+	CalcStatusSlave3                 = 131 // ID_WEB_StatusSlave_3
+	CalcStatusSlave4                 = 132 // ID_WEB_StatusSlave_4
+	CalcStatusSlave5                 = 133 // ID_WEB_StatusSlave_5
+	CalcControllerTime               = 134 // ID_WEB_AktuelleTimeStamp
+	CalcSHMK3                        = 135 // ID_WEB_SH_MK3
+	CalcSollwertTVLMK3               = 136 // ID_WEB_Sollwert_TVL_MK3
+	CalcTemperaturTFB3               = 137 // ID_WEB_Temperatur_TFB3
+	CalcMZ3out                       = 138 // ID_WEB_MZ3out
+	CalcMA3out                       = 139 // ID_WEB_MA3out
+	CalcFP3out                       = 140 // ID_WEB_FP3out
+	CalcTimeAbtIn                    = 141 // ID_WEB_Time_AbtIn
+	CalcTemperaturRFV2               = 142 // ID_WEB_Temperatur_RFV2
+	CalcTemperaturRFV3               = 143 // ID_WEB_Temperatur_RFV3
+	CalcSHSW                         = 144 // ID_WEB_SH_SW
+	CalcZaehlerBetrZeitSW            = 145 // ID_WEB_Zaehler_BetrZeitSW
+	CalcFreigabKuehl                 = 146 // ID_WEB_FreigabKuehl
+	CalcAnalogIn                     = 147 // ID_WEB_AnalogIn
+	CalcSonderZeichen                = 148 // ID_WEB_SonderZeichen
+	CalcSHZIP                        = 149 // ID_WEB_SH_ZIP
+	CalcWebsrvProgrammWerteBeobarten = 150 // ID_WEB_WebsrvProgrammWerteBeobarten
+	CalcHeatQuantityHeating          = 151 // ID_WEB_WMZ_Heizung
+	CalcHeatQuantityHotWater         = 152 // ID_WEB_WMZ_Brauchwasser
+	CalcWMZSchwimmbad                = 153 // ID_WEB_WMZ_Schwimmbad
+	CalcWMZSeit                      = 154 // ID_WEB_WMZ_Seit
+	CalcWMZDurchfluss                = 155 // ID_WEB_WMZ_Durchfluss
+	CalcAnalogOut1                   = 156 // ID_WEB_AnalogOut1
+	CalcAnalogOut2                   = 157 // ID_WEB_AnalogOut2
+	CalcTimeHeissgas                 = 158 // ID_WEB_Time_Heissgas
+	CalcTempLueftungZuluft           = 159 // ID_WEB_Temp_Lueftung_Zuluft
+	CalcTempLueftungAbluft           = 160 // ID_WEB_Temp_Lueftung_Abluft
+	CalcZaehlerBetrZeitSolar         = 161 // ID_WEB_Zaehler_BetrZeitSolar
+	CalcAnalogOut3                   = 162 // ID_WEB_AnalogOut3
+	CalcAnalogOut4                   = 163 // ID_WEB_AnalogOut4
+	CalcOutVZU                       = 164 // ID_WEB_Out_VZU
+	CalcOutVAB                       = 165 // ID_WEB_Out_VAB
+	CalcOutVSK                       = 166 // ID_WEB_Out_VSK
+	CalcOutFRH                       = 167 // ID_WEB_Out_FRH
+	CalcAnalogIn2                    = 168 // ID_WEB_AnalogIn2
+	CalcAnalogIn3                    = 169 // ID_WEB_AnalogIn3
+	CalcSAXin                        = 170 // ID_WEB_SAXin
+	CalcSPLin                        = 171 // ID_WEB_SPLin
+	CalcCompactExists                = 172 // ID_WEB_Compact_exists
+	CalcDurchflussWQ                 = 173 // ID_WEB_Durchfluss_WQ
+	CalcLINExists                    = 174 // ID_WEB_LIN_exists
+	CalcLINANSAUGVERDAMPFER          = 175 // ID_WEB_LIN_ANSAUG_VERDAMPFER
+	CalcLINANSAUGVERDICHTER          = 176 // ID_WEB_LIN_ANSAUG_VERDICHTER
+	CalcLINVDH                       = 177 // ID_WEB_LIN_VDH
+	CalcLINUH                        = 178 // ID_WEB_LIN_UH
+	CalcLINUHSoll                    = 179 // ID_WEB_LIN_UH_Soll
+	CalcLINHD                        = 180 // ID_WEB_LIN_HD
+	CalcLINND                        = 181 // ID_WEB_LIN_ND
+	CalcLINVDHOut                    = 182 // ID_WEB_LIN_VDH_out
+	CalcHZIOPWM                      = 183 // ID_WEB_HZIO_PWM
+	CalcHZIOVEN                      = 184 // ID_WEB_HZIO_VEN
+	CalcHZIOEVU2                     = 185 // ID_WEB_HZIO_EVU2
+	CalcHZIOSTB                      = 186 // ID_WEB_HZIO_STB
+	CalcSECQhSoll                    = 187 // ID_WEB_SEC_Qh_Soll
+	CalcSECQhIst                     = 188 // ID_WEB_SEC_Qh_Ist
+	CalcSECTVLSoll                   = 189 // ID_WEB_SEC_TVL_Soll
+	CalcSECSoftware                  = 190 // ID_WEB_SEC_Software
+	CalcSECBZ                        = 191 // ID_WEB_SEC_BZ
+	CalcSECVWV                       = 192 // ID_WEB_SEC_VWV
+	CalcSECVD                        = 193 // ID_WEB_SEC_VD
+	CalcSECVerdEVI                   = 194 // ID_WEB_SEC_VerdEVI
+	CalcSECAnsEVI                    = 195 // ID_WEB_SEC_AnsEVI
+	CalcSECUEHEVI                    = 196 // ID_WEB_SEC_UEH_EVI
+	CalcSECUEHEVIS                   = 197 // ID_WEB_SEC_UEH_EVI_S
+	CalcSECKondTemp                  = 198 // ID_WEB_SEC_KondTemp
+	CalcSECFlussigEx                 = 199 // ID_WEB_SEC_FlussigEx
+	CalcSECUKEEV                     = 200 // ID_WEB_SEC_UK_EEV
+	CalcSECEVIDruck                  = 201 // ID_WEB_SEC_EVI_Druck
+	CalcSECUInv                      = 202 // ID_WEB_SEC_U_Inv
+	CalcTemperaturTHG2               = 203 // ID_WEB_Temperatur_THG_2
+	CalcTemperaturTWE2               = 204 // ID_WEB_Temperatur_TWE_2
+	CalcLINANSAUGVERDAMPFER2         = 205 // ID_WEB_LIN_ANSAUG_VERDAMPFER_2
+	CalcLINANSAUGVERDICHTER2         = 206 // ID_WEB_LIN_ANSAUG_VERDICHTER_2
+	CalcLINVDH2                      = 207 // ID_WEB_LIN_VDH_2
+	CalcLINUH2                       = 208 // ID_WEB_LIN_UH_2
+	CalcLINUHSoll2                   = 209 // ID_WEB_LIN_UH_Soll_2
+	CalcLINHD2                       = 210 // ID_WEB_LIN_HD_2
+	CalcLINND2                       = 211 // ID_WEB_LIN_ND_2
+	CalcHDin2                        = 212 // ID_WEB_HDin_2
+	CalcAVout2                       = 213 // ID_WEB_AVout_2
+	CalcVBOout2                      = 214 // ID_WEB_VBOout_2
+	CalcVD1out2                      = 215 // ID_WEB_VD1out_2
+	CalcLINVDHOut2                   = 216 // ID_WEB_LIN_VDH_out_2
+	CalcSwitchoff2FileNr0            = 217 // ID_WEB_Switchoff2_file_Nr0
+	CalcSwitchoff2FileNr1            = 218 // ID_WEB_Switchoff2_file_Nr1
+	CalcSwitchoff2FileNr2            = 219 // ID_WEB_Switchoff2_file_Nr2
+	CalcSwitchoff2FileNr3            = 220 // ID_WEB_Switchoff2_file_Nr3
+	CalcSwitchoff2FileNr4            = 221 // ID_WEB_Switchoff2_file_Nr4
+	CalcSwitchoff2FileTime0          = 222 // ID_WEB_Switchoff2_file_Time0
+	CalcSwitchoff2FileTime1          = 223 // ID_WEB_Switchoff2_file_Time1
+	CalcSwitchoff2FileTime2          = 224 // ID_WEB_Switchoff2_file_Time2
+	CalcSwitchoff2FileTime3          = 225 // ID_WEB_Switchoff2_file_Time3
+	CalcSwitchoff2FileTime4          = 226 // ID_WEB_Switchoff2_file_Time4
+	CalcRBERTIst                     = 227 // ID_WEB_RBE_RT_Ist
+	CalcRBERTSoll                    = 228 // ID_WEB_RBE_RT_Soll
+	CalcTemperaturBWOben             = 229 // ID_WEB_Temperatur_BW_oben
+	CalcCodeWPAkt2                   = 230 // ID_WEB_Code_WP_akt_2
+	CalcFreqVD                       = 231 // ID_WEB_Freq_VD
+	CalcVapourisationTemperature     = 232 // Vapourisation_Temperature
+	CalcLiquefactionTemperature      = 233 // Liquefaction_Temperature
+	CalcFreqVDSoll                   = 236 // ID_WEB_Freq_VD_Soll
+	CalcFreqVDMin                    = 237 // ID_WEB_Freq_VD_Min
+	CalcFreqVDMax                    = 238 // ID_WEB_Freq_VD_Max
+	CalcVBOTempSpreadSoll            = 239 // VBO_Temp_Spread_Soll
+	CalcVBOTempSpreadIst             = 240 // VBO_Temp_Spread_Ist
+	CalcHUPPWM                       = 241 // HUP_PWM
+	CalcHUPTempSpreadSoll            = 242 // HUP_Temp_Spread_Soll
+	CalcHUPTempSpreadIst             = 243 // HUP_Temp_Spread_Ist
+	CalcFlowRate254                  = 254 // Flow_Rate_254
+	CalcHeatOutput                   = 257 // Heat_Output
+	CalcRBEVersion                   = 258 // RBE_Version
+)
@@ -0,0 +1,35 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClockOf returns the clock of the controller from already read
+// calculations, the zero time if the controller does not report it.
+func ClockOf(calculations DataTypeMap) time.Time {
+	t, _ := calculations[CalcControllerTime].FromHeatPump().(time.Time)
+	return t
+}
+
+// ReadClock reads the calculations and returns the clock of the controller.
+// The clock is not among the writeable parameters of the protocol, a drift
+// has to be corrected on the display or in the web interface of the
+// controller.
+func (c *Client) ReadClock() (time.Time, error) {
+	return c.ReadClockContext(context.Background())
+}
+
+// ReadClockContext is ReadClock with a context, see ReadParametersContext.
+func (c *Client) ReadClockContext(ctx context.Context) (time.Time, error) {
+	pm := NewCalculationsMap()
+	if err := c.ReadCalculationsContext(ctx, pm); err != nil {
+		return time.Time{}, fmt.Errorf("ReadClock: %w", err)
+	}
+	t := ClockOf(pm)
+	if t.IsZero() {
+		return t, fmt.Errorf("ReadClock %s not set: %w", pm[CalcControllerTime].luxtronikName, ErrInvalidValue)
+	}
+	return t, nil
+}
@@ -0,0 +1,36 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadClock(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	_, err := c.ReadClock()
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.Local)
+	m.mu.Lock()
+	m.calculations[CalcControllerTime] = uint32(now.Unix())
+	m.mu.Unlock()
+	clock, err := c.ReadClock()
+	require.NoError(t, err)
+	assert.True(t, clock.Equal(now))
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	c2 := MustNewClient(m.addr(), Options{Location: tokyo})
+	require.NoError(t, c2.Connect())
+	defer c2.Close()
+	clock, err = c2.ReadClock()
+	require.NoError(t, err)
+	assert.Equal(t, tokyo, clock.Location())
+	assert.True(t, clock.Equal(now))
+}
@@ -0,0 +1,271 @@
+package luxtronik
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Platforms of a CloudTwin.
+const (
+	// CloudAWS reports to the classic device shadow of AWS IoT Core.
+	CloudAWS = "aws"
+	// CloudAzure reports to the device twin of Azure IoT Hub.
+	CloudAzure = "azure"
+)
+
+type CloudTwinOptions struct {
+	// Platform is CloudAWS or CloudAzure.
+	Platform string
+	// Thing is the thing name of AWS IoT Core, Azure IoT Hub derives the
+	// device from the connection.
+	Thing string
+	// Names are the luxtronik names of the values in the reported state.
+	// The desired state may change the writeable parameters among them.
+	Names []string
+	// Publish sends a message to the broker, e.g. with an MQTT client
+	// connected as the device.
+	Publish func(topic string, payload []byte) error
+	Logger  *zap.Logger
+}
+
+type cloudPoint struct {
+	block string
+	name  string
+}
+
+// CloudTwin keeps the reported state of a device shadow or device twin up to
+// date with the values of the heat pump and writes the desired state of
+// writeable parameters, passed to Desired, with the next poll of the
+// parameters. Only changed values are reported, both platforms merge them
+// into the document. Without a client the twin only reports.
+type CloudTwin struct {
+	client *Client
+	opts   CloudTwinOptions
+	points map[string]cloudPoint // by lower case name
+
+	mu       sync.Mutex
+	reported map[string]any
+	pending  map[string]any
+	rid      int
+}
+
+func NewCloudTwin(c *Client, opts CloudTwinOptions) (*CloudTwin, error) {
+	switch {
+	case opts.Platform != CloudAWS && opts.Platform != CloudAzure:
+		return nil, fmt.Errorf("NewCloudTwin unknown platform %q, want %s or %s", opts.Platform, CloudAWS, CloudAzure)
+	case opts.Platform == CloudAWS && opts.Thing == "":
+		return nil, errors.New("NewCloudTwin AWS needs the thing name")
+	case len(opts.Names) == 0:
+		return nil, errors.New("NewCloudTwin needs the names of the reported values")
+	case opts.Publish == nil:
+		return nil, errors.New("NewCloudTwin needs Publish")
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	t := &CloudTwin{
+		client:   c,
+		opts:     opts,
+		points:   map[string]cloudPoint{},
+		reported: map[string]any{},
+		pending:  map[string]any{},
+	}
+	blocks := map[string]DataTypeMap{
+		BlockParameters:   NewParameterMap(),
+		BlockCalculations: NewCalculationsMap(),
+		BlockVisibilities: NewVisibilitiesMap(),
+	}
+	for _, name := range opts.Names {
+		if _, err := strconv.Atoi(name); err == nil {
+			return nil, fmt.Errorf("NewCloudTwin %s: indexes are ambiguous, use the luxtronik name", name)
+		}
+		var p cloudPoint
+		for block, pm := range blocks {
+			if _, b, ok := pm.Lookup(name); ok {
+				p = cloudPoint{block: block, name: b.Name()}
+				break
+			}
+		}
+		if p.name == "" {
+			return nil, fmt.Errorf("NewCloudTwin unknown name %q", name)
+		}
+		t.points[strings.ToLower(p.name)] = p
+	}
+	return t, nil
+}
+
+// DesiredTopic returns the topic to subscribe to and pass to Desired: the
+// delta of the shadow or the patches of the desired twin properties.
+func (t *CloudTwin) DesiredTopic() string {
+	if t.opts.Platform == CloudAWS {
+		return "$aws/things/" + t.opts.Thing + "/shadow/update/delta"
+	}
+	return "$iothub/twin/PATCH/properties/desired/#"
+}
+
+// Desired queues the writes of a message received on DesiredTopic. Values
+// are in the units and English codes of the reported state. Names which
+// are not writeable parameters of Names are skipped and returned as error.
+func (t *CloudTwin) Desired(payload []byte) error {
+	var state map[string]json.RawMessage
+	if t.opts.Platform == CloudAWS {
+		var delta struct {
+			State map[string]json.RawMessage `json:"state"`
+		}
+		if err := json.Unmarshal(payload, &delta); err != nil {
+			return fmt.Errorf("CloudTwin.Desired: %w", err)
+		}
+		state = delta.State
+	} else if err := json.Unmarshal(payload, &state); err != nil {
+		return fmt.Errorf("CloudTwin.Desired: %w", err)
+	}
+
+	var errs []error
+	pm := NewParameterMap()
+	for name, raw := range state {
+		if strings.HasPrefix(name, "$") {
+			continue // metadata of Azure, e.g. $version
+		}
+		p, ok := t.points[strings.ToLower(name)]
+		if _, b, found := pm.Lookup(name); !ok || p.block != BlockParameters || !found || !b.writeable {
+			errs = append(errs, fmt.Errorf("CloudTwin.Desired %s: %w", name, ErrWritingNotAllowed))
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			errs = append(errs, fmt.Errorf("CloudTwin.Desired %s: %w", name, err))
+			continue
+		}
+		if t.client == nil {
+			continue
+		}
+		t.mu.Lock()
+		t.pending[p.name] = v
+		t.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Resync reports all values again with the next poll, e.g. after a
+// reconnect.
+func (t *CloudTwin) Resync() {
+	t.mu.Lock()
+	t.reported = map[string]any{}
+	t.mu.Unlock()
+}
+
+func (t *CloudTwin) Write(ctx context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	if t.client != nil && host != t.client.Name() {
+		return nil
+	}
+	var errs []error
+	if block == BlockParameters {
+		errs = append(errs, t.applyPending(ctx, pm))
+	}
+
+	changed := map[string]any{}
+	t.mu.Lock()
+	for _, p := range t.points {
+		if p.block != block {
+			continue
+		}
+		_, b, ok := pm.Lookup(p.name)
+		if !ok {
+			continue
+		}
+		v := DumpValue(b.canonical().FromHeatPump())
+		if prev, seen := t.reported[p.name]; seen && prev == v {
+			continue
+		}
+		t.reported[p.name] = v
+		changed[p.name] = v
+	}
+	t.mu.Unlock()
+	if len(changed) > 0 {
+		if err := t.report(changed); err != nil {
+			// reported again with the next poll
+			t.mu.Lock()
+			for name := range changed {
+				delete(t.reported, name)
+			}
+			t.mu.Unlock()
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// report publishes the changed values as reported state.
+func (t *CloudTwin) report(changed map[string]any) error {
+	var doc any = changed
+	topic := "$aws/things/" + t.opts.Thing + "/shadow/update"
+	if t.opts.Platform == CloudAWS {
+		doc = map[string]any{"state": map[string]any{"reported": changed}}
+	} else {
+		t.mu.Lock()
+		t.rid++
+		topic = fmt.Sprintf("$iothub/twin/PATCH/properties/reported/?$rid=%d", t.rid)
+		t.mu.Unlock()
+	}
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("CloudTwin.Write: %w", err)
+	}
+	if err := t.opts.Publish(topic, payload); err != nil {
+		return fmt.Errorf("CloudTwin.Write publish %s: %w", topic, err)
+	}
+	return nil
+}
+
+// applyPending writes the received desired values to the heat pump.
+func (t *CloudTwin) applyPending(ctx context.Context, pm DataTypeMap) error {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = map[string]any{}
+	t.mu.Unlock()
+
+	var errs []error
+	for name, v := range pending {
+		idx, _, ok := pm.Lookup(name)
+		if !ok {
+			continue
+		}
+		if err := t.client.writeChanged(ctx, pm, idx, v); err != nil {
+			errs = append(errs, fmt.Errorf("CloudTwin desired %s: %w", name, err))
+			continue
+		}
+		t.opts.Logger.Info("cloud desired state applied", zap.String("name", name), zap.Any("value", v))
+	}
+	return errors.Join(errs...)
+}
+
+func (t *CloudTwin) Close() error { return nil }
+
+// AzureSASToken returns a shared access signature of the resource, e.g.
+// myhub.azure-devices.net/devices/cellar, signed with the base64 encoded key
+// of the device and valid until expiry. It is the MQTT password of a device
+// of Azure IoT Hub.
+func AzureSASToken(resource, key string, expiry time.Time) (string, error) {
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", fmt.Errorf("AzureSASToken invalid key: %w", err)
+	}
+	sr := url.QueryEscape(resource)
+	se := strconv.FormatInt(expiry.Unix(), 10)
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte(sr + "\n" + se))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return "SharedAccessSignature sr=" + sr + "&sig=" + url.QueryEscape(sig) + "&se=" + se, nil
+}
@@ -0,0 +1,126 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cloudMessage struct {
+	topic   string
+	payload map[string]any
+}
+
+func TestCloudTwin_AWS(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+	require.NoError(t, c.Connect())
+
+	var sent []cloudMessage
+	twin, err := NewCloudTwin(c, CloudTwinOptions{
+		Platform: CloudAWS,
+		Thing:    "cellar",
+		Names:    []string{"ID_Einst_BWS_akt", "id_ba_hz_akt", "ID_WEB_Temperatur_TA"},
+		Publish: func(topic string, payload []byte) error {
+			m := cloudMessage{topic: topic}
+			require.NoError(t, json.Unmarshal(payload, &m.payload))
+			sent = append(sent, m)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "$aws/things/cellar/shadow/update/delta", twin.DesiredTopic())
+
+	ctx := context.Background()
+	pm := NewParameterMap()
+	pm[ParamHotWaterTarget].reading.Raw = 485
+	pm[ParamHeatingMode].reading.Raw = 3
+	require.NoError(t, twin.Write(ctx, c.Name(), time.Now(), BlockParameters, pm))
+	require.Len(t, sent, 1)
+	assert.Equal(t, "$aws/things/cellar/shadow/update", sent[0].topic)
+	assert.Equal(t, map[string]any{"state": map[string]any{"reported": map[string]any{
+		"ID_Einst_BWS_akt": 48.5,
+		"ID_Ba_Hz_akt":     "Holidays",
+	}}}, sent[0].payload)
+
+	// unchanged values are not reported again
+	require.NoError(t, twin.Write(ctx, c.Name(), time.Now(), BlockParameters, pm))
+	assert.Len(t, sent, 1)
+	cm := NewCalculationsMap()
+	cm[CalcOutdoorTemperature].reading.Raw = 53
+	require.NoError(t, twin.Write(ctx, c.Name(), time.Now(), BlockCalculations, cm))
+	require.Len(t, sent, 2)
+	assert.Equal(t, map[string]any{"state": map[string]any{"reported": map[string]any{
+		"ID_WEB_Temperatur_TA": 5.3,
+	}}}, sent[1].payload)
+
+	err = twin.Desired([]byte(`{"version":7,"timestamp":1700000000,"state":{"ID_Einst_BWS_akt":50,"ID_WEB_Temperatur_TA":20}}`))
+	assert.ErrorIs(t, err, ErrWritingNotAllowed, "calculations are not writeable")
+	require.NoError(t, twin.Write(ctx, c.Name(), time.Now(), BlockParameters, pm))
+	hp.mu.Lock()
+	assert.Equal(t, uint32(500), hp.parameters[ParamHotWaterTarget])
+	hp.mu.Unlock()
+
+	twin.Resync()
+	require.NoError(t, twin.Write(ctx, c.Name(), time.Now(), BlockCalculations, cm))
+	assert.Len(t, sent, 3)
+}
+
+func TestCloudTwin_Azure(t *testing.T) {
+	var sent []cloudMessage
+	twin, err := NewCloudTwin(nil, CloudTwinOptions{
+		Platform: CloudAzure,
+		Names:    []string{"ID_Ba_Hz_akt"},
+		Publish: func(topic string, payload []byte) error {
+			m := cloudMessage{topic: topic}
+			require.NoError(t, json.Unmarshal(payload, &m.payload))
+			sent = append(sent, m)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "$iothub/twin/PATCH/properties/desired/#", twin.DesiredTopic())
+
+	pm := NewParameterMap()
+	pm[ParamHeatingMode].reading.Raw = 0
+	require.NoError(t, twin.Write(context.Background(), "any", time.Now(), BlockParameters, pm))
+	pm[ParamHeatingMode].reading.Raw = 4
+	require.NoError(t, twin.Write(context.Background(), "any", time.Now(), BlockParameters, pm))
+	require.Len(t, sent, 2)
+	assert.Equal(t, "$iothub/twin/PATCH/properties/reported/?$rid=1", sent[0].topic)
+	assert.Equal(t, "$iothub/twin/PATCH/properties/reported/?$rid=2", sent[1].topic)
+	assert.Equal(t, map[string]any{"ID_Ba_Hz_akt": "Off"}, sent[1].payload)
+
+	assert.NoError(t, twin.Desired([]byte(`{"ID_Ba_Hz_akt":"Automatic","$version":3}`)))
+	assert.ErrorIs(t, twin.Desired([]byte(`{"ID_Einst_BWS_akt":50}`)), ErrWritingNotAllowed, "not in Names")
+	assert.Error(t, twin.Desired([]byte(`[]`)))
+}
+
+func TestNewCloudTwin_Invalid(t *testing.T) {
+	publish := func(string, []byte) error { return nil }
+	for name, opts := range map[string]CloudTwinOptions{
+		"platform": {Platform: "gcp", Names: []string{"ID_Ba_Hz_akt"}, Publish: publish},
+		"thing":    {Platform: CloudAWS, Names: []string{"ID_Ba_Hz_akt"}, Publish: publish},
+		"names":    {Platform: CloudAzure, Publish: publish},
+		"unknown":  {Platform: CloudAzure, Names: []string{"ID_Nope"}, Publish: publish},
+		"index":    {Platform: CloudAzure, Names: []string{"3"}, Publish: publish},
+		"publish":  {Platform: CloudAzure, Names: []string{"ID_Ba_Hz_akt"}},
+	} {
+		_, err := NewCloudTwin(nil, opts)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestAzureSASToken(t *testing.T) {
+	token, err := AzureSASToken("myhub.azure-devices.net/devices/cellar", "c2VjcmV0LWRldmljZS1rZXk=", time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "SharedAccessSignature sr=myhub.azure-devices.net%2Fdevices%2Fcellar&sig=T6%2FLFREaoSjKxaWpq%2BqXcufWQl8Zi3UZ%2BpkuTko%2Bbj4%3D&se=1700000000", token)
+
+	_, err = AzureSASToken("myhub.azure-devices.net/devices/cellar", "not base64!", time.Now())
+	assert.Error(t, err)
+}
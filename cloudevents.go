@@ -0,0 +1,61 @@
+package luxtronik
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CloudEventsContentType is the media type of a CloudEvent in the structured
+// JSON mode.
+const CloudEventsContentType = "application/cloudevents+json"
+
+// CloudEventsTypePrefix starts the type of the CloudEvents, it is followed
+// by the kind, e.g. io.github.schumacherfm.luxtronik.change.
+const CloudEventsTypePrefix = "io.github.schumacherfm.luxtronik."
+
+// CloudEvent is an event in the JSON format of CloudEvents 1.0. Source is
+// the heat pump, e.g. /luxtronik/cellar, and Subject the name of a changed
+// value, the rule of an alert or the code of an error. Data is a
+// ChangeEvent or a Notification.
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            any       `json:"data"`
+}
+
+func newCloudEvent(kind, host, subject string, ts time.Time, data any) CloudEvent {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              hex.EncodeToString(id[:]),
+		Source:          "/luxtronik/" + url.PathEscape(host),
+		Type:            CloudEventsTypePrefix + kind,
+		Subject:         subject,
+		Time:            ts,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// ChangeCloudEvent wraps a changed value.
+func ChangeCloudEvent(e ChangeEvent) CloudEvent {
+	return newCloudEvent(EventChange, e.Host, e.Name, e.Time, e)
+}
+
+// NotificationCloudEvent wraps an alert or an error of the error memory.
+func NotificationCloudEvent(n Notification) CloudEvent {
+	subject := n.Rule
+	if n.Kind == NotificationError {
+		subject = strconv.FormatUint(uint64(n.Code), 10)
+	}
+	return newCloudEvent(n.Kind, n.Host, subject, n.Time, n)
+}
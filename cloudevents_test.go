@@ -0,0 +1,81 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationCloudEvent(t *testing.T) {
+	ts := time.Unix(1700000000, 0).UTC()
+	n := ErrorNotification("cellar", ErrorEntry{Time: ts, Code: 701, Message: "low pressure fault"})
+	e := NotificationCloudEvent(n)
+	assert.Equal(t, "1.0", e.SpecVersion)
+	assert.Len(t, e.ID, 32)
+	assert.NotEqual(t, e.ID, NotificationCloudEvent(n).ID, "ids are unique")
+	assert.Equal(t, "/luxtronik/cellar", e.Source)
+	assert.Equal(t, "io.github.schumacherfm.luxtronik.error", e.Type)
+	assert.Equal(t, "701", e.Subject)
+	assert.Equal(t, ts, e.Time)
+	assert.Equal(t, n, e.Data)
+
+	r, err := ParseAlertRule("cold: ID_WEB_Temperatur_TA < 5")
+	require.NoError(t, err)
+	e = NotificationCloudEvent(AlertNotification(AlertEvent{Rule: r, Host: "hp 2", Time: ts}))
+	assert.Equal(t, "/luxtronik/hp%202", e.Source)
+	assert.Equal(t, r.String(), e.Subject)
+}
+
+func TestEventSink_CloudEvents(t *testing.T) {
+	pub := &recordingPublisher{}
+	s, err := NewEventSink(EventOptions{Publisher: pub, Format: EventFormatCloudEvents})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	pm := NewCalculationsMap()
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	pm[CalcOutdoorTemperature].reading.Raw = 48
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.Len(t, pub.msgs, 1)
+
+	var e struct {
+		CloudEvent
+		Data ChangeEvent `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(pub.msgs[0].Payload, &e))
+	assert.Equal(t, "1.0", e.SpecVersion)
+	assert.Equal(t, "io.github.schumacherfm.luxtronik.change", e.Type)
+	assert.Equal(t, "ID_WEB_Temperatur_TA", e.Subject)
+	assert.Equal(t, "application/json", e.DataContentType)
+	assert.Equal(t, 4.8, e.Data.Value)
+
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationAlert, Host: "cellar", Time: ts, Rule: "cold"}))
+	assert.Contains(t, string(pub.msgs[1].Payload), `"type":"io.github.schumacherfm.luxtronik.alert"`)
+}
+
+func TestWebhookNotifier_CloudEvents(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, CloudEventsContentType, r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &got))
+	}))
+	defer srv.Close()
+
+	n, err := NotifierConfig{Type: "webhook", URL: srv.URL, Format: EventFormatCloudEvents}.Notifier()
+	require.NoError(t, err)
+	require.NoError(t, n.Notify(context.Background(), ErrorNotification("cellar", ErrorEntry{Time: time.Now(), Code: 701})))
+	assert.Equal(t, "1.0", got["specversion"])
+	assert.Equal(t, "io.github.schumacherfm.luxtronik.error", got["type"])
+
+	_, err = NotifierConfig{Type: "webhook", URL: srv.URL, Format: "xml"}.Notifier()
+	assert.Error(t, err)
+}
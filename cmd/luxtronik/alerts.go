@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// alertFlags define the alert rules and notifiers, see newAlerts.
+var alertFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  "alert",
+		Usage: `logs an alert when a value fulfills the rule, e.g. "low brine: ID_WEB_LIN_ND < 0.5 for 5m hysteresis 0.2"`,
+	},
+	&cli.StringFlag{Name: "alert-config", Usage: "YAML file with alert rules and notifiers like telegram or pushover"},
+	&cli.StringSliceFlag{Name: "webhook", Usage: "posts alerts and new entries of the error memory as JSON to this URL"},
+	&cli.StringFlag{Name: "webhook-format", Usage: "json or cloudevents", Value: "json"},
+	&cli.StringFlag{Name: "webhook-secret", Usage: "signs the webhook body with HMAC-SHA256 in the X-Luxtronik-Signature header", EnvVars: []string{"LUXTRONIK_WEBHOOK_SECRET"}},
+}
+
+// newAlerts returns the sinks of the alert rules and of the notifiers,
+// without the respective flags nil. The extra notifiers receive the alerts
+// as well.
+func newAlerts(c *cli.Context, logger *zap.Logger, extra ...luxtronik.Notifier) (*luxtronik.AlertEngine, *luxtronik.Dispatcher, error) {
+	var cfg luxtronik.AlertConfig
+	if path := c.String("alert-config"); path != "" {
+		var err error
+		if cfg, err = luxtronik.LoadAlertConfig(path); err != nil {
+			return nil, nil, cli.Exit(err.Error(), 2)
+		}
+	}
+	for _, s := range c.StringSlice("alert") {
+		r, err := luxtronik.ParseAlertRule(s)
+		if err != nil {
+			return nil, nil, cli.Exit(err.Error(), 2)
+		}
+		cfg.Rules = append(cfg.Rules, r)
+	}
+	for _, u := range c.StringSlice("webhook") {
+		cfg.Notifiers = append(cfg.Notifiers, luxtronik.NotifierConfig{Type: "webhook", URL: u, Secret: c.String("webhook-secret"), Format: c.String("webhook-format")})
+	}
+
+	notifiers := extra
+	for _, nc := range cfg.Notifiers {
+		if strings.EqualFold(nc.Type, "webhook") {
+			// one spool per URL, see --spool-dir
+			sum := sha256.Sum256([]byte(nc.URL))
+			spool, err := openSpool(c, "webhook-"+hex.EncodeToString(sum[:6]), logger)
+			if err != nil {
+				return nil, nil, err
+			}
+			nc.Spool = spool
+		}
+		n, err := nc.Notifier()
+		if err != nil {
+			return nil, nil, cli.Exit(err.Error(), 2)
+		}
+		notifiers = append(notifiers, n)
+	}
+	var dispatcher *luxtronik.Dispatcher
+	var notify func(luxtronik.AlertEvent)
+	if len(notifiers) > 0 {
+		dispatcher = luxtronik.NewDispatcher(logger, notifiers...)
+		notify = dispatcher.Alert
+	}
+	var alerts *luxtronik.AlertEngine
+	if len(cfg.Rules) > 0 {
+		alerts = luxtronik.NewAlertEngine(luxtronik.AlertOptions{Rules: cfg.Rules, Notify: notify, Logger: logger})
+	}
+	return alerts, dispatcher, nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var backupCommand = &cli.Command{
+	Name:  "backup",
+	Usage: "Writes all writeable parameters to a file",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "format", Value: "json", Usage: "json or yaml"},
+		&cli.StringFlag{Name: "out", Usage: "output file of a single heat pump, defaults to luxtronik-backup-<pump>-<time>.<format>"},
+	},
+	Action: runBackup,
+}
+
+var restoreCommand = &cli.Command{
+	Name:      "restore",
+	Usage:     "Writes the parameters of a backup file back to the heat pump",
+	ArgsUsage: "<backup-file>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "applies all changes without confirmation"},
+		&cli.BoolFlag{Name: "diff", Usage: "only prints the differences"},
+	},
+	Action: runRestore,
+}
+
+func runBackup(c *cli.Context) error {
+	format := c.String("format")
+	write := (*luxtronik.Dump).WriteJSON
+	switch format {
+	case "json":
+	case "yaml", "yml":
+		write = (*luxtronik.Dump).WriteYAML
+	default:
+		return cli.Exit(fmt.Sprintf("unsupported format %q", format), 2)
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	out := c.String("out")
+	if out != "" && pool.Len() > 1 {
+		return cli.Exit("--out requires a single heat pump, select one with --pump", 2)
+	}
+
+	// each heat pump gets its own file so that it can be restored separately
+	now := time.Now()
+	for _, client := range pool.Clients() {
+		blocks, err := firmwareBlocks(client)
+		if err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		pm := blocks[luxtronik.BlockParameters]
+		if err := readBlocks(client, map[string]luxtronik.DataTypeMap{luxtronik.BlockParameters: pm}); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		d := luxtronik.NewParameterBackup(client.Name(), now, pm)
+
+		file := out
+		if file == "" {
+			file = fmt.Sprintf("luxtronik-backup-%s-%s.%s", client.Name(), now.Format("20060102-150405"), format)
+		}
+		if err := writeBackup(c, d, file, write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBackup(c *cli.Context, d *luxtronik.Dump, out string, write func(*luxtronik.Dump, io.Writer) error) error {
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := write(d, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "saved %d parameters to %s\n", len(d.Entries), out)
+	return nil
+}
+
+func runRestore(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.Exit("usage: luxtronik restore <backup-file>", 2)
+	}
+	dumps, err := readDumpFile(c.Args().First())
+	if err != nil {
+		return err
+	}
+	if len(dumps) != 1 {
+		return cli.Exit(fmt.Sprintf("expected one backup in file, found %d", len(dumps)), 1)
+	}
+	backup := dumps[0]
+
+	client, err := restoreClient(c, backup.Host)
+	if err != nil {
+		return err
+	}
+	blocks, err := firmwareBlocks(client)
+	if err != nil {
+		return err
+	}
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	pm := blocks[luxtronik.BlockParameters]
+	if err := client.ReadParameters(pm); err != nil {
+		return err
+	}
+
+	changes := backup.ParameterChanges(pm)
+	if len(changes) == 0 {
+		fmt.Fprintln(c.App.Writer, "heat pump already matches the backup")
+		return nil
+	}
+	fmt.Fprintf(c.App.Writer, "backup of %s from %s differs in %d parameters:\n",
+		backup.Host, backup.Time.Format(time.DateTime), len(changes))
+
+	written := 0
+	for _, ch := range changes {
+		fmt.Fprintf(c.App.Writer, "%s (%d): %v → %v %s\n", ch.Base.Name(), ch.Index,
+			ch.Base.FromHeatPumpRaw(ch.Current), ch.Base.FromHeatPumpRaw(ch.Backup), ch.Base.Unit())
+		if c.Bool("diff") {
+			continue
+		}
+		if !c.Bool("yes") && !dryRun(c) && !confirm(c, "Restore?") {
+			continue
+		}
+		if err := client.WriteParameterRaw(pm, ch.Index, ch.Backup); err != nil {
+			return err
+		}
+		written++
+	}
+	if written == 0 {
+		return nil
+	}
+	if dryRun(c) {
+		fmt.Fprintf(c.App.Writer, "dry run, %d parameters not written\n", written)
+		return nil
+	}
+
+	if err := client.ReadParameters(pm); err != nil {
+		return fmt.Errorf("verifying restore: %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "restored %d parameters, %d still differ\n", written, len(backup.ParameterChanges(pm)))
+	return nil
+}
+
+// restoreClient selects the heat pump the backup was taken from if several
+// are configured.
+func restoreClient(c *cli.Context, host string) (*luxtronik.Client, error) {
+	pool, err := newPool(c)
+	if err != nil {
+		return nil, err
+	}
+	if pool.Len() == 1 {
+		return pool.Clients()[0], nil
+	}
+	if client, ok := pool.Client(host); ok {
+		return client, nil
+	}
+	return nil, cli.Exit(fmt.Sprintf("backup of %q matches none of the heat pumps, select one with --pump", host), 1)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var clockCommand = &cli.Command{
+	Name:  "clock",
+	Usage: "Compares the clocks of the heat pumps with the local clock, exits 1 if one drifts more than --max-drift",
+	Description: `The protocol offers no way to set the clock of the controller, correct a
+drift on its display or web interface.`,
+	Flags: []cli.Flag{
+		&cli.DurationFlag{Name: "max-drift", Usage: "largest tolerated difference", Value: 2 * time.Minute},
+	},
+	Action: runClock,
+}
+
+func runClock(c *cli.Context) error {
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	var mu sync.Mutex
+	clocks := map[string]time.Time{}
+	now := time.Now()
+	err = pool.Each(c.Context, func(ctx context.Context, client *luxtronik.Client) error {
+		if err := client.ConnectContext(ctx); err != nil {
+			return err
+		}
+		clock, err := client.ReadClockContext(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		clocks[client.Name()] = clock
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "PUMP\tCLOCK\tDRIFT")
+	drifting := false
+	for _, client := range pool.Clients() {
+		clock := clocks[client.Name()]
+		// the controller reports whole seconds
+		drift := clock.Sub(now.Truncate(time.Second))
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", client.Name(), clock.Format(time.DateTime), drift)
+		drifting = drifting || drift.Abs() > c.Duration("max-drift")
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if drifting {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var cloudCommand = &cli.Command{
+	Name:  "cloud",
+	Usage: "Mirrors values into an AWS IoT Core shadow or an Azure IoT Hub device twin",
+	Description: `Reports the --name values as state of the device shadow (--platform aws) or
+device twin (--platform azure) whenever they change, and writes the desired
+state of writeable parameters among them to the heat pump. Values use metric
+units and English codes. Run one bridge per site with its own thing or device.
+
+AWS IoT Core authenticates with the X.509 certificate of the thing: pass the
+endpoint as --mqtt-broker, e.g. ssl://abc-ats.iot.eu-central-1.amazonaws.com:8883,
+and --mqtt-cert, --mqtt-key. The client id defaults to --thing.
+
+Azure IoT Hub connects to --azure-hub and authenticates with SAS tokens
+signed by --azure-key or with an X.509 certificate via --mqtt-cert, --mqtt-key.`,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{Name: "platform", Usage: luxtronik.CloudAWS + " or " + luxtronik.CloudAzure, Required: true},
+		&cli.StringFlag{Name: "thing", Usage: "thing name of AWS IoT Core or device id of Azure IoT Hub", Required: true},
+		&cli.StringSliceFlag{Name: "name", Usage: "luxtronik name of a reported value, e.g. ID_WEB_Temperatur_TA", Required: true},
+		&cli.StringFlag{Name: "azure-hub", Usage: "host name of the IoT hub, e.g. myhub.azure-devices.net"},
+		&cli.StringFlag{Name: "azure-key", Usage: "base64 encoded symmetric key of the device", EnvVars: []string{"LUXTRONIK_AZURE_KEY"}},
+		&cli.DurationFlag{Name: "azure-token-ttl", Usage: "validity of the SAS tokens, renewed on every connect", Value: time.Hour},
+		&cli.DurationFlag{Name: "interval", Usage: "poll interval", Value: pollInterval},
+	}, append(budgetFlags, mqttFlags...)...),
+	Action: runCloud,
+}
+
+func runCloud(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	var mc mqtt.Client
+	twin, err := luxtronik.NewCloudTwin(client, luxtronik.CloudTwinOptions{
+		Platform: c.String("platform"),
+		Thing:    c.String("thing"),
+		Names:    c.StringSlice("name"),
+		Publish: func(topic string, payload []byte) error {
+			t := mc.Publish(topic, mqttQoS(c), false, payload)
+			if !t.WaitTimeout(10 * time.Second) {
+				return errors.New("timed out")
+			}
+			return t.Error()
+		},
+		Logger: logger,
+	})
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+
+	opts, err := cloudClientOptions(c)
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	topic := twin.DesiredTopic()
+	onDesired := func(_ mqtt.Client, msg mqtt.Message) {
+		if err := twin.Desired(msg.Payload()); err != nil {
+			logger.Warn("cloud desired state rejected", zap.String("topic", msg.Topic()), zap.Error(err))
+		}
+	}
+	mc, err = connectMQTT(c, opts, func(mc mqtt.Client) {
+		twin.Resync()
+		if t := mc.Subscribe(topic, mqttQoS(c), onDesired); t.Wait() && t.Error() != nil {
+			logger.Error("mqtt subscription failed", zap.String("topic", topic), zap.Error(t.Error()))
+		}
+	}, logger)
+	if err != nil {
+		return err
+	}
+	defer disconnectMQTT(c, mc)
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	p := luxtronik.NewPoller(client, pollerOptions(c, logger), twin)
+	defer p.Close()
+	logger.Info("cloud bridge started", zap.String("platform", c.String("platform")),
+		zap.String("broker", c.String("mqtt-broker")), zap.String("thing", c.String("thing")))
+	return p.Run(ctx)
+}
+
+// cloudClientOptions returns the MQTT options of --platform, both platforms
+// identify the device by its client id.
+func cloudClientOptions(c *cli.Context) (*mqtt.ClientOptions, error) {
+	thing := c.String("thing")
+	if c.String("mqtt-client-id") == "" {
+		if err := c.Set("mqtt-client-id", thing); err != nil {
+			return nil, err
+		}
+	}
+	hub := c.String("azure-hub")
+	if c.String("platform") == luxtronik.CloudAzure {
+		if hub == "" {
+			return nil, errors.New("--platform azure needs --azure-hub")
+		}
+		if c.String("mqtt-broker") == "" {
+			if err := c.Set("mqtt-broker", "ssl://"+hub+":8883"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	opts, err := mqttClientOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	opts.SetProtocolVersion(4) // MQTT 3.1.1, the only version of both
+	if c.String("platform") != luxtronik.CloudAzure {
+		return opts, nil
+	}
+
+	username := hub + "/" + thing + "/?api-version=2021-04-12"
+	key, ttl := c.String("azure-key"), c.Duration("azure-token-ttl")
+	if key == "" {
+		return opts.SetUsername(username), nil
+	}
+	resource := hub + "/devices/" + url.PathEscape(thing)
+	if _, err := luxtronik.AzureSASToken(resource, key, time.Now()); err != nil {
+		return nil, fmt.Errorf("--azure-key: %w", err)
+	}
+	return opts.SetCredentialsProvider(func() (string, string) {
+		token, _ := luxtronik.AzureSASToken(resource, key, time.Now().Add(ttl))
+		return username, token
+	}), nil
+}
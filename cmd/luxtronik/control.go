@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// burstFlags start a burst of fast polls and serve its control endpoint, see
+// startBurst.
+var burstFlags = []cli.Flag{
+	&cli.DurationFlag{Name: "burst", Usage: "polls every --burst-interval for this long after the start, e.g. 10m"},
+	&cli.DurationFlag{Name: "burst-interval", Usage: "poll interval of a burst", Value: luxtronik.MinBurstInterval},
+	&cli.StringFlag{
+		Name:  "control-listen",
+		Usage: "serves GET, POST and DELETE /burst to control bursts, GET /schema and the API of the command on this address, e.g. :8092",
+	},
+}
+
+// startBurst starts the burst requested by --burst and serves the control
+// endpoint with the additional routes until ctx is done.
+func startBurst(ctx context.Context, c *cli.Context, logger *zap.Logger, p *luxtronik.Poller, routes map[string]http.Handler) error {
+	if d := c.Duration("burst"); d > 0 {
+		p.Burst(c.Duration("burst-interval"), d)
+	}
+	addr := c.String("control-listen")
+	if addr == "" {
+		return nil
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/burst", luxtronik.BurstHandler(p))
+	mux.Handle("/schema", luxtronik.SchemaHandler(newBlocks()))
+	for pattern, h := range routes {
+		mux.Handle(pattern, h)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("control endpoint failed", zap.Error(err))
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	logger.Info("serving the control endpoint", zap.String("addr", ln.Addr().String()))
+	return nil
+}
+
+// historyFlags store the changed values, see openHistory, and keep the last
+// values in memory.
+var historyFlags = []cli.Flag{
+	&cli.IntFlag{Name: "recent", Usage: "keeps the values of the last polls in memory, served via GET /api/v1/recent on --control-listen"},
+	&cli.StringFlag{Name: "history-db", Usage: "stores every changed value in this embedded database, served via GET /api/v1/history on --control-listen"},
+	&cli.DurationFlag{Name: "history-retention", Usage: "drops stored values older than this, 0 keeps them forever", Value: 90 * 24 * time.Hour},
+}
+
+// openHistory opens the store of --history-db, nil without the flag.
+func openHistory(c *cli.Context, deadbands luxtronik.Deadbands, logger *zap.Logger) (*luxtronik.HistoryStore, error) {
+	path := c.String("history-db")
+	if path == "" {
+		return nil, nil
+	}
+	return luxtronik.NewHistoryStore(luxtronik.HistoryOptions{
+		Path:      path,
+		Retention: c.Duration("history-retention"),
+		Deadbands: deadbands,
+		Logger:    logger,
+	})
+}
+
+// parquetFlags write all values to rolling Parquet files, see newParquetSink.
+var parquetFlags = []cli.Flag{
+	&cli.StringFlag{Name: "parquet-dir", Usage: "writes all values to Parquet files in this directory, e.g. for pandas or DuckDB"},
+	&cli.DurationFlag{Name: "parquet-rotate", Usage: "starts a new Parquet file for every period", Value: 24 * time.Hour},
+}
+
+// newParquetSink returns the sink of --parquet-dir, nil without the flag.
+func newParquetSink(c *cli.Context) (*luxtronik.ParquetSink, error) {
+	dir := c.String("parquet-dir")
+	if dir == "" {
+		return nil, nil
+	}
+	return luxtronik.NewParquetSink(luxtronik.ParquetSinkOptions{
+		Dir:    dir,
+		Rotate: c.Duration("parquet-rotate"),
+	})
+}
+
+// formsTokenFlag serves the parameter forms, see newForms.
+var formsTokenFlag = &cli.StringFlag{
+	Name:    "forms-token",
+	Usage:   "serves forms to write the parameters via /ui/parameters on --control-listen, writes need this token",
+	EnvVars: []string{"LUXTRONIK_FORMS_TOKEN"},
+}
+
+// newForms returns the parameter forms of --forms-token, nil without the
+// flag. The forms use their own client, so that writes do not interfere
+// with the polls.
+func newForms(c *cli.Context, logger *zap.Logger) (*luxtronik.Forms, error) {
+	token := c.String(formsTokenFlag.Name)
+	if token == "" {
+		return nil, nil
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %w", formsTokenFlag.Name, err)
+	}
+	return luxtronik.NewForms(luxtronik.NewDevice(client), luxtronik.FormsOptions{Token: token, Logger: logger}), nil
+}
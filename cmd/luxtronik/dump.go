@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var dumpCommand = &cli.Command{
+	Name:  "dump",
+	Usage: "Writes a timestamped snapshot of all values to a file",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "format", Value: "json", Usage: "csv, json, parquet or yaml"},
+		&cli.StringFlag{Name: "out", Usage: "output file, - writes to stdout, defaults to luxtronik-<time>.<format>"},
+	},
+	Action: runDump,
+}
+
+func runDump(c *cli.Context) error {
+	format := c.String("format")
+	var writeAll func(io.Writer, ...*luxtronik.Dump) error
+	var writeOne func(*luxtronik.Dump, io.Writer) error
+	switch format {
+	case "csv":
+		writeAll = luxtronik.WriteDumpsCSV
+	case "parquet":
+		writeAll = luxtronik.WriteDumpsParquet
+	case "json":
+		writeOne = (*luxtronik.Dump).WriteJSON
+	case "yaml", "yml":
+		writeOne = (*luxtronik.Dump).WriteYAML
+	default:
+		return cli.Exit(fmt.Sprintf("unsupported format %q", format), 2)
+	}
+	// several heat pumps end up in one table or one document stream
+	write := func(dumps []*luxtronik.Dump, w io.Writer) error {
+		if writeAll != nil {
+			return writeAll(w, dumps...)
+		}
+		for _, d := range dumps {
+			if err := writeOne(d, w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var dumps []*luxtronik.Dump
+	values := 0
+	for _, client := range pool.Clients() {
+		blocks, err := firmwareBlocks(client)
+		if err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		if err := readBlocks(client, blocks); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		d := luxtronik.NewDump(client.Name(), now, blocks)
+		dumps = append(dumps, d)
+		values += len(d.Entries)
+	}
+
+	out := c.String("out")
+	if out == "" {
+		out = fmt.Sprintf("luxtronik-%s.%s", now.Format("20060102-150405"), format)
+	}
+	if out == "-" {
+		return write(dumps, c.App.Writer)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := write(dumps, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.ErrWriter, "wrote %d values of %d heat pumps to %s\n", values, len(dumps), out)
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var envelopeCommand = &cli.Command{
+	Name:  "envelope",
+	Usage: "Compares the operating point with the envelope of the heat pump model",
+	Flags: []cli.Flag{
+		&cli.Float64Flag{Name: "margin", Usage: "distance in K to a limit which counts as near", Value: 2},
+	},
+	Action: runEnvelope,
+}
+
+func runEnvelope(c *cli.Context) error {
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "PUMP\tMODEL\tSOURCE\tFLOW\tENVELOPE\tSTATE")
+	outside := false
+	for _, client := range pool.Clients() {
+		blocks, err := firmwareBlocks(client)
+		if err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		pm := blocks[luxtronik.BlockCalculations]
+		if err := readBlocks(client, map[string]luxtronik.DataTypeMap{luxtronik.BlockCalculations: pm}); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		check, ok := luxtronik.CheckEnvelope(pm, c.Float64("margin"))
+		if !ok {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\tunknown model\t-\n", client.Name(), check.Model)
+			continue
+		}
+		e := check.Envelope
+		fmt.Fprintf(tw, "%s\t%s\t%.1f °C\t%.1f °C\tsource %g…%g °C, flow ≤ %g °C\t%s\n",
+			client.Name(), check.Model, check.SourceTemp, check.FlowTemp,
+			e.MinSource, e.MaxSource, e.MaxFlow, check.State)
+		outside = outside || check.State == luxtronik.EnvelopeOutside
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if outside {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
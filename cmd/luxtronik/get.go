@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var getCommand = &cli.Command{
+	Name:  "get",
+	Usage: "Prints values by name, glob pattern or index",
+	Description: "A single match prints only the value, several matches print one name and value per line.\n" +
+		"Indexes require --block. Example: luxtronik get 'ID_WEB_Temperatur_T*'",
+	ArgsUsage: "<name-or-index>...",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "block", Usage: "restricts the lookup to parameters, calculations or visibilities"},
+		&cli.BoolFlag{Name: "raw", Usage: "prints the raw value instead of the converted one"},
+		&cli.BoolFlag{Name: "json", Usage: "prints a JSON array"},
+		&cli.BoolFlag{Name: "provenance", Usage: "adds the origin and conversion of each value to the JSON output"},
+	},
+	Action: runGet,
+}
+
+type getResult struct {
+	Host  string `json:"host"`
+	Block string `json:"block"`
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+	Unit  string `json:"unit,omitempty"`
+	Raw   uint32 `json:"raw"`
+	// Provenance is only set with --provenance.
+	Provenance *luxtronik.Provenance `json:"provenance,omitempty"`
+
+	base *luxtronik.Base
+}
+
+func runGet(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cli.Exit("usage: luxtronik get <name-or-index>...", 2)
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+
+	var results []getResult
+	for _, client := range pool.Clients() {
+		res, err := getResults(c, client)
+		if err != nil {
+			return err
+		}
+		results = append(results, res...)
+	}
+
+	w := c.App.Writer
+	switch {
+	case c.Bool("json"):
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case len(results) == 1:
+		fmt.Fprintln(w, getValue(c, results[0]))
+	default:
+		for _, r := range results {
+			if pool.Len() > 1 {
+				fmt.Fprintf(w, "%s\t", r.Host)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", r.Name, getValue(c, r))
+		}
+	}
+	return nil
+}
+
+// getResults resolves the arguments and reads the matching values of a
+// single heat pump.
+func getResults(c *cli.Context, client *luxtronik.Client) ([]getResult, error) {
+	blocks, err := firmwareBlocks(client)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", client.Name(), err)
+	}
+	if b := c.String("block"); b != "" {
+		pm, ok := blocks[b]
+		if !ok {
+			return nil, cli.Exit(fmt.Sprintf("unknown block %q", b), 2)
+		}
+		blocks = map[string]luxtronik.DataTypeMap{b: pm}
+	}
+
+	results, err := resolveGetArgs(c.String("block"), c.Args().Slice(), blocks)
+	if err != nil {
+		return nil, cli.Exit(err.Error(), 1)
+	}
+
+	// only read the blocks which contain matches
+	used := map[string]luxtronik.DataTypeMap{}
+	for _, r := range results {
+		used[r.Block] = blocks[r.Block]
+	}
+	if err := readBlocks(client, used); err != nil {
+		return nil, fmt.Errorf("%s: %w", client.Name(), err)
+	}
+
+	for i := range results {
+		r := &results[i]
+		r.Host = client.Name()
+		r.Raw = r.base.RawValue()
+		r.Value = luxtronik.DumpValue(r.base.FromHeatPump())
+		if c.Bool("provenance") {
+			p := r.base.Provenance(r.Block, r.Index)
+			r.Provenance = &p
+		}
+	}
+	return results, nil
+}
+
+func getValue(c *cli.Context, r getResult) string {
+	if c.Bool("raw") {
+		return strconv.FormatUint(uint64(r.Raw), 10)
+	}
+	return formatValue(r.base)
+}
+
+func resolveGetArgs(block string, args []string, blocks map[string]luxtronik.DataTypeMap) ([]getResult, error) {
+	var results []getResult
+	seen := map[string]bool{}
+	add := func(block string, idx int, b *luxtronik.Base) {
+		key := block + ":" + strconv.Itoa(idx)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		results = append(results, getResult{Block: block, Index: idx, Name: b.Name(), Unit: b.Unit(), base: b})
+	}
+
+	// iterate blocks in a stable order
+	names := make([]string, 0, len(blocks))
+	for name := range blocks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, arg := range args {
+		if idx, err := strconv.Atoi(arg); err == nil {
+			if block == "" {
+				return nil, fmt.Errorf("index %d requires --block", idx)
+			}
+			b, ok := blocks[block][idx]
+			if !ok {
+				return nil, fmt.Errorf("unknown index %d in %s", idx, block)
+			}
+			add(block, idx, b)
+			continue
+		}
+
+		found := false
+		for _, name := range names {
+			pm := blocks[name]
+			idxs, err := pm.Match(arg)
+			if err != nil {
+				return nil, err
+			}
+			for _, idx := range idxs {
+				add(name, idx, pm[idx])
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no value matches %q", arg)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/SchumacherFM/luxtronik/grpcapi"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var grpcCommand = &cli.Command{
+	Name:  "grpc",
+	Usage: "Serves the values of a heat pump via gRPC, see grpcapi/luxtronik.proto",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "listen", Usage: "address of the gRPC server, e.g. :50051 for all interfaces", Value: "localhost:50051"},
+		&cli.StringFlag{Name: "token", Usage: "allows the Write RPC with this bearer token, read-only without", EnvVars: []string{"LUXTRONIK_GRPC_TOKEN"}},
+		&cli.StringFlag{Name: "tls-cert", Usage: "serves TLS with this certificate"},
+		&cli.StringFlag{Name: "tls-key", Usage: "private key of --tls-cert"},
+		&cli.DurationFlag{Name: "interval", Usage: "refresh interval of the values", Value: pollInterval},
+	},
+	Action: runGRPC,
+}
+
+func runGRPC(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	d := luxtronik.NewDevice(client)
+	defer d.Close()
+
+	var serverOpts []grpc.ServerOption
+	cert, key := c.String("tls-cert"), c.String("tls-key")
+	if (cert == "") != (key == "") {
+		return cli.Exit("--tls-cert and --tls-key must be set together", 2)
+	}
+	if cert != "" {
+		creds, err := credentials.NewServerTLSFromFile(cert, key)
+		if err != nil {
+			return err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	} else if c.String("token") != "" {
+		logger.Warn("the gRPC token is sent unencrypted, see --tls-cert")
+	}
+
+	ln, err := net.Listen("tcp", c.String("listen"))
+	if err != nil {
+		return err
+	}
+	srv := grpcapi.NewServer(d, grpcapi.Options{Interval: c.Duration("interval"), Token: c.String("token"), Logger: logger})
+	gs := grpc.NewServer(serverOpts...)
+	grpcapi.RegisterLuxtronikServer(gs, srv)
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go srv.Run(ctx)
+	go func() {
+		<-ctx.Done()
+		gs.GracefulStop()
+	}()
+	logger.Info("serving gRPC", zap.String("addr", ln.Addr().String()), zap.Bool("read_only", c.String("token") == ""))
+	return gs.Serve(ln)
+}
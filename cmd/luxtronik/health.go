@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	healthListenFlag = &cli.StringFlag{
+		Name:  "health-listen",
+		Usage: "serves the reachability of the heat pumps via GET /healthz on this address, e.g. :8091",
+	}
+	healthTimeoutFlag = &cli.DurationFlag{
+		Name:  "health-timeout",
+		Usage: "budget of a single health check",
+		Value: 5 * time.Second,
+	}
+)
+
+var healthCommand = &cli.Command{
+	Name:  "health",
+	Usage: "Checks that the heat pumps answer and prints the latency, exits 1 if one is unreachable",
+	Flags: []cli.Flag{
+		healthTimeoutFlag,
+	},
+	Action: runHealth,
+}
+
+func runHealth(c *cli.Context) error {
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	res := luxtronik.CheckHealth(c.Context, c.Duration(healthTimeoutFlag.Name), pool.Clients()...)
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "PUMP\tREACHABLE\tLATENCY\tERROR")
+	down := false
+	for _, s := range res {
+		latency := "-"
+		if s.Reachable {
+			latency = s.Latency.Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(tw, "%s\t%t\t%s\t%s\n", s.Pump, s.Reachable, latency, s.Error)
+		down = down || !s.Reachable
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if down {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+// startHealth serves the readiness probe until ctx is done. It uses its own
+// clients because the clients of the poller must not be shared.
+func startHealth(ctx context.Context, c *cli.Context, logger *zap.Logger) error {
+	addr := c.String(healthListenFlag.Name)
+	if addr == "" {
+		return nil
+	}
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", luxtronik.HealthHandler(c.Duration(healthTimeoutFlag.Name), pool.Clients()...))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("health endpoint failed", zap.Error(err))
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+		_ = pool.Close()
+	}()
+	logger.Info("serving health checks", zap.String("addr", ln.Addr().String()))
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var holidayCommand = &cli.Command{
+	Name:  "holiday",
+	Usage: "Shows or programs the holiday mode",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "show",
+			Usage:  "Prints the holiday and the circuits in the holiday mode",
+			Action: runHolidayShow,
+		},
+		{
+			Name:      "set",
+			Usage:     "Writes the holiday dates and switches the circuits to the holiday mode",
+			ArgsUsage: "<start> <end>",
+			Description: `Start and end are dates like 2024-07-01 or times like 2024-07-01T08:00 in the
+time zone of --timezone.
+The holiday ends at the beginning of the end date, the day of return.`,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{Name: "heating", Usage: "puts the heating into the holiday mode", Value: true},
+				&cli.BoolFlag{Name: "hot-water", Usage: "puts the hot water into the holiday mode", Value: true},
+				&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "skip the confirmation"},
+			},
+			Action: runHolidaySet,
+		},
+		{
+			Name:   "clear",
+			Usage:  "Returns the circuits in the holiday mode to Automatic",
+			Action: runHolidayClear,
+		},
+	},
+}
+
+func runHolidayShow(c *cli.Context) error {
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	h, err := client.ReadHolidayContext(c.Context)
+	if err != nil {
+		return err
+	}
+	printHoliday(c, h)
+	return nil
+}
+
+func runHolidaySet(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cli.Exit("usage: luxtronik holiday set <start> <end>", 2)
+	}
+	loc, err := location(c)
+	if err != nil {
+		return err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	start, err := parseHolidayDate(c.Args().Get(0), loc)
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	end, err := parseHolidayDate(c.Args().Get(1), loc)
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	h := luxtronik.Holiday{Start: start, End: end, Heating: c.Bool("heating"), HotWater: c.Bool("hot-water")}
+	if !h.Heating && !h.HotWater {
+		return cli.Exit("nothing to do with --heating=false and --hot-water=false, see holiday clear", 2)
+	}
+	if !end.After(start) {
+		return cli.Exit("the end must be after the start", 2)
+	}
+
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	printHoliday(c, h)
+	if !c.Bool("yes") && !dryRun(c) && !confirm(c, "Write holiday?") {
+		return cli.Exit("aborted", 1)
+	}
+	if err := client.WriteHolidayContext(c.Context, h); err != nil {
+		return err
+	}
+	if dryRun(c) {
+		fmt.Fprintln(c.App.Writer, "dry run, not written")
+		return nil
+	}
+	h, err = client.ReadHolidayContext(c.Context)
+	if err != nil {
+		return fmt.Errorf("verifying write: %w", err)
+	}
+	fmt.Fprintln(c.App.Writer, "written, the heat pump reports:")
+	printHoliday(c, h)
+	return nil
+}
+
+func runHolidayClear(c *cli.Context) error {
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.WriteHolidayContext(c.Context, luxtronik.Holiday{}); err != nil {
+		return err
+	}
+	if dryRun(c) {
+		fmt.Fprintln(c.App.Writer, "dry run, not written")
+		return nil
+	}
+	fmt.Fprintln(c.App.Writer, "holiday mode cleared")
+	return nil
+}
+
+func parseHolidayDate(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, want e.g. 2024-07-01 or 2024-07-01T08:00", s)
+}
+
+func printHoliday(c *cli.Context, h luxtronik.Holiday) {
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Format("2006-01-02 15:04")
+	}
+	fmt.Fprintf(c.App.Writer, "start:     %s\nend:       %s\nheating:   %t\nhot water: %t\n",
+		format(h.Start), format(h.End), h.Heating, h.HotWater)
+}
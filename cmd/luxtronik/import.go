@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var importCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Imports historical CSV exports of other tools into InfluxDB",
+	ArgsUsage: "<file.csv>...",
+	Description: "Understands the CSV written by the dump command and wide tables with a time column\n" +
+		"and one column per value, named by luxtronik name, English alias or --column mapping.",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{Name: "host", Usage: "host tag of the imported values", Value: "import"},
+		&cli.StringFlag{Name: "time-column", Usage: "name of the timestamp column, detected by default"},
+		&cli.StringFlag{Name: "time-layout", Usage: "Go time layout of the timestamps, detected by default"},
+		&cli.StringFlag{Name: "timezone", Usage: "zone of timestamps without offset, e.g. Europe/Berlin", Value: "Local"},
+		&cli.StringSliceFlag{Name: "column", Usage: "maps a column to a luxtronik name, e.g. Vorlauf=ID_WEB_Temperatur_TVL"},
+		&cli.BoolFlag{Name: "dry-run", Usage: "only parses the files and prints the statistics"},
+	}, influxFlags...),
+	Action: runImport,
+}
+
+func runImport(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cli.Exit("usage: luxtronik import <file.csv>...", 2)
+	}
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	loc, err := time.LoadLocation(c.String("timezone"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("invalid timezone: %s", err), 2)
+	}
+	columns := map[string]string{}
+	for _, m := range c.StringSlice("column") {
+		header, name, ok := strings.Cut(m, "=")
+		if !ok {
+			return cli.Exit(fmt.Sprintf("invalid column mapping %q, want header=name", m), 2)
+		}
+		columns[header] = name
+	}
+	opts := luxtronik.CSVImportOptions{
+		Host:       c.String("host"),
+		TimeColumn: c.String("time-column"),
+		TimeLayout: c.String("time-layout"),
+		Location:   loc,
+		Columns:    columns,
+	}
+
+	var sink luxtronik.Sink = luxtronik.SinkFunc(func(context.Context, string, time.Time, string, luxtronik.DataTypeMap) error {
+		return nil
+	})
+	if !c.Bool("dry-run") {
+		influx, err := newInfluxSink(c, nil, logger)
+		if err != nil {
+			return err
+		}
+		sink = influx
+	}
+
+	for _, file := range c.Args().Slice() {
+		stats, err := importFile(c.Context, file, opts, sink)
+		if err != nil {
+			_ = sink.Close()
+			return err
+		}
+		fmt.Fprintf(c.App.Writer, "%s: %d rows, %d values\n", file, stats.Rows, stats.Values)
+		if len(stats.Ignored) > 0 {
+			fmt.Fprintf(c.App.Writer, "%s: ignored columns %s\n", file, strings.Join(stats.Ignored, ", "))
+		}
+	}
+	return sink.Close()
+}
+
+func importFile(ctx context.Context, file string, opts luxtronik.CSVImportOptions, sink luxtronik.Sink) (luxtronik.ImportStats, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return luxtronik.ImportStats{}, err
+	}
+	defer f.Close()
+	return luxtronik.ImportCSV(ctx, f, opts, sink)
+}
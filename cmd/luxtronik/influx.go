@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// influxFlags configure the connection to InfluxDB, see newInfluxSink.
+var influxFlags = []cli.Flag{
+	&cli.StringFlag{Name: "url", Value: "http://localhost:8086", EnvVars: []string{"INFLUX_URL"}},
+	&cli.StringFlag{Name: "db", Usage: "InfluxDB v1 database", Value: "luxtronik"},
+	&cli.StringFlag{Name: "rp", Usage: "InfluxDB v1 retention policy"},
+	&cli.StringFlag{Name: "username", Usage: "InfluxDB v1 username", EnvVars: []string{"INFLUX_USERNAME"}},
+	&cli.StringFlag{Name: "password", Usage: "InfluxDB v1 password", EnvVars: []string{"INFLUX_PASSWORD"}},
+	&cli.StringFlag{Name: "org", Usage: "InfluxDB v2 organization"},
+	&cli.StringFlag{Name: "bucket", Usage: "InfluxDB v2 bucket", Value: "luxtronik"},
+	&cli.StringFlag{Name: "token", Usage: "InfluxDB v2 token, selects the v2 API", EnvVars: []string{"INFLUX_TOKEN"}},
+	&cli.DurationFlag{Name: "flush-interval", Value: flushInterval},
+	&cli.IntFlag{Name: "batch-size", Value: 5000},
+}
+
+var influxCommand = &cli.Command{
+	Name:  "influx",
+	Usage: "Polls the heat pump and writes all values to InfluxDB",
+	Flags: append([]cli.Flag{
+		&cli.DurationFlag{Name: "interval", Usage: "poll interval", Value: pollInterval},
+		&cli.StringSliceFlag{Name: "block", Usage: "blocks to poll, defaults to all"},
+		spoolDirFlag,
+		spoolMaxBytesFlag,
+		healthListenFlag,
+		healthTimeoutFlag,
+		deadbandFlag,
+		&cli.BoolFlag{Name: "hide-invisible", Usage: "leaves out values the heat pump declares invisible, e.g. of missing sensors"},
+		&cli.BoolFlag{Name: "derived", Usage: "adds the delta-T and thermal power computed from the calculations"},
+		statsHistoryFlag,
+		formsTokenFlag,
+	}, append(append(append(append(append(append(append(append(append(budgetFlags, burstFlags...), historyFlags...), parquetFlags...), alertFlags...), knxFlags...), scheduleFlags...), influxFlags...), sensorsFlags...), priceFlags...)...),
+	Action: runInflux,
+}
+
+func runInflux(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+
+	spool, err := openSpool(c, "influx", logger)
+	if err != nil {
+		return err
+	}
+	sink, err := newInfluxSink(c, spool, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sensors, err := startSensors(ctx, c, logger)
+	if err != nil {
+		return err
+	}
+	if err := startHealth(ctx, c, logger); err != nil {
+		return err
+	}
+
+	deadbands, err := luxtronik.ParseDeadbands(c.String("deadband"))
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	diff := luxtronik.NewDiffLogger(logger)
+	diff.SetDeadbands(deadbands)
+
+	opts := pollerOptions(c, logger)
+	opts.Blocks = c.StringSlice("block")
+	opts.Sensors = sensors
+	if opts.Prices, err = newPriceFeed(ctx, c, logger); err != nil {
+		return err
+	}
+	opts.HideInvisible = c.Bool("hide-invisible")
+	if c.Bool("derived") {
+		opts.Derived = luxtronik.NewDeriver(luxtronik.DerivedOptions{})
+	}
+	sinks := []luxtronik.Sink{sink, diff, luxtronik.NewEnvelopeMonitor(logger, 0)}
+	if path := c.String(statsHistoryFlag.Name); path != "" {
+		history, err := luxtronik.NewStatsHistory(luxtronik.StatsHistoryOptions{Path: path})
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, history)
+	}
+	alerts, dispatcher, err := newAlerts(c, logger)
+	if err != nil {
+		return err
+	}
+	if alerts != nil {
+		sinks = append(sinks, alerts)
+	}
+	if dispatcher != nil {
+		sinks = append(sinks, dispatcher)
+	}
+	knx, err := newKNX(c, pool, logger)
+	if err != nil {
+		return err
+	}
+	if knx != nil {
+		sinks = append(sinks, knx)
+	}
+	scheduler, err := newScheduler(c, pool, logger)
+	if err != nil {
+		return err
+	}
+	if scheduler != nil {
+		sinks = append(sinks, scheduler)
+	}
+	routes := map[string]http.Handler{}
+	history, err := openHistory(c, deadbands, logger)
+	if err != nil {
+		return err
+	}
+	if history != nil {
+		sinks = append(sinks, history)
+		routes["/api/v1/history"] = history
+	}
+	parquet, err := newParquetSink(c)
+	if err != nil {
+		return err
+	}
+	if parquet != nil {
+		sinks = append(sinks, parquet)
+	}
+	forms, err := newForms(c, logger)
+	if err != nil {
+		return err
+	}
+	if forms != nil {
+		routes["/ui/parameters"] = forms
+	}
+	opts.RecentSize = c.Int("recent")
+	p := luxtronik.NewPoolPoller(pool, opts, sinks...)
+	defer p.Close()
+	if opts.RecentSize > 0 {
+		routes["/api/v1/recent"] = luxtronik.RecentHandler(p)
+	}
+	if err := startBurst(ctx, c, logger, p, routes); err != nil {
+		return err
+	}
+
+	return p.Run(ctx)
+}
+
+func newInfluxSink(c *cli.Context, spool *luxtronik.Spool, logger *zap.Logger) (*luxtronik.InfluxSink, error) {
+	sink, err := luxtronik.NewInfluxSink(luxtronik.InfluxOptions{
+		URL:             c.String("url"),
+		Database:        c.String("db"),
+		RetentionPolicy: c.String("rp"),
+		Username:        c.String("username"),
+		Password:        c.String("password"),
+		Org:             c.String("org"),
+		Bucket:          c.String("bucket"),
+		Token:           c.String("token"),
+		BatchSize:       c.Int("batch-size"),
+		FlushInterval:   c.Duration("flush-interval"),
+		Spool:           spool,
+		Logger:          logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("influx sink: %w", err)
+	}
+	return sink, nil
+}
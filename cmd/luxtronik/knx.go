@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// knxFlags configure the KNX/IP sink, see newKNX.
+var knxFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  "knx-map",
+		Usage: `sends the value to a KNX group address, e.g. "ID_WEB_Temperatur_TA=1/2/4" or "ID_Einst_BWS_akt=1/2/3:9.001"; group writes to writeable parameters are written to the heat pump`,
+	},
+	&cli.StringFlag{Name: "knx-address", Usage: "KNXnet/IP routing multicast address", Value: luxtronik.DefaultKNXAddress},
+	&cli.StringFlag{Name: "knx-source", Usage: "individual address of the sender", Value: "15.15.250"},
+}
+
+// newKNX returns the KNX sink, without --knx-map nil. Group writes need a
+// single heat pump in the pool.
+func newKNX(c *cli.Context, pool *luxtronik.ClientPool, logger *zap.Logger) (*luxtronik.KNXSink, error) {
+	if len(c.StringSlice("knx-map")) == 0 {
+		return nil, nil
+	}
+	opts := luxtronik.KNXOptions{
+		Address: c.String("knx-address"),
+		Source:  c.String("knx-source"),
+		Logger:  logger,
+	}
+	for _, s := range c.StringSlice("knx-map") {
+		m, err := luxtronik.ParseKNXMapping(s)
+		if err != nil {
+			return nil, cli.Exit(err.Error(), 2)
+		}
+		opts.Mappings = append(opts.Mappings, m)
+	}
+	if pool.Len() != 1 {
+		return nil, cli.Exit("--knx-map supports a single heat pump only", 2)
+	}
+	return luxtronik.NewKNXSink(pool.Clients()[0], opts)
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/SchumacherFM/luxtronik"
 	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -17,17 +23,78 @@ func main() {
 				Flags:  []cli.Flag{},
 				Action: runHTTP,
 			},
+			influxCommand,
+			setCommand,
+			searchCommand,
+			getCommand,
+			watchCommand,
+			dumpCommand,
+			seasonsCommand,
+			backupCommand,
+			restoreCommand,
+			scanCommand,
+			tariffCommand,
+			envelopeCommand,
+			importCommand,
+			reportCommand,
+			healthCommand,
+			schemaCommand,
+			shutoffsCommand,
+			thermostatCommand,
+			statsCommand,
+			grpcCommand,
+			surplusCommand,
+			holidayCommand,
+			clockCommand,
+			rawCommand,
+			cloudCommand,
+			mqttCommand,
+			eventsCommand,
 		},
 		Usage: "Luxtronik Viewer",
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
 				Name:     "ip-port",
 				Required: false,
-				Usage:    "192.168.0.121" + ":" + luxtronik.DefaultPort,
+				Usage:    "[alias=]host[:port] of each heat pump, e.g. cellar=192.168.0.121" + ":" + luxtronik.DefaultPort + ", discovered if not set",
 				EnvVars:  []string{"HEATPUMP_IP"},
 			},
+			&cli.StringSliceFlag{
+				Name:  "pump",
+				Usage: "restricts commands to the heat pumps with these aliases",
+			},
+			&cli.BoolFlag{
+				Name:  "strict-frames",
+				Usage: "rejects frames whose length does not match the catalog instead of adding unknown entries",
+			},
+			&cli.StringFlag{
+				Name:    "timezone",
+				Usage:   "IANA time zone of the heat pump's timestamps, e.g. Europe/Berlin, defaults to the local one",
+				EnvVars: []string{"HEATPUMP_TZ"},
+			},
+			&cli.StringFlag{
+				Name:    "units",
+				Usage:   "unit system of the values, metric or imperial (°F, BTU, gpm, psi)",
+				Value:   "metric",
+				EnvVars: []string{"HEATPUMP_UNITS"},
+			},
+			&cli.StringFlag{
+				Name:    "language",
+				Usage:   "language of the selection codes, en or de",
+				Value:   "en",
+				EnvVars: []string{"HEATPUMP_LANG"},
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "validates and logs writes instead of sending them to the heat pump",
+			},
+			&cli.BoolFlag{
+				Name:  "trace",
+				Usage: "prints every frame sent to and received from the heat pump as hex dump to stderr",
+			},
 			&cli.BoolFlag{
 				Name:  "verbose",
+				Usage: "logs the communication with the heat pump at debug level",
 				Value: false,
 			},
 		},
@@ -38,6 +105,236 @@ func main() {
 	}
 }
 
+const (
+	pollInterval  = 30 * time.Second
+	flushInterval = 10 * time.Second
+)
+
+// budgetFlags bound the operations of the long running commands, see
+// pollerOptions.
+var budgetFlags = []cli.Flag{
+	&cli.DurationFlag{Name: "read-timeout", Usage: "budget to read a heat pump, defaults to the interval"},
+	&cli.DurationFlag{Name: "sink-timeout", Usage: "budget of a single export or write, defaults to the interval"},
+	&cli.IntFlag{Name: "connections", Value: 1, Usage: "connections per heat pump to read the blocks in parallel, up to 3"},
+}
+
+// deadbandFlag hides small changes, e.g. temperature=0.3.
+var deadbandFlag = &cli.StringFlag{
+	Name:  "deadband",
+	Usage: "comma separated minimum changes by class or name, e.g. temperature=0.3,ID_WEB_Temperatur_TA=1",
+}
+
+// pollerOptions returns the options shared by all polling commands.
+func pollerOptions(c *cli.Context, logger *zap.Logger) luxtronik.PollerOptions {
+	return luxtronik.PollerOptions{
+		Interval:    c.Duration("interval"),
+		ReadTimeout: c.Duration("read-timeout"),
+		SinkTimeout: c.Duration("sink-timeout"),
+		Connections: c.Int("connections"),
+		Logger:      logger,
+	}
+}
+
 func runHTTP(c *cli.Context) error {
 	return nil
 }
+
+// newPool returns the clients of all configured heat pumps selected by
+// --pump.
+func newPool(c *cli.Context) (*luxtronik.ClientPool, error) {
+	logger, err := newLogger(c)
+	if err != nil {
+		return nil, err
+	}
+	hostPorts := c.StringSlice("ip-port")
+	if len(hostPorts) == 0 {
+		if hostPorts, err = discoverHeatPump(c, logger); err != nil {
+			return nil, err
+		}
+	}
+	loc, err := location(c)
+	if err != nil {
+		return nil, err
+	}
+	units, lang, err := valueFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	opts := luxtronik.Options{
+		SafeMode:       true,
+		Logger:         logger,
+		TolerantFrames: !c.Bool("strict-frames"),
+		Location:       loc,
+		Units:          units,
+		Language:       lang,
+		DryRun:         dryRun(c),
+	}
+	if c.Bool("trace") {
+		opts.TraceWriter = c.App.ErrWriter
+	}
+	pool, err := luxtronik.NewClientPool(hostPorts, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pool.Select(c.StringSlice("pump")...)
+}
+
+// valueFormat returns the unit system and the language of the values of
+// --units and --language.
+func valueFormat(c *cli.Context) (luxtronik.Units, luxtronik.Language, error) {
+	units, err := luxtronik.ParseUnits(c.String("units"))
+	if err != nil {
+		return units, "", fmt.Errorf("invalid --units: %w", err)
+	}
+	lang, err := luxtronik.ParseLanguage(c.String("language"))
+	if err != nil {
+		return units, lang, fmt.Errorf("invalid --language: %w", err)
+	}
+	return units, lang, nil
+}
+
+// discoverHeatPump looks for the heat pump if no --ip-port is given. It
+// only picks it if it is the single one found.
+func discoverHeatPump(c *cli.Context, logger *zap.Logger) ([]string, error) {
+	ctrls, err := luxtronik.Discover(c.Context, 2*time.Second)
+	if err != nil {
+		logger.Warn("heat pump discovery failed", zap.Error(err))
+	}
+	switch len(ctrls) {
+	case 0:
+		return nil, errors.New("missing flag --ip-port or env var HEATPUMP_IP, no heat pump discovered")
+	case 1:
+		logger.Info("discovered heat pump", zap.String("addr", ctrls[0].Addr), zap.String("source", ctrls[0].Source))
+		return []string{ctrls[0].Addr}, nil
+	}
+	addrs := make([]string, len(ctrls))
+	for i, ctrl := range ctrls {
+		addrs[i] = ctrl.Addr
+	}
+	return nil, fmt.Errorf("discovered several heat pumps, select them with --ip-port: %s", strings.Join(addrs, ", "))
+}
+
+// dryRun reports whether --dry-run is set globally or for the command.
+func dryRun(c *cli.Context) bool {
+	for _, l := range c.Lineage() {
+		if l.Bool("dry-run") {
+			return true
+		}
+	}
+	return false
+}
+
+// location returns the time zone of --timezone, nil if not set.
+func location(c *cli.Context) (*time.Location, error) {
+	tz := c.String("timezone")
+	if tz == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// newClient returns the client of a single heat pump for commands which
+// cannot serve several at once.
+func newClient(c *cli.Context) (*luxtronik.Client, error) {
+	pool, err := newPool(c)
+	if err != nil {
+		return nil, err
+	}
+	if pool.Len() > 1 {
+		return nil, errors.New("several heat pumps configured, select one with --pump")
+	}
+	return pool.Clients()[0], nil
+}
+
+// newLogger returns the logger of the invocation, --verbose enables debug
+// output. The logger is created once and shared by all callers.
+func newLogger(c *cli.Context) (*zap.Logger, error) {
+	if l, ok := c.App.Metadata["logger"].(*zap.Logger); ok {
+		return l, nil
+	}
+	cfg := zap.NewProductionConfig()
+	if c.Bool("verbose") {
+		cfg.Level.SetLevel(zap.DebugLevel)
+	}
+	l, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	if c.App.Metadata == nil {
+		c.App.Metadata = map[string]any{}
+	}
+	c.App.Metadata["logger"] = l
+	return l, nil
+}
+
+func newBlocks() map[string]luxtronik.DataTypeMap {
+	return map[string]luxtronik.DataTypeMap{
+		luxtronik.BlockParameters:   luxtronik.NewParameterMap(),
+		luxtronik.BlockCalculations: luxtronik.NewCalculationsMap(),
+		luxtronik.BlockVisibilities: luxtronik.NewVisibilitiesMap(),
+	}
+}
+
+// firmwareBlocks returns the maps of all blocks for the firmware of the heat
+// pump, see luxtronik.SelectMaps. The client learns the firmware with the
+// calculations, they are read first if it is not known yet.
+func firmwareBlocks(client *luxtronik.Client) (map[string]luxtronik.DataTypeMap, error) {
+	if client.Firmware() == "" {
+		err := readBlocks(client, map[string]luxtronik.DataTypeMap{luxtronik.BlockCalculations: luxtronik.NewCalculationsMap()})
+		// with --strict-frames the calculations of another firmware fail
+		// after the firmware is known
+		if client.Firmware() == "" {
+			if err != nil {
+				return nil, err
+			}
+			return newBlocks(), nil
+		}
+	}
+	return luxtronik.SelectMaps(client.Firmware())
+}
+
+// readBlocks connects to the heat pump and reads all given blocks.
+func readBlocks(client *luxtronik.Client, blocks map[string]luxtronik.DataTypeMap) (err error) {
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	for block, pm := range blocks {
+		switch block {
+		case luxtronik.BlockParameters:
+			err = client.ReadParameters(pm)
+		case luxtronik.BlockCalculations:
+			err = client.ReadCalculations(pm)
+		case luxtronik.BlockVisibilities:
+			err = client.ReadVisibilities(pm)
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", block, err)
+		}
+	}
+	return nil
+}
+
+// formatValue returns the converted value followed by its unit.
+func formatValue(b *luxtronik.Base) string {
+	var s string
+	switch v := b.FromHeatPump().(type) {
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return luxtronik.FormatValue(v)
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if u := b.Unit(); u != "" {
+		s += " " + u
+	}
+	return s
+}
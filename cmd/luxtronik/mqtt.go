@@ -0,0 +1,157 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Payloads of --mqtt-availability-topic as expected by Home Assistant.
+const (
+	mqttOnline  = "online"
+	mqttOffline = "offline"
+)
+
+// mqttFlags configure the connection to the MQTT broker, see
+// mqttClientOptions.
+var mqttFlags = []cli.Flag{
+	&cli.StringFlag{Name: "mqtt-broker", Usage: "e.g. tcp://localhost:1883 or ssl://broker:8883"},
+	&cli.StringFlag{Name: "mqtt-username", EnvVars: []string{"LUXTRONIK_MQTT_USERNAME"}},
+	&cli.StringFlag{Name: "mqtt-password", EnvVars: []string{"LUXTRONIK_MQTT_PASSWORD"}},
+	&cli.IntFlag{Name: "mqtt-qos", Usage: "QoS level 0, 1 or 2 of all subscriptions and messages", Value: 1},
+	&cli.StringSliceFlag{Name: "mqtt-retain", Usage: "topic classes published with the retain flag: values, notifications or availability", Value: cli.NewStringSlice(luxtronik.MQTTValues, luxtronik.MQTTAvailability)},
+	&cli.StringFlag{Name: "mqtt-client-id", Usage: "defaults to the command and the process id, e.g. luxtronik-surplus-1234"},
+	&cli.StringFlag{Name: "mqtt-ca", Usage: "PEM file of the CA the broker certificate must be signed by, instead of the system CAs"},
+	&cli.StringFlag{Name: "mqtt-cert", Usage: "PEM file of the client certificate, needs --mqtt-key"},
+	&cli.StringFlag{Name: "mqtt-key", Usage: "PEM file of the key of --mqtt-cert"},
+	&cli.StringFlag{Name: "mqtt-availability-topic", Usage: "publishes online after connecting and offline on exit or, as last will, when the connection dies, e.g. luxtronik/status"},
+}
+
+// mqttClientOptions returns the options of the mqttFlags, connect with
+// connectMQTT.
+func mqttClientOptions(c *cli.Context) (*mqtt.ClientOptions, error) {
+	broker := c.String("mqtt-broker")
+	if broker == "" {
+		return nil, errors.New("missing --mqtt-broker")
+	}
+	if q := c.Int("mqtt-qos"); q < 0 || q > 2 {
+		return nil, fmt.Errorf("--mqtt-qos %d, want 0, 1 or 2", q)
+	}
+	for _, class := range c.StringSlice("mqtt-retain") {
+		switch class {
+		case luxtronik.MQTTValues, luxtronik.MQTTNotifications, luxtronik.MQTTAvailability:
+		default:
+			return nil, fmt.Errorf("--mqtt-retain %q, want %s, %s or %s", class, luxtronik.MQTTValues, luxtronik.MQTTNotifications, luxtronik.MQTTAvailability)
+		}
+	}
+	id := c.String("mqtt-client-id")
+	if id == "" {
+		id = fmt.Sprintf("luxtronik-%s-%d", c.Command.Name, os.Getpid())
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(id).
+		SetUsername(c.String("mqtt-username")).
+		SetPassword(c.String("mqtt-password")).
+		SetAutoReconnect(true)
+
+	tlsConfig, err := mqttTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+	if topic := c.String("mqtt-availability-topic"); topic != "" {
+		opts.SetBinaryWill(topic, []byte(mqttOffline), mqttQoS(c), mqttRetain(c, luxtronik.MQTTAvailability))
+	}
+	return opts, nil
+}
+
+// mqttTLSConfig returns the TLS configuration of --mqtt-ca, --mqtt-cert and
+// --mqtt-key, nil without them.
+func mqttTLSConfig(c *cli.Context) (*tls.Config, error) {
+	ca, cert, key := c.String("mqtt-ca"), c.String("mqtt-cert"), c.String("mqtt-key")
+	if ca == "" && cert == "" && key == "" {
+		return nil, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if ca != "" {
+		pem, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, fmt.Errorf("--mqtt-ca: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("--mqtt-ca %s contains no PEM certificate", ca)
+		}
+	}
+	if (cert == "") != (key == "") {
+		return nil, errors.New("--mqtt-cert and --mqtt-key must be set together")
+	}
+	if cert != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("--mqtt-cert: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{pair}
+	}
+	return cfg, nil
+}
+
+// connectMQTT connects and, on every connect, publishes the birth message
+// to --mqtt-availability-topic before calling onConnect.
+func connectMQTT(c *cli.Context, opts *mqtt.ClientOptions, onConnect mqtt.OnConnectHandler, logger *zap.Logger) (mqtt.Client, error) {
+	availability := c.String("mqtt-availability-topic")
+	opts.SetOnConnectHandler(func(mc mqtt.Client) {
+		if availability != "" {
+			if t := mc.Publish(availability, mqttQoS(c), mqttRetain(c, luxtronik.MQTTAvailability), mqttOnline); t.Wait() && t.Error() != nil {
+				logger.Error("mqtt availability not published", zap.String("topic", availability), zap.Error(t.Error()))
+			}
+		}
+		if onConnect != nil {
+			onConnect(mc)
+		}
+	}).SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+		logger.Warn("mqtt connection lost", zap.Error(err))
+	})
+
+	broker := c.String("mqtt-broker")
+	mc := mqtt.NewClient(opts)
+	t := mc.Connect()
+	if !t.WaitTimeout(30 * time.Second) {
+		return nil, fmt.Errorf("timed out connecting to the MQTT broker %s", broker)
+	}
+	if err := t.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to the MQTT broker %s: %w", broker, err)
+	}
+	return mc, nil
+}
+
+// disconnectMQTT publishes offline to --mqtt-availability-topic, the broker
+// does not send the last will on a clean disconnect.
+func disconnectMQTT(c *cli.Context, mc mqtt.Client) {
+	if topic := c.String("mqtt-availability-topic"); topic != "" {
+		mc.Publish(topic, mqttQoS(c), mqttRetain(c, luxtronik.MQTTAvailability), mqttOffline).WaitTimeout(time.Second)
+	}
+	mc.Disconnect(250)
+}
+
+// mqttQoS returns --mqtt-qos, validated by mqttClientOptions.
+func mqttQoS(c *cli.Context) byte {
+	return byte(c.Int("mqtt-qos"))
+}
+
+// mqttRetain reports whether --mqtt-retain lists the topic class.
+func mqttRetain(c *cli.Context, class string) bool {
+	return slices.Contains(c.StringSlice("mqtt-retain"), class)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// newMQTTContext returns a context of the mqtt command with the mqttFlags
+// set to args.
+func newMQTTContext(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+	set := flag.NewFlagSet("mqtt", flag.ContinueOnError)
+	for _, f := range mqttFlags {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Parse(args))
+	c := cli.NewContext(cli.NewApp(), set, nil)
+	c.Command = &cli.Command{Name: "mqtt"}
+	return c
+}
+
+// writeCertificate writes a self-signed certificate and its key as PEM files
+// to dir.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "luxtronik"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestMQTTTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := writeCertificate(t, dir)
+	_, otherKey := writeCertificate(t, t.TempDir())
+	notPEM := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("no certificate"), 0o600))
+
+	tests := []struct {
+		name    string
+		args    []string
+		tls     bool
+		rootCAs bool
+		certs   int
+		err     string
+	}{
+		{name: "insecure", args: nil},
+		{name: "ca", args: []string{"--mqtt-ca", cert}, tls: true, rootCAs: true},
+		{name: "client certificate", args: []string{"--mqtt-cert", cert, "--mqtt-key", key}, tls: true, certs: 1},
+		{name: "ca and client certificate", args: []string{"--mqtt-ca", cert, "--mqtt-cert", cert, "--mqtt-key", key}, tls: true, rootCAs: true, certs: 1},
+		{name: "missing ca", args: []string{"--mqtt-ca", filepath.Join(dir, "missing.pem")}, err: "--mqtt-ca"},
+		{name: "ca without certificate", args: []string{"--mqtt-ca", notPEM}, err: "contains no PEM certificate"},
+		{name: "certificate without key", args: []string{"--mqtt-cert", cert}, err: "must be set together"},
+		{name: "key without certificate", args: []string{"--mqtt-key", key}, err: "must be set together"},
+		{name: "key of another certificate", args: []string{"--mqtt-cert", cert, "--mqtt-key", otherKey}, err: "--mqtt-cert"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := mqttTLSConfig(newMQTTContext(t, tt.args...))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.tls {
+				assert.Nil(t, cfg, "plain connection")
+				return
+			}
+			require.NotNil(t, cfg)
+			assert.Equal(t, tt.rootCAs, cfg.RootCAs != nil, "RootCAs")
+			assert.Len(t, cfg.Certificates, tt.certs)
+			assert.False(t, cfg.InsecureSkipVerify)
+		})
+	}
+}
+
+func TestMQTTClientOptions_QoS(t *testing.T) {
+	for _, qos := range []string{"-1", "3", "10"} {
+		_, err := mqttClientOptions(newMQTTContext(t, "--mqtt-broker", "tcp://localhost:1883", "--mqtt-qos", qos))
+		assert.ErrorContains(t, err, "--mqtt-qos", qos)
+	}
+	for _, qos := range []string{"0", "1", "2"} {
+		c := newMQTTContext(t, "--mqtt-broker", "tcp://localhost:1883", "--mqtt-qos", qos, "--mqtt-availability-topic", "luxtronik/status")
+		opts, err := mqttClientOptions(c)
+		require.NoError(t, err, qos)
+		assert.Equal(t, qos, string('0'+mqttQoS(c)))
+		assert.Equal(t, mqttQoS(c), opts.WillQos, "last will")
+	}
+
+	_, err := mqttClientOptions(newMQTTContext(t))
+	assert.ErrorContains(t, err, "--mqtt-broker")
+}
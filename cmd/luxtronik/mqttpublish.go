@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var mqttCommand = &cli.Command{
+	Name:  "mqtt",
+	Usage: "Publishes values, alerts and errors to an MQTT broker",
+	Description: `Publishes the values as plain text in metric units, all of a block on the
+first poll and then the changed ones. Alerts and new errors of the error
+memory are published as JSON.
+
+--mqtt-topic-template is a Go template of the topics with the fields .Host,
+.Kind (block of a value, alert or error), .Name (value, rule or error code),
+.Index and .Class and the function lower, e.g.
+home/{{.Host}}/{{.Kind}}/{{.Name | lower}}.
+
+--mqtt-retain lists the topic classes published with the retain flag, values
+and availability by default. With --spool-dir the messages are buffered on
+disk while the broker is unreachable.`,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{Name: "mqtt-topic-template", Usage: "Go template of the topics", Value: luxtronik.DefaultMQTTTopic},
+		&cli.DurationFlag{Name: "interval", Usage: "poll interval", Value: pollInterval},
+		&cli.StringSliceFlag{Name: "block", Usage: "blocks to poll, defaults to all"},
+		deadbandFlag,
+		spoolDirFlag,
+		spoolMaxBytesFlag,
+	}, append(append(append(budgetFlags, alertFlags...), priceFlags...), mqttFlags...)...),
+	Action: runMQTT,
+}
+
+func runMQTT(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	deadbands, err := luxtronik.ParseDeadbands(c.String("deadband"))
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	spool, err := openSpool(c, "mqtt", logger)
+	if err != nil {
+		return err
+	}
+	var mc mqtt.Client
+	sink, err := luxtronik.NewMQTTSink(luxtronik.MQTTOptions{
+		Topic:  c.String("mqtt-topic-template"),
+		Retain: c.StringSlice("mqtt-retain"),
+		Publish: func(topic string, payload []byte, retain bool) error {
+			t := mc.Publish(topic, mqttQoS(c), retain, payload)
+			if !t.WaitTimeout(10 * time.Second) {
+				return errors.New("timed out")
+			}
+			return t.Error()
+		},
+		Deadbands: deadbands,
+		Spool:     spool,
+	})
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	opts, err := mqttClientOptions(c)
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	mc, err = connectMQTT(c, opts, nil, logger)
+	if err != nil {
+		return err
+	}
+	defer disconnectMQTT(c, mc)
+
+	alerts, dispatcher, err := newAlerts(c, logger, sink)
+	if err != nil {
+		return err
+	}
+	var sinks []luxtronik.Sink
+	if alerts != nil {
+		sinks = append(sinks, alerts)
+	}
+	sinks = append(sinks, dispatcher, sink)
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	popts := pollerOptions(c, logger)
+	popts.Blocks = c.StringSlice("block")
+	if popts.Prices, err = newPriceFeed(ctx, c, logger); err != nil {
+		return err
+	}
+	p := luxtronik.NewPoolPoller(pool, popts, sinks...)
+	defer p.Close()
+	logger.Info("mqtt publisher started", zap.String("broker", c.String("mqtt-broker")),
+		zap.Strings("retain", c.StringSlice("mqtt-retain")))
+	return p.Run(ctx)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// priceFlags configure the price feed of newPriceFeed.
+var priceFlags = []cli.Flag{
+	&cli.StringFlag{Name: "price-url", Usage: "HTTP/JSON source of hourly prices", EnvVars: []string{"PRICE_URL"}},
+	&cli.StringFlag{Name: "price-body", Usage: "request body, switches to POST"},
+	&cli.StringSliceFlag{Name: "price-header", Usage: "request header, e.g. \"Authorization: Bearer xyz\"", EnvVars: []string{"PRICE_HEADER"}},
+	&cli.StringFlag{Name: "price-path", Usage: "dot separated path to the list of prices"},
+	&cli.StringFlag{Name: "price-start-field", Value: "startsAt"},
+	&cli.StringFlag{Name: "price-value-field", Value: "total"},
+	&cli.Float64Flag{Name: "price-scale", Usage: "factor converting the prices into per kWh", Value: 1},
+	&cli.DurationFlag{Name: "price-refresh", Value: time.Hour},
+}
+
+// newPriceFeed returns the feed of --price-url, refreshed until ctx is done,
+// or nil without the flag.
+func newPriceFeed(ctx context.Context, c *cli.Context, logger *zap.Logger) (*luxtronik.PriceFeed, error) {
+	u := c.String("price-url")
+	if u == "" {
+		return nil, nil
+	}
+	header := http.Header{}
+	for _, h := range c.StringSlice("price-header") {
+		k, v, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, want \"Key: Value\"", h)
+		}
+		header.Add(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	feed, err := luxtronik.NewPriceFeed(luxtronik.PriceFeedOptions{
+		URL:        u,
+		Body:       c.String("price-body"),
+		Header:     header,
+		Path:       c.String("price-path"),
+		StartField: c.String("price-start-field"),
+		ValueField: c.String("price-value-field"),
+		Scale:      c.Float64("price-scale"),
+		Logger:     logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go feed.Run(ctx, c.Duration("price-refresh"))
+	return feed, nil
+}
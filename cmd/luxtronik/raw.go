@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var rawCommand = &cli.Command{
+	Name:  "raw",
+	Usage: "Prints the raw answer of a read command as annotated hex",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "cmd",
+			Value: luxtronik.CalculationsRead,
+			Usage: fmt.Sprintf("read command, %d parameters, %d calculations or %d visibilities",
+				luxtronik.ParametersRead, luxtronik.CalculationsRead, luxtronik.VisibilitiesRead),
+		},
+	},
+	Action: runRaw,
+}
+
+func runRaw(c *cli.Context) error {
+	cmd := int32(c.Int("cmd"))
+	switch cmd {
+	case luxtronik.ParametersRead, luxtronik.CalculationsRead, luxtronik.VisibilitiesRead:
+	default:
+		return cli.Exit(fmt.Sprintf("unsupported command %d", cmd), 2)
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	meta, raw, err := client.ReadFrame(c.Context, cmd)
+	if err != nil {
+		return err
+	}
+	return luxtronik.WriteFrameHex(c.App.Writer, meta, raw)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var reportCommand = &cli.Command{
+	Name:  "report",
+	Usage: "Polls the heat pumps and prints summaries at fixed local times",
+	Description: `Each --report has the form name=times[@locale], e.g. "morning=07:00@de" or
+"daily=07:00 19:00". The times follow the wall clock of --timezone, so they
+don't move when daylight saving time starts or ends.`,
+	Flags: append([]cli.Flag{
+		&cli.StringSliceFlag{Name: "report", Usage: "name=times[@locale] of a report", Value: cli.NewStringSlice("daily=07:00")},
+		&cli.StringFlag{Name: "locale", Usage: "language of reports without locale, en or de", Value: "en"},
+		&cli.StringFlag{Name: "timezone", Usage: "zone of the report times, e.g. Europe/Berlin", Value: "Local"},
+		&cli.DurationFlag{Name: "interval", Value: time.Minute},
+	}, budgetFlags...),
+	Action: runReport,
+}
+
+func runReport(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	loc, err := time.LoadLocation(c.String("timezone"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("invalid timezone: %s", err), 2)
+	}
+
+	w := c.App.Writer
+	var sinks []luxtronik.Sink
+	for _, spec := range c.StringSlice("report") {
+		name, times, ok := strings.Cut(spec, "=")
+		if !ok {
+			return cli.Exit(fmt.Sprintf("invalid report %q, want name=times[@locale]", spec), 2)
+		}
+		times, locale, ok := strings.Cut(times, "@")
+		if !ok {
+			locale = c.String("locale")
+		}
+		schedule, err := luxtronik.ParseDailySchedule(times, loc)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("invalid report %q: %s", spec, err), 2)
+		}
+		r, err := luxtronik.NewReporter(luxtronik.ReportOptions{
+			Name:     name,
+			Schedule: schedule,
+			Locale:   locale,
+			Logger:   logger,
+			Deliver: func(_ context.Context, _ luxtronik.Summary, text string) error {
+				_, err := fmt.Fprintln(w, text)
+				return err
+			},
+		})
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, r)
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := pollerOptions(c, logger)
+	opts.Blocks = []string{luxtronik.BlockCalculations}
+	p := luxtronik.NewPoolPoller(pool, opts, sinks...)
+	defer p.Close()
+
+	return p.Run(ctx)
+}
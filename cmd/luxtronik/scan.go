@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var scanCommand = &cli.Command{
+	Name:      "scan",
+	Usage:     "Finds controllers in the local network",
+	ArgsUsage: "[cidr, e.g. 192.168.0.0/24]",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "port", Value: luxtronik.DefaultPort},
+		&cli.DurationFlag{Name: "timeout", Value: time.Second, Usage: "timeout per host"},
+		&cli.BoolFlag{Name: "no-broadcast", Usage: "disables the UDP broadcast discovery"},
+		&cli.BoolFlag{Name: "no-mdns", Usage: "disables the multicast DNS discovery"},
+	},
+	Action: runScan,
+}
+
+func runScan(c *cli.Context) error {
+	timeout := c.Duration("timeout")
+	found := map[string]luxtronik.Controller{}
+	var order []string
+	add := func(ctrl luxtronik.Controller) {
+		if _, ok := found[ctrl.Addr]; !ok {
+			order = append(order, ctrl.Addr)
+		}
+		found[ctrl.Addr] = ctrl
+	}
+
+	discoveries := []struct {
+		flag     string
+		discover func(context.Context, time.Duration) ([]luxtronik.Controller, error)
+	}{
+		{"no-mdns", luxtronik.DiscoverMDNS},
+		{"no-broadcast", luxtronik.DiscoverBroadcast},
+	}
+	for _, d := range discoveries {
+		if c.Bool(d.flag) {
+			continue
+		}
+		ctrls, err := d.discover(c.Context, 2*time.Second)
+		if err != nil {
+			fmt.Fprintf(c.App.ErrWriter, "discovery: %s\n", err)
+		}
+		for _, ctrl := range ctrls {
+			if prev, ok := found[ctrl.Addr]; ok {
+				prev.Source += "+" + ctrl.Source
+				found[ctrl.Addr] = prev
+				continue
+			}
+			// most discovery answers do not contain the details, the TCP
+			// port may be firewalled though
+			if ctrl.Firmware == "" {
+				if probed, err := luxtronik.ProbeController(ctrl.Addr, timeout); err == nil {
+					probed.Name, probed.Source = ctrl.Name, ctrl.Source
+					ctrl = probed
+				}
+			}
+			add(ctrl)
+		}
+	}
+
+	if cidr := c.Args().First(); cidr != "" {
+		ctrls, err := luxtronik.Scan(c.Context, cidr, luxtronik.ScanOptions{
+			Port:    c.String("port"),
+			Timeout: timeout,
+		})
+		if err != nil {
+			return err
+		}
+		for _, ctrl := range ctrls {
+			if prev, ok := found[ctrl.Addr]; ok {
+				ctrl.Source = prev.Source + "+" + ctrl.Source
+			}
+			add(ctrl)
+		}
+	}
+
+	if len(found) == 0 {
+		return cli.Exit("no controller found", 1)
+	}
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "ADDRESS\tNAME\tMODEL\tFIRMWARE\tFOUND BY")
+	for _, addr := range order {
+		ctrl := found[addr]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", ctrl.Addr, ctrl.Name, ctrl.Model, ctrl.Firmware, ctrl.Source)
+	}
+	return tw.Flush()
+}
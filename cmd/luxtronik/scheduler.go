@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// scheduleFlags configure the timed parameter writes, see newScheduler.
+var scheduleFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  "schedule",
+		Usage: `writes a parameter at the times of a cron expression, e.g. "0 22 * * * ID_Einst_WK_akt=-2"`,
+	},
+	&cli.StringFlag{Name: "schedule-config", Usage: "YAML file with a list of cron, parameter and value entries"},
+}
+
+// newScheduler returns the scheduler sink, without entries nil. The writes
+// need a single heat pump in the pool.
+func newScheduler(c *cli.Context, pool *luxtronik.ClientPool, logger *zap.Logger) (*luxtronik.Scheduler, error) {
+	var entries []luxtronik.ScheduleEntry
+	if path := c.String("schedule-config"); path != "" {
+		var err error
+		if entries, err = luxtronik.LoadSchedule(path); err != nil {
+			return nil, cli.Exit(err.Error(), 2)
+		}
+	}
+	for _, s := range c.StringSlice("schedule") {
+		e, err := luxtronik.ParseScheduleEntry(s)
+		if err != nil {
+			return nil, cli.Exit(err.Error(), 2)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if pool.Len() != 1 {
+		return nil, cli.Exit("--schedule supports a single heat pump only", 2)
+	}
+	s, err := luxtronik.NewScheduler(pool.Clients()[0], luxtronik.SchedulerOptions{Entries: entries, Logger: logger})
+	if err != nil {
+		return nil, cli.Exit(err.Error(), 2)
+	}
+	for _, w := range s.Next() {
+		logger.Info("parameter write scheduled", zap.Stringer("entry", w.ScheduleEntry), zap.Time("next", w.Next))
+	}
+	return s, nil
+}
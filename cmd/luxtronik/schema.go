@@ -0,0 +1,18 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var schemaCommand = &cli.Command{
+	Name:  "schema",
+	Usage: "Prints the catalog of all values with types, units, codes and writability as JSON",
+	Action: func(c *cli.Context) error {
+		enc := json.NewEncoder(c.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(luxtronik.Schema(newBlocks()))
+	},
+}
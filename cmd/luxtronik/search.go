@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var searchCommand = &cli.Command{
+	Name:      "search",
+	Usage:     "Searches names, aliases and code strings of all known values",
+	ArgsUsage: "<keyword>",
+	Flags: []cli.Flag{
+		&cli.IntFlag{Name: "limit", Value: 30, Usage: "maximum number of matches, 0 prints all"},
+		&cli.BoolFlag{Name: "offline", Usage: "searches the catalog without reading current values"},
+	},
+	Action: runSearch,
+}
+
+func runSearch(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.Exit("usage: luxtronik search <keyword>", 2)
+	}
+
+	blocks := newBlocks()
+	matches := luxtronik.Search(c.Args().First(), blocks)
+	if len(matches) == 0 {
+		return cli.Exit("no matches", 1)
+	}
+	if limit := c.Int("limit"); limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	if c.Bool("offline") {
+		fmt.Fprintln(tw, "BLOCK\tINDEX\tNAME\tVALUE\tMATCH")
+		for _, m := range matches {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t-\t%s\n", m.Block, m.Index, m.Base.Name(), m.Field)
+		}
+		return tw.Flush()
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	multi := pool.Len() > 1
+	if multi {
+		fmt.Fprint(tw, "PUMP\t")
+	}
+	fmt.Fprintln(tw, "BLOCK\tINDEX\tNAME\tVALUE\tMATCH")
+	// the matches are looked up in the maps of the firmware of each heat
+	// pump, values it lacks are shown as -
+	for _, client := range pool.Clients() {
+		read, err := firmwareBlocks(client)
+		if err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		if err := readBlocks(client, read); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		for _, m := range matches {
+			if multi {
+				fmt.Fprintf(tw, "%s\t", client.Name())
+			}
+			value := "-"
+			if b, ok := read[m.Block][m.Index]; ok {
+				value = formatValue(b)
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", m.Block, m.Index, m.Base.Name(), value, m.Field)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var seasonsCommand = &cli.Command{
+	Name:      "seasons",
+	Usage:     "Detects heating seasons in dump files and prints a summary per season",
+	ArgsUsage: "<dump-file>...",
+	Flags: []cli.Flag{
+		&cli.Float64Flag{Name: "heating-limit", Value: 15, Usage: "daily mean outdoor temperature below which a day can be a heating day"},
+		&cli.DurationFlag{Name: "min-runtime", Value: time.Hour, Usage: "daily heating runtime of a heating day"},
+		&cli.IntFlag{Name: "start-days", Value: 3, Usage: "consecutive heating days starting a season"},
+		&cli.IntFlag{Name: "end-days", Value: 7, Usage: "consecutive days without heating ending a season"},
+		&cli.Float64Flag{Name: "room-temp", Value: 20, Usage: "base temperature of the degree days"},
+		&cli.BoolFlag{Name: "monthly", Usage: "prints the degree day normalized energy per month"},
+	},
+	Action: runSeasons,
+}
+
+func runSeasons(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cli.Exit("usage: luxtronik seasons <dump-file>...", 2)
+	}
+
+	var samples []luxtronik.SeasonSample
+	for _, file := range c.Args().Slice() {
+		dumps, err := readDumpFile(file)
+		if err != nil {
+			return err
+		}
+		for _, d := range dumps {
+			pm := luxtronik.NewCalculationsMap()
+			d.Apply(luxtronik.BlockCalculations, pm)
+			samples = append(samples, luxtronik.SeasonSampleFromCalculations(d.Time, pm))
+		}
+	}
+
+	opts := luxtronik.SeasonOptions{
+		HeatingLimit: c.Float64("heating-limit"),
+		MinRuntime:   c.Duration("min-runtime"),
+		StartDays:    c.Int("start-days"),
+		EndDays:      c.Int("end-days"),
+		RoomTemp:     c.Float64("room-temp"),
+	}
+	if c.Bool("monthly") {
+		return printMonthlyStats(c, luxtronik.MonthlyStats(samples, opts))
+	}
+
+	seasons := luxtronik.DetectSeasons(samples, opts)
+	if len(seasons) == 0 {
+		return cli.Exit("no heating season found", 1)
+	}
+
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "SEASON\tSTART\tEND\tDAYS\tTEMP °C\tHEATING kWh\tHOT WATER kWh\tRUNTIME h\tSTARTS\tDEFROSTS\tCOP\tDEGREE DAYS\tkWh/Kd\t")
+	for _, s := range seasons {
+		label := s.Label()
+		if s.Ongoing {
+			label += "*"
+		}
+		cop := "-"
+		if s.COP > 0 {
+			cop = fmt.Sprintf("%.2f", s.COP)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%.1f\t%.1f\t%.1f\t%.0f\t%d\t%d\t%s\t%.0f\t%.2f\t\n",
+			label, s.Start.Format("2006-01-02"), s.End.Format("2006-01-02"), s.Days,
+			s.MeanOutdoorTemp, s.HeatingEnergy, s.HotWaterEnergy, s.HeatingRuntime.Hours(),
+			s.CompressorStarts, s.Defrosts, cop, s.DegreeDays, s.EnergyPerDegreeDay)
+	}
+	return tw.Flush()
+}
+
+func printMonthlyStats(c *cli.Context, months []luxtronik.MonthStats) error {
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "MONTH\tDAYS\tTEMP °C\tDEGREE DAYS\tHEATING kWh\tHOT WATER kWh\tkWh/Kd\t")
+	for _, m := range months {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%.0f\t%.1f\t%.1f\t%.2f\t\n",
+			m.Month.Format("2006-01"), m.Days, m.MeanOutdoorTemp, m.DegreeDays,
+			m.HeatingEnergy, m.HotWaterEnergy, m.EnergyPerDegreeDay)
+	}
+	return tw.Flush()
+}
+
+// readDumpFile detects the format of a file written by the dump command by
+// its extension.
+func readDumpFile(file string) ([]*luxtronik.Dump, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := strings.TrimPrefix(filepath.Ext(file), ".")
+	dumps, err := luxtronik.ReadDumps(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return dumps, nil
+}
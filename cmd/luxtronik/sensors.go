@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	sensorsListenFlag = &cli.StringFlag{
+		Name:  "sensors-listen",
+		Usage: "accepts room sensor readings as JSON via POST /sensors on this address, e.g. :8090",
+	}
+	sensorsMaxAgeFlag = &cli.DurationFlag{
+		Name:  "sensors-max-age",
+		Usage: "ignores sensor readings older than this",
+		Value: time.Hour,
+	}
+	sensorsMQTTTopicFlag = &cli.StringFlag{
+		Name:  "sensors-mqtt-topic",
+		Usage: "subscribes to room sensor readings as JSON on --mqtt-broker, the sensor defaults to the last topic level, e.g. zigbee2mqtt/+",
+	}
+)
+
+// sensorsFlags configure the room sensors of startSensors.
+var sensorsFlags = append([]cli.Flag{sensorsListenFlag, sensorsMaxAgeFlag, sensorsMQTTTopicFlag}, mqttFlags...)
+
+// startSensors serves the room sensor endpoint and subscribes to the MQTT
+// topic until ctx is done. Returns nil if neither is configured.
+func startSensors(ctx context.Context, c *cli.Context, logger *zap.Logger) (*luxtronik.RoomSensors, error) {
+	addr, topic := c.String(sensorsListenFlag.Name), c.String(sensorsMQTTTopicFlag.Name)
+	if addr == "" && topic == "" {
+		return nil, nil
+	}
+	sensors := luxtronik.NewRoomSensors(c.Duration(sensorsMaxAgeFlag.Name))
+	if topic != "" {
+		if err := subscribeSensors(ctx, c, sensors, topic, logger); err != nil {
+			return nil, err
+		}
+	}
+	if addr == "" {
+		return sensors, nil
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/sensors", sensors)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("sensor endpoint failed", zap.Error(err))
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	logger.Info("accepting room sensor readings", zap.String("addr", ln.Addr().String()))
+	return sensors, nil
+}
+
+// subscribeSensors feeds the readings of the MQTT topic into sensors until
+// ctx is done.
+func subscribeSensors(ctx context.Context, c *cli.Context, sensors *luxtronik.RoomSensors, topic string, logger *zap.Logger) error {
+	opts, err := mqttClientOptions(c)
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	onReading := func(_ mqtt.Client, msg mqtt.Message) {
+		if err := sensors.HandleMQTT(msg.Topic(), msg.Payload()); err != nil {
+			logger.Warn("room sensor reading rejected", zap.Error(err))
+		}
+	}
+	mc, err := connectMQTT(c, opts, func(mc mqtt.Client) {
+		if t := mc.Subscribe(topic, mqttQoS(c), onReading); t.Wait() && t.Error() != nil {
+			logger.Error("mqtt subscription failed", zap.String("topic", topic), zap.Error(t.Error()))
+		}
+	}, logger)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		disconnectMQTT(c, mc)
+	}()
+	logger.Info("subscribed to room sensor readings", zap.String("topic", topic))
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var setCommand = &cli.Command{
+	Name:      "set",
+	Usage:     "Writes a parameter to the heat pump and prints the verified result",
+	ArgsUsage: "<name-or-index> <value>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "skip the confirmation"},
+		&cli.StringFlag{Name: "access", Usage: "unlocks this access level before writing, e.g. installer"},
+	},
+	Action: runSet,
+}
+
+func runSet(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cli.Exit("usage: luxtronik set <name-or-index> <value>", 2)
+	}
+	nameOrIndex, value := c.Args().Get(0), c.Args().Get(1)
+
+	units, lang, err := valueFormat(c)
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	pm := luxtronik.NewParameterMap()
+	idx, b, ok := pm.Lookup(nameOrIndex)
+	if !ok {
+		return cli.Exit(fmt.Sprintf("unknown parameter %q", nameOrIndex), 1)
+	}
+	// validates writability and the range before anything gets sent, in the
+	// units and language the clients convert with
+	b.SetUnits(units)
+	b.SetLanguage(lang)
+	if _, err := b.ToHeatPump(value); err != nil {
+		return cli.Exit(fmt.Sprintf("invalid value for %s: %s", b.Name(), err), 1)
+	}
+	if s := c.String("access"); s != "" {
+		if _, err := luxtronik.ParseAccessLevel(s); err != nil {
+			return cli.Exit(err.Error(), 2)
+		}
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	for _, client := range pool.Clients() {
+		if pool.Len() > 1 {
+			fmt.Fprintf(c.App.Writer, "== %s\n", client.Name())
+		}
+		if err := setParameter(c, client, idx, value); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+	}
+	return nil
+}
+
+// setParameter writes a single value after the confirmation and verifies it
+// by reading the parameters again.
+func setParameter(c *cli.Context, client *luxtronik.Client, idx int, value string) error {
+	blocks, err := firmwareBlocks(client)
+	if err != nil {
+		return err
+	}
+	pm := blocks[luxtronik.BlockParameters]
+	b, ok := pm[idx]
+	if !ok {
+		return cli.Exit(fmt.Sprintf("parameter %d is not known for firmware %s", idx, client.Firmware()), 1)
+	}
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.ReadParameters(pm); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "%s (%d)\ncurrent: %s\nnew:     %s %s\n",
+		b.Name(), idx, formatValue(b), value, b.Unit())
+
+	if !c.Bool("yes") && !dryRun(c) && !confirm(c, "Write new value?") {
+		return cli.Exit("aborted", 1)
+	}
+
+	if s := c.String("access"); s != "" {
+		level, _ := luxtronik.ParseAccessLevel(s)
+		if pm.AccessLevel() != level {
+			if err := client.Unlock(c.Context, pm, level); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := client.WriteParameter(pm, idx, value); err != nil {
+		return err
+	}
+	if dryRun(c) {
+		fmt.Fprintln(c.App.Writer, "dry run, not written")
+		return nil
+	}
+	want, _ := b.ToHeatPump(value)
+	if err := client.ReadParameters(pm); err != nil {
+		return fmt.Errorf("verifying write: %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "written: %s\n", formatValue(b))
+
+	if got, _ := b.ToHeatPump(b.FromHeatPump()); got != want {
+		return cli.Exit("heat pump reports a different value than written", 1)
+	}
+	return nil
+}
+
+// stdin is shared so that several confirmations do not lose buffered input.
+var stdin = bufio.NewReader(os.Stdin)
+
+func confirm(c *cli.Context, question string) bool {
+	fmt.Fprintf(c.App.Writer, "%s [y/N] ", question)
+	answer, err := stdin.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
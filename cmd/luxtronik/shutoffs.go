@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var shutoffsCommand = &cli.Command{
+	Name:   "shutoffs",
+	Usage:  "Prints the last five reasons why the heat pumps stopped",
+	Action: runShutoffs,
+}
+
+func runShutoffs(c *cli.Context) error {
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "PUMP\tTIME\tCODE\tREASON")
+	for _, client := range pool.Clients() {
+		if err := client.Connect(); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		entries, err := client.ReadSwitchoffHistoryContext(c.Context)
+		_ = client.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		for _, e := range entries {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", client.Name(), e.Time.Format(time.DateTime), e.Code, e.Reason)
+		}
+	}
+	return tw.Flush()
+}
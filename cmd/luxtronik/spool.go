@@ -0,0 +1,36 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	spoolDirFlag = &cli.StringFlag{
+		Name:  "spool-dir",
+		Usage: "buffers data on disk while the target is unreachable; disabled when empty",
+	}
+	spoolMaxBytesFlag = &cli.Int64Flag{
+		Name:  "spool-max-bytes",
+		Usage: "drops the oldest spooled data once this size is exceeded",
+		Value: 64 << 20,
+	}
+)
+
+// openSpool returns a spool in a sub directory per exporter so that several
+// exporters can share the same --spool-dir. Returns nil if spooling is
+// disabled.
+func openSpool(c *cli.Context, exporter string, logger *zap.Logger) (*luxtronik.Spool, error) {
+	dir := c.String(spoolDirFlag.Name)
+	if dir == "" {
+		return nil, nil
+	}
+	return luxtronik.OpenSpool(luxtronik.SpoolOptions{
+		Dir:      filepath.Join(dir, exporter),
+		MaxBytes: c.Int64(spoolMaxBytesFlag.Name),
+		Logger:   logger,
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+// statsHistoryFlag stores the operating counters of each day, see the stats
+// command.
+var statsHistoryFlag = &cli.StringFlag{
+	Name:  "stats-history",
+	Usage: "JSON file storing the operating counters of each day for the per-day deltas of the stats command",
+}
+
+var statsCommand = &cli.Command{
+	Name:  "stats",
+	Usage: "Prints the operating hours, compressor starts and heat quantities, with --stats-history per day",
+	Flags: []cli.Flag{
+		statsHistoryFlag,
+	},
+	Action: runStats,
+}
+
+func runStats(c *cli.Context) error {
+	tw := tabwriter.NewWriter(c.App.Writer, 4, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "PUMP\tDATE\tCOMPRESSOR\tSTARTS\tHEATING\tHOT WATER\tCOOLING\tAUX HEATER\tHEAT HEATING\tHEAT HOT WATER")
+	row := func(pump, date string, s luxtronik.OperatingStats) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%.1f kWh\t%.1f kWh\n", pump, date,
+			hours(s.CompressorRuntime), s.CompressorStarts, hours(s.HeatingRuntime), hours(s.HotWaterRuntime),
+			hours(s.CoolingRuntime), hours(s.AuxHeaterRuntime), s.HeatingEnergy, s.HotWaterEnergy)
+	}
+
+	if path := c.String(statsHistoryFlag.Name); path != "" {
+		history, err := luxtronik.NewStatsHistory(luxtronik.StatsHistoryOptions{Path: path})
+		if err != nil {
+			return err
+		}
+		for _, d := range history.Daily() {
+			date := d.Date.Format(time.DateOnly)
+			if d.Partial {
+				date += "*"
+			}
+			row(d.Host, date, d.Delta)
+		}
+		return tw.Flush()
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+	for _, client := range pool.Clients() {
+		blocks, err := firmwareBlocks(client)
+		if err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		pm := blocks[luxtronik.BlockCalculations]
+		if err := readBlocks(client, map[string]luxtronik.DataTypeMap{luxtronik.BlockCalculations: pm}); err != nil {
+			return fmt.Errorf("%s: %w", client.Name(), err)
+		}
+		row(client.Name(), "total", luxtronik.Stats(time.Now(), pm))
+	}
+	return tw.Flush()
+}
+
+func hours(d time.Duration) string {
+	return fmt.Sprintf("%.1f h", d.Hours())
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var surplusCommand = &cli.Command{
+	Name:  "surplus",
+	Usage: "Boosts hot water and heating while a PV system produces surplus power",
+	Description: `The surplus in W arrives from an MQTT topic (--mqtt-broker, --mqtt-topic),
+is fetched from --surplus-url or is posted to /surplus on --surplus-listen,
+either as plain number or as JSON with the value at --surplus-path. Negative
+values mean grid consumption.
+
+From --on W the hot water target is raised to --hot-water-target, the heating
+curve offset by --heating-boost and the --switch parameters are set. Below
+--off W or without readings for --max-age the previous values are restored,
+also on exit. A boost lasts at least --min-on, the next one starts at least
+--min-off later. Try the settings with --dry-run first.`,
+	Flags: append([]cli.Flag{
+		&cli.Float64Flag{Name: "on", Usage: "surplus in W which starts a boost", Value: 1500},
+		&cli.Float64Flag{Name: "off", Usage: "surplus in W below which a boost ends", Value: 500},
+		&cli.DurationFlag{Name: "min-on", Usage: "minimum duration of a boost", Value: 15 * time.Minute},
+		&cli.DurationFlag{Name: "min-off", Usage: "minimum time between two boosts", Value: 15 * time.Minute},
+		&cli.DurationFlag{Name: "max-age", Usage: "ends a boost if no surplus arrived for this long", Value: 5 * time.Minute},
+		&cli.Float64Flag{Name: "hot-water-target", Usage: fmt.Sprintf("hot water target in °C during a boost, at most %.0f °C", luxtronik.MaxSurplusHotWater)},
+		&cli.Float64Flag{Name: "heating-boost", Usage: "raises the heating curve offset in K during a boost"},
+		&cli.StringSliceFlag{Name: "switch", Usage: `sets a writeable parameter during a boost, e.g. "ID_Ba_Bw_akt=Party"`},
+		&cli.BoolFlag{Name: "dry-run", Usage: "logs the boosts instead of writing them"},
+		&cli.StringFlag{Name: "mqtt-topic", Usage: "topic publishing the surplus"},
+		&cli.StringFlag{Name: "surplus-url", Usage: "fetches the surplus from this URL every --interval"},
+		&cli.StringFlag{Name: "surplus-listen", Usage: "accepts the surplus via POST /surplus on this address, e.g. :8091"},
+		&cli.StringFlag{Name: "surplus-path", Usage: `dot separated path to the surplus within a JSON document, e.g. "site.export"`},
+		&cli.DurationFlag{Name: "interval", Usage: "poll interval", Value: time.Minute},
+	}, append(budgetFlags, mqttFlags...)...),
+	Action: runSurplus,
+}
+
+func runSurplus(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	if c.String("mqtt-topic") == "" && c.String("surplus-url") == "" && c.String("surplus-listen") == "" {
+		return errors.New("one of --mqtt-topic, --surplus-url or --surplus-listen is required to receive the surplus")
+	}
+	var switches []luxtronik.SurplusSwitch
+	for _, s := range c.StringSlice("switch") {
+		sw, err := luxtronik.ParseSurplusSwitch(s)
+		if err != nil {
+			return cli.Exit(err.Error(), 2)
+		}
+		switches = append(switches, sw)
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	sc, err := luxtronik.NewSurplusController(client, luxtronik.SurplusOptions{
+		On:             c.Float64("on"),
+		Off:            c.Float64("off"),
+		MinOn:          c.Duration("min-on"),
+		MinOff:         c.Duration("min-off"),
+		MaxAge:         c.Duration("max-age"),
+		HotWaterTarget: c.Float64("hot-water-target"),
+		HeatingBoost:   c.Float64("heating-boost"),
+		Switches:       switches,
+		DryRun:         dryRun(c),
+		URL:            c.String("surplus-url"),
+		Path:           c.String("surplus-path"),
+		Logger:         logger,
+	})
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if c.String("surplus-url") != "" {
+		go sc.Run(ctx, c.Duration("interval"))
+	}
+	if addr := c.String("surplus-listen"); addr != "" {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/surplus", sc)
+		srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("surplus endpoint failed", zap.Error(err))
+			}
+		}()
+		defer srv.Close()
+		logger.Info("accepting the surplus", zap.String("addr", ln.Addr().String()))
+	}
+	if topic := c.String("mqtt-topic"); topic != "" {
+		mc, err := subscribeSurplus(c, sc, logger)
+		if err != nil {
+			return err
+		}
+		defer disconnectMQTT(c, mc)
+	}
+
+	opts := pollerOptions(c, logger)
+	opts.Blocks = []string{luxtronik.BlockParameters}
+	// the poller restores the targets when closing the controller
+	p := luxtronik.NewPoller(client, opts, sc)
+	defer p.Close()
+
+	logger.Info("surplus controller started",
+		zap.Float64("on_w", c.Float64("on")),
+		zap.Float64("off_w", c.Float64("off")),
+		zap.Bool("dry_run", dryRun(c)))
+	return p.Run(ctx)
+}
+
+// subscribeSurplus forwards the surplus published to --mqtt-topic. The
+// subscription is renewed on reconnects.
+func subscribeSurplus(c *cli.Context, sc *luxtronik.SurplusController, logger *zap.Logger) (mqtt.Client, error) {
+	topic, path := c.String("mqtt-topic"), c.String("surplus-path")
+	if c.String("mqtt-broker") == "" {
+		return nil, errors.New("--mqtt-topic needs --mqtt-broker")
+	}
+	onMessage := func(_ mqtt.Client, msg mqtt.Message) {
+		v, err := luxtronik.ParseSurplus(msg.Payload(), path)
+		if err != nil {
+			logger.Warn("invalid surplus", zap.String("topic", msg.Topic()), zap.Error(err))
+			return
+		}
+		sc.Update(v)
+	}
+	opts, err := mqttClientOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	mc, err := connectMQTT(c, opts, func(mc mqtt.Client) {
+		if t := mc.Subscribe(topic, mqttQoS(c), onMessage); t.Wait() && t.Error() != nil {
+			logger.Error("mqtt subscription failed", zap.String("topic", topic), zap.Error(t.Error()))
+		}
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("subscribed to the surplus", zap.String("broker", c.String("mqtt-broker")), zap.String("topic", topic))
+	return mc, nil
+}
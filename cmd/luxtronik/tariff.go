@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var tariffCommand = &cli.Command{
+	Name:  "tariff",
+	Usage: "Raises hot water and heating targets during cheap tariff windows or prices and reports the savings",
+	Flags: append([]cli.Flag{
+		&cli.StringSliceFlag{Name: "cheap", Usage: "cheap tariff window, e.g. 22:00-06:00"},
+		&cli.Float64Flag{Name: "hot-water", Usage: "hot water target in °C during cheap windows", Value: 55},
+		&cli.Float64Flag{Name: "boost", Usage: "heating curve offset in K added during cheap windows", Value: 2},
+		&cli.Float64Flag{Name: "high-price", Usage: "price per kWh of the high tariff"},
+		&cli.Float64Flag{Name: "low-price", Usage: "price per kWh of the low tariff"},
+		&cli.Float64Flag{Name: "cop", Usage: "estimated COP to convert heat into electrical energy", Value: 3.5},
+		&cli.Float64Flag{Name: "setback", Usage: "heating curve offset in K subtracted during expensive periods"},
+		&cli.Float64Flag{Name: "hot-water-floor", Usage: "hot water target in °C during expensive periods"},
+		&cli.Float64Flag{Name: "outdoor-floor", Usage: "no setback below this outdoor temperature in °C"},
+		&cli.Float64Flag{Name: "max-price", Usage: "prices up to this value per kWh are cheap"},
+		&cli.IntFlag{Name: "cheapest-hours", Usage: "the cheapest hours of each day are cheap"},
+		&cli.DurationFlag{Name: "interval", Usage: "poll interval", Value: pollInterval},
+	}, append(budgetFlags, priceFlags...)...),
+	Action: runTariff,
+}
+
+func runTariff(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	var windows []luxtronik.TariffWindow
+	for _, s := range c.StringSlice("cheap") {
+		w, err := luxtronik.ParseTariffWindow(s)
+		if err != nil {
+			return err
+		}
+		windows = append(windows, w)
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// the prices replace --cheap
+	feed, err := newPriceFeed(ctx, c, logger)
+	if err != nil {
+		return err
+	}
+	if feed == nil && len(windows) == 0 {
+		return errors.New("either --cheap or --price-url is required")
+	}
+
+	pool, err := newPool(c)
+	if err != nil {
+		return err
+	}
+
+	// every heat pump gets its own shifter because the shifter keeps the
+	// original targets of a single controller
+	return pool.Each(ctx, func(ctx context.Context, client *luxtronik.Client) error {
+		logger := logger.With(zap.String("host", client.Name()))
+		shifter := luxtronik.NewTariffShifter(client, luxtronik.TariffOptions{
+			CheapWindows:   windows,
+			HighPrice:      c.Float64("high-price"),
+			LowPrice:       c.Float64("low-price"),
+			HotWaterTarget: c.Float64("hot-water"),
+			HeatingBoost:   c.Float64("boost"),
+			Prices:         feed,
+			MaxPrice:       c.Float64("max-price"),
+			CheapestHours:  c.Int("cheapest-hours"),
+			Setback:        c.Float64("setback"),
+			HotWaterFloor:  c.Float64("hot-water-floor"),
+			OutdoorFloor:   c.Float64("outdoor-floor"),
+			COP:            c.Float64("cop"),
+			Logger:         logger,
+		})
+
+		opts := pollerOptions(c, logger)
+		opts.Blocks = []string{luxtronik.BlockParameters, luxtronik.BlockCalculations}
+		p := luxtronik.NewPoller(client, opts, shifter)
+		defer func() {
+			// the poller restores the targets when closing the shifter
+			_ = p.Close()
+			r := shifter.Report()
+			logger.Info("tariff report",
+				zap.Int("shifts", r.Shifts),
+				zap.Float64("cheap_heat_kwh", r.CheapHeat),
+				zap.Float64("expensive_heat_kwh", r.ExpensiveHeat),
+				zap.Float64("savings", r.Savings))
+		}()
+
+		return p.Run(ctx)
+	})
+}
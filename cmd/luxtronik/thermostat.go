@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var thermostatCommand = &cli.Command{
+	Name:  "thermostat",
+	Usage: "Adjusts the heating curve offset so that a room sensor reaches a target temperature",
+	Description: `The room temperature is posted to --sensors-listen or published to
+--sensors-mqtt-topic. The offset stays within --min-offset and --max-offset
+(at most ±5 K) and changes at most --max-step per --min-interval. It is
+restored on exit. Try the settings with --dry-run first, which only logs the
+offsets.`,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{Name: "sensor", Usage: "name of the room sensor", Required: true},
+		&cli.Float64Flag{Name: "target", Usage: "room temperature target in °C", Required: true},
+		&cli.Float64Flag{Name: "kp", Usage: "offset in K per K of control error", Value: 1},
+		&cli.Float64Flag{Name: "ki", Usage: "offset in K per K of control error and hour", Value: 0.5},
+		&cli.Float64Flag{Name: "min-offset", Usage: "lowest heating curve offset in K", Value: -2},
+		&cli.Float64Flag{Name: "max-offset", Usage: "highest heating curve offset in K", Value: 2},
+		&cli.Float64Flag{Name: "max-step", Usage: "largest change of the offset per write in K", Value: 0.5},
+		&cli.DurationFlag{Name: "min-interval", Usage: "minimum time between two writes", Value: luxtronik.DefaultThermostatInterval},
+		&cli.BoolFlag{Name: "dry-run", Usage: "logs the offsets instead of writing them"},
+		&cli.DurationFlag{Name: "interval", Usage: "poll interval", Value: pollInterval},
+	}, append(budgetFlags, sensorsFlags...)...),
+	Action: runThermostat,
+}
+
+func runThermostat(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	if c.String(sensorsListenFlag.Name) == "" && c.String(sensorsMQTTTopicFlag.Name) == "" {
+		return errors.New("--sensors-listen or --sensors-mqtt-topic is required to receive the room temperature")
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	th, err := luxtronik.NewThermostat(client, luxtronik.ThermostatOptions{
+		Sensor:      c.String("sensor"),
+		Target:      c.Float64("target"),
+		Kp:          c.Float64("kp"),
+		Ki:          c.Float64("ki"),
+		MinOffset:   c.Float64("min-offset"),
+		MaxOffset:   c.Float64("max-offset"),
+		MaxStep:     c.Float64("max-step"),
+		MinInterval: c.Duration("min-interval"),
+		DryRun:      dryRun(c),
+		Logger:      logger,
+	})
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sensors, err := startSensors(ctx, c, logger)
+	if err != nil {
+		return err
+	}
+	opts := pollerOptions(c, logger)
+	opts.Blocks = []string{luxtronik.BlockParameters}
+	opts.Sensors = sensors
+	// the poller restores the offset when closing the thermostat
+	p := luxtronik.NewPoller(client, opts, th)
+	defer p.Close()
+
+	logger.Info("thermostat started",
+		zap.String("sensor", c.String("sensor")),
+		zap.Float64("target", c.Float64("target")),
+		zap.Bool("dry_run", dryRun(c)))
+	return p.Run(ctx)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/urfave/cli/v2"
+)
+
+var watchCommand = &cli.Command{
+	Name:  "watch",
+	Usage: "Continuously prints the values of a block as a table",
+	Flags: append([]cli.Flag{
+		&cli.DurationFlag{Name: "interval", Value: 5 * time.Second},
+		&cli.StringFlag{Name: "block", Value: luxtronik.BlockCalculations},
+		&cli.StringSliceFlag{Name: "class", Usage: "only shows values of these classes, e.g. temperature"},
+		&cli.BoolFlag{Name: "changed-only", Usage: "only shows values which changed since the previous poll"},
+		deadbandFlag,
+		&cli.StringFlag{Name: "replay", Usage: "replays a file of the dump command in a loop instead of reading the heat pump"},
+		&cli.StringFlag{Name: "serial", Usage: "reads the temperatures of a Luxtronik 1 controller from this serial port, e.g. /dev/ttyUSB0"},
+	}, append(append(burstFlags, historyFlags...), sensorsFlags...)...),
+	Action: runWatch,
+}
+
+func runWatch(c *cli.Context) error {
+	logger, err := newLogger(c)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	var (
+		pool   *luxtronik.ClientPool
+		source luxtronik.DataSource
+		hosts  = 1
+	)
+	if file := c.String("replay"); file != "" {
+		replay, err := luxtronik.NewFileSource(file)
+		if err != nil {
+			return err
+		}
+		replay.Loop = true
+		source = replay
+	} else if port := c.String("serial"); port != "" {
+		source = luxtronik.NewSerialSource(port, luxtronik.SerialOptions{Logger: logger})
+	} else {
+		if pool, err = newPool(c); err != nil {
+			return err
+		}
+		hosts = pool.Len()
+	}
+
+	classes := c.StringSlice("class")
+	changedOnly := c.Bool("changed-only")
+	deadbands, err := luxtronik.ParseDeadbands(c.String("deadband"))
+	if err != nil {
+		return cli.Exit(err.Error(), 2)
+	}
+	changes := luxtronik.NewChangeDetector(deadbands)
+	w := c.App.Writer
+
+	print := luxtronik.SinkFunc(func(_ context.Context, host string, ts time.Time, block string, pm luxtronik.DataTypeMap) error {
+		tw := tabwriter.NewWriter(w, 4, 1, 2, ' ', 0)
+		header := ts.Format(time.DateTime)
+		if hosts > 1 {
+			header += " " + host
+		}
+		fmt.Fprintf(tw, "%s\nINDEX\tNAME\tCLASS\tVALUE\n", header)
+		pm.IterateSorted(func(idx int, b *luxtronik.Base) {
+			if _, changed := changes.Changed(host, block, idx, b); changedOnly && !changed {
+				return
+			}
+			if len(classes) > 0 && !slices.Contains(classes, b.Class()) {
+				return
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", idx, b.Name(), b.Class(), formatValue(b))
+		})
+		fmt.Fprintln(tw)
+		return tw.Flush()
+	})
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sensors, err := startSensors(ctx, c, logger)
+	if err != nil {
+		return err
+	}
+
+	sinks := []luxtronik.Sink{print}
+	routes := map[string]http.Handler{}
+	history, err := openHistory(c, deadbands, logger)
+	if err != nil {
+		return err
+	}
+	if history != nil {
+		sinks = append(sinks, history)
+		routes["/api/v1/history"] = history
+	}
+
+	opts := luxtronik.PollerOptions{
+		Interval:   c.Duration("interval"),
+		Blocks:     []string{c.String("block")},
+		Sensors:    sensors,
+		RecentSize: c.Int("recent"),
+		Logger:     logger,
+	}
+	var p *luxtronik.Poller
+	if source != nil {
+		p = luxtronik.NewPoller(source, opts, sinks...)
+	} else {
+		p = luxtronik.NewPoolPoller(pool, opts, sinks...)
+	}
+	defer p.Close()
+	if c.Int("recent") > 0 {
+		routes["/api/v1/recent"] = luxtronik.RecentHandler(p)
+	}
+	if err := startBurst(ctx, c, logger, p, routes); err != nil {
+		return err
+	}
+
+	return p.Run(ctx)
+}
@@ -0,0 +1,323 @@
+package luxtronik
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVImportOptions configures ImportCSV.
+type CSVImportOptions struct {
+	// Host labels the imported values, defaults to "import".
+	Host string
+	// TimeColumn names the timestamp column. By default the first column
+	// named time, timestamp, date, datetime, zeit or datum is used.
+	TimeColumn string
+	// TimeLayout is a Go time layout. By default RFC 3339, common date
+	// formats and unix timestamps are detected.
+	TimeLayout string
+	// Location of timestamps without zone, defaults to time.Local.
+	Location *time.Location
+	// Columns maps column headers to luxtronik names, e.g. "Vorlauf" to
+	// ID_WEB_Temperatur_TVL. Headers which are luxtronik names themselves
+	// or English aliases need no mapping.
+	Columns map[string]string
+	// Comma is detected from the header line if zero. With a semicolon as
+	// separator decimal commas are accepted.
+	Comma rune
+}
+
+// ImportStats summarizes an import.
+type ImportStats struct {
+	Rows   int
+	Values int
+	// Ignored lists the columns which match no known value.
+	Ignored []string
+}
+
+var csvTimeColumns = []string{"time", "timestamp", "date", "datetime", "zeit", "datum"}
+
+var csvTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"01/02/2006 15:04:05",
+}
+
+type csvColumn struct {
+	col   int
+	block string
+	index int
+	base  *Base
+}
+
+// ImportCSV reads historical data and hands every row to the sink as if it
+// had been polled at the row's time, so that any storage sink can be
+// filled. Two layouts are understood: the long format written by
+// Dump.WriteCSV and wide tables with a time column and one column per value
+// as written by python-luxtronik loggers and most spreadsheet exports.
+func ImportCSV(ctx context.Context, r io.Reader, opts CSVImportOptions, sink Sink) (ImportStats, error) {
+	if opts.Host == "" {
+		opts.Host = "import"
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	br := bufio.NewReader(r)
+	header, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ImportStats{}, fmt.Errorf("ImportCSV failed to read header: %w", err)
+	}
+	if i := bytes.IndexByte(header, '\n'); i >= 0 {
+		header = header[:i]
+	}
+	if opts.Comma == 0 {
+		opts.Comma = ','
+		if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+			opts.Comma = ';'
+		}
+	}
+	if bytes.HasPrefix(header, []byte("time,host,block,index,")) {
+		return importDumpCSV(ctx, br, sink)
+	}
+	return importWideCSV(ctx, br, opts, sink)
+}
+
+func importDumpCSV(ctx context.Context, r io.Reader, sink Sink) (ImportStats, error) {
+	dumps, err := readDumpsCSV(r)
+	if err != nil {
+		return ImportStats{}, fmt.Errorf("ImportCSV: %w", err)
+	}
+	var stats ImportStats
+	for _, d := range dumps {
+		stats.Rows++
+		for block, pm := range d.blocks() {
+			stats.Values += len(pm)
+			if err := sink.Write(ctx, d.Host, d.Time, block, pm); err != nil {
+				return stats, fmt.Errorf("ImportCSV failed to write %s: %w", d.Time, err)
+			}
+		}
+	}
+	return stats, nil
+}
+
+// blocks returns the entries of the dump as maps containing only the dumped
+// indexes.
+func (d *Dump) blocks() map[string]DataTypeMap {
+	full := map[string]DataTypeMap{
+		BlockParameters:   NewParameterMap(),
+		BlockCalculations: NewCalculationsMap(),
+		BlockVisibilities: NewVisibilitiesMap(),
+	}
+	out := map[string]DataTypeMap{}
+	for _, e := range d.Entries {
+		b, ok := full[e.Block][e.Index]
+		if !ok {
+			continue
+		}
+		if out[e.Block] == nil {
+			out[e.Block] = DataTypeMap{}
+		}
+		b.SetRaw(e.Raw)
+		out[e.Block][e.Index] = b
+	}
+	return out
+}
+
+func importWideCSV(ctx context.Context, r io.Reader, opts CSVImportOptions, sink Sink) (ImportStats, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = opts.Comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var stats ImportStats
+	header, err := cr.Read()
+	if err != nil {
+		return stats, fmt.Errorf("ImportCSV failed to read header: %w", err)
+	}
+	timeCol, columns, ignored := mapCSVColumns(header, opts)
+	stats.Ignored = ignored
+	if timeCol < 0 {
+		return stats, fmt.Errorf("ImportCSV found no time column in %q", header)
+	}
+	if len(columns) == 0 {
+		return stats, errors.New("ImportCSV found no known value columns")
+	}
+
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, fmt.Errorf("ImportCSV line %d: %w", line, err)
+		}
+		if timeCol >= len(rec) {
+			continue
+		}
+		ts, err := parseCSVTime(rec[timeCol], opts)
+		if err != nil {
+			return stats, fmt.Errorf("ImportCSV line %d invalid time %q: %w", line, rec[timeCol], err)
+		}
+
+		blocks := map[string]DataTypeMap{}
+		for _, c := range columns {
+			if c.col >= len(rec) || strings.TrimSpace(rec[c.col]) == "" {
+				continue
+			}
+			raw, err := csvRawValue(c.base, rec[c.col], opts.Comma)
+			if err != nil {
+				return stats, fmt.Errorf("ImportCSV line %d column %q: %w", line, header[c.col], err)
+			}
+			if blocks[c.block] == nil {
+				blocks[c.block] = DataTypeMap{}
+			}
+			b := *c.base
+			b.SetRaw(raw)
+			blocks[c.block][c.index] = &b
+			stats.Values++
+		}
+		stats.Rows++
+
+		// stable block order keeps the sink output reproducible
+		names := make([]string, 0, len(blocks))
+		for name := range blocks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := sink.Write(ctx, opts.Host, ts, name, blocks[name]); err != nil {
+				return stats, fmt.Errorf("ImportCSV line %d failed to write: %w", line, err)
+			}
+		}
+	}
+}
+
+// mapCSVColumns finds the time column and resolves all other headers to
+// values of the calculations or parameters block.
+func mapCSVColumns(header []string, opts CSVImportOptions) (int, []csvColumn, []string) {
+	lookup := []struct {
+		block string
+		pm    DataTypeMap
+	}{
+		{BlockCalculations, NewCalculationsMap()},
+		{BlockParameters, NewParameterMap()},
+	}
+	// aliases can be shared, the first name in sort order wins
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	byAlias := map[string]string{}
+	for _, name := range names {
+		for _, a := range aliases[name] {
+			if _, ok := byAlias[a]; !ok {
+				byAlias[a] = name
+			}
+		}
+	}
+
+	timeCol := -1
+	var columns []csvColumn
+	var ignored []string
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		switch {
+		case opts.TimeColumn != "" && strings.EqualFold(h, opts.TimeColumn),
+			opts.TimeColumn == "" && timeCol < 0 && containsFold(csvTimeColumns, h):
+			timeCol = i
+			continue
+		}
+		name := h
+		if mapped, ok := opts.Columns[h]; ok {
+			name = mapped
+		} else if n, ok := byAlias[strings.ToLower(h)]; ok {
+			name = n
+		}
+		found := false
+		for _, l := range lookup {
+			if isIndex(name) {
+				break // Lookup would accept indexes of any block
+			}
+			if idx, b, ok := l.pm.Lookup(name); ok {
+				columns = append(columns, csvColumn{col: i, block: l.block, index: idx, base: b})
+				found = true
+				break
+			}
+		}
+		if !found {
+			ignored = append(ignored, h)
+		}
+	}
+	return timeCol, columns, ignored
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, l := range list {
+		if strings.EqualFold(l, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCSVTime(s string, opts CSVImportOptions) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if opts.TimeLayout != "" {
+		return time.ParseInLocation(opts.TimeLayout, s, opts.Location)
+	}
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, opts.Location); err == nil {
+			return t, nil
+		}
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		if n > 1e11 {
+			return time.UnixMilli(int64(n)), nil
+		}
+		return time.Unix(int64(n), 0), nil
+	}
+	return time.Time{}, errors.New("unknown time format")
+}
+
+// csvRawValue converts an exported value back into its raw representation.
+// Negative numbers are stored in two's complement like the controller does.
+func csvRawValue(b *Base, s string, comma rune) (uint32, error) {
+	s = strings.TrimSpace(s)
+	if b.codes != nil {
+		if n, err := strconv.ParseUint(s, 10, 32); err == nil {
+			return uint32(n), nil
+		}
+		return b.toRaw(s)
+	}
+	if comma == ';' {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return b.toRaw(s)
+	}
+	if f < 0 {
+		raw, err := b.toRaw(-f)
+		return uint32(-int32(raw)), err
+	}
+	return b.toRaw(f)
+}
@@ -0,0 +1,61 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordImport(rows *[]string) Sink {
+	return SinkFunc(func(_ context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+		pm.IterateSorted(func(_ int, b *Base) {
+			*rows = append(*rows, fmt.Sprintf("%s %s %s %s=%d",
+				host, ts.UTC().Format(time.RFC3339), block, b.Name(), int32(b.RawValue())))
+		})
+		return nil
+	})
+}
+
+func TestImportCSV_Wide(t *testing.T) {
+	in := "Datum;ID_WEB_Temperatur_TVL;outdoor temperature;Vorlauf;Kommentar\n" +
+		"01.02.2023 10:00;35,5;-2,5;31;x\n" +
+		"01.02.2023 10:05;36;;30,5;\n"
+
+	var rows []string
+	stats, err := ImportCSV(context.Background(), strings.NewReader(in), CSVImportOptions{
+		Host:     "old",
+		Location: time.UTC,
+		Columns:  map[string]string{"Vorlauf": "ID_WEB_Temperatur_TRL"},
+	}, recordImport(&rows))
+	require.NoError(t, err)
+
+	assert.Equal(t, ImportStats{Rows: 2, Values: 5, Ignored: []string{"Kommentar"}}, stats)
+	assert.Equal(t, []string{
+		"old 2023-02-01T10:00:00Z calculations ID_WEB_Temperatur_TVL=355",
+		"old 2023-02-01T10:00:00Z calculations ID_WEB_Temperatur_TRL=310",
+		"old 2023-02-01T10:00:00Z calculations ID_WEB_Temperatur_TA=-25",
+		"old 2023-02-01T10:05:00Z calculations ID_WEB_Temperatur_TVL=360",
+		"old 2023-02-01T10:05:00Z calculations ID_WEB_Temperatur_TRL=305",
+	}, rows)
+}
+
+func TestImportCSV_Dump(t *testing.T) {
+	pm := NewCalculationsMap()
+	pm[10].SetRaw(355)
+	d := NewDump("hp", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), map[string]DataTypeMap{BlockCalculations: pm})
+	var buf bytes.Buffer
+	require.NoError(t, d.WriteCSV(&buf))
+
+	var rows []string
+	stats, err := ImportCSV(context.Background(), &buf, CSVImportOptions{}, recordImport(&rows))
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Rows)
+	assert.Equal(t, len(pm), stats.Values)
+	assert.Contains(t, rows, "hp 2024-01-02T03:04:05Z calculations ID_WEB_Temperatur_TVL=355")
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"math"
 	"net/netip"
+	"path"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,49 +35,189 @@ var ErrWritingNotAllowed = errors.New("writing to heat pump not allowed or not p
 
 type DataTypeMap map[int]*Base
 
+// IterateSorted calls cb for all entries ordered by index.
 func (pm DataTypeMap) IterateSorted(cb func(int, *Base)) {
+	// the maps of the catalog are dense, walking the range of indexes avoids
+	// collecting and sorting the keys on every poll
+	lowest, highest := 0, -1
+	for idx := range pm {
+		lowest, highest = min(lowest, idx), max(highest, idx)
+	}
+	if lowest == 0 && highest < 2*len(pm) {
+		for idx := 0; idx <= highest; idx++ {
+			if b, ok := pm[idx]; ok {
+				cb(idx, b)
+			}
+		}
+		return
+	}
+
 	keys := lo.Keys(pm)
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] < keys[j]
-	})
+	slices.Sort(keys)
 	for _, key := range keys {
 		cb(key, pm[key])
 	}
 }
 
+// SetLocation sets the time zone of the values of the time class, see
+// Options.Location.
+func (pm DataTypeMap) SetLocation(loc *time.Location) {
+	for _, b := range pm {
+		b.location = loc
+	}
+}
+
+// Clone returns a point in time copy of the map, e.g. to diff it with a
+// later read. The readings are copied, the definitions are shared.
+func (pm DataTypeMap) Clone() DataTypeMap {
+	c := make(DataTypeMap, len(pm))
+	for idx, b := range pm {
+		cb := *b
+		c[idx] = &cb
+	}
+	return c
+}
+
+// Merge layers the entries of other on top of pm, e.g. the definitions
+// which differ in a firmware, see FirmwareMaps. Replaced entries keep their
+// reading. It returns pm for use in map definitions.
+func (pm DataTypeMap) Merge(other DataTypeMap) DataTypeMap {
+	for idx, b := range other {
+		cb := *b
+		if prev, ok := pm[idx]; ok {
+			cb.reading = prev.reading
+		}
+		pm[idx] = &cb
+	}
+	return pm
+}
+
 func (pm DataTypeMap) SetRawValues(data []uint32) error {
 	if dl, pml := len(data), len(pm); dl != pml {
-		return fmt.Errorf("DataTypeMap.SetRawValues length of data:%d not equal to length of DataTypeMap:%d", dl, pml)
+		return fmt.Errorf("DataTypeMap.SetRawValues length of data:%d not equal to length of DataTypeMap:%d: %w", dl, pml, ErrLengthMismatch)
 	}
 
+	now := time.Now()
 	for idx, raw := range data {
-		pm[idx].SetRaw(raw)
+		pm[idx].setRead(raw, now)
 	}
 
 	return nil
 }
 
-func (pm DataTypeMap) GetVersion() string {
-	var buf strings.Builder
-	for i := 81; i <= 87; i++ {
-		buf.WriteString(pm[i].FromHeatPump().(string))
+// SetRawValuesTolerant stores the values like SetRawValues but accepts frames
+// of another length, e.g. from a newer firmware. Values beyond the map get
+// unknown entries named prefix and index, e.g. Unknown_Parameter_1200.
+// Entries without a value in data keep their previous one. It returns the
+// indexes of the added and of the missing entries.
+func (pm DataTypeMap) SetRawValuesTolerant(data []uint32, prefix string) (added, missing []int) {
+	now := time.Now()
+	for idx, raw := range data {
+		b, ok := pm[idx]
+		if !ok {
+			b = NewUnknown(prefix + strconv.Itoa(idx))
+			pm[idx] = b
+			added = append(added, idx)
+		}
+		b.setRead(raw, now)
+	}
+	for idx := range pm {
+		if idx >= len(data) {
+			missing = append(missing, idx)
+		}
 	}
-	return buf.String()
+	sort.Ints(missing)
+	return added, missing
 }
 
-type Base struct {
-	customFromHP  func(uint32) any
-	customToHP    func(any) (uint32, error)
-	codes         []string
+// Lookup finds an entry either by its index or by its luxtronik name. Names
+// are matched case-insensitive.
+func (pm DataTypeMap) Lookup(nameOrIndex string) (int, *Base, bool) {
+	if idx, err := strconv.Atoi(nameOrIndex); err == nil {
+		b, ok := pm[idx]
+		return idx, b, ok
+	}
+	for idx, b := range pm {
+		if strings.EqualFold(b.luxtronikName, nameOrIndex) {
+			return idx, b, true
+		}
+	}
+	return 0, nil, false
+}
+
+// Match returns the sorted indexes of all entries whose luxtronik name matches
+// the shell glob pattern, see path.Match. Matching is case-insensitive.
+func (pm DataTypeMap) Match(pattern string) ([]int, error) {
+	pattern = strings.ToLower(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("DataTypeMap.Match invalid pattern %q: %w", pattern, err)
+	}
+	var idxs []int
+	pm.IterateSorted(func(idx int, b *Base) {
+		if ok, _ := path.Match(pattern, strings.ToLower(b.luxtronikName)); ok {
+			idxs = append(idxs, idx)
+		}
+	})
+	return idxs, nil
+}
+
+// GetVersion returns the firmware version contained in the calculations,
+// see DeviceInfo.
+func (pm DataTypeMap) GetVersion() string {
+	_, version := pm.infoLayout()
+	return version
+}
+
+// Definition describes an entry of a map: its names, class, unit, codes
+// and the conversion of the raw value. The maps of the catalog share their
+// definitions, they must not change once a map is built.
+type Definition struct {
+	customFromHP func(uint32) any
+	customToHP   func(any) (uint32, error)
+	codes        []string
+	// enum converts a raw value of the codes into their enum type, e.g.
+	// HeatingMode.
+	enum          func(uint32) any
 	returnType    reflect.Kind
 	name          string
 	class         string
 	luxtronikName string
 	unit          string
-	rawValue      uint32
-	prevRawValue  uint32
 	factor        float32
 	writeable     bool
+	// signed values are stored in two's complement, e.g. sub-zero
+	// temperatures.
+	signed bool
+	// visibility names the entry of the visibilities which tells whether
+	// the controller shows the value.
+	visibility string
+	// limits bound the written values, see WithRange.
+	limits *valueRange
+	// access is the level needed to write the value, see WithAccess.
+	access AccessLevel
+}
+
+// Reading is the state of an entry of a single read.
+type Reading struct {
+	Raw uint32
+	// Prev is the raw value of the read before, see HasChanges.
+	Prev uint32
+	// Time of the read, zero if the value was set by SetRaw.
+	Time time.Time
+}
+
+// Base is an entry of a map: the shared Definition and the Reading of the
+// last read. Copies of a Base, e.g. the snapshots of the Poller, have their
+// own Reading.
+type Base struct {
+	*Definition
+	reading Reading
+	// hidden is set by ApplyVisibilities.
+	hidden bool
+	// location of the values of the time class, nil means time.Local.
+	location *time.Location
+	units    Units
+	lang     Language
 }
 
 func (b *Base) String() string {
@@ -86,60 +229,170 @@ func (b *Base) String() string {
 	)
 }
 
-func (b *Base) Name() string {
-	return b.luxtronikName
+func (d *Definition) Name() string {
+	return d.luxtronikName
 }
 
+// Unit returns the unit of the converted value, see SetUnits.
 func (b *Base) Unit() string {
+	if conv, ok := b.conversion(); ok {
+		return conv.unit
+	}
 	return b.unit
 }
 
+// Writeable reports whether the value may be written to the heat pump.
+func (d *Definition) Writeable() bool {
+	return d.writeable
+}
+
+// Class returns the category of the value, e.g. temperature or selection.
+func (d *Definition) Class() string {
+	return d.class
+}
+
+// RawValue returns the value as received from the heat pump.
+func (b *Base) RawValue() uint32 {
+	return b.reading.Raw
+}
+
+// PrevRawValue returns the raw value of the previous read, see HasChanges.
+func (b *Base) PrevRawValue() uint32 {
+	return b.reading.Prev
+}
+
+// Reading returns the state of the last read.
+func (b *Base) Reading() Reading {
+	return b.reading
+}
+
+// Factor returns the factor which scales the raw value, e.g. 0.1 for tenths
+// of a degree. It is 0 for values which are not scaled.
+func (d *Definition) Factor() float32 {
+	return d.factor
+}
+
+// Type returns the name of the data type, e.g. celsius or HeatingMode.
+func (d *Definition) Type() string {
+	return d.name
+}
+
+// SetLocation sets the time zone of the values of the time class.
+func (b *Base) SetLocation(loc *time.Location) {
+	b.location = loc
+}
+
+func (b *Base) timeLocation() *time.Location {
+	if b.location == nil {
+		return time.Local
+	}
+	return b.location
+}
+
 func (b *Base) SetRaw(val uint32) {
-	b.prevRawValue = b.rawValue
-	b.rawValue = val
+	b.setRead(val, time.Time{})
+}
+
+// setRead stores the value of a read at ts.
+func (b *Base) setRead(val uint32, ts time.Time) {
+	b.reading = Reading{Raw: val, Prev: b.reading.Raw, Time: ts}
 }
 
 func (b *Base) HasChanges() bool {
-	return b.prevRawValue != b.rawValue
+	return b.reading.Prev != b.reading.Raw
 }
 
 func (b *Base) FromHeatPump() any {
+	return b.fromRaw(b.reading.Raw)
+}
+
+// PrevFromHeatPump converts the value of the previous read.
+func (b *Base) PrevFromHeatPump() any {
+	return b.fromRaw(b.reading.Prev)
+}
+
+// FromHeatPumpRaw converts any raw value with the definition of b.
+func (b *Base) FromHeatPumpRaw(raw uint32) any {
+	return b.fromRaw(raw)
+}
+
+func (b *Base) fromRaw(rawValue uint32) any {
 	if b.codes != nil {
-		if b.rawValue > uint32(len(b.codes)) {
-			return fmt.Sprintf("unknown code: %d", b.rawValue)
+		// translated codes stay strings, the enums print in English
+		if b.enum != nil && !b.translated() {
+			return b.enum(rawValue)
 		}
-
-		return b.codes[b.rawValue]
+		return b.translate(codeString(b.codes, rawValue))
 	}
 
 	if b.customFromHP != nil {
-		return b.customFromHP(b.rawValue)
+		return b.customFromHP(rawValue)
+	}
+	if b.class == classTime {
+		if rawValue < 1 {
+			return time.Time{}
+		}
+		return time.Unix(int64(rawValue), 0).In(b.timeLocation())
 	}
 	if b.class == classDuration {
 		if b.name == "seconds" {
-			return time.Duration(b.rawValue) * time.Second
+			return time.Duration(rawValue) * time.Second
 		}
 	}
 
 	switch b.returnType {
 	case reflect.Uint32:
 		if b.factor != 0 {
-			return uint32(float32(b.rawValue) * b.factor)
+			return uint32(float32(rawValue) * b.factor)
 		}
-		return b.rawValue
+		return rawValue
 
-	case reflect.Float32:
+	case reflect.Int32:
+		if b.factor != 0 {
+			return int32(float32(int32(rawValue)) * b.factor)
+		}
+		return int32(rawValue)
 
+	case reflect.Float32:
+		v := b.number(rawValue)
+		if b.factor != 0 {
+			v *= float64(b.factor)
+		}
+		if conv, ok := b.conversion(); ok {
+			return roundFloat(conv.from(v), 3)
+		}
 		if b.factor != 0 {
-			return roundFloat(float64(b.rawValue)*float64(b.factor), 3)
+			return roundFloat(v, 3)
 		}
-		return float32(b.rawValue)
+		return float32(v)
 
 	default:
-		return b.rawValue
+		return rawValue
 	}
 }
 
+// number returns the raw value as number, respecting the sign.
+func (b *Base) number(rawValue uint32) float64 {
+	if b.signed {
+		return float64(int32(rawValue))
+	}
+	return float64(rawValue)
+}
+
+// scaled reports whether the factor applies to the raw value.
+func (b *Base) scaled() bool {
+	switch b.returnType {
+	case reflect.Uint32, reflect.Int32, reflect.Float32:
+		return b.factor != 0
+	}
+	return false
+}
+
+// Signed reports whether the raw value is stored in two's complement.
+func (d *Definition) Signed() bool {
+	return d.signed || d.returnType == reflect.Int32
+}
+
 func roundFloat(val float64, precision uint) float32 {
 	ratio := math.Pow(10, float64(precision))
 	return float32(math.Round(val*ratio) / ratio)
@@ -147,53 +400,95 @@ func roundFloat(val float64, precision uint) float32 {
 
 func (b *Base) ToHeatPump(val any) (uint32, error) {
 	if !b.writeable {
-		return 0, fmt.Errorf("ToHeatPump can't write non-writeable value: %v", val)
+		return 0, fmt.Errorf("ToHeatPump can't write value %v: %w", val, ErrWritingNotAllowed)
 	}
+	raw, err := b.toRaw(val)
+	if err != nil {
+		return 0, err
+	}
+	if err := b.checkRange(val, raw); err != nil {
+		return 0, err
+	}
+	return raw, nil
+}
 
+// toRaw converts val into the raw representation regardless of writability.
+func (b *Base) toRaw(val any) (uint32, error) {
 	if b.codes != nil {
+		if b.enum != nil && reflect.TypeOf(val) == reflect.TypeOf(b.enum(0)) {
+			return uint32(reflect.ValueOf(val).Uint()), nil
+		}
 		vals := cast.ToString(val)
 		for idx, code := range b.codes {
-			if code == vals {
+			if code != "" && (code == vals || b.translate(code) == vals) {
 				return uint32(idx), nil
 			}
 		}
-		return 0, fmt.Errorf("ToHeatPump can't find value: %q in list of codes", vals)
+		return 0, fmt.Errorf("ToHeatPump can't find value: %q in list of codes: %w", vals, ErrInvalidValue)
 	}
 	if b.customToHP != nil {
-		return b.customToHP(b.rawValue)
+		return b.customToHP(val)
+	}
+	if b.class == classTime {
+		return b.timeToRaw(val)
 	}
 
-	switch b.returnType {
-	case reflect.Uint32:
-		if b.factor != 0 {
-			return uint32(cast.ToFloat32(val) / b.factor), nil
+	f, err := cast.ToFloat64E(val)
+	if err != nil {
+		return 0, fmt.Errorf("ToHeatPump can't convert value: %v to a number: %w: %w", val, ErrInvalidValue, err)
+	}
+	if conv, ok := b.conversion(); ok {
+		f = conv.to(f)
+	}
+	// mirrors FromHeatPump which applies the factor only to these types
+	if b.scaled() {
+		f /= float64(b.factor)
+	}
+	f = math.Round(f)
+	if b.Signed() {
+		if f < math.MinInt32 || f > math.MaxInt32 {
+			return 0, fmt.Errorf("ToHeatPump value: %v out of range: %w", val, ErrInvalidValue)
 		}
-		return b.rawValue, nil
+		return uint32(int32(f)), nil
+	}
+	if f < 0 || f > math.MaxUint32 {
+		return 0, fmt.Errorf("ToHeatPump value: %v out of range: %w", val, ErrInvalidValue)
+	}
+	return uint32(f), nil
+}
 
-	case reflect.Float32:
-		if b.factor != 0 {
-			return uint32(cast.ToFloat32(val) / b.factor), nil
-		}
-		return cast.ToUint32(val), nil
+// codeString returns the code of a raw value, unused codes are empty.
+func codeString(codes []string, raw uint32) string {
+	if raw >= uint32(len(codes)) {
+		return fmt.Sprintf("unknown code: %d", raw)
+	}
+	return codes[raw]
+}
 
-	default:
-		return b.rawValue, nil
+// parseCode returns the raw value of an English code, ignoring the case.
+func parseCode(fn string, codes []string, s string) (uint32, error) {
+	s = strings.TrimSpace(s)
+	for idx, code := range codes {
+		if code != "" && strings.EqualFold(code, s) {
+			return uint32(idx), nil
+		}
 	}
+	return 0, fmt.Errorf("%s unknown code %q: %w", fn, s, ErrInvalidValue)
 }
 
 func NewEnergy(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "energy",
 		class:         classEnergy,
 		luxtronikName: name,
 		unit:          "kWh",
 		factor:        0.1,
-	}
+	}}
 }
 
 func NewCelsius(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "celsius",
 		class:         classTemperature,
@@ -201,11 +496,12 @@ func NewCelsius(name string, writeable bool) *Base {
 		unit:          "°C",
 		writeable:     writeable,
 		factor:        0.1,
-	}
+		signed:        true,
+	}}
 }
 
 func NewKelvin(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "kelvin",
 		class:         classTemperature,
@@ -213,198 +509,196 @@ func NewKelvin(name string, writeable bool) *Base {
 		unit:          "K",
 		writeable:     writeable,
 		factor:        0.1,
-	}
+		signed:        true,
+	}}
 }
 
 func NewVoltage(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "Voltage",
 		class:         "voltage",
 		luxtronikName: name,
 		unit:          "V",
 		factor:        0.1,
-	}
+	}}
 }
 
 func NewFlow(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "Flow",
 		class:         "flow",
 		luxtronikName: name,
 		unit:          "l/h",
-	}
+	}}
 }
 
 func NewPressure(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "Pressure",
 		class:         "pressure",
 		factor:        0.01,
 		luxtronikName: name,
 		unit:          "bar",
-	}
+	}}
 }
 
 func NewUnknown(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          classNone,
 		class:         "none",
 		luxtronikName: name,
 		writeable:     false,
-	}
+	}}
 }
 
 func NewHeatingMode(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "HeatingMode",
 		luxtronikName: name,
 		class:         classSelection,
 		writeable:     writeable,
-		codes: []string{
-			0: "Automatic",
-			1: "Second heatsource",
-			2: "Party",
-			3: "Holidays",
-			4: "Off",
-		},
-	}
+		codes:         heatingModeCodes,
+		enum:          func(v uint32) any { return HeatingMode(v) },
+	}}
 }
 
 func NewHotWaterMode(name string, writeable bool) *Base {
 	b := NewHeatingMode(name, writeable)
 	b.name = "HotWaterMode"
+	b.codes = hotWaterModeCodes
+	b.enum = func(v uint32) any { return HotWaterMode(v) }
 	return b
 }
 
 func NewPoolMode(name string, writeable bool) *Base {
 	b := NewHeatingMode(name, writeable)
-	b.codes[1] = ""
 	b.name = "PoolMode"
+	b.codes = poolModeCodes
+	b.enum = func(v uint32) any { return PoolMode(v) }
 	return b
 }
 
 func NewAccessLevel(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "AccessLevel",
 		luxtronikName: name,
 		class:         "selection",
 		writeable:     writeable,
-		codes: []string{
-			0: "user",
-			1: "after sales service",
-			2: "manufacturer",
-			3: "installer",
-		},
-	}
+		codes:         accessLevelCodes,
+		enum:          func(v uint32) any { return AccessLevel(v) },
+	}}
 }
 
 func NewMixedCircuitMode(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "MixedCircuitMode",
 		luxtronikName: name,
 		class:         "selection",
 		writeable:     writeable,
-		codes: []string{
-			0: "Automatic", 2: "Party", 3: "Holidays", 4: "Off",
-		},
-	}
+		codes:         mixedCircuitModeCodes,
+		enum:          func(v uint32) any { return MixedCircuitMode(v) },
+	}}
 }
 
 func NewFrequency(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Float32,
 		name:          "Frequency",
 		class:         "frequency",
 		luxtronikName: name,
 		unit:          "Hz",
-	}
+	}}
 }
 
 func NewIcon(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          "Icon",
 		class:         "icon",
 		luxtronikName: name,
-	}
+	}}
 }
 
 func NewPercent2(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          "Percent2",
 		class:         "percent",
 		luxtronikName: name,
 		unit:          "%",
-	}
+	}}
 }
 
 func NewSpeed(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          "Speed",
 		class:         "speed",
 		luxtronikName: name,
 		unit:          "rpm",
-	}
+	}}
 }
 
 func NewPower(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          "Power",
 		class:         "power",
 		luxtronikName: name,
 		unit:          "W",
-	}
+	}}
 }
 
 func NewCount(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          "Count",
 		class:         classCount,
 		luxtronikName: name,
-	}
+	}}
 }
 
 func NewLevel(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Uint32,
 		name:          "Level",
 		class:         classCount,
 		luxtronikName: name,
-	}
+	}}
 }
 
+// NewErrorcode returns an ErrorCode, which carries the message of the code.
 func NewErrorcode(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
+		customFromHP: func(val uint32) any {
+			return ErrorCode(val)
+		},
 		returnType:    reflect.Uint32,
 		name:          "Errorcode",
 		class:         "value",
 		luxtronikName: name,
-	}
+	}}
 }
 
 func NewSeconds(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Int64,
 		name:          "seconds",
 		class:         classDuration,
 		luxtronikName: name,
 		unit:          "s",
-	}
+	}}
 }
 
 func NewHours(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Int64,
 		name:          "hours",
 		class:         classDuration,
@@ -412,11 +706,11 @@ func NewHours(name string, writeable bool) *Base {
 		unit:          "h",
 		writeable:     writeable,
 		factor:        0.1,
-	}
+	}}
 }
 
 func NewHours2(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		customFromHP: func(val uint32) any {
 			return 1 + val/2
 		},
@@ -429,42 +723,87 @@ func NewHours2(name string, writeable bool) *Base {
 		luxtronikName: name,
 		unit:          "h",
 		writeable:     writeable,
-	}
+	}}
 }
 
 func NewMinutes(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.Int64,
 		name:          "minutes",
 		class:         classDuration,
 		luxtronikName: name,
 		unit:          "min",
 		writeable:     writeable,
-	}
+	}}
 }
 
+// TimeLayout formats the values of the time class as text, see FormatValue.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// timeLayouts are accepted when writing values of the time class.
+var timeLayouts = []string{TimeLayout, time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+// NewTime returns the timestamp as time.Time in the location of the value,
+// see SetLocation. A raw value of zero is the zero time.
 func NewTime(name string) *Base {
-	return &Base{
-		customFromHP: func(val uint32) any {
-			if val < 1 {
-				return ""
-			}
-			return time.Unix(int64(val), 0).Format("2006-01-02 15:04:05")
-		},
-		customToHP: func(val any) (uint32, error) {
-			t, err := time.Parse("2006-01-02 15:04:05", cast.ToString(val))
-			return uint32(t.Unix()), err
-		},
-		returnType:    reflect.String,
+	return &Base{Definition: &Definition{
+		returnType:    reflect.Struct,
 		name:          "time",
 		class:         classTime,
 		luxtronikName: name,
 		unit:          "ts",
+	}}
+}
+
+// NewTimestamp is a writeable NewTime. Besides time.Time values it accepts
+// strings in the location of the value, a zero time clears the timestamp.
+func NewTimestamp(name string, writeable bool) *Base {
+	b := NewTime(name)
+	b.name = "timestamp"
+	b.writeable = writeable
+	return b
+}
+
+func (b *Base) timeToRaw(val any) (uint32, error) {
+	t, ok := val.(time.Time)
+	if !ok {
+		s := cast.ToString(val)
+		if s == "" {
+			return 0, nil
+		}
+		var err error
+		for _, layout := range timeLayouts {
+			if t, err = time.ParseInLocation(layout, s, b.timeLocation()); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return 0, fmt.Errorf("ToHeatPump invalid timestamp %q: %w", s, ErrInvalidValue)
+		}
+	}
+	if t.IsZero() {
+		return 0, nil
+	}
+	if t.Unix() < 1 || t.Unix() > math.MaxUint32 {
+		return 0, fmt.Errorf("ToHeatPump timestamp %s out of range: %w", t, ErrInvalidValue)
 	}
+	return uint32(t.Unix()), nil
+}
+
+// FormatValue returns a converted value as text: times in TimeLayout and
+// empty if not set, everything else like fmt.Sprint.
+func FormatValue(v any) string {
+	if t, ok := v.(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(TimeLayout)
+	}
+	return fmt.Sprint(v)
 }
 
 func NewMajorMinorVersion(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		customFromHP: func(val uint32) any {
 			if val > 0 {
 				major := val / 100
@@ -482,43 +821,43 @@ func NewMajorMinorVersion(name string) *Base {
 		class:         "version",
 		luxtronikName: name,
 		unit:          "",
-	}
+	}}
 }
 
 func NewCoolingMode(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "CoolingMode",
 		luxtronikName: name,
 		class:         "selection",
 		writeable:     writeable,
-		codes: []string{
-			0: "Off", 1: "Automatic",
-		},
-	}
+		codes:         coolingModeCodes,
+		enum:          func(v uint32) any { return CoolingMode(v) },
+	}}
 }
 
 func NewSolarMode(name string, writeable bool) *Base {
 	m := NewCoolingMode(name, writeable)
 	m.name = "SolarMode"
+	m.codes = solarModeCodes
+	m.enum = func(v uint32) any { return SolarMode(v) }
 	return m
 }
 
 func NewVentilationMode(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "VentilationMode",
 		luxtronikName: name,
 		class:         "selection",
 		writeable:     writeable,
-		codes: []string{
-			0: "Automatic", 1: "Party", 2: "Holidays", 3: "Off",
-		},
-	}
+		codes:         ventilationModeCodes,
+		enum:          func(v uint32) any { return VentilationMode(v) },
+	}}
 }
 
 func NewBool(name string, writeable bool) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		customFromHP: func(val uint32) any {
 			return val == 1
 		},
@@ -533,11 +872,84 @@ func NewBool(name string, writeable bool) *Base {
 		class:         "boolean",
 		luxtronikName: name,
 		writeable:     writeable,
+	}}
+}
+
+// Flags are the labels of the set bits of a bitfield, see NewBitfield.
+type Flags []string
+
+// String joins the labels with commas, the format ToHeatPump accepts.
+func (f Flags) String() string {
+	return strings.Join(f, ",")
+}
+
+// Has reports whether the flag label is set.
+func (f Flags) Has(label string) bool {
+	return slices.Contains(f, label)
+}
+
+// NewBitfield converts a raw value whose bits are flags, e.g. output states
+// or days of a schedule. bitLabels name the bits starting with the lowest,
+// set bits without label read as bit0, bit1 and so on. FromHeatPump returns
+// Flags, ToHeatPump accepts Flags, a []string, labels separated by commas
+// or the mask as number.
+func NewBitfield(name string, writeable bool, bitLabels ...string) *Base {
+	label := func(bit int) string {
+		if bit < len(bitLabels) && bitLabels[bit] != "" {
+			return bitLabels[bit]
+		}
+		return "bit" + strconv.Itoa(bit)
 	}
+	return &Base{Definition: &Definition{
+		customFromHP: func(val uint32) any {
+			flags := Flags{}
+			for bit := 0; bit < 32; bit++ {
+				if val&(1<<bit) != 0 {
+					flags = append(flags, label(bit))
+				}
+			}
+			return flags
+		},
+		customToHP: func(val any) (uint32, error) {
+			var labels []string
+			switch v := val.(type) {
+			case Flags:
+				labels = v
+			case []string:
+				labels = v
+			default:
+				s := strings.TrimSpace(cast.ToString(v))
+				if n, err := strconv.ParseUint(s, 0, 32); err == nil {
+					return uint32(n), nil
+				}
+				if s != "" {
+					labels = strings.Split(s, ",")
+				}
+			}
+			var raw uint32
+		next:
+			for _, l := range labels {
+				l = strings.TrimSpace(l)
+				for bit := 0; bit < 32; bit++ {
+					if label(bit) == l {
+						raw |= 1 << bit
+						continue next
+					}
+				}
+				return 0, fmt.Errorf("ToHeatPump %s has no flag %q: %w", name, l, ErrInvalidValue)
+			}
+			return raw, nil
+		},
+		returnType:    reflect.Slice,
+		name:          "Bitfield",
+		class:         "bitfield",
+		luxtronikName: name,
+		writeable:     writeable,
+	}}
 }
 
 func NewIPV4Address(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		customFromHP: func(val uint32) any {
 			var b [SocketReadSizeInteger]byte
 			binary.BigEndian.PutUint32(b[:], val)
@@ -545,17 +957,51 @@ func NewIPV4Address(name string) *Base {
 			return a.String()
 		},
 		customToHP: func(a any) (uint32, error) {
-			panic("todo implement")
+			addr, err := netip.ParseAddr(cast.ToString(a))
+			if err != nil || !addr.Is4() {
+				return 0, fmt.Errorf("ToHeatPump %v is no IPv4 address: %w", a, ErrInvalidValue)
+			}
+			b := addr.As4()
+			return binary.BigEndian.Uint32(b[:]), nil
 		},
 		returnType:    reflect.String,
 		name:          "IPAddress",
 		class:         "string",
 		luxtronikName: name,
+	}}
+}
+
+// NewIPV4Parameter is a writeable NewIPV4Address, e.g. the network
+// settings of the controller. It accepts dotted quads like 192.168.0.121.
+func NewIPV4Parameter(name string, writeable bool) *Base {
+	b := NewIPV4Address(name)
+	b.name = "IPParameter"
+	b.writeable = writeable
+	return b
+}
+
+// NewNetmask is a NewIPV4Parameter which only accepts contiguous masks like
+// 255.255.255.0, a typo must not cut the controller off the network.
+func NewNetmask(name string, writeable bool) *Base {
+	b := NewIPV4Parameter(name, writeable)
+	b.name = "Netmask"
+	toHP := b.customToHP
+	b.customToHP = func(a any) (uint32, error) {
+		raw, err := toHP(a)
+		if err != nil {
+			return 0, err
+		}
+		// the inverted mask plus one is a power of two for contiguous masks
+		if inv := ^raw; inv&(inv+1) != 0 {
+			return 0, fmt.Errorf("ToHeatPump %v is no contiguous netmask: %w", a, ErrInvalidValue)
+		}
+		return raw, nil
 	}
+	return b
 }
 
 func NewHeatpumpCode(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "HeatpumpCode",
 		luxtronikName: name,
@@ -639,44 +1085,33 @@ func NewHeatpumpCode(name string) *Base {
 			81: "TODO unknown 81",
 			82: "TODO unknown 82",
 		},
-	}
+	}}
 }
 
 func NewBivalenceLevel(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "BivalenceLevel",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			1: "one compressor allowed to run",
-			2: "two compressors allowed to run",
-			3: "additional heat generator allowed to run",
-		},
-	}
+		codes:         bivalenceLevelCodes,
+		enum:          func(v uint32) any { return BivalenceLevel(v) },
+	}}
 }
 
 func NewOperationMode(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "OperationMode",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			0: "heating",
-			1: "hot water",
-			2: "swimming pool/solar",
-			3: "evu",
-			4: "defrost",
-			5: "no request",
-			6: "heating external source",
-			7: "cooling",
-		},
-	}
+		codes:         operationModeCodes,
+		enum:          func(v uint32) any { return OperationMode(v) },
+	}}
 }
 
 func NewCharacter(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "Character",
 		luxtronikName: name,
@@ -692,7 +1127,7 @@ func NewCharacter(name string) *Base {
 			}
 			return fmt.Sprintf("char %d:%x not found", u, u)
 		},
-	}
+	}}
 }
 
 var charTable = [127]string{
@@ -776,105 +1211,56 @@ var charTable = [127]string{
 }
 
 func NewSwitchoffFile(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "SwitchoffFile",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			1:  "heatpump error",
-			2:  "system error",
-			3:  "evu lock",
-			4:  "operation mode second heat generator",
-			5:  "air defrost",
-			6:  "maximal usage temperature",
-			7:  "minimal usage temperature",
-			8:  "lower usage limit",
-			9:  "no request",
-			11: "flow rate",
-			19: "PV max",
-		},
-	}
+		codes:         switchoffReasonCodes,
+		enum:          func(v uint32) any { return SwitchoffReason(v) },
+	}}
 }
 
 func NewMainMenuStatusLine1(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "MainMenuStatusLine1",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			0: "heatpump running",
-			1: "heatpump idle",
-			2: "heatpump coming",
-			3: "errorcode slot 0",
-			4: "defrost",
-			5: "waiting on LIN connection",
-			6: "compressor heating up",
-			7: "pump forerun",
-		},
-	}
+		codes:         mainMenuStatusLine1Codes,
+		enum:          func(v uint32) any { return MainMenuStatusLine1(v) },
+	}}
 }
 
 func NewMainMenuStatusLine2(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "MainMenuStatusLine2",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			0: "since", 1: "in",
-		},
-	}
+		codes:         mainMenuStatusLine2Codes,
+		enum:          func(v uint32) any { return MainMenuStatusLine2(v) },
+	}}
 }
 
 func NewMainMenuStatusLine3(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "MainMenuStatusLine3",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			0:  "heating",
-			1:  "no request",
-			2:  "grid switch on delay",
-			3:  "cycle lock",
-			4:  "lock time",
-			5:  "domestic water",
-			6:  "info bake out program",
-			7:  "defrost",
-			8:  "pump forerun",
-			9:  "thermal desinfection",
-			10: "cooling",
-			12: "swimming pool/solar",
-			13: "heating external energy source",
-			14: "domestic water external energy source",
-			16: "flow monitoring",
-			17: "second heat generator 1 active",
-		},
-	}
+		codes:         mainMenuStatusLine3Codes,
+		enum:          func(v uint32) any { return MainMenuStatusLine3(v) },
+	}}
 }
 
 func NewSecOperationMode(name string) *Base {
-	return &Base{
+	return &Base{Definition: &Definition{
 		returnType:    reflect.String,
 		name:          "SecOperationMode",
 		luxtronikName: name,
 		class:         "selection",
-		codes: []string{
-			0:  "off",
-			1:  "cooling",
-			2:  "heating",
-			3:  "fault",
-			4:  "transition",
-			5:  "defrost",
-			6:  "waiting",
-			7:  "waiting",
-			8:  "transition",
-			9:  "stop",
-			10: "manual",
-			11: "simulation start",
-			12: "evu lock",
-		},
-	}
+		codes:         secOperationModeCodes,
+		enum:          func(v uint32) any { return SecOperationMode(v) },
+	}}
 }
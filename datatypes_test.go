@@ -0,0 +1,400 @@
+package luxtronik
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataTypeMap_Match(t *testing.T) {
+	pm := NewCalculationsMap()
+
+	idxs, err := pm.Match("id_web_temperatur_t?l")
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 11}, idxs)
+
+	idxs, err = pm.Match("ID_WEB_ERROR_Nr*")
+	require.NoError(t, err)
+	assert.Equal(t, []int{100, 101, 102, 103, 104}, idxs)
+
+	_, err = pm.Match("[")
+	assert.Error(t, err)
+}
+
+func TestDataTypeMap_SetRawValuesTolerant(t *testing.T) {
+	pm := DataTypeMap{
+		0: NewCelsius("ID_WEB_Temperatur_TVL", false),
+		1: NewCelsius("ID_WEB_Temperatur_TRL", false),
+		2: NewCelsius("ID_WEB_Temperatur_TA", false),
+	}
+	assert.ErrorIs(t, pm.SetRawValues([]uint32{1, 2, 3, 4}), ErrLengthMismatch)
+
+	added, missing := pm.SetRawValuesTolerant([]uint32{325, 300, 50, 7, 8}, "Unknown_Calculation_")
+	assert.Equal(t, []int{3, 4}, added)
+	assert.Empty(t, missing)
+	assert.Equal(t, "Unknown_Calculation_4", pm[4].Name())
+	assert.Equal(t, uint32(8), pm[4].RawValue())
+
+	added, missing = pm.SetRawValuesTolerant([]uint32{330, 305}, "Unknown_Calculation_")
+	assert.Empty(t, added)
+	assert.Equal(t, []int{2, 3, 4}, missing)
+	assert.Equal(t, uint32(330), pm[0].RawValue())
+	assert.Equal(t, uint32(50), pm[2].RawValue(), "keeps the previous value")
+}
+
+func TestBase_Provenance(t *testing.T) {
+	b := NewCelsius("ID_WEB_Temperatur_TVL", false)
+	b.SetRaw(215)
+	assert.Equal(t, Provenance{
+		Block:      BlockCalculations,
+		Index:      10,
+		Name:       "ID_WEB_Temperatur_TVL",
+		Raw:        215,
+		Signed:     true,
+		Conversion: "factor",
+		Factor:     0.1,
+		Type:       "float32",
+		Class:      b.Class(),
+		Unit:       "°C",
+	}, b.Provenance(BlockCalculations, 10))
+
+	p := NewParameterMap()[3].Provenance(BlockParameters, 3)
+	assert.Equal(t, "code", p.Conversion)
+	assert.Equal(t, "luxtronik.HeatingMode", p.Type)
+	assert.Zero(t, p.Factor)
+}
+
+func TestBase_Accessors(t *testing.T) {
+	b := NewCelsius("ID_WEB_Temperatur_TVL", true)
+	b.SetRaw(210)
+	b.SetRaw(215)
+	assert.Equal(t, "ID_WEB_Temperatur_TVL", b.Name())
+	assert.Equal(t, "celsius", b.Type())
+	assert.Equal(t, "°C", b.Unit())
+	assert.Equal(t, float32(0.1), b.Factor())
+	assert.True(t, b.Writeable())
+	assert.Equal(t, uint32(215), b.RawValue())
+	assert.Equal(t, uint32(210), b.PrevRawValue())
+
+	mode := NewParameterMap()[3]
+	assert.Zero(t, mode.Factor())
+	assert.NotEmpty(t, mode.Codes())
+}
+
+func TestDataTypeMap_SharedDefinitions(t *testing.T) {
+	a, b := NewCalculationsMap(), NewCalculationsMap()
+	assert.Same(t, a[10].Definition, b[10].Definition)
+
+	require.NoError(t, a.SetRawValues(make([]uint32, len(a))))
+	data := make([]uint32, len(a))
+	data[10] = 215
+	require.NoError(t, a.SetRawValues(data))
+	snapshot := a.Clone()
+	a[10].SetRaw(220)
+
+	assert.Equal(t, uint32(220), a[10].RawValue())
+	r := snapshot[10].Reading()
+	assert.Equal(t, uint32(215), r.Raw)
+	assert.Zero(t, r.Prev)
+	assert.False(t, r.Time.IsZero())
+	assert.Zero(t, b[10].RawValue())
+}
+
+func TestBase_WithCopiesDefinition(t *testing.T) {
+	pm := NewParameterMap()
+	b := pm[ParamHotWaterTarget].WithRange(40, 50, 1).WithAccess(AccessInstaller).WithVisibility("ID_Visi_Heizung")
+	low, high, _, _ := b.Range()
+	assert.Equal(t, []float64{40, 50}, []float64{low, high})
+	assert.Equal(t, AccessInstaller, b.Access())
+	assert.Equal(t, "ID_Visi_Heizung", b.Visibility())
+
+	other := NewParameterMap()[ParamHotWaterTarget]
+	low, high, _, _ = other.Range()
+	assert.Equal(t, []float64{30, 65}, []float64{low, high}, "catalog unchanged")
+	assert.Equal(t, AccessUser, other.Access())
+	assert.Empty(t, other.Visibility())
+}
+
+func TestDataTypeMap_Merge(t *testing.T) {
+	pm := NewCalculationsMap()
+	pm[10].SetRaw(215)
+	n := len(pm)
+
+	got := pm.Merge(DataTypeMap{
+		10:   NewKelvin("ID_WEB_Temperatur_TVL", false),
+		5000: NewCount("ID_WEB_New"),
+	})
+	assert.Len(t, got, n+1)
+	assert.Equal(t, "K", pm[10].Unit())
+	assert.Equal(t, uint32(215), pm[10].RawValue(), "keeps the reading")
+	assert.Equal(t, "ID_WEB_New", pm[5000].Name())
+	assert.Equal(t, "°C", NewCalculationsMap()[10].Unit(), "catalog unchanged")
+}
+
+func TestNewNetmask(t *testing.T) {
+	pm := NewParameterMap()
+	addr := pm[ParamNetworkAddress]
+	raw, err := addr.ToHeatPump("192.168.0.121")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0xC0A80079), raw)
+	assert.Equal(t, "192.168.0.121", addr.FromHeatPumpRaw(raw))
+	_, err = addr.ToHeatPump("fe80::1")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	mask := pm[ParamNetworkMask]
+	raw, err = mask.ToHeatPump("255.255.254.0")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0xFFFFFE00), raw)
+	_, err = mask.ToHeatPump("255.0.255.0")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	assert.Equal(t, AccessInstaller, mask.Access())
+}
+
+func TestNewBitfield(t *testing.T) {
+	b := NewBitfield("ID_Outputs", true, "compressor", "", "pump")
+	assert.Equal(t, Flags{}, b.FromHeatPumpRaw(0))
+	flags := b.FromHeatPumpRaw(0b10111).(Flags)
+	assert.Equal(t, Flags{"compressor", "bit1", "pump", "bit4"}, flags)
+	assert.Equal(t, "compressor,bit1,pump,bit4", FormatValue(flags))
+	assert.True(t, flags.Has("pump"))
+
+	for _, val := range []any{flags, []string{"pump", "compressor", "bit1", "bit4"}, "compressor, bit1,pump,bit4", "0x17", 23} {
+		raw, err := b.ToHeatPump(val)
+		require.NoError(t, err, "%v", val)
+		assert.Equal(t, uint32(0b10111), raw, "%v", val)
+	}
+	raw, err := b.ToHeatPump("")
+	require.NoError(t, err)
+	assert.Zero(t, raw)
+	_, err = b.ToHeatPump("heater")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = NewParameterMap()[ParamBitmaske0].ToHeatPump("bit0")
+	assert.ErrorIs(t, err, ErrWritingNotAllowed)
+}
+
+func TestBase_Enums(t *testing.T) {
+	pm := NewParameterMap()
+	mode := pm[ParamHeatingMode]
+	mode.SetRaw(3)
+	v, ok := mode.FromHeatPump().(HeatingMode)
+	require.True(t, ok)
+	assert.Equal(t, HeatingModeHolidays, v)
+	assert.Equal(t, "Holidays", FormatValue(v))
+	assert.Equal(t, "unknown code: 9", HeatingMode(9).String())
+
+	data, err := json.Marshal(map[string]any{"mode": v})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mode":"Holidays"}`, string(data))
+	var got struct{ Mode HotWaterMode }
+	require.NoError(t, json.Unmarshal([]byte(`{"Mode":"party"}`), &got))
+	assert.Equal(t, HotWaterModeParty, got.Mode)
+	_, err = ParseHeatingMode("Fiesta")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	raw, err := mode.ToHeatPump(HeatingModeOff)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), raw)
+	raw, err = mode.ToHeatPump(HotWaterModeParty)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), raw, "other enums convert by their code")
+
+	sec := NewSecOperationMode("ID_WEB_SEC_BZ")
+	raw, err = sec.toRaw(SecOperationModeWaiting2)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), raw, "repeated codes keep their value")
+
+	pool := NewPoolMode("ID_Ba_Sw_akt", true)
+	assert.Equal(t, "", FormatValue(pool.FromHeatPumpRaw(1)))
+	assert.Equal(t, "Second heatsource", mode.FromHeatPumpRaw(1).(HeatingMode).String(), "codes are not shared")
+}
+
+func TestBase_SetLocation(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	b := NewTime("ID_WEB_AktuelleTimeStamp")
+	b.SetRaw(1720915200) // 2024-07-14 00:00:00 UTC
+	b.SetLocation(berlin)
+
+	v, ok := b.FromHeatPump().(time.Time)
+	require.True(t, ok)
+	assert.Equal(t, berlin, v.Location())
+	assert.Equal(t, "2024-07-14 01:00:00", FormatValue(v))
+
+	ts := NewTimestamp("ID_SU_FrkdHz", true)
+	ts.SetLocation(berlin)
+	raw, err := ts.ToHeatPump("2024-07-14 01:00:00")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1720915200), raw)
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"2024-07-14T01:00:00+01:00"`, string(data))
+}
+
+func TestBase_Signed(t *testing.T) {
+	b := NewCelsius("ID_WEB_Temperatur_TA", false)
+	b.SetRaw(uint32(0xFFFFFFFF - 54)) // -55
+	assert.Equal(t, float32(-5.5), b.FromHeatPump())
+
+	offset := NewCelsius("ID_Einst_WK_akt", true)
+	raw, err := offset.ToHeatPump(-2.5)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0xFFFFFFFF-24), raw)
+	assert.Equal(t, float32(-2.5), offset.FromHeatPumpRaw(raw))
+
+	count := NewCount("ID_WEB_Zaehler_BetrZeitImpVD1")
+	count.SetRaw(0xFFFFFFFF)
+	assert.Equal(t, uint32(0xFFFFFFFF), count.FromHeatPump(), "unsigned stays unsigned")
+	_, err = count.toRaw(-1)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	i := &Base{Definition: &Definition{returnType: reflect.Int32, factor: 0.1}}
+	i.SetRaw(uint32(0xFFFFFFFF - 99)) // -100
+	assert.Equal(t, int32(-10), i.FromHeatPump())
+}
+
+func TestDataTypeMap_IterateSorted(t *testing.T) {
+	for _, pm := range []DataTypeMap{
+		{2: NewUnknown("c"), 0: NewUnknown("a"), 1: NewUnknown("b")},
+		{900: NewUnknown("c"), 3: NewUnknown("a"), 40: NewUnknown("b")},
+		{-1: NewUnknown("a"), 0: NewUnknown("b"), 1: NewUnknown("c")},
+	} {
+		var names []string
+		pm.IterateSorted(func(_ int, b *Base) { names = append(names, b.Name()) })
+		assert.Equal(t, []string{"a", "b", "c"}, names)
+	}
+}
+
+func BenchmarkDataTypeMap_SetRawValues(b *testing.B) {
+	for _, bm := range []struct {
+		name     string
+		pm       DataTypeMap
+		tolerant bool
+	}{
+		{"parameters", NewParameterMap(), false},
+		{"calculations", NewCalculationsMap(), false},
+		{"calculations/tolerant", NewCalculationsMap(), true},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			raw := make([]uint32, len(bm.pm))
+			for i := range raw {
+				raw[i] = uint32(i)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bm.tolerant {
+					bm.pm.SetRawValuesTolerant(raw, "Unknown_")
+				} else if err := bm.pm.SetRawValues(raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBase_FromHeatPump(b *testing.B) {
+	pm := NewCalculationsMap()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range pm {
+			_ = v.FromHeatPump()
+		}
+	}
+}
+
+func BenchmarkDataTypeMap_IterateSorted(b *testing.B) {
+	calculations := NewCalculationsMap()
+	sparse := DataTypeMap{}
+	for idx, v := range NewParameterMap() {
+		if idx%4 == 0 {
+			sparse[idx] = v
+		}
+	}
+	for _, bm := range []struct {
+		name string
+		pm   DataTypeMap
+	}{
+		{"calculations", calculations},
+		{"sparse", sparse},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			n := 0
+			for i := 0; i < b.N; i++ {
+				bm.pm.IterateSorted(func(int, *Base) { n++ })
+			}
+		})
+	}
+}
+
+func TestBase_WithRange(t *testing.T) {
+	pm := NewParameterMap()
+	b := pm[ParamHotWaterTarget]
+	raw, err := b.ToHeatPump(48.5)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(485), raw)
+	_, err = b.ToHeatPump(70)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	assert.EqualError(t, err, "ToHeatPump ID_Einst_BWS_akt value 70 out of range 30 to 65 °C: invalid value")
+
+	offset := pm[ParamHeatingOffset]
+	_, err = offset.ToHeatPump(1.5)
+	require.NoError(t, err)
+	_, err = offset.ToHeatPump(1.3)
+	assert.ErrorContains(t, err, "not a multiple of 0.5 °C from -5")
+	raw, err = offset.ToHeatPump(5)
+	require.NoError(t, err, "the bounds are included despite the float32 factor")
+	assert.Equal(t, raw, offset.clampRaw(80))
+	assert.Equal(t, uint32(0xFFFFFFCE), offset.clampRaw(0xFFFFFF9C), "-10 K clamped to -5 K")
+	low, high, step, ok := offset.Range()
+	assert.True(t, ok)
+	assert.Equal(t, []float64{-5, 5, 0.5}, []float64{low, high, step})
+
+	b.SetUnits(UnitsImperial)
+	_, err = b.ToHeatPump(160)
+	assert.EqualError(t, err, "ToHeatPump ID_Einst_BWS_akt value 160 out of range 86 to 149 °F: invalid value")
+
+	_, _, _, ok = pm[ParamHeatingMode].Range()
+	assert.False(t, ok)
+}
+
+// FuzzBase_FromHeatPump decodes arbitrary raw values with every entry of the
+// catalogs and converts the results and arbitrary text back.
+func FuzzBase_FromHeatPump(f *testing.F) {
+	for _, raw := range []uint32{0, 1, 126, 127, 255, 1 << 31, math.MaxUint32} {
+		f.Add(raw, "", false, false)
+	}
+	f.Add(uint32(215), "21.5", true, true)
+	f.Add(uint32(3), "Heizen", false, true)
+	f.Add(uint32(1700000000), "2024-01-02 03:04:05", false, false)
+
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	maps := []DataTypeMap{NewParameterMap(), NewCalculationsMap(), NewVisibilitiesMap()}
+	f.Fuzz(func(t *testing.T, raw uint32, s string, imperial, german bool) {
+		for _, pm := range maps {
+			for _, b := range pm {
+				b := *b
+				b.SetLocation(loc)
+				if imperial {
+					b.SetUnits(UnitsImperial)
+				}
+				if german {
+					b.SetLanguage(LanguageGerman)
+				}
+				b.SetRaw(raw)
+				v := b.FromHeatPump()
+				_ = FormatValue(v)
+				_, _ = b.toRaw(v)
+				_, _ = b.toRaw(s)
+				_, _ = b.ToHeatPump(s)
+			}
+		}
+	})
+}
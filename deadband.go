@@ -0,0 +1,102 @@
+package luxtronik
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cast"
+)
+
+// Deadbands are the minimum changes of numeric values in their unit to count
+// as a change, e.g. 0.3 for temperatures to hide the jitter of sensors.
+// Values without a deadband change with every raw tick.
+type Deadbands struct {
+	// Classes maps a class like temperature to its deadband.
+	Classes map[string]float64
+	// Values maps a luxtronik name to its deadband, overriding its class.
+	Values map[string]float64
+}
+
+// ParseDeadbands parses a comma separated list of class or name with the
+// deadband, e.g. "temperature=0.3,ID_WEB_Temperatur_TA=1". Names start with
+// ID_, everything else is a class.
+func ParseDeadbands(s string) (Deadbands, error) {
+	d := Deadbands{Classes: map[string]float64{}, Values: map[string]float64{}}
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		key, val, ok := strings.Cut(f, "=")
+		band, err := strconv.ParseFloat(val, 64)
+		if !ok || key == "" || err != nil || band < 0 {
+			return Deadbands{}, fmt.Errorf("ParseDeadbands invalid deadband %q, want class=value or name=value", f)
+		}
+		if strings.HasPrefix(key, "ID_") {
+			d.Values[key] = band
+		} else {
+			d.Classes[key] = band
+		}
+	}
+	return d, nil
+}
+
+// For returns the deadband of b, zero if none is configured.
+func (d Deadbands) For(b *Base) float64 {
+	if band, ok := d.Values[b.luxtronikName]; ok {
+		return band
+	}
+	return d.Classes[b.class]
+}
+
+// ChangeDetector decides whether a value changed since it has been reported
+// the last time. Comparing with the reported value instead of the previous
+// read lets slow drifts through once they exceed the deadband. It is safe
+// for concurrent use.
+type ChangeDetector struct {
+	deadbands Deadbands
+
+	mu       sync.Mutex
+	reported map[string]uint32
+}
+
+func NewChangeDetector(d Deadbands) *ChangeDetector {
+	return &ChangeDetector{deadbands: d, reported: map[string]uint32{}}
+}
+
+// Changed reports whether b at index idx of the block of host changed and
+// returns the raw value reported before. A value seen for the first time is
+// compared with its previous read.
+func (cd *ChangeDetector) Changed(host, block string, idx int, b *Base) (prev uint32, changed bool) {
+	key := host + "/" + block + "/" + strconv.Itoa(idx)
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+
+	prev, ok := cd.reported[key]
+	if !ok {
+		prev = b.reading.Prev
+		cd.reported[key] = prev
+	}
+	if prev == b.reading.Raw {
+		return prev, false
+	}
+	if band := cd.deadbands.For(b); band > 0 {
+		cur, err1 := cast.ToFloat64E(b.FromHeatPump())
+		last, err2 := cast.ToFloat64E(b.FromHeatPumpRaw(prev))
+		// converted values have at most three decimals, round away the float32
+		// error before comparing
+		if err1 == nil && err2 == nil && math.Round(math.Abs(cur-last)*1e3)/1e3 < band {
+			return prev, false
+		}
+	}
+	cd.reported[key] = b.reading.Raw
+	return prev, true
+}
+
+// Revert restores the raw value reported before Changed returned prev, e.g.
+// after a failed publish, so that the change is reported again.
+func (cd *ChangeDetector) Revert(host, block string, idx int, prev uint32) {
+	key := host + "/" + block + "/" + strconv.Itoa(idx)
+	cd.mu.Lock()
+	cd.reported[key] = prev
+	cd.mu.Unlock()
+}
@@ -0,0 +1,48 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeDetector(t *testing.T) {
+	db, err := ParseDeadbands("temperature=0.3, ID_WEB_Temperatur_TA=1")
+	require.NoError(t, err)
+	cd := NewChangeDetector(db)
+
+	pm := DataTypeMap{
+		10: NewCelsius("ID_WEB_Temperatur_TVL", false),
+		15: NewCelsius("ID_WEB_Temperatur_TA", false),
+		80: NewOperationMode("ID_WEB_WP_BZ_akt"),
+	}
+	tests := []struct {
+		idx     int
+		raw     uint32
+		changed bool
+		prev    uint32
+	}{
+		{10, 300, true, 0},
+		{10, 301, false, 300},
+		{10, 302, false, 300},
+		{10, 303, true, 300}, // slow drifts get through
+		{10, 301, false, 303},
+		{15, 50, true, 0},
+		{15, 59, false, 50},
+		{15, 60, true, 50},
+		{80, 1, true, 0},
+		{80, 1, false, 1},
+	}
+	for i, tt := range tests {
+		pm[tt.idx].SetRaw(tt.raw)
+		prev, changed := cd.Changed("hp", BlockCalculations, tt.idx, pm[tt.idx])
+		assert.Equal(t, tt.changed, changed, "step %d", i)
+		assert.Equal(t, tt.prev, prev, "step %d", i)
+	}
+
+	_, err = ParseDeadbands("temperature")
+	assert.Error(t, err)
+	_, err = ParseDeadbands("temperature=-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package luxtronik
+
+import (
+	"math"
+	"reflect"
+	"sync"
+)
+
+// BlockDerived labels the values computed from the calculations, see
+// Deriver.
+const BlockDerived = "derived"
+
+// Indexes of the values in BlockDerived.
+const (
+	DerivedDeltaT       = 0 // flow minus return temperature in K
+	DerivedThermalPower = 1 // thermal power from the flow rate in W
+	DerivedCOP          = 2 // heat quantity per electrical energy
+)
+
+// specificHeatWater is the heat in Wh to warm one litre of water by one K.
+const specificHeatWater = 1.163
+
+// DerivedOptions configure a Deriver.
+type DerivedOptions struct {
+	// ElectricalEnergy returns the electrical energy counter in kWh, e.g.
+	// from an index of a firmware which provides it or from an external
+	// meter. Without it there is no COP.
+	ElectricalEnergy func(calculations DataTypeMap) (kWh float64, ok bool)
+}
+
+// Deriver computes values the controller does not report from the
+// calculations: the delta-T of flow and return, the thermal power from the
+// flow rate of the heat meter and, with an electrical energy counter, the
+// COP since the first sample of each heat pump. It is safe for concurrent
+// use.
+type Deriver struct {
+	opts DerivedOptions
+
+	mu    sync.Mutex
+	first map[string]copSample
+	maps  map[string]DataTypeMap
+}
+
+type copSample struct {
+	heat, electrical float64 // kWh
+}
+
+func NewDeriver(opts DerivedOptions) *Deriver {
+	return &Deriver{opts: opts, first: map[string]copSample{}, maps: map[string]DataTypeMap{}}
+}
+
+// newDerivedValue stores signed values with the given number of decimals.
+func newDerivedValue(name, class, unit string, decimals int) *Base {
+	return &Base{Definition: &Definition{
+		returnType:    reflect.Float32,
+		name:          "derived",
+		class:         class,
+		luxtronikName: name,
+		unit:          unit,
+		factor:        float32(math.Pow10(-decimals)),
+		signed:        true,
+	}}
+}
+
+// Derive returns the derived values of the calculations of host. The map is
+// reused by the next call for the same host, so that changes can be
+// detected. Values which cannot be computed are left out.
+func (d *Deriver) Derive(host string, calculations DataTypeMap) DataTypeMap {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	all, ok := d.maps[host]
+	if !ok {
+		all = DataTypeMap{
+			DerivedDeltaT:       newDerivedValue("Derived_DeltaT", "temperature", "K", 1),
+			DerivedThermalPower: newDerivedValue("Derived_ThermalPower", "power", "W", 0),
+			DerivedCOP:          newDerivedValue("Derived_COP", "ratio", "", 2),
+		}
+		d.maps[host] = all
+	}
+	set := func(pm DataTypeMap, idx int, v float64) {
+		b := all[idx]
+		b.SetRaw(uint32(int32(math.Round(v / float64(b.factor)))))
+		pm[idx] = b
+	}
+
+	pm := DataTypeMap{}
+	flow, okFlow := derivedInput(calculations, CalcFlowTemperature)
+	ret, okRet := derivedInput(calculations, CalcReturnTemperature)
+	if okFlow && okRet {
+		deltaT := flow - ret
+		set(pm, DerivedDeltaT, deltaT)
+		if rate, ok := derivedInput(calculations, CalcWMZDurchfluss); ok {
+			set(pm, DerivedThermalPower, rate*deltaT*specificHeatWater)
+		}
+	}
+
+	if d.opts.ElectricalEnergy == nil {
+		return pm
+	}
+	heating, okH := derivedInput(calculations, CalcHeatQuantityHeating)
+	hotWater, okW := derivedInput(calculations, CalcHeatQuantityHotWater)
+	elec, okE := d.opts.ElectricalEnergy(calculations)
+	if !okH || !okW || !okE {
+		return pm
+	}
+	cur := copSample{heat: heating + hotWater, electrical: elec}
+	first, ok := d.first[host]
+	if !ok {
+		d.first[host] = cur
+		return pm
+	}
+	if used := cur.electrical - first.electrical; used > 0 {
+		set(pm, DerivedCOP, (cur.heat-first.heat)/used)
+	}
+	return pm
+}
+
+// derivedInput returns the converted value at idx as float.
+func derivedInput(pm DataTypeMap, idx int) (float64, bool) {
+	b, ok := pm[idx]
+	if !ok {
+		return 0, false
+	}
+	switch v := b.canonical().FromHeatPump().(type) {
+	case float32:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	}
+	return 0, false
+}
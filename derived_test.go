@@ -0,0 +1,39 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriver(t *testing.T) {
+	elec := 100.0
+	d := NewDeriver(DerivedOptions{
+		ElectricalEnergy: func(DataTypeMap) (float64, bool) { return elec, true },
+	})
+
+	pm := NewCalculationsMap()
+	pm[CalcFlowTemperature].SetRaw(350)
+	pm[CalcReturnTemperature].SetRaw(300)
+	pm[CalcWMZDurchfluss].SetRaw(1200)
+	pm[CalcHeatQuantityHeating].SetRaw(10000)
+	pm[CalcHeatQuantityHotWater].SetRaw(2000)
+
+	got := d.Derive("cellar", pm)
+	require.Len(t, got, 2, "no COP before the second sample")
+	assert.Equal(t, float32(5), got[DerivedDeltaT].FromHeatPump())
+	assert.Equal(t, "K", got[DerivedDeltaT].Unit())
+	assert.Equal(t, float32(6978), got[DerivedThermalPower].FromHeatPump())
+
+	pm[CalcReturnTemperature].SetRaw(360)
+	pm[CalcHeatQuantityHeating].SetRaw(10080)
+	pm[CalcHeatQuantityHotWater].SetRaw(2020)
+	elec = 102.5
+	got = d.Derive("cellar", pm)
+	assert.Equal(t, float32(-1), got[DerivedDeltaT].FromHeatPump())
+	assert.Equal(t, float32(4), got[DerivedCOP].FromHeatPump())
+	assert.True(t, got[DerivedDeltaT].HasChanges())
+
+	assert.NotContains(t, d.Derive("attic", DataTypeMap{}), DerivedDeltaT)
+}
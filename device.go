@@ -0,0 +1,308 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// deviceBlocks is the lookup order of names, calculations are asked for
+// most often.
+var deviceBlocks = []string{BlockCalculations, BlockParameters, BlockVisibilities}
+
+// Value is a single decoded entry of a Device.
+type Value struct {
+	Block string
+	Index int
+	Name  string
+	Unit  string
+	Class string
+	Value any
+	Raw   uint32
+}
+
+func newValue(block string, idx int, b *Base) Value {
+	return Value{
+		Block: block,
+		Index: idx,
+		Name:  b.Name(),
+		Unit:  b.Unit(),
+		Class: b.Class(),
+		Value: b.FromHeatPump(),
+		Raw:   b.RawValue(),
+	}
+}
+
+// Change is sent to subscribers for every value which changed with a
+// Refresh or Set.
+type Change struct {
+	Time     time.Time
+	Value    Value
+	Previous any
+}
+
+// Device owns a client and the maps of all three blocks, so that values can
+// be addressed by name without knowing the block they live in. All methods
+// are safe for concurrent use, the requests to the heat pump are serialized.
+type Device struct {
+	client *Client
+
+	io sync.Mutex // serializes the use of the client
+
+	mu      sync.RWMutex
+	blocks  map[string]DataTypeMap
+	subs    map[chan Change]struct{}
+	changes *ChangeDetector
+	// firmware the blocks were selected for, see SelectMaps
+	firmware string
+}
+
+// NewDevice creates the device, call Refresh to read the values.
+func NewDevice(c *Client) *Device {
+	return &Device{
+		client: c,
+		blocks: map[string]DataTypeMap{
+			BlockParameters:   NewParameterMap(),
+			BlockCalculations: NewCalculationsMap(),
+			BlockVisibilities: NewVisibilitiesMap(),
+		},
+		subs:    map[chan Change]struct{}{},
+		changes: NewChangeDetector(Deadbands{}),
+	}
+}
+
+// SetDeadbands hides changes smaller than the deadbands from the
+// subscribers.
+func (d *Device) SetDeadbands(db Deadbands) {
+	d.mu.Lock()
+	d.changes = NewChangeDetector(db)
+	d.mu.Unlock()
+}
+
+// Client returns the client of the device.
+func (d *Device) Client() *Client {
+	return d.client
+}
+
+// Refresh reads all three blocks and notifies the subscribers of the
+// changed values, the first refresh reports all values which are not zero.
+// A failed read closes the connection, the values stay untouched. Once the
+// calculations report another firmware, the blocks are replaced by those of
+// SelectMaps.
+func (d *Device) Refresh(ctx context.Context) error {
+	d.io.Lock()
+	defer d.io.Unlock()
+
+	if err := d.client.connect(ctx); err != nil {
+		return fmt.Errorf("Device.Refresh: %w", err)
+	}
+	// read into copies so that Get does not see half decoded blocks
+	d.mu.RLock()
+	read := make(map[string]DataTypeMap, len(d.blocks))
+	for block, pm := range d.blocks {
+		read[block] = pm.Clone()
+	}
+	d.mu.RUnlock()
+
+	firmware := d.firmware
+	for _, block := range deviceBlocks {
+		err := d.client.readFromHeatPump(ctx, read[block], blockCommands[block], 0)
+		if block == BlockCalculations && d.client.Firmware() != "" && d.client.Firmware() != firmware {
+			firmware = d.client.Firmware()
+			maps, serr := SelectMaps(firmware)
+			if serr != nil {
+				return fmt.Errorf("Device.Refresh %s: %w", firmware, serr)
+			}
+			// read again into the map of the firmware the frame came from
+			read = maps
+			err = d.client.readFromHeatPump(ctx, read[block], blockCommands[block], 0)
+		}
+		if err != nil {
+			_ = d.client.Close()
+			return fmt.Errorf("Device.Refresh %s: %w", block, err)
+		}
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	d.blocks = read
+	d.firmware = firmware
+	var changes []Change
+	for _, block := range deviceBlocks {
+		read[block].IterateSorted(func(idx int, b *Base) {
+			if prev, ok := d.changes.Changed("", block, idx, b); ok {
+				changes = append(changes, Change{Time: now, Value: newValue(block, idx, b), Previous: b.FromHeatPumpRaw(prev)})
+			}
+		})
+	}
+	d.mu.Unlock()
+	d.notify(changes)
+	return nil
+}
+
+// Get returns a value by its luxtronik name, e.g. ID_WEB_Temperatur_TVL, or
+// by block and index, e.g. parameters:3.
+func (d *Device) Get(name string) (Value, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	block, idx, b, err := d.lookup(name)
+	if err != nil {
+		return Value{}, err
+	}
+	return newValue(block, idx, b), nil
+}
+
+// Values returns all values of the given blocks, of all blocks if none are
+// given, sorted by block and index.
+func (d *Device) Values(blocks ...string) []Value {
+	if len(blocks) == 0 {
+		blocks = deviceBlocks
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var res []Value
+	for _, block := range blocks {
+		d.blocks[block].IterateSorted(func(idx int, b *Base) {
+			res = append(res, newValue(block, idx, b))
+		})
+	}
+	return res
+}
+
+// Schema describes the values of the given blocks, of all blocks if none are
+// given, with the maps of the firmware of the last Refresh, see Schema.
+func (d *Device) Schema(blocks ...string) []SchemaEntry {
+	if len(blocks) == 0 {
+		blocks = deviceBlocks
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	maps := make(map[string]DataTypeMap, len(blocks))
+	for _, block := range blocks {
+		if pm, ok := d.blocks[block]; ok {
+			maps[block] = pm
+		}
+	}
+	return Schema(maps)
+}
+
+// Set converts val and writes it to the parameter, see Client.WriteParameter.
+// Parameters above the access level of the last Refresh are rejected with
+// ErrInsufficientAccess.
+func (d *Device) Set(ctx context.Context, name string, val any) error {
+	d.mu.RLock()
+	block, idx, b, err := d.lookup(name)
+	var access error
+	if err == nil && block == BlockParameters {
+		access = checkAccess(d.blocks[BlockParameters], b)
+	}
+	d.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	if block != BlockParameters {
+		return fmt.Errorf("Device.Set %q is a %s value: %w", name, block, ErrWritingNotAllowed)
+	}
+	if access != nil {
+		return fmt.Errorf("Device.Set %w", access)
+	}
+	raw, err := b.ToHeatPump(val)
+	if err != nil {
+		return fmt.Errorf("Device.Set %q: %w", name, err)
+	}
+
+	if d.client.opts.DryRun {
+		// nothing changes, the cache keeps the value of the heat pump
+		return d.client.writeParameterRaw(ctx, b, idx, raw)
+	}
+
+	d.io.Lock()
+	defer d.io.Unlock()
+	if err := d.client.connect(ctx); err != nil {
+		return fmt.Errorf("Device.Set: %w", err)
+	}
+	if err := d.client.writeParameterRaw(ctx, b, idx, raw); err != nil {
+		_ = d.client.Close()
+		return fmt.Errorf("Device.Set %q: %w", name, err)
+	}
+
+	d.mu.Lock()
+	b = d.blocks[block][idx]
+	b.SetRaw(raw)
+	prev, changed := d.changes.Changed("", block, idx, b)
+	change := Change{Time: time.Now(), Value: newValue(block, idx, b), Previous: b.FromHeatPumpRaw(prev)}
+	d.mu.Unlock()
+	if changed {
+		d.notify([]Change{change})
+	}
+	return nil
+}
+
+func (d *Device) lookup(name string) (string, int, *Base, error) {
+	if block, index, ok := strings.Cut(name, ":"); ok {
+		pm, known := d.blocks[block]
+		idx, err := strconv.Atoi(index)
+		if !known || err != nil {
+			return "", 0, nil, fmt.Errorf("Device %q: %w", name, ErrUnknownIndex)
+		}
+		if b, ok := pm[idx]; ok {
+			return block, idx, b, nil
+		}
+		return "", 0, nil, fmt.Errorf("Device %q: %w", name, ErrUnknownIndex)
+	}
+	if isIndex(name) {
+		return "", 0, nil, fmt.Errorf("Device %q: indexes need a block like parameters:%s: %w", name, name, ErrUnknownIndex)
+	}
+	for _, block := range deviceBlocks {
+		if idx, b, ok := d.blocks[block].Lookup(name); ok {
+			return block, idx, b, nil
+		}
+	}
+	return "", 0, nil, fmt.Errorf("Device %q: %w", name, ErrUnknownIndex)
+}
+
+// Subscribe returns a channel receiving the changes of all values. Changes
+// are dropped if the channel buffer of size buf is full. The returned
+// function ends the subscription and closes the channel.
+func (d *Device) Subscribe(buf int) (<-chan Change, func()) {
+	ch := make(chan Change, buf)
+	d.mu.Lock()
+	d.subs[ch] = struct{}{}
+	d.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			d.mu.Lock()
+			delete(d.subs, ch)
+			d.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (d *Device) notify(changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for ch := range d.subs {
+		for _, c := range changes {
+			select {
+			case ch <- c:
+			default:
+			}
+		}
+	}
+}
+
+// Close closes the connection. Subscriptions stay open.
+func (d *Device) Close() error {
+	d.io.Lock()
+	defer d.io.Unlock()
+	return d.client.Close()
+}
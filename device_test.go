@@ -0,0 +1,67 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevice(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[10] = 325
+	hp.parameters[2] = 480
+	d := NewDevice(MustNewClient(hp.addr(), Options{}))
+	defer d.Close()
+
+	ctx := context.Background()
+	changes, unsubscribe := d.Subscribe(16)
+	require.NoError(t, d.Refresh(ctx))
+
+	v, err := d.Get("id_web_temperatur_tvl")
+	require.NoError(t, err)
+	assert.Equal(t, Value{
+		Block: BlockCalculations, Index: 10, Name: "ID_WEB_Temperatur_TVL",
+		Unit: "°C", Class: "temperature", Value: float32(32.5), Raw: 325,
+	}, v)
+	v, err = d.Get("parameters:2")
+	require.NoError(t, err)
+	assert.Equal(t, float32(48), v.Value)
+
+	_, err = d.Get("2")
+	assert.ErrorIs(t, err, ErrUnknownIndex)
+	_, err = d.Get("ID_Nope")
+	assert.ErrorIs(t, err, ErrUnknownIndex)
+	assert.ErrorIs(t, d.Set(ctx, "ID_WEB_Temperatur_TVL", 20), ErrWritingNotAllowed)
+
+	assert.ErrorIs(t, d.Set(ctx, "ID_Einst_BWS_Hyst_akt", 5), ErrInsufficientAccess)
+	assert.ErrorIs(t, d.Set(ctx, "ID_AdresseIP_akt", "192.168.1.2"), ErrInsufficientAccess)
+	hp.mu.Lock()
+	assert.Zero(t, hp.parameters[ParamEinstBWSHystAkt], "nothing is written")
+	hp.mu.Unlock()
+
+	require.NoError(t, d.Set(ctx, "ID_Einst_BWS_akt", 50))
+	v, err = d.Get("ID_Einst_BWS_akt")
+	require.NoError(t, err)
+	assert.Equal(t, float32(50), v.Value)
+	assert.Equal(t, uint32(500), hp.parameters[2])
+
+	hp.mu.Lock()
+	hp.calculations[10] = 330
+	hp.mu.Unlock()
+	require.NoError(t, d.Refresh(ctx))
+	unsubscribe()
+
+	var got []Change
+	for c := range changes {
+		got = append(got, c)
+	}
+	require.Len(t, got, 4)
+	assert.Equal(t, "ID_WEB_Temperatur_TVL", got[0].Value.Name)
+	assert.Equal(t, "ID_Einst_BWS_akt", got[1].Value.Name)
+	assert.Equal(t, float32(50), got[2].Value.Value)
+	assert.Equal(t, float32(48), got[2].Previous)
+	assert.Equal(t, float32(33), got[3].Value.Value)
+	assert.Equal(t, float32(32.5), got[3].Previous)
+}
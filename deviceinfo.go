@@ -0,0 +1,139 @@
+package luxtronik
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InfoLayout locates the values of DeviceInfo in the calculations. It
+// depends on the firmware, see FirmwareMaps.Info.
+type InfoLayout struct {
+	// Version lists the indexes of the characters of the firmware version.
+	Version        []int
+	HeatpumpCode   int
+	BivalenceLevel int
+	IPAddress      int
+	SubnetMask     int
+	Broadcast      int
+	Gateway        int
+	// SerialDate and SerialHex are the indexes of the parts of the serial
+	// number in the parameters.
+	SerialDate int
+	SerialHex  int
+	// AccessLevel is the index of the access code in the parameters.
+	AccessLevel int
+}
+
+// DefaultInfoLayout is the layout of the catalog of this package.
+var DefaultInfoLayout = InfoLayout{
+	Version: []int{
+		CalcSoftStand0, CalcSoftStand1, CalcSoftStand2, CalcSoftStand3,
+		CalcSoftStand4, CalcSoftStand5, CalcSoftStand6,
+	},
+	HeatpumpCode:   CalcHeatpumpCode,
+	BivalenceLevel: CalcBIVStufeAkt,
+	IPAddress:      CalcAdresseIPAkt,
+	SubnetMask:     CalcSubNetMaskAkt,
+	Broadcast:      CalcAddBroadcast,
+	Gateway:        CalcAddStdGateway,
+	SerialDate:     ParamSerialNumberDate,
+	SerialHex:      ParamSerialNumberHex,
+	AccessLevel:    ParamAccessLevel,
+}
+
+// DeviceInfo describes the heat pump and its controller.
+type DeviceInfo struct {
+	// Firmware is the version, e.g. V3.89.1.
+	Firmware string
+	// Model is the type of the heat pump, e.g. LWD.
+	Model string
+	// BivalenceLevel tells which heat generators may run.
+	BivalenceLevel string
+	IPAddress      string
+	SubnetMask     string
+	Broadcast      string
+	Gateway        string
+	// SerialNumber identifies the heat pump, e.g. 2311-4b3. It is read
+	// from the parameters, see Snapshot.DeviceInfo.
+	SerialNumber string
+	// AccessLevel is the level of the access code from the parameters, see
+	// Client.Unlock.
+	AccessLevel AccessLevel
+}
+
+// DeviceInfo returns the information about the heat pump contained in the
+// calculations. The firmware version is located with DefaultInfoLayout, the
+// first entry of the FirmwareCatalog matching it may move the other values.
+// Values missing in pm are left empty, as are SerialNumber and AccessLevel.
+func (pm DataTypeMap) DeviceInfo() DeviceInfo {
+	layout, version := pm.infoLayout()
+	return DeviceInfo{
+		Firmware:       version,
+		Model:          pm.infoString(layout.HeatpumpCode),
+		BivalenceLevel: pm.infoString(layout.BivalenceLevel),
+		IPAddress:      pm.infoString(layout.IPAddress),
+		SubnetMask:     pm.infoString(layout.SubnetMask),
+		Broadcast:      pm.infoString(layout.Broadcast),
+		Gateway:        pm.infoString(layout.Gateway),
+	}
+}
+
+// DeviceInfo is DataTypeMap.DeviceInfo of the calculations including the
+// serial number from the parameters.
+func (s *Snapshot) DeviceInfo() DeviceInfo {
+	info := s.Calculations.DeviceInfo()
+	layout, _ := s.Calculations.infoLayout()
+	info.SerialNumber = s.Parameters.serialNumber(layout)
+	if b, ok := s.Parameters[layout.AccessLevel]; ok {
+		info.AccessLevel = AccessLevel(b.RawValue())
+	}
+	return info
+}
+
+// serialNumber joins the parts of the serial number, empty if the parameters
+// lack it.
+func (pm DataTypeMap) serialNumber(layout InfoLayout) string {
+	date, ok := pm[layout.SerialDate]
+	if !ok || date.RawValue() == 0 {
+		return ""
+	}
+	hex, ok := pm[layout.SerialHex]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%d-%x", date.RawValue(), hex.RawValue())
+}
+
+// infoLayout returns the layout of the firmware of pm and the version.
+func (pm DataTypeMap) infoLayout() (InfoLayout, string) {
+	version := pm.version(DefaultInfoLayout.Version)
+	for _, e := range FirmwareCatalog {
+		if ok, err := e.Match(version); err == nil && ok {
+			if e.Info == nil {
+				break
+			}
+			return *e.Info, pm.version(e.Info.Version)
+		}
+	}
+	return DefaultInfoLayout, version
+}
+
+// version joins the characters at idxs, missing ones are skipped.
+func (pm DataTypeMap) version(idxs []int) string {
+	var buf strings.Builder
+	for _, idx := range idxs {
+		if b, ok := pm[idx]; ok {
+			buf.WriteString(fmt.Sprint(b.canonical().FromHeatPump()))
+		}
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// infoString returns the untranslated value at idx, empty if it is missing.
+func (pm DataTypeMap) infoString(idx int) string {
+	b, ok := pm[idx]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(b.canonical().FromHeatPump())
+}
@@ -0,0 +1,55 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataTypeMap_DeviceInfo(t *testing.T) {
+	pm := NewCalculationsMap()
+	for i, c := range "V3.89.1" {
+		pm[CalcSoftStand0+i].SetRaw(uint32(c))
+	}
+	pm[CalcHeatpumpCode].SetRaw(13)
+	pm[CalcBIVStufeAkt].SetRaw(2)
+	pm[CalcAdresseIPAkt].SetRaw(0xC0A80079)
+	pm[CalcSubNetMaskAkt].SetRaw(0xFFFFFF00)
+	pm[CalcAddBroadcast].SetRaw(0xC0A800FF)
+	pm[CalcAddStdGateway].SetRaw(0xC0A80001)
+	pm.SetLanguage(LanguageGerman)
+
+	assert.Equal(t, "V3.89.1", pm.GetVersion())
+	assert.Equal(t, DeviceInfo{
+		Firmware:       "V3.89.1",
+		Model:          "L2G",
+		BivalenceLevel: "two compressors allowed to run",
+		IPAddress:      "192.168.0.121",
+		SubnetMask:     "255.255.255.0",
+		Broadcast:      "192.168.0.255",
+		Gateway:        "192.168.0.1",
+	}, pm.DeviceInfo())
+
+	defer func(catalog []FirmwareMaps) { FirmwareCatalog = catalog }(FirmwareCatalog)
+	FirmwareCatalog = []FirmwareMaps{{
+		Versions: "V3.89",
+		Info:     &InfoLayout{Version: DefaultInfoLayout.Version, HeatpumpCode: CalcCodeWPAkt2},
+	}}
+	pm[CalcCodeWPAkt2].SetRaw(1)
+	info := pm.DeviceInfo()
+	assert.Equal(t, "V3.89.1", info.Firmware)
+	assert.Equal(t, "SW1", info.Model)
+	assert.Empty(t, DataTypeMap{}.DeviceInfo())
+}
+
+func TestSnapshot_DeviceInfo(t *testing.T) {
+	s := &Snapshot{Calculations: NewCalculationsMap(), Parameters: NewParameterMap()}
+	assert.Empty(t, s.DeviceInfo().SerialNumber)
+
+	s.Calculations[CalcAdresseIPAkt].SetRaw(0xC0A80079)
+	s.Parameters[ParamSerialNumberDate].SetRaw(2311)
+	s.Parameters[ParamSerialNumberHex].SetRaw(0x4b3)
+	info := s.DeviceInfo()
+	assert.Equal(t, "2311-4b3", info.SerialNumber)
+	assert.Equal(t, "192.168.0.121", info.IPAddress)
+}
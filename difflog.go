@@ -0,0 +1,82 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DiffLogger is a Sink which logs the changed values of each poll as one
+// compact structured entry, e.g.
+//
+//	{"msg":"values changed","block":"calculations","changes":{"ID_WEB_WP_BZ_akt":"heating→hot water"}}
+//
+// The first poll of each block is skipped because every value differs from
+// its zero value.
+type DiffLogger struct {
+	logger  *zap.Logger
+	seen    map[string]bool
+	changes *ChangeDetector
+}
+
+func NewDiffLogger(logger *zap.Logger) *DiffLogger {
+	return &DiffLogger{
+		logger:  logger,
+		seen:    map[string]bool{},
+		changes: NewChangeDetector(Deadbands{}),
+	}
+}
+
+// SetDeadbands hides changes smaller than the deadbands, call it before the
+// first write.
+func (d *DiffLogger) SetDeadbands(db Deadbands) {
+	d.changes = NewChangeDetector(db)
+}
+
+func (d *DiffLogger) Write(_ context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	key := host + "/" + block
+	if !d.seen[key] {
+		d.seen[key] = true
+		return nil
+	}
+
+	var changes valueChanges
+	pm.IterateSorted(func(idx int, b *Base) {
+		if prev, ok := d.changes.Changed(host, block, idx, b); ok {
+			changes = append(changes, valueChange{
+				name:   b.luxtronikName,
+				change: fmt.Sprintf("%v→%v", b.FromHeatPumpRaw(prev), b.FromHeatPump()),
+			})
+		}
+	})
+	if len(changes) == 0 {
+		return nil
+	}
+
+	d.logger.Info("values changed",
+		zap.String("host", host),
+		zap.String("block", block),
+		zap.Int("count", len(changes)),
+		zap.Object("changes", changes),
+	)
+	return nil
+}
+
+func (d *DiffLogger) Close() error { return nil }
+
+type valueChange struct {
+	name   string
+	change string
+}
+
+type valueChanges []valueChange
+
+func (vc valueChanges) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, c := range vc {
+		enc.AddString(c.name, c.change)
+	}
+	return nil
+}
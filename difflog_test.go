@@ -0,0 +1,37 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDiffLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	d := NewDiffLogger(zap.New(core))
+	ctx := context.Background()
+
+	pm := DataTypeMap{
+		0: NewOperationMode("ID_WEB_WP_BZ_akt"),
+		1: NewCelsius("ID_WEB_Temperatur_TA", false),
+	}
+
+	require.NoError(t, pm.SetRawValues([]uint32{0, 35}))
+	require.NoError(t, d.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+	assert.Zero(t, logs.Len(), "first poll must not be logged")
+
+	require.NoError(t, pm.SetRawValues([]uint32{1, 35}))
+	require.NoError(t, d.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+
+	require.NoError(t, pm.SetRawValues([]uint32{1, 35}))
+	require.NoError(t, d.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{"ID_WEB_WP_BZ_akt": "heating→hot water"}, entries[0].ContextMap()["changes"])
+}
@@ -0,0 +1,258 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The vendor tools discover controllers by broadcasting a magic packet to
+// these UDP ports. Controllers answer with a semicolon separated message
+// starting with DiscoveryResponsePrefix followed by their TCP port, newer
+// firmwares append their name and firmware version.
+const (
+	DiscoveryMagicPacket    = "2000;111;1;\x00"
+	DiscoveryResponsePrefix = "2500;111;"
+)
+
+var DiscoveryPorts = []int{4444, 47808}
+
+// Controller describes a heat pump found by Scan or DiscoverBroadcast.
+type Controller struct {
+	Addr     string // host:port of the TCP interface
+	Name     string // name announced by the controller, if any
+	Firmware string
+	Model    string
+	Source   string // tcp or udp
+}
+
+type ScanOptions struct {
+	// Port defaults to DefaultPort.
+	Port string
+	// Timeout for connecting and reading per host, defaults to one second.
+	Timeout time.Duration
+	// Concurrency limits the number of parallel probes, defaults to 64.
+	Concurrency int
+}
+
+// Scan probes all hosts of the IPv4 network in CIDR notation, e.g.
+// 192.168.0.0/24, and returns the controllers which answer on the Luxtronik
+// port sorted by address. Networks larger than /16 are rejected.
+func Scan(ctx context.Context, cidr string, opts ScanOptions) ([]Controller, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("Scan invalid network %q: %w", cidr, err)
+	}
+	if !prefix.Addr().Is4() || prefix.Bits() < 16 {
+		return nil, fmt.Errorf("Scan supports IPv4 networks up to /16, got %q", cidr)
+	}
+	if opts.Port == "" {
+		opts.Port = DefaultPort
+	}
+	if opts.Timeout < 1 {
+		opts.Timeout = time.Second
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 64
+	}
+
+	var (
+		found []Controller
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, opts.Concurrency)
+	)
+	prefix = prefix.Masked()
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(hostPort string) {
+			defer func() { <-sem; wg.Done() }()
+			if c, err := ProbeController(hostPort, opts.Timeout); err == nil {
+				mu.Lock()
+				found = append(found, c)
+				mu.Unlock()
+			}
+		}(net.JoinHostPort(addr.String(), opts.Port))
+	}
+	wg.Wait()
+
+	sortControllers(found)
+	return found, ctx.Err()
+}
+
+// ProbeController connects to a single address and reads the firmware
+// version and heat pump model from the calculations block.
+func ProbeController(hostPort string, timeout time.Duration) (Controller, error) {
+	c := MustNewClient(hostPort, Options{
+		DialTimeout: timeout,
+		ConnCB: func(conn net.Conn) {
+			_ = conn.SetDeadline(time.Now().Add(3 * timeout))
+		},
+	})
+	if err := c.Connect(); err != nil {
+		return Controller{}, err
+	}
+	defer c.Close()
+
+	pm := NewCalculationsMap()
+	if err := c.ReadCalculations(pm); err != nil {
+		return Controller{}, err
+	}
+	return Controller{
+		Addr:     hostPort,
+		Firmware: pm.GetVersion(),
+		Model:    fmt.Sprint(pm[78].FromHeatPump()),
+		Source:   "tcp",
+	}, nil
+}
+
+// DiscoverBroadcast sends the vendor discovery packet to the broadcast
+// addresses of all interfaces and collects the answers until the timeout
+// expires. It needs no access to the TCP port, the controllers contain the
+// name and firmware only if the controller announced them.
+func DiscoverBroadcast(ctx context.Context, timeout time.Duration) ([]Controller, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, fmt.Errorf("DiscoverBroadcast failed to listen: %w", err)
+	}
+	defer conn.Close()
+
+	var sent int
+	for _, ip := range broadcastAddrs() {
+		for _, port := range DiscoveryPorts {
+			dst := &net.UDPAddr{IP: ip, Port: port}
+			if _, err = conn.WriteToUDP([]byte(DiscoveryMagicPacket), dst); err == nil {
+				sent++
+			}
+		}
+	}
+	if sent == 0 {
+		return nil, fmt.Errorf("DiscoverBroadcast failed to send: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var found []Controller
+	buf := make([]byte, 512)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var nErr net.Error
+			if errors.As(err, &nErr) && nErr.Timeout() {
+				break
+			}
+			return found, fmt.Errorf("DiscoverBroadcast failed to read: %w", err)
+		}
+		c, ok := parseDiscoveryResponse(src.IP, buf[:n])
+		if !ok || seen[c.Addr] {
+			continue
+		}
+		seen[c.Addr] = true
+		found = append(found, c)
+	}
+
+	sortControllers(found)
+	return found, nil
+}
+
+// broadcastAddrs returns the limited broadcast address and the directed
+// ones of the IPv4 networks of all interfaces which are up, so that the
+// packet leaves every interface and not only the one of the default route.
+func broadcastAddrs() []net.IP {
+	addrs := []net.IP{net.IPv4bcast}
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return addrs
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagBroadcast == 0 {
+			continue
+		}
+		ifAddrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, a := range ifAddrs {
+			n, ok := a.(*net.IPNet)
+			if !ok || n.IP.To4() == nil {
+				continue
+			}
+			ip, mask := n.IP.To4(), net.IP(n.Mask).To4()
+			if mask == nil {
+				continue
+			}
+			bcast := make(net.IP, net.IPv4len)
+			for i := range bcast {
+				bcast[i] = ip[i] | ^mask[i]
+			}
+			addrs = append(addrs, bcast)
+		}
+	}
+	return addrs
+}
+
+// parseDiscoveryResponse parses answers like "2500;111;8889;..." where the
+// third field is the TCP port. Invalid ports fall back to DefaultPort. Of
+// the further fields, a version like V3.89.1 is the firmware and the first
+// one which is no number the name.
+func parseDiscoveryResponse(ip net.IP, msg []byte) (Controller, bool) {
+	res := strings.TrimRight(string(msg), "\x00")
+	if !strings.HasPrefix(res, DiscoveryResponsePrefix) {
+		return Controller{}, false
+	}
+	c := Controller{Source: "udp"}
+	port := DefaultPort
+	fields := strings.Split(res, ";")
+	if len(fields) > 2 {
+		if p, err := strconv.Atoi(fields[2]); err == nil && p > 0 && p < 65536 {
+			port = fields[2]
+		}
+	}
+	for i := 3; i < len(fields); i++ {
+		f := strings.TrimSpace(fields[i])
+		switch {
+		case f == "":
+		case len(f) > 1 && f[0] == 'V' && isDigit(f[1]):
+			c.Firmware = f
+		case c.Name == "":
+			if _, err := strconv.Atoi(f); err != nil {
+				c.Name = f
+			}
+		}
+	}
+	c.Addr = net.JoinHostPort(ip.String(), port)
+	return c, true
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func sortControllers(cs []Controller) {
+	sort.Slice(cs, func(i, j int) bool {
+		ai, _ := netip.ParseAddrPort(cs[i].Addr)
+		aj, _ := netip.ParseAddrPort(cs[j].Addr)
+		if c := ai.Addr().Compare(aj.Addr()); c != 0 {
+			return c < 0
+		}
+		return ai.Port() < aj.Port()
+	})
+}
@@ -0,0 +1,58 @@
+package luxtronik
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	hp := newMockHeatPump(t)
+	for i, r := range "V3.89.0" {
+		hp.calculations[81+i] = uint32(r)
+	}
+	hp.calculations[78] = 57
+
+	_, port, err := net.SplitHostPort(hp.addr())
+	require.NoError(t, err)
+
+	found, err := Scan(context.Background(), "127.0.0.1/30", ScanOptions{Port: port, Timeout: 200 * time.Millisecond})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, Controller{Addr: hp.addr(), Firmware: "V3.89.0", Model: "MSW 4", Source: "tcp"}, found[0])
+
+	_, err = Scan(context.Background(), "10.0.0.0/8", ScanOptions{})
+	assert.Error(t, err)
+}
+
+func TestParseDiscoveryResponse(t *testing.T) {
+	ip := net.IPv4(192, 168, 0, 121)
+
+	c, ok := parseDiscoveryResponse(ip, []byte("2500;111;8888;2;\x00"))
+	assert.True(t, ok)
+	assert.Equal(t, "192.168.0.121:8888", c.Addr)
+
+	c, ok = parseDiscoveryResponse(ip, []byte("2500;111;0;"))
+	assert.True(t, ok)
+	assert.Equal(t, "192.168.0.121:8889", c.Addr)
+
+	c, ok = parseDiscoveryResponse(ip, []byte("2500;111;8889;1;Keller;V3.89.1;\x00"))
+	assert.True(t, ok)
+	assert.Equal(t, Controller{Addr: "192.168.0.121:8889", Name: "Keller", Firmware: "V3.89.1", Source: "udp"}, c)
+
+	_, ok = parseDiscoveryResponse(ip, []byte(DiscoveryMagicPacket))
+	assert.False(t, ok)
+}
+
+func TestBroadcastAddrs(t *testing.T) {
+	addrs := broadcastAddrs()
+	require.NotEmpty(t, addrs)
+	assert.True(t, addrs[0].Equal(net.IPv4bcast))
+	for _, a := range addrs {
+		assert.NotNil(t, a.To4(), a.String())
+	}
+}
@@ -0,0 +1,107 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// Thermal disinfection targets outside this range in °C are rejected, below
+// it legionella survive and above it the hot water gets dangerous.
+const (
+	minDisinfectionTarget = 50.0
+	maxDisinfectionTarget = 75.0
+)
+
+// Disinfection is the thermal disinfection schedule of the hot water: on the
+// selected weekdays the hot water gets heated to Target once.
+type Disinfection struct {
+	// Weekdays of the disinfection, ordered from Monday to Sunday.
+	Weekdays []time.Weekday
+	// Continuous keeps the hot water at Target regardless of the weekdays.
+	Continuous bool
+	// Target temperature in °C.
+	Target float64
+}
+
+// disinfectionIndex returns the parameter of the weekday, the controller
+// starts the week on Monday.
+func disinfectionIndex(d time.Weekday) int {
+	return ParamDisinfectionMonday + (int(d)+6)%7
+}
+
+// DisinfectionOf returns the disinfection schedule of already read
+// parameters.
+func DisinfectionOf(pm DataTypeMap) Disinfection {
+	var d Disinfection
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((i + 1) % 7)
+		if pm[disinfectionIndex(day)].reading.Raw == 1 {
+			d.Weekdays = append(d.Weekdays, day)
+		}
+	}
+	d.Continuous = pm[ParamDisinfectionContinuous].reading.Raw == 1
+	b := pm[ParamDisinfectionTarget]
+	d.Target = math.Round(b.number(b.reading.Raw)*float64(b.factor)*10) / 10
+	return d
+}
+
+// ReadDisinfection reads the parameters and returns the disinfection
+// schedule.
+func (c *Client) ReadDisinfection() (Disinfection, error) {
+	return c.ReadDisinfectionContext(context.Background())
+}
+
+// ReadDisinfectionContext is ReadDisinfection with a context, see
+// ReadParametersContext.
+func (c *Client) ReadDisinfectionContext(ctx context.Context) (Disinfection, error) {
+	pm := NewParameterMap()
+	if err := c.ReadParametersContext(ctx, pm); err != nil {
+		return Disinfection{}, fmt.Errorf("ReadDisinfection: %w", err)
+	}
+	return DisinfectionOf(pm), nil
+}
+
+// WriteDisinfection reads the parameters and writes the values of d which
+// differ from the current ones, unchanged values are not written to spare
+// the controller's flash. The target must lie within 50 to 75 °C.
+func (c *Client) WriteDisinfection(d Disinfection) error {
+	return c.WriteDisinfectionContext(context.Background(), d)
+}
+
+// WriteDisinfectionContext is WriteDisinfection with a context, see
+// ReadParametersContext.
+func (c *Client) WriteDisinfectionContext(ctx context.Context, d Disinfection) error {
+	if d.Target < minDisinfectionTarget || d.Target > maxDisinfectionTarget {
+		return fmt.Errorf("WriteDisinfection target %.1f °C out of range %.0f-%.0f °C: %w",
+			d.Target, minDisinfectionTarget, maxDisinfectionTarget, ErrInvalidValue)
+	}
+	for _, day := range d.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("WriteDisinfection invalid weekday %d: %w", day, ErrInvalidValue)
+		}
+	}
+	pm := NewParameterMap()
+	if err := c.ReadParametersContext(ctx, pm); err != nil {
+		return fmt.Errorf("WriteDisinfection: %w", err)
+	}
+
+	want := map[int]any{
+		ParamDisinfectionContinuous: d.Continuous,
+		ParamDisinfectionTarget:     d.Target,
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		want[disinfectionIndex(day)] = slices.Contains(d.Weekdays, day)
+	}
+	for idx := ParamDisinfectionMonday; idx <= ParamDisinfectionContinuous; idx++ {
+		if err := c.writeChanged(ctx, pm, idx, want[idx]); err != nil {
+			return fmt.Errorf("WriteDisinfection: %w", err)
+		}
+	}
+	if err := c.writeChanged(ctx, pm, ParamDisinfectionTarget, d.Target); err != nil {
+		return fmt.Errorf("WriteDisinfection: %w", err)
+	}
+	return nil
+}
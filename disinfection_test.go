@@ -0,0 +1,32 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisinfection(t *testing.T) {
+	m := newMockHeatPump(t)
+	m.parameters[ParamDisinfectionSunday] = 1
+	m.parameters[ParamDisinfectionWednesday] = 1
+	m.parameters[ParamDisinfectionTarget] = 650
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	d, err := c.ReadDisinfection()
+	require.NoError(t, err)
+	assert.Equal(t, Disinfection{Weekdays: []time.Weekday{time.Wednesday, time.Sunday}, Target: 65}, d)
+
+	require.NoError(t, c.WriteDisinfection(Disinfection{Weekdays: []time.Weekday{time.Monday, time.Friday}, Target: 62.5}))
+	m.mu.Lock()
+	assert.Equal(t, []uint32{1, 0, 0, 0, 1, 0, 0, 0}, m.parameters[ParamDisinfectionMonday:ParamDisinfectionContinuous+1])
+	assert.Equal(t, uint32(625), m.parameters[ParamDisinfectionTarget])
+	m.mu.Unlock()
+
+	assert.ErrorIs(t, c.WriteDisinfection(Disinfection{Target: 40}), ErrInvalidValue)
+	assert.ErrorIs(t, c.WriteDisinfection(Disinfection{Weekdays: []time.Weekday{7}, Target: 60}), ErrInvalidValue)
+}
@@ -0,0 +1,314 @@
+package luxtronik
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dump is a point in time snapshot of all values of one or more blocks, used
+// for offline analysis.
+type Dump struct {
+	Time    time.Time   `json:"time" yaml:"time"`
+	Host    string      `json:"host" yaml:"host"`
+	Entries []DumpEntry `json:"entries" yaml:"entries"`
+}
+
+type DumpEntry struct {
+	Block string `json:"block" yaml:"block"`
+	Index int    `json:"index" yaml:"index"`
+	Name  string `json:"name" yaml:"name"`
+	Class string `json:"class" yaml:"class"`
+	Value any    `json:"value" yaml:"value"`
+	Unit  string `json:"unit,omitempty" yaml:"unit,omitempty"`
+	Raw   uint32 `json:"raw" yaml:"raw"`
+}
+
+// NewDump collects the current values of all blocks sorted by block name and
+// index.
+func NewDump(host string, ts time.Time, blocks map[string]DataTypeMap) *Dump {
+	names := make([]string, 0, len(blocks))
+	for name := range blocks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	d := &Dump{Time: ts, Host: host}
+	for _, block := range names {
+		blocks[block].IterateSorted(func(idx int, b *Base) {
+			d.Entries = append(d.Entries, DumpEntry{
+				Block: block,
+				Index: idx,
+				Name:  b.luxtronikName,
+				Class: b.class,
+				Value: DumpValue(b.FromHeatPump()),
+				Unit:  b.Unit(),
+				Raw:   b.reading.Raw,
+			})
+		})
+	}
+	return d
+}
+
+// NewParameterBackup collects all writeable parameters of pm, see Restore.
+func NewParameterBackup(host string, ts time.Time, pm DataTypeMap) *Dump {
+	writeable := make(DataTypeMap)
+	for idx, b := range pm {
+		if b.writeable {
+			writeable[idx] = b
+		}
+	}
+	return NewDump(host, ts, map[string]DataTypeMap{BlockParameters: writeable})
+}
+
+// RestoreChange is a parameter whose current value differs from the backup.
+type RestoreChange struct {
+	Index   int
+	Base    *Base
+	Current uint32
+	Backup  uint32
+}
+
+// ParameterChanges compares the writeable parameters of the dump with the
+// current values in pm.
+func (d *Dump) ParameterChanges(pm DataTypeMap) []RestoreChange {
+	var changes []RestoreChange
+	for _, e := range d.Entries {
+		if e.Block != BlockParameters {
+			continue
+		}
+		b, ok := pm[e.Index]
+		if !ok || !b.writeable || b.reading.Raw == e.Raw {
+			continue
+		}
+		changes = append(changes, RestoreChange{Index: e.Index, Base: b, Current: b.reading.Raw, Backup: e.Raw})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Index < changes[j].Index
+	})
+	return changes
+}
+
+// DumpValue converts a value of FromHeatPump which has no useful text
+// representation for the entries of a Dump: durations into seconds, times
+// into RFC 3339 and codes into their text.
+func DumpValue(v any) any {
+	switch tv := v.(type) {
+	case time.Duration:
+		return tv.Seconds()
+	case time.Time:
+		if tv.IsZero() {
+			return nil
+		}
+		return tv.Format(time.RFC3339)
+	case fmt.Stringer:
+		return tv.String()
+	}
+	return v
+}
+
+func (d *Dump) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+func (d *Dump) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	if err := enc.Encode(d); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteCSV writes one row per value with a header row. The time and host
+// get repeated in every row so that several dumps can be concatenated.
+func (d *Dump) WriteCSV(w io.Writer) error {
+	return WriteDumpsCSV(w, d)
+}
+
+// WriteDumpsCSV writes several dumps, e.g. of different heat pumps, below a
+// single header.
+func WriteDumpsCSV(w io.Writer, dumps ...*Dump) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"time", "host", "block", "index", "name", "class", "value", "unit", "raw"})
+	for _, d := range dumps {
+		ts := d.Time.Format(time.RFC3339)
+		for _, e := range d.Entries {
+			_ = cw.Write([]string{
+				ts,
+				d.Host,
+				e.Block,
+				strconv.Itoa(e.Index),
+				e.Name,
+				e.Class,
+				fmt.Sprint(e.Value),
+				e.Unit,
+				strconv.FormatUint(uint64(e.Raw), 10),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Apply sets the raw values of all entries of the block to pm. Entries with
+// an unknown index are ignored.
+func (d *Dump) Apply(block string, pm DataTypeMap) {
+	for _, e := range d.Entries {
+		if e.Block != block {
+			continue
+		}
+		if b, ok := pm[e.Index]; ok {
+			b.SetRaw(e.Raw)
+		}
+	}
+}
+
+// ReadDumps decodes all dumps of a stream written by WriteJSON, WriteYAML or
+// WriteCSV. CSV rows get grouped into dumps by their time and host columns.
+// The format raw reads the answers of the controller as sent on the wire,
+// e.g. captured with netcat, a repeated block starts the next dump.
+func ReadDumps(r io.Reader, format string) ([]*Dump, error) {
+	switch format {
+	case "json":
+		var dumps []*Dump
+		dec := json.NewDecoder(r)
+		for {
+			d := &Dump{}
+			if err := dec.Decode(d); errors.Is(err, io.EOF) {
+				return dumps, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("ReadDumps.json failed: %w", err)
+			}
+			dumps = append(dumps, d)
+		}
+	case "yaml", "yml":
+		var dumps []*Dump
+		dec := yaml.NewDecoder(r)
+		for {
+			d := &Dump{}
+			if err := dec.Decode(d); errors.Is(err, io.EOF) {
+				return dumps, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("ReadDumps.yaml failed: %w", err)
+			}
+			dumps = append(dumps, d)
+		}
+	case "csv":
+		return readDumpsCSV(r)
+	case "raw":
+		return readDumpsRaw(r)
+	}
+	return nil, fmt.Errorf("ReadDumps unsupported format %q", format)
+}
+
+func readDumpsCSV(r io.Reader) ([]*Dump, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 9
+	var (
+		dumps []*Dump
+		cur   *Dump
+	)
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return dumps, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ReadDumps.csv failed: %w", err)
+		}
+		if rec[0] == "time" {
+			continue // header, also repeated in concatenated files
+		}
+
+		ts, err := time.Parse(time.RFC3339, rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("ReadDumps.csv line %d invalid time: %w", line, err)
+		}
+		idx, err := strconv.Atoi(rec[3])
+		if err != nil {
+			return nil, fmt.Errorf("ReadDumps.csv line %d invalid index: %w", line, err)
+		}
+		raw, err := strconv.ParseUint(rec[8], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("ReadDumps.csv line %d invalid raw value: %w", line, err)
+		}
+
+		if cur == nil || !cur.Time.Equal(ts) || cur.Host != rec[1] {
+			cur = &Dump{Time: ts, Host: rec[1]}
+			dumps = append(dumps, cur)
+		}
+		cur.Entries = append(cur.Entries, DumpEntry{
+			Block: rec[2],
+			Index: idx,
+			Name:  rec[4],
+			Class: rec[5],
+			Value: rec[6],
+			Unit:  rec[7],
+			Raw:   uint32(raw),
+		})
+	}
+}
+
+func readDumpsRaw(r io.Reader) ([]*Dump, error) {
+	br := bufio.NewReader(r)
+	var (
+		dumps []*Dump
+		cur   *Dump
+		seen  map[string]bool
+	)
+	for frame := 1; ; frame++ {
+		var cmd uint32
+		if err := binary.Read(br, binary.BigEndian, &cmd); errors.Is(err, io.EOF) {
+			return dumps, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("ReadDumps.raw frame %d failed: %w", frame, err)
+		}
+		block, ok := blockNames[int32(cmd)]
+		if !ok {
+			return nil, fmt.Errorf("ReadDumps.raw frame %d: %w: %d", frame, ErrInvalidCommand, cmd)
+		}
+		header := []uint32{0}
+		if cmd == CalculationsRead {
+			header = append(header, 0) // status
+		}
+		if err := binary.Read(br, binary.BigEndian, header); err != nil {
+			return nil, fmt.Errorf("ReadDumps.raw frame %d header failed: %w", frame, err)
+		}
+		length := header[len(header)-1]
+		if length > maxFrameLength {
+			return nil, fmt.Errorf("ReadDumps.raw frame %d: %w: %d values exceed %d", frame, ErrLengthMismatch, length, maxFrameLength)
+		}
+		raw := make([]uint32, length)
+		if cmd == VisibilitiesRead {
+			chars := make([]byte, length)
+			if _, err := io.ReadFull(br, chars); err != nil {
+				return nil, fmt.Errorf("ReadDumps.raw frame %d values failed: %w", frame, err)
+			}
+			for i, c := range chars {
+				raw[i] = uint32(c)
+			}
+		} else if err := binary.Read(br, binary.BigEndian, raw); err != nil {
+			return nil, fmt.Errorf("ReadDumps.raw frame %d values failed: %w", frame, err)
+		}
+
+		if cur == nil || seen[block] {
+			cur, seen = &Dump{}, map[string]bool{}
+			dumps = append(dumps, cur)
+		}
+		seen[block] = true
+		pm := newBlockMap(block)
+		pm.SetRawValuesTolerant(raw, unknownPrefixes[int32(cmd)])
+		cur.Entries = append(cur.Entries, NewDump("", time.Time{}, map[string]DataTypeMap{block: pm}).Entries...)
+	}
+}
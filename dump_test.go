@@ -0,0 +1,64 @@
+package luxtronik
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDump_WriteCSV(t *testing.T) {
+	pm := DataTypeMap{
+		0: NewCelsius("ID_WEB_Temperatur_TVL", false),
+		1: NewSeconds("ID_WEB_Zaehler_BetrZeitVD1"),
+	}
+	require.NoError(t, pm.SetRawValues([]uint32{325, 3600}))
+
+	d := NewDump("hp:8889", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), map[string]DataTypeMap{BlockCalculations: pm})
+
+	var buf bytes.Buffer
+	require.NoError(t, d.WriteCSV(&buf))
+	assert.Equal(t, `time,host,block,index,name,class,value,unit,raw
+2024-01-02T03:04:05Z,hp:8889,calculations,0,ID_WEB_Temperatur_TVL,temperature,32.5,°C,325
+2024-01-02T03:04:05Z,hp:8889,calculations,1,ID_WEB_Zaehler_BetrZeitVD1,duration,3600,s,3600
+`, buf.String())
+}
+
+func TestDump_ParameterChanges(t *testing.T) {
+	pm := NewParameterMap()
+	pm[2].SetRaw(480)
+	pm[3].SetRaw(0)
+
+	backup := NewParameterBackup("hp", time.Now(), pm)
+	for _, e := range backup.Entries {
+		require.True(t, pm[e.Index].Writeable())
+	}
+
+	pm[2].SetRaw(500)
+	pm[5].SetRaw(1) // not writeable, must not show up
+	changes := backup.ParameterChanges(pm)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 2, changes[0].Index)
+	assert.Equal(t, uint32(500), changes[0].Current)
+	assert.Equal(t, uint32(480), changes[0].Backup)
+}
+
+func BenchmarkDump_WriteJSON(b *testing.B) {
+	blocks := map[string]DataTypeMap{
+		BlockParameters:   NewParameterMap(),
+		BlockCalculations: NewCalculationsMap(),
+		BlockVisibilities: NewVisibilitiesMap(),
+	}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewDump("hp", ts, blocks).WriteJSON(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
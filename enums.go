@@ -0,0 +1,625 @@
+// Code generated by genenums from data/enums.csv; DO NOT EDIT.
+
+package luxtronik
+
+// HeatingMode is a code returned by FromHeatPump, see ParseHeatingMode.
+type HeatingMode uint32
+
+const (
+	HeatingModeAutomatic        HeatingMode = 0 // Automatic
+	HeatingModeSecondHeatsource HeatingMode = 1 // Second heatsource
+	HeatingModeParty            HeatingMode = 2 // Party
+	HeatingModeHolidays         HeatingMode = 3 // Holidays
+	HeatingModeOff              HeatingMode = 4 // Off
+)
+
+var heatingModeCodes = []string{
+	0: "Automatic",
+	1: "Second heatsource",
+	2: "Party",
+	3: "Holidays",
+	4: "Off",
+}
+
+func (v HeatingMode) String() string {
+	return codeString(heatingModeCodes, uint32(v))
+}
+
+// ParseHeatingMode parses the English code of a HeatingMode, e.g. Automatic.
+func ParseHeatingMode(s string) (HeatingMode, error) {
+	v, err := parseCode("ParseHeatingMode", heatingModeCodes, s)
+	return HeatingMode(v), err
+}
+
+func (v HeatingMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *HeatingMode) UnmarshalText(text []byte) error {
+	p, err := ParseHeatingMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// HotWaterMode is a code returned by FromHeatPump, see ParseHotWaterMode.
+type HotWaterMode uint32
+
+const (
+	HotWaterModeAutomatic        HotWaterMode = 0 // Automatic
+	HotWaterModeSecondHeatsource HotWaterMode = 1 // Second heatsource
+	HotWaterModeParty            HotWaterMode = 2 // Party
+	HotWaterModeHolidays         HotWaterMode = 3 // Holidays
+	HotWaterModeOff              HotWaterMode = 4 // Off
+)
+
+var hotWaterModeCodes = []string{
+	0: "Automatic",
+	1: "Second heatsource",
+	2: "Party",
+	3: "Holidays",
+	4: "Off",
+}
+
+func (v HotWaterMode) String() string {
+	return codeString(hotWaterModeCodes, uint32(v))
+}
+
+// ParseHotWaterMode parses the English code of a HotWaterMode, e.g. Automatic.
+func ParseHotWaterMode(s string) (HotWaterMode, error) {
+	v, err := parseCode("ParseHotWaterMode", hotWaterModeCodes, s)
+	return HotWaterMode(v), err
+}
+
+func (v HotWaterMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *HotWaterMode) UnmarshalText(text []byte) error {
+	p, err := ParseHotWaterMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// PoolMode is a code returned by FromHeatPump, see ParsePoolMode.
+type PoolMode uint32
+
+const (
+	PoolModeAutomatic PoolMode = 0 // Automatic
+	PoolModeParty     PoolMode = 2 // Party
+	PoolModeHolidays  PoolMode = 3 // Holidays
+	PoolModeOff       PoolMode = 4 // Off
+)
+
+var poolModeCodes = []string{
+	0: "Automatic",
+	2: "Party",
+	3: "Holidays",
+	4: "Off",
+}
+
+func (v PoolMode) String() string {
+	return codeString(poolModeCodes, uint32(v))
+}
+
+// ParsePoolMode parses the English code of a PoolMode, e.g. Automatic.
+func ParsePoolMode(s string) (PoolMode, error) {
+	v, err := parseCode("ParsePoolMode", poolModeCodes, s)
+	return PoolMode(v), err
+}
+
+func (v PoolMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *PoolMode) UnmarshalText(text []byte) error {
+	p, err := ParsePoolMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// MixedCircuitMode is a code returned by FromHeatPump, see ParseMixedCircuitMode.
+type MixedCircuitMode uint32
+
+const (
+	MixedCircuitModeAutomatic MixedCircuitMode = 0 // Automatic
+	MixedCircuitModeParty     MixedCircuitMode = 2 // Party
+	MixedCircuitModeHolidays  MixedCircuitMode = 3 // Holidays
+	MixedCircuitModeOff       MixedCircuitMode = 4 // Off
+)
+
+var mixedCircuitModeCodes = []string{
+	0: "Automatic",
+	2: "Party",
+	3: "Holidays",
+	4: "Off",
+}
+
+func (v MixedCircuitMode) String() string {
+	return codeString(mixedCircuitModeCodes, uint32(v))
+}
+
+// ParseMixedCircuitMode parses the English code of a MixedCircuitMode, e.g. Automatic.
+func ParseMixedCircuitMode(s string) (MixedCircuitMode, error) {
+	v, err := parseCode("ParseMixedCircuitMode", mixedCircuitModeCodes, s)
+	return MixedCircuitMode(v), err
+}
+
+func (v MixedCircuitMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *MixedCircuitMode) UnmarshalText(text []byte) error {
+	p, err := ParseMixedCircuitMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// CoolingMode is a code returned by FromHeatPump, see ParseCoolingMode.
+type CoolingMode uint32
+
+const (
+	CoolingModeOff       CoolingMode = 0 // Off
+	CoolingModeAutomatic CoolingMode = 1 // Automatic
+)
+
+var coolingModeCodes = []string{
+	0: "Off",
+	1: "Automatic",
+}
+
+func (v CoolingMode) String() string {
+	return codeString(coolingModeCodes, uint32(v))
+}
+
+// ParseCoolingMode parses the English code of a CoolingMode, e.g. Off.
+func ParseCoolingMode(s string) (CoolingMode, error) {
+	v, err := parseCode("ParseCoolingMode", coolingModeCodes, s)
+	return CoolingMode(v), err
+}
+
+func (v CoolingMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *CoolingMode) UnmarshalText(text []byte) error {
+	p, err := ParseCoolingMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// SolarMode is a code returned by FromHeatPump, see ParseSolarMode.
+type SolarMode uint32
+
+const (
+	SolarModeOff       SolarMode = 0 // Off
+	SolarModeAutomatic SolarMode = 1 // Automatic
+)
+
+var solarModeCodes = []string{
+	0: "Off",
+	1: "Automatic",
+}
+
+func (v SolarMode) String() string {
+	return codeString(solarModeCodes, uint32(v))
+}
+
+// ParseSolarMode parses the English code of a SolarMode, e.g. Off.
+func ParseSolarMode(s string) (SolarMode, error) {
+	v, err := parseCode("ParseSolarMode", solarModeCodes, s)
+	return SolarMode(v), err
+}
+
+func (v SolarMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *SolarMode) UnmarshalText(text []byte) error {
+	p, err := ParseSolarMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// VentilationMode is a code returned by FromHeatPump, see ParseVentilationMode.
+type VentilationMode uint32
+
+const (
+	VentilationModeAutomatic VentilationMode = 0 // Automatic
+	VentilationModeParty     VentilationMode = 1 // Party
+	VentilationModeHolidays  VentilationMode = 2 // Holidays
+	VentilationModeOff       VentilationMode = 3 // Off
+)
+
+var ventilationModeCodes = []string{
+	0: "Automatic",
+	1: "Party",
+	2: "Holidays",
+	3: "Off",
+}
+
+func (v VentilationMode) String() string {
+	return codeString(ventilationModeCodes, uint32(v))
+}
+
+// ParseVentilationMode parses the English code of a VentilationMode, e.g. Automatic.
+func ParseVentilationMode(s string) (VentilationMode, error) {
+	v, err := parseCode("ParseVentilationMode", ventilationModeCodes, s)
+	return VentilationMode(v), err
+}
+
+func (v VentilationMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *VentilationMode) UnmarshalText(text []byte) error {
+	p, err := ParseVentilationMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// OperationMode is a code returned by FromHeatPump, see ParseOperationMode.
+type OperationMode uint32
+
+const (
+	OperationModeHeating               OperationMode = 0 // heating
+	OperationModeHotWater              OperationMode = 1 // hot water
+	OperationModeSwimmingPoolSolar     OperationMode = 2 // swimming pool/solar
+	OperationModeEvu                   OperationMode = 3 // evu
+	OperationModeDefrost               OperationMode = 4 // defrost
+	OperationModeNoRequest             OperationMode = 5 // no request
+	OperationModeHeatingExternalSource OperationMode = 6 // heating external source
+	OperationModeCooling               OperationMode = 7 // cooling
+)
+
+var operationModeCodes = []string{
+	0: "heating",
+	1: "hot water",
+	2: "swimming pool/solar",
+	3: "evu",
+	4: "defrost",
+	5: "no request",
+	6: "heating external source",
+	7: "cooling",
+}
+
+func (v OperationMode) String() string {
+	return codeString(operationModeCodes, uint32(v))
+}
+
+// ParseOperationMode parses the English code of a OperationMode, e.g. heating.
+func ParseOperationMode(s string) (OperationMode, error) {
+	v, err := parseCode("ParseOperationMode", operationModeCodes, s)
+	return OperationMode(v), err
+}
+
+func (v OperationMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *OperationMode) UnmarshalText(text []byte) error {
+	p, err := ParseOperationMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// BivalenceLevel is a code returned by FromHeatPump, see ParseBivalenceLevel.
+type BivalenceLevel uint32
+
+const (
+	BivalenceLevelOneCompressorAllowedToRun           BivalenceLevel = 1 // one compressor allowed to run
+	BivalenceLevelTwoCompressorsAllowedToRun          BivalenceLevel = 2 // two compressors allowed to run
+	BivalenceLevelAdditionalHeatGeneratorAllowedToRun BivalenceLevel = 3 // additional heat generator allowed to run
+)
+
+var bivalenceLevelCodes = []string{
+	1: "one compressor allowed to run",
+	2: "two compressors allowed to run",
+	3: "additional heat generator allowed to run",
+}
+
+func (v BivalenceLevel) String() string {
+	return codeString(bivalenceLevelCodes, uint32(v))
+}
+
+// ParseBivalenceLevel parses the English code of a BivalenceLevel, e.g. one compressor allowed to run.
+func ParseBivalenceLevel(s string) (BivalenceLevel, error) {
+	v, err := parseCode("ParseBivalenceLevel", bivalenceLevelCodes, s)
+	return BivalenceLevel(v), err
+}
+
+func (v BivalenceLevel) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *BivalenceLevel) UnmarshalText(text []byte) error {
+	p, err := ParseBivalenceLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// SwitchoffReason is a code returned by FromHeatPump, see ParseSwitchoffReason.
+type SwitchoffReason uint32
+
+const (
+	SwitchoffReasonHeatpumpError                    SwitchoffReason = 1  // heatpump error
+	SwitchoffReasonSystemError                      SwitchoffReason = 2  // system error
+	SwitchoffReasonEvuLock                          SwitchoffReason = 3  // evu lock
+	SwitchoffReasonOperationModeSecondHeatGenerator SwitchoffReason = 4  // operation mode second heat generator
+	SwitchoffReasonAirDefrost                       SwitchoffReason = 5  // air defrost
+	SwitchoffReasonMaximalUsageTemperature          SwitchoffReason = 6  // maximal usage temperature
+	SwitchoffReasonMinimalUsageTemperature          SwitchoffReason = 7  // minimal usage temperature
+	SwitchoffReasonLowerUsageLimit                  SwitchoffReason = 8  // lower usage limit
+	SwitchoffReasonNoRequest                        SwitchoffReason = 9  // no request
+	SwitchoffReasonFlowRate                         SwitchoffReason = 11 // flow rate
+	SwitchoffReasonPVMax                            SwitchoffReason = 19 // PV max
+)
+
+var switchoffReasonCodes = []string{
+	1:  "heatpump error",
+	2:  "system error",
+	3:  "evu lock",
+	4:  "operation mode second heat generator",
+	5:  "air defrost",
+	6:  "maximal usage temperature",
+	7:  "minimal usage temperature",
+	8:  "lower usage limit",
+	9:  "no request",
+	11: "flow rate",
+	19: "PV max",
+}
+
+func (v SwitchoffReason) String() string {
+	return codeString(switchoffReasonCodes, uint32(v))
+}
+
+// ParseSwitchoffReason parses the English code of a SwitchoffReason, e.g. heatpump error.
+func ParseSwitchoffReason(s string) (SwitchoffReason, error) {
+	v, err := parseCode("ParseSwitchoffReason", switchoffReasonCodes, s)
+	return SwitchoffReason(v), err
+}
+
+func (v SwitchoffReason) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *SwitchoffReason) UnmarshalText(text []byte) error {
+	p, err := ParseSwitchoffReason(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// MainMenuStatusLine1 is a code returned by FromHeatPump, see ParseMainMenuStatusLine1.
+type MainMenuStatusLine1 uint32
+
+const (
+	MainMenuStatusLine1HeatpumpRunning        MainMenuStatusLine1 = 0 // heatpump running
+	MainMenuStatusLine1HeatpumpIdle           MainMenuStatusLine1 = 1 // heatpump idle
+	MainMenuStatusLine1HeatpumpComing         MainMenuStatusLine1 = 2 // heatpump coming
+	MainMenuStatusLine1ErrorcodeSlot0         MainMenuStatusLine1 = 3 // errorcode slot 0
+	MainMenuStatusLine1Defrost                MainMenuStatusLine1 = 4 // defrost
+	MainMenuStatusLine1WaitingOnLINConnection MainMenuStatusLine1 = 5 // waiting on LIN connection
+	MainMenuStatusLine1CompressorHeatingUp    MainMenuStatusLine1 = 6 // compressor heating up
+	MainMenuStatusLine1PumpForerun            MainMenuStatusLine1 = 7 // pump forerun
+)
+
+var mainMenuStatusLine1Codes = []string{
+	0: "heatpump running",
+	1: "heatpump idle",
+	2: "heatpump coming",
+	3: "errorcode slot 0",
+	4: "defrost",
+	5: "waiting on LIN connection",
+	6: "compressor heating up",
+	7: "pump forerun",
+}
+
+func (v MainMenuStatusLine1) String() string {
+	return codeString(mainMenuStatusLine1Codes, uint32(v))
+}
+
+// ParseMainMenuStatusLine1 parses the English code of a MainMenuStatusLine1, e.g. heatpump running.
+func ParseMainMenuStatusLine1(s string) (MainMenuStatusLine1, error) {
+	v, err := parseCode("ParseMainMenuStatusLine1", mainMenuStatusLine1Codes, s)
+	return MainMenuStatusLine1(v), err
+}
+
+func (v MainMenuStatusLine1) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *MainMenuStatusLine1) UnmarshalText(text []byte) error {
+	p, err := ParseMainMenuStatusLine1(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// MainMenuStatusLine2 is a code returned by FromHeatPump, see ParseMainMenuStatusLine2.
+type MainMenuStatusLine2 uint32
+
+const (
+	MainMenuStatusLine2Since MainMenuStatusLine2 = 0 // since
+	MainMenuStatusLine2In    MainMenuStatusLine2 = 1 // in
+)
+
+var mainMenuStatusLine2Codes = []string{
+	0: "since",
+	1: "in",
+}
+
+func (v MainMenuStatusLine2) String() string {
+	return codeString(mainMenuStatusLine2Codes, uint32(v))
+}
+
+// ParseMainMenuStatusLine2 parses the English code of a MainMenuStatusLine2, e.g. since.
+func ParseMainMenuStatusLine2(s string) (MainMenuStatusLine2, error) {
+	v, err := parseCode("ParseMainMenuStatusLine2", mainMenuStatusLine2Codes, s)
+	return MainMenuStatusLine2(v), err
+}
+
+func (v MainMenuStatusLine2) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *MainMenuStatusLine2) UnmarshalText(text []byte) error {
+	p, err := ParseMainMenuStatusLine2(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// MainMenuStatusLine3 is a code returned by FromHeatPump, see ParseMainMenuStatusLine3.
+type MainMenuStatusLine3 uint32
+
+const (
+	MainMenuStatusLine3Heating                           MainMenuStatusLine3 = 0  // heating
+	MainMenuStatusLine3NoRequest                         MainMenuStatusLine3 = 1  // no request
+	MainMenuStatusLine3GridSwitchOnDelay                 MainMenuStatusLine3 = 2  // grid switch on delay
+	MainMenuStatusLine3CycleLock                         MainMenuStatusLine3 = 3  // cycle lock
+	MainMenuStatusLine3LockTime                          MainMenuStatusLine3 = 4  // lock time
+	MainMenuStatusLine3DomesticWater                     MainMenuStatusLine3 = 5  // domestic water
+	MainMenuStatusLine3InfoBakeOutProgram                MainMenuStatusLine3 = 6  // info bake out program
+	MainMenuStatusLine3Defrost                           MainMenuStatusLine3 = 7  // defrost
+	MainMenuStatusLine3PumpForerun                       MainMenuStatusLine3 = 8  // pump forerun
+	MainMenuStatusLine3ThermalDesinfection               MainMenuStatusLine3 = 9  // thermal desinfection
+	MainMenuStatusLine3Cooling                           MainMenuStatusLine3 = 10 // cooling
+	MainMenuStatusLine3SwimmingPoolSolar                 MainMenuStatusLine3 = 12 // swimming pool/solar
+	MainMenuStatusLine3HeatingExternalEnergySource       MainMenuStatusLine3 = 13 // heating external energy source
+	MainMenuStatusLine3DomesticWaterExternalEnergySource MainMenuStatusLine3 = 14 // domestic water external energy source
+	MainMenuStatusLine3FlowMonitoring                    MainMenuStatusLine3 = 16 // flow monitoring
+	MainMenuStatusLine3SecondHeatGenerator1Active        MainMenuStatusLine3 = 17 // second heat generator 1 active
+)
+
+var mainMenuStatusLine3Codes = []string{
+	0:  "heating",
+	1:  "no request",
+	2:  "grid switch on delay",
+	3:  "cycle lock",
+	4:  "lock time",
+	5:  "domestic water",
+	6:  "info bake out program",
+	7:  "defrost",
+	8:  "pump forerun",
+	9:  "thermal desinfection",
+	10: "cooling",
+	12: "swimming pool/solar",
+	13: "heating external energy source",
+	14: "domestic water external energy source",
+	16: "flow monitoring",
+	17: "second heat generator 1 active",
+}
+
+func (v MainMenuStatusLine3) String() string {
+	return codeString(mainMenuStatusLine3Codes, uint32(v))
+}
+
+// ParseMainMenuStatusLine3 parses the English code of a MainMenuStatusLine3, e.g. heating.
+func ParseMainMenuStatusLine3(s string) (MainMenuStatusLine3, error) {
+	v, err := parseCode("ParseMainMenuStatusLine3", mainMenuStatusLine3Codes, s)
+	return MainMenuStatusLine3(v), err
+}
+
+func (v MainMenuStatusLine3) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *MainMenuStatusLine3) UnmarshalText(text []byte) error {
+	p, err := ParseMainMenuStatusLine3(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// SecOperationMode is a code returned by FromHeatPump, see ParseSecOperationMode.
+type SecOperationMode uint32
+
+const (
+	SecOperationModeOff             SecOperationMode = 0  // off
+	SecOperationModeCooling         SecOperationMode = 1  // cooling
+	SecOperationModeHeating         SecOperationMode = 2  // heating
+	SecOperationModeFault           SecOperationMode = 3  // fault
+	SecOperationModeTransition      SecOperationMode = 4  // transition
+	SecOperationModeDefrost         SecOperationMode = 5  // defrost
+	SecOperationModeWaiting         SecOperationMode = 6  // waiting
+	SecOperationModeWaiting2        SecOperationMode = 7  // waiting
+	SecOperationModeTransition2     SecOperationMode = 8  // transition
+	SecOperationModeStop            SecOperationMode = 9  // stop
+	SecOperationModeManual          SecOperationMode = 10 // manual
+	SecOperationModeSimulationStart SecOperationMode = 11 // simulation start
+	SecOperationModeEvuLock         SecOperationMode = 12 // evu lock
+)
+
+var secOperationModeCodes = []string{
+	0:  "off",
+	1:  "cooling",
+	2:  "heating",
+	3:  "fault",
+	4:  "transition",
+	5:  "defrost",
+	6:  "waiting",
+	7:  "waiting",
+	8:  "transition",
+	9:  "stop",
+	10: "manual",
+	11: "simulation start",
+	12: "evu lock",
+}
+
+func (v SecOperationMode) String() string {
+	return codeString(secOperationModeCodes, uint32(v))
+}
+
+// ParseSecOperationMode parses the English code of a SecOperationMode, e.g. off.
+func ParseSecOperationMode(s string) (SecOperationMode, error) {
+	v, err := parseCode("ParseSecOperationMode", secOperationModeCodes, s)
+	return SecOperationMode(v), err
+}
+
+func (v SecOperationMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *SecOperationMode) UnmarshalText(text []byte) error {
+	p, err := ParseSecOperationMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
@@ -0,0 +1,197 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Envelope is the operating range of a heat pump model from the data sheet.
+// Source is the heat source inlet temperature, the air temperature for air
+// source and the brine or well water temperature for ground source units.
+type Envelope struct {
+	MinSource float64 // °C
+	MaxSource float64 // °C
+	MaxFlow   float64 // °C
+}
+
+// Envelopes maps the heat pump codes of ID_WEB_Code_WP_akt to typical data
+// sheet values of the model families. The values are conservative, entries
+// can be replaced or added for a specific installation.
+var Envelopes = newEnvelopes([]envelopeFamily{
+	{
+		Envelope: Envelope{MinSource: -20, MaxSource: 35, MaxFlow: 60}, // air/water
+		codes: []string{
+			"L1I", "L2I", "L1A", "L2A", "L2G", "LWC", "L1S", "L1H", "L2H", "L1I407", "L2I407",
+			"L1A407", "L2A407", "L2G407", "LWC407", "L1AREV", "L2AREV", "L2G404", "LW SEC",
+		},
+	},
+	{
+		Envelope: Envelope{MinSource: -22, MaxSource: 35, MaxFlow: 65}, // air/water split
+		codes: []string{
+			"LD5", "LD7", "LD9", "LD5 (230V)", "LD7 (230 V)", "LD5 REV", "LD7 REV",
+			"LD5 REV 230V", "LD7 REV 230V", "LD9 REV 230V",
+		},
+	},
+	{
+		Envelope: Envelope{MinSource: -5, MaxSource: 25, MaxFlow: 62}, // brine/water
+		codes: []string{
+			"SW1", "SW2", "SWC", "KSW", "SW 37_45", "SW 58_69", "SW 29_56", "SW 291",
+			"MSW 4", "MSW 6", "MSW 8", "MSW 10", "MSW 12", "MSW 14", "MSW 17", "MSW 19", "MSW 23",
+			"MSW 26", "MSW 30", "MSW 4S", "MSW 6S", "MSW 8S", "MSW 10S", "MSW 13S", "MSW 16S",
+			"MSW2-6S", "MSW4-16",
+		},
+	},
+	{
+		Envelope: Envelope{MinSource: 7, MaxSource: 25, MaxFlow: 62}, // water/water
+		codes:    []string{"WW1", "WW2", "WWC1", "WWC2"},
+	},
+})
+
+type envelopeFamily struct {
+	Envelope
+	codes []string
+}
+
+func newEnvelopes(families []envelopeFamily) map[string]Envelope {
+	m := map[string]Envelope{}
+	for _, f := range families {
+		for _, code := range f.codes {
+			m[code] = f.Envelope
+		}
+	}
+	return m
+}
+
+// EnvelopeState is the result of an envelope check.
+type EnvelopeState int
+
+const (
+	EnvelopeInside EnvelopeState = iota
+	EnvelopeNear
+	EnvelopeOutside
+)
+
+func (s EnvelopeState) String() string {
+	return [...]string{"inside", "near", "outside"}[s]
+}
+
+// EnvelopeCheck describes the operating point and its worst state.
+type EnvelopeCheck struct {
+	Model      string
+	SourceTemp float64
+	FlowTemp   float64
+	Envelope   Envelope
+	State      EnvelopeState
+	// Reasons lists the limits which are near or exceeded.
+	Reasons []string
+}
+
+// Check compares the operating point with the envelope. Values within margin
+// Kelvin of a limit are reported as near.
+func (e Envelope) Check(source, flow, margin float64) (EnvelopeState, []string) {
+	state := EnvelopeInside
+	var reasons []string
+	check := func(name string, dist float64) {
+		s := EnvelopeInside
+		switch {
+		case dist < 0:
+			s = EnvelopeOutside
+		case dist <= margin:
+			s = EnvelopeNear
+		default:
+			return
+		}
+		reasons = append(reasons, fmt.Sprintf("%s %s", name, s))
+		if s > state {
+			state = s
+		}
+	}
+	check(fmt.Sprintf("source %.1f °C min %.1f °C", source, e.MinSource), source-e.MinSource)
+	check(fmt.Sprintf("source %.1f °C max %.1f °C", source, e.MaxSource), e.MaxSource-source)
+	check(fmt.Sprintf("flow %.1f °C max %.1f °C", flow, e.MaxFlow), e.MaxFlow-flow)
+	return state, reasons
+}
+
+// CheckEnvelope looks up the model of a read calculations block and checks
+// the current operating point. The second return value is false for unknown
+// models.
+func CheckEnvelope(pm DataTypeMap, margin float64) (EnvelopeCheck, bool) {
+	model := strings.TrimSpace(fmt.Sprint(pm[CalcHeatpumpCode].canonical().FromHeatPump()))
+	env, ok := Envelopes[model]
+	if !ok {
+		return EnvelopeCheck{Model: model}, false
+	}
+	c := EnvelopeCheck{
+		Model:      model,
+		SourceTemp: float64(int32(pm[CalcSourceInTemperature].reading.Raw)) * 0.1,
+		FlowTemp:   float64(int32(pm[CalcFlowTemperature].reading.Raw)) * 0.1,
+		Envelope:   env,
+	}
+	c.State, c.Reasons = env.Check(c.SourceTemp, c.FlowTemp, margin)
+	return c, true
+}
+
+// EnvelopeMonitor is a Sink which warns once the operating point of a heat
+// pump gets near or outside the envelope of its model, and logs when it is
+// back inside. Unknown models are reported once.
+type EnvelopeMonitor struct {
+	logger *zap.Logger
+	margin float64
+
+	mu     sync.Mutex
+	states map[string]EnvelopeState
+}
+
+// NewEnvelopeMonitor creates the monitor, margin defaults to 2 K.
+func NewEnvelopeMonitor(logger *zap.Logger, margin float64) *EnvelopeMonitor {
+	if margin <= 0 {
+		margin = 2
+	}
+	return &EnvelopeMonitor{logger: logger, margin: margin, states: map[string]EnvelopeState{}}
+}
+
+func (m *EnvelopeMonitor) Write(_ context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	if block != BlockCalculations {
+		return nil
+	}
+	c, ok := CheckEnvelope(pm, m.margin)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prev, seen := m.states[host]
+	if !ok {
+		if !seen {
+			m.logger.Info("no operating envelope known for heat pump model",
+				zap.String("host", host), zap.String("model", c.Model))
+		}
+		m.states[host] = EnvelopeInside
+		return nil
+	}
+	m.states[host] = c.State
+	if c.State == prev {
+		return nil
+	}
+	fields := []zap.Field{
+		zap.String("host", host),
+		zap.String("model", c.Model),
+		zap.Float64("source_temp", c.SourceTemp),
+		zap.Float64("flow_temp", c.FlowTemp),
+		zap.Strings("reasons", c.Reasons),
+	}
+	switch c.State {
+	case EnvelopeOutside:
+		m.logger.Warn("heat pump operates outside its envelope", fields...)
+	case EnvelopeNear:
+		m.logger.Warn("heat pump operates near its envelope limits", fields...)
+	default:
+		m.logger.Info("heat pump operates inside its envelope again", fields...)
+	}
+	return nil
+}
+
+func (m *EnvelopeMonitor) Close() error { return nil }
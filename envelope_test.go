@@ -0,0 +1,63 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCheckEnvelope(t *testing.T) {
+	pm := NewCalculationsMap()
+	pm[CalcHeatpumpCode].reading.Raw = 42                        // LD7
+	pm[CalcSourceInTemperature].reading.Raw = uint32(0xFFFFFF2E) // -21.0 °C
+	pm[CalcFlowTemperature].reading.Raw = 500
+
+	c, ok := CheckEnvelope(pm, 2)
+	require.True(t, ok)
+	assert.Equal(t, "LD7", c.Model)
+	assert.Equal(t, EnvelopeNear, c.State)
+	assert.Equal(t, []string{"source -21.0 °C min -22.0 °C near"}, c.Reasons)
+
+	pm[CalcFlowTemperature].reading.Raw = 660
+	c, _ = CheckEnvelope(pm, 2)
+	assert.Equal(t, EnvelopeOutside, c.State)
+	assert.Len(t, c.Reasons, 2)
+
+	pm[CalcHeatpumpCode].reading.Raw = 0 // ERC
+	_, ok = CheckEnvelope(pm, 2)
+	assert.False(t, ok)
+}
+
+func TestEnvelopeMonitor(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	m := NewEnvelopeMonitor(zap.New(core), 0)
+	ctx := context.Background()
+
+	pm := NewCalculationsMap()
+	pm[CalcHeatpumpCode].reading.Raw = 1 // SW1
+	pm[CalcSourceInTemperature].reading.Raw = 50
+	pm[CalcFlowTemperature].reading.Raw = 350
+
+	write := func(flow uint32) {
+		pm[CalcFlowTemperature].reading.Raw = flow
+		require.NoError(t, m.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+	}
+	write(350)
+	write(630)
+	write(640)
+	write(350)
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{
+		"heat pump operates outside its envelope",
+		"heat pump operates inside its envelope again",
+	}, msgs)
+}
@@ -0,0 +1,133 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrorEntry is an error of the error memory of the controller.
+type ErrorEntry struct {
+	Time    time.Time
+	Code    uint32
+	Message string
+}
+
+// ErrorCodes are the texts of the error codes from the manuals of the
+// controller.
+var ErrorCodes = map[uint32]string{
+	701: "low pressure fault",
+	702: "low pressure lock",
+	703: "frost protection",
+	704: "hot gas fault",
+	705: "motor protection fan",
+	706: "motor protection brine or well pump",
+	707: "heat pump coding",
+	708: "return sensor",
+	709: "flow sensor",
+	710: "hot gas sensor",
+	711: "outdoor temperature sensor",
+	712: "hot water sensor",
+	713: "heat source inlet sensor",
+	714: "hot gas hot water",
+	715: "high pressure switch-off",
+	716: "high pressure fault",
+	717: "heat source flow rate",
+	718: "maximum outdoor temperature",
+	719: "minimum outdoor temperature",
+	720: "heat source temperature",
+	721: "low pressure switch-off",
+	722: "temperature difference heating water",
+	723: "temperature difference hot water",
+	724: "temperature difference defrost",
+	725: "system fault hot water",
+	726: "mixing circuit 1 sensor",
+	727: "brine pressure",
+	728: "heat source outlet sensor",
+	729: "phase sequence error",
+	730: "screed heating power",
+	732: "cooling fault",
+	733: "anode fault",
+	734: "anode fault",
+	735: "external energy sensor",
+	736: "solar collector sensor",
+	737: "solar tank sensor",
+	738: "mixing circuit 2 sensor",
+	750: "external return sensor",
+	751: "phase monitoring fault",
+	752: "phase monitoring or flow rate fault",
+	755: "connection to slave lost",
+	756: "connection to master lost",
+}
+
+// ErrorMessage returns the text of an error code.
+func ErrorMessage(code uint32) string {
+	if msg, ok := ErrorCodes[code]; ok {
+		return msg
+	}
+	return fmt.Sprintf("unknown error %d", code)
+}
+
+// ErrorCode is the value of the error slots of the calculations, 0 for an
+// empty slot. It prints with its message, e.g. 701: low pressure fault.
+type ErrorCode uint32
+
+// Message returns the text of the code, empty for 0.
+func (c ErrorCode) Message() string {
+	if c == 0 {
+		return ""
+	}
+	if msg, ok := ErrorCodes[uint32(c)]; ok {
+		return msg
+	}
+	return "unknown error"
+}
+
+func (c ErrorCode) String() string {
+	if c == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d: %s", uint32(c), c.Message())
+}
+
+func (c ErrorCode) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// ErrorMemory decodes the error memory from the calculations, the latest
+// error first. The controller keeps the last five errors, empty slots are
+// left out.
+func ErrorMemory(calculations DataTypeMap) []ErrorEntry {
+	var entries []ErrorEntry
+	for i := 0; i < 5; i++ {
+		ts, okTime := calculations[CalcERRORTime0+i]
+		code, okCode := calculations[CalcERRORNr0+i]
+		if !okTime || !okCode || ts.reading.Raw == 0 {
+			continue
+		}
+		entries = append(entries, ErrorEntry{
+			Time:    time.Unix(int64(ts.reading.Raw), 0),
+			Code:    code.reading.Raw,
+			Message: ErrorMessage(code.reading.Raw),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.After(entries[j].Time) })
+	return entries
+}
+
+// ReadErrorMemory reads the calculations and returns the error memory, see
+// ErrorMemory.
+func (c *Client) ReadErrorMemory() ([]ErrorEntry, error) {
+	return c.ReadErrorMemoryContext(context.Background())
+}
+
+// ReadErrorMemoryContext is ReadErrorMemory with a context, see
+// ReadParametersContext.
+func (c *Client) ReadErrorMemoryContext(ctx context.Context) ([]ErrorEntry, error) {
+	pm := NewCalculationsMap()
+	if err := c.ReadCalculationsContext(ctx, pm); err != nil {
+		return nil, fmt.Errorf("ReadErrorMemory: %w", err)
+	}
+	return ErrorMemory(pm), nil
+}
@@ -0,0 +1,39 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadErrorMemory(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[CalcERRORTime0] = 1700000000
+	hp.calculations[CalcERRORNr0] = 701
+	hp.calculations[CalcERRORTime1] = 1710000000
+	hp.calculations[CalcERRORNr1] = 799
+
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+	require.NoError(t, c.Connect())
+	entries, err := c.ReadErrorMemory()
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorEntry{
+		{Time: time.Unix(1710000000, 0), Code: 799, Message: "unknown error 799"},
+		{Time: time.Unix(1700000000, 0), Code: 701, Message: "low pressure fault"},
+	}, entries)
+}
+
+func TestErrorCode(t *testing.T) {
+	b := NewCalculationsMap()[CalcERRORNr0]
+	b.SetRaw(701)
+	code, ok := b.FromHeatPump().(ErrorCode)
+	require.True(t, ok)
+	assert.Equal(t, "low pressure fault", code.Message())
+	assert.Equal(t, "701: low pressure fault", FormatValue(code))
+	assert.Equal(t, "799: unknown error", ErrorCode(799).String())
+	assert.Equal(t, "0", ErrorCode(0).String())
+	assert.Empty(t, ErrorCode(0).Message())
+}
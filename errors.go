@@ -0,0 +1,60 @@
+package luxtronik
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Sentinel errors which are wrapped by ProtocolError or returned by the
+// conversions. Use errors.Is to check for them.
+var (
+	ErrInvalidCommand = errors.New("invalid command")
+	ErrInvalidIndex   = errors.New("invalid index")
+	ErrLengthMismatch = errors.New("length mismatch")
+	ErrUnknownIndex   = errors.New("unknown index")
+	ErrInvalidValue   = errors.New("invalid value")
+	ErrNotConnected   = errors.New("not connected")
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInsufficientAccess rejects writes which need a higher access
+	// level, see Client.Unlock.
+	ErrInsufficientAccess = errors.New("insufficient access level")
+)
+
+// ProtocolError reports that the controller answered, but not as the
+// protocol expects. Reconnecting usually does not help.
+type ProtocolError struct {
+	Cmd int32
+	// Index of the value, -1 if the error concerns the whole frame.
+	Index int
+	Err   error
+}
+
+func (e *ProtocolError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("luxtronik protocol error in command %d: %s", e.Cmd, e.Err)
+	}
+	return fmt.Sprintf("luxtronik protocol error in command %d at index %d: %s", e.Cmd, e.Index, e.Err)
+}
+
+func (e *ProtocolError) Unwrap() error { return e.Err }
+
+// ConnectionError reports a failure of the TCP connection. The next attempt
+// may succeed after reconnecting.
+type ConnectionError struct {
+	Op   string // dial, read or write
+	Addr string
+	Err  error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("luxtronik connection error during %s to %s: %s", e.Op, e.Addr, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error { return e.Err }
+
+// Timeout reports whether the underlying network error is a timeout.
+func (e *ConnectionError) Timeout() bool {
+	var nErr net.Error
+	return errors.As(e.Err, &nErr) && nErr.Timeout()
+}
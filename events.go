@@ -0,0 +1,239 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Formats of the payloads of an EventSink.
+const (
+	// EventFormatJSON encodes a ChangeEvent or a Notification as JSON.
+	EventFormatJSON = "json"
+	// EventFormatLine encodes an event as a line of the InfluxDB line
+	// protocol, see InfluxSink.
+	EventFormatLine = "line"
+	// EventFormatCloudEvents wraps the JSON of EventFormatJSON into a
+	// CloudEvent.
+	EventFormatCloudEvents = "cloudevents"
+)
+
+// EventChange is the kind of a ChangeEvent, alerts and errors use the kinds
+// of their Notification.
+const EventChange = "change"
+
+// ChangeEvent is a changed value as published by an EventSink. Value is in
+// metric units and English codes.
+type ChangeEvent struct {
+	Host  string    `json:"host"`
+	Block string    `json:"block"`
+	Index int       `json:"index"`
+	Name  string    `json:"name"`
+	Time  time.Time `json:"time"`
+	Raw   uint32    `json:"raw"`
+	Prev  uint32    `json:"prev"`
+	Value any       `json:"value"`
+	Unit  string    `json:"unit,omitempty"`
+}
+
+// BusMessage is an event for an EventPublisher. Kind, Host and Name, empty
+// for alerts and errors, make up the subject or the key.
+type BusMessage struct {
+	Kind    string
+	Host    string
+	Name    string
+	Payload []byte
+}
+
+// EventPublisher delivers messages to a message bus, see NewEventPublisher.
+type EventPublisher interface {
+	Publish(ctx context.Context, msgs []BusMessage) error
+	Close() error
+}
+
+// NewEventPublisher returns the publisher of the URL: nats:// or tls://
+// for a NATS server, see NATSPublisher, http:// or https:// for the REST
+// Proxy of Kafka, see KafkaRESTPublisher. Prefix starts the subjects and
+// topics, e.g. luxtronik.
+func NewEventPublisher(rawURL, prefix string, logger *zap.Logger) (EventPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("NewEventPublisher: %w", err)
+	}
+	switch u.Scheme {
+	case "nats", "tls":
+		return NewNATSPublisher(NATSOptions{URL: rawURL, Prefix: prefix, Logger: logger})
+	case "http", "https":
+		return NewKafkaRESTPublisher(KafkaRESTOptions{URL: rawURL, Prefix: prefix})
+	}
+	return nil, fmt.Errorf("NewEventPublisher unsupported scheme %q, want nats, tls, http or https", u.Scheme)
+}
+
+type EventOptions struct {
+	Publisher EventPublisher
+	// Format of the payloads, defaults to EventFormatJSON.
+	Format string
+	// Deadbands hide small changes, see ChangeDetector.
+	Deadbands Deadbands
+	// Spool buffers the messages on disk while the bus is unreachable and
+	// publishes them in order once it is back.
+	Spool *Spool
+}
+
+// EventSink publishes every changed value as ChangeEvent, the first poll of
+// each block is skipped like by the DiffLogger. As Notifier of a Dispatcher
+// it publishes alerts and new entries of the error memory.
+type EventSink struct {
+	opts    EventOptions
+	changes *ChangeDetector
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func NewEventSink(opts EventOptions) (*EventSink, error) {
+	if opts.Publisher == nil {
+		return nil, errors.New("NewEventSink needs a publisher")
+	}
+	switch opts.Format {
+	case "":
+		opts.Format = EventFormatJSON
+	case EventFormatJSON, EventFormatLine, EventFormatCloudEvents:
+	default:
+		return nil, fmt.Errorf("NewEventSink unknown format %q, want %s, %s or %s", opts.Format, EventFormatJSON, EventFormatLine, EventFormatCloudEvents)
+	}
+	return &EventSink{
+		opts:    opts,
+		changes: NewChangeDetector(opts.Deadbands),
+		seen:    map[string]bool{},
+	}, nil
+}
+
+func (s *EventSink) Write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	key := host + "/" + block
+	s.mu.Lock()
+	first := !s.seen[key]
+	s.seen[key] = true
+	s.mu.Unlock()
+
+	var msgs []BusMessage
+	var errs []error
+	pm.IterateSorted(func(idx int, b *Base) {
+		prev, ok := s.changes.Changed(host, block, idx, b)
+		if !ok || first {
+			return
+		}
+		payload, err := s.changeEvent(host, ts, block, idx, prev, b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("EventSink.Write %s: %w", b.luxtronikName, err))
+			return
+		}
+		msgs = append(msgs, BusMessage{Kind: EventChange, Host: host, Name: b.luxtronikName, Payload: payload})
+	})
+	if len(msgs) > 0 {
+		if err := s.publish(ctx, msgs); err != nil {
+			errs = append(errs, fmt.Errorf("EventSink.Write: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *EventSink) changeEvent(host string, ts time.Time, block string, idx int, prev uint32, b *Base) ([]byte, error) {
+	if s.opts.Format == EventFormatLine {
+		var buf bytes.Buffer
+		appendInfluxLine(&buf, host, block, ts, b)
+		return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+	}
+	e := ChangeEvent{
+		Host:  host,
+		Block: block,
+		Index: idx,
+		Name:  b.luxtronikName,
+		Time:  ts,
+		Raw:   b.reading.Raw,
+		Prev:  prev,
+		Value: DumpValue(b.canonical().FromHeatPump()),
+		Unit:  b.canonical().Unit(),
+	}
+	if s.opts.Format == EventFormatCloudEvents {
+		return json.Marshal(ChangeCloudEvent(e))
+	}
+	return json.Marshal(e)
+}
+
+// Notify publishes an alert or an error of the error memory.
+func (s *EventSink) Notify(ctx context.Context, n Notification) error {
+	var payload []byte
+	var err error
+	switch s.opts.Format {
+	case EventFormatLine:
+		payload = notificationLine(n)
+	case EventFormatCloudEvents:
+		payload, err = json.Marshal(NotificationCloudEvent(n))
+	default:
+		payload, err = json.Marshal(n)
+	}
+	if err != nil {
+		return fmt.Errorf("EventSink.Notify: %w", err)
+	}
+	return s.publish(ctx, []BusMessage{{Kind: n.Kind, Host: n.Host, Payload: payload}})
+}
+
+// publish sends msgs through the Spool, if any, as one record.
+func (s *EventSink) publish(ctx context.Context, msgs []BusMessage) error {
+	if s.opts.Spool == nil {
+		return s.opts.Publisher.Publish(ctx, msgs)
+	}
+	record, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	return s.opts.Spool.Deliver(record, func(record []byte) error {
+		var msgs []BusMessage
+		if err := json.Unmarshal(record, &msgs); err != nil {
+			// a corrupt record would block the spool
+			return nil
+		}
+		return s.opts.Publisher.Publish(ctx, msgs)
+	})
+}
+
+// Close closes the publisher.
+func (s *EventSink) Close() error {
+	return s.opts.Publisher.Close()
+}
+
+// notificationLine encodes n in the line protocol with kind as measurement.
+func notificationLine(n Notification) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(influxMeasurementEscaper.Replace(n.Kind))
+	if n.Host != "" {
+		buf.WriteString(",host=")
+		buf.WriteString(influxTagEscaper.Replace(n.Host))
+	}
+	buf.WriteString(` message="`)
+	buf.WriteString(influxStringEscaper.Replace(n.Message))
+	buf.WriteByte('"')
+	switch n.Kind {
+	case NotificationAlert:
+		buf.WriteString(",value=")
+		buf.WriteString(strconv.FormatFloat(n.Value, 'f', -1, 64))
+		buf.WriteString(",resolved=")
+		buf.WriteString(strconv.FormatBool(n.Resolved))
+	case NotificationError:
+		buf.WriteString(",code=")
+		buf.WriteString(strconv.FormatUint(uint64(n.Code), 10))
+		buf.WriteByte('i')
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.FormatInt(n.Time.Unix(), 10))
+	return buf.Bytes()
+}
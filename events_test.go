@@ -0,0 +1,146 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	msgs   []BusMessage
+	closed bool
+	down   bool
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, msgs []BusMessage) error {
+	if p.down {
+		return errors.New("bus down")
+	}
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestEventSink_Spool(t *testing.T) {
+	pub := &recordingPublisher{down: true}
+	spool, err := OpenSpool(SpoolOptions{Dir: t.TempDir()})
+	require.NoError(t, err)
+	s, err := NewEventSink(EventOptions{Publisher: pub, Spool: spool})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationAlert, Host: "cellar", Time: ts, Rule: "cold"}))
+	assert.Equal(t, 1, spool.Len())
+
+	pub.down = false
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationAlert, Host: "cellar", Time: ts, Rule: "hot"}))
+	require.Len(t, pub.msgs, 2)
+	assert.Contains(t, string(pub.msgs[0].Payload), `"cold"`, "spooled messages come first")
+	assert.Equal(t, "cellar", pub.msgs[0].Host)
+	assert.Contains(t, string(pub.msgs[1].Payload), `"hot"`)
+	assert.Zero(t, spool.Len())
+}
+
+func TestEventSink(t *testing.T) {
+	pub := &recordingPublisher{}
+	s, err := NewEventSink(EventOptions{Publisher: pub})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	pm := NewCalculationsMap()
+	pm[CalcOutdoorTemperature].reading.Raw = 53
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	assert.Empty(t, pub.msgs, "the first poll is skipped")
+
+	pm[CalcOutdoorTemperature].reading.Raw = 48
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.Len(t, pub.msgs, 1)
+	m := pub.msgs[0]
+	assert.Equal(t, EventChange, m.Kind)
+	assert.Equal(t, "cellar", m.Host)
+	assert.Equal(t, "ID_WEB_Temperatur_TA", m.Name)
+	var e ChangeEvent
+	require.NoError(t, json.Unmarshal(m.Payload, &e))
+	assert.Equal(t, ChangeEvent{
+		Host:  "cellar",
+		Block: BlockCalculations,
+		Index: CalcOutdoorTemperature,
+		Name:  "ID_WEB_Temperatur_TA",
+		Time:  ts,
+		Raw:   48,
+		Prev:  53,
+		Value: 4.8,
+		Unit:  "°C",
+	}, e)
+
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationAlert, Host: "cellar", Time: ts, Message: "cold", Value: 4.8}))
+	require.Len(t, pub.msgs, 2)
+	assert.Equal(t, NotificationAlert, pub.msgs[1].Kind)
+	assert.JSONEq(t, `{"kind":"alert","host":"cellar","time":"2023-11-14T22:13:20Z","message":"cold","value":4.8}`, string(pub.msgs[1].Payload))
+
+	require.NoError(t, s.Close())
+	assert.True(t, pub.closed)
+}
+
+func TestEventSink_ZeroTime(t *testing.T) {
+	pub := &recordingPublisher{}
+	s, err := NewEventSink(EventOptions{Publisher: pub})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	pm := DataTypeMap{ParamHolidayEndHeating: NewParameterMap()[ParamHolidayEndHeating]}
+	pm[ParamHolidayEndHeating].SetRaw(1700000000)
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockParameters, pm))
+	pm[ParamHolidayEndHeating].SetRaw(0)
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockParameters, pm))
+	require.Len(t, pub.msgs, 1)
+	var e ChangeEvent
+	require.NoError(t, json.Unmarshal(pub.msgs[0].Payload, &e))
+	assert.Nil(t, e.Value, "no holiday instead of 0001-01-01T00:00:00Z")
+}
+
+func TestEventSink_Line(t *testing.T) {
+	pub := &recordingPublisher{}
+	s, err := NewEventSink(EventOptions{Publisher: pub, Format: EventFormatLine})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0)
+	pm := NewCalculationsMap()
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	pm[CalcOutdoorTemperature].reading.Raw = 48
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.Len(t, pub.msgs, 1)
+	assert.Equal(t, "calculations,host=cellar,class=temperature,name=ID_WEB_Temperatur_TA raw=48i,value=4.8 1700000000", string(pub.msgs[0].Payload))
+
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationError, Host: "cellar", Time: ts, Message: `low "pressure"`, Code: 701}))
+	assert.Equal(t, `error,host=cellar message="low \"pressure\"",code=701i 1700000000`, string(pub.msgs[1].Payload))
+
+	_, err = NewEventSink(EventOptions{Publisher: pub, Format: "avro"})
+	assert.Error(t, err)
+	_, err = NewEventSink(EventOptions{})
+	assert.Error(t, err)
+}
+
+func TestNewEventPublisher(t *testing.T) {
+	p, err := NewEventPublisher("nats://localhost", "", nil)
+	require.NoError(t, err)
+	assert.IsType(t, &NATSPublisher{}, p)
+	p, err = NewEventPublisher("https://kafka-rest:8082", "", nil)
+	require.NoError(t, err)
+	assert.IsType(t, &KafkaRESTPublisher{}, p)
+	_, err = NewEventPublisher("kafka://broker:9092", "", nil)
+	assert.Error(t, err)
+}
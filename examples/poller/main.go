@@ -0,0 +1,47 @@
+// Command poller prints the flow, return and outdoor temperature of a heat
+// pump every interval, e.g.
+//
+//	go run ./examples/poller -addr 192.168.0.121:8889
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+)
+
+func main() {
+	addr := flag.String("addr", "", "host:port of the heat pump")
+	interval := flag.Duration("interval", 30*time.Second, "poll interval")
+	flag.Parse()
+
+	c, err := luxtronik.NewClient(*addr, luxtronik.WithTimeout(10*time.Second), luxtronik.WithReconnect(3, time.Second))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	print := luxtronik.SinkFunc(func(_ context.Context, host string, ts time.Time, _ string, pm luxtronik.DataTypeMap) error {
+		fmt.Printf("%s %s flow %v return %v outdoor %v\n", ts.Format(time.DateTime), host,
+			pm[luxtronik.CalcFlowTemperature].FromHeatPump(),
+			pm[luxtronik.CalcReturnTemperature].FromHeatPump(),
+			pm[luxtronik.CalcOutdoorTemperature].FromHeatPump())
+		return nil
+	})
+	p := luxtronik.NewPoller(c, luxtronik.PollerOptions{
+		Interval: *interval,
+		Blocks:   []string{luxtronik.BlockCalculations},
+	}, print)
+	defer p.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := p.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Command verifywrite sets the hot water target temperature and reads it back
+// to verify that the controller accepted the value, e.g.
+//
+//	go run ./examples/verifywrite -addr 192.168.0.121:8889 -target 48.5
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+)
+
+const name = "ID_Einst_BWS_akt"
+
+func main() {
+	addr := flag.String("addr", "", "host:port of the heat pump")
+	target := flag.Float64("target", 0, "hot water target temperature in °C")
+	flag.Parse()
+
+	c, err := luxtronik.NewClient(*addr, luxtronik.Options{SafeMode: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	d := luxtronik.NewDevice(c)
+	defer d.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := d.Refresh(ctx); err != nil {
+		log.Fatal(err)
+	}
+	before, err := d.Get(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := d.Set(ctx, name, *target); err != nil {
+		log.Fatal(err)
+	}
+	sent, err := d.Get(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the controller may clamp or reject the value, so read it back
+	if err := d.Refresh(ctx); err != nil {
+		log.Fatal(err)
+	}
+	after, err := d.Get(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s: %v → %v %s\n", name, before.Value, after.Value, after.Unit)
+	if after.Raw != sent.Raw {
+		log.Fatalf("the controller keeps %v %s instead of %v", after.Value, after.Unit, sent.Value)
+	}
+}
@@ -0,0 +1,202 @@
+package luxtronik
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FirmwareMaps are the map definitions of a range of firmware versions,
+// because the meaning of an index can change between firmwares.
+type FirmwareMaps struct {
+	// Versions is the range of firmwares, e.g. "V2.88" for all V2.88 builds,
+	// "V3.x" or "V3.80-V3.89". Bounds are compared by their components, so
+	// "V3.8" covers V3.8.0 up to V3.8.9 but not V3.80. Empty matches all
+	// firmwares.
+	Versions        string
+	NewParameters   func() DataTypeMap
+	NewCalculations func() DataTypeMap
+	NewVisibilities func() DataTypeMap
+	// Info locates the values of DeviceInfo, nil means DefaultInfoLayout.
+	Info *InfoLayout
+	// Factors override the factors of values by block and index, e.g. for
+	// pressures sent in hundredths instead of tenths, see SetFactors.
+	Factors map[string]map[int]float32
+}
+
+// FirmwareCatalog lists the map definitions which differ from the catalog
+// of this package. SelectMaps uses the first matching entry, register more
+// specific ranges before wider ones. Missing constructors fall back to
+// NewParameterMap, NewCalculationsMap and NewVisibilitiesMap, a constructor
+// usually merges its changes into those, see DataTypeMap.Merge.
+//
+// The catalog of this package describes V3.x. The V2.x firmwares, e.g.
+// V2.88, send shorter frames, the values added later are missing.
+var FirmwareCatalog = []FirmwareMaps{
+	{
+		Versions:        "V2.x",
+		NewParameters:   func() DataTypeMap { return NewParameterMap().firstValues(v2Parameters) },
+		NewCalculations: func() DataTypeMap { return NewCalculationsMap().firstValues(v2Calculations) },
+		NewVisibilities: func() DataTypeMap { return NewVisibilitiesMap().firstValues(v2Visibilities) },
+		// the pressures are sent in tenths of a bar, the voltages in
+		// hundredths of a volt
+		Factors: map[string]map[int]float32{
+			BlockCalculations: {
+				CalcLINHD: 0.1, CalcLINND: 0.1, CalcSECEVIDruck: 0.1, CalcLINHD2: 0.1, CalcLINND2: 0.1,
+				CalcAnalogIn: 0.01, CalcAnalogIn2: 0.01, CalcAnalogIn3: 0.01,
+				CalcAnalogOut1: 0.01, CalcAnalogOut2: 0.01, CalcAnalogOut3: 0.01, CalcAnalogOut4: 0.01,
+				CalcOutVZU: 0.01, CalcOutVAB: 0.01, CalcSECUInv: 0.01,
+			},
+		},
+	},
+	{Versions: "V3.x"},
+}
+
+// Number of values of the V2.x firmwares.
+const (
+	v2Parameters   = 1056
+	v2Calculations = 248
+	v2Visibilities = 370
+)
+
+// SelectMaps returns the maps of all three blocks for a firmware version as
+// returned by Client.Firmware, keyed by block. The firmware is sent with the
+// calculations, so read them with NewCalculationsMap first.
+func SelectMaps(version string) (map[string]DataTypeMap, error) {
+	fm := FirmwareMaps{}
+	for _, e := range FirmwareCatalog {
+		ok, err := e.Match(version)
+		if err != nil {
+			return nil, fmt.Errorf("SelectMaps %q: %w", version, err)
+		}
+		if ok {
+			fm = e
+			break
+		}
+	}
+	maps := map[string]DataTypeMap{
+		BlockParameters:   newMapOr(fm.NewParameters, NewParameterMap),
+		BlockCalculations: newMapOr(fm.NewCalculations, NewCalculationsMap),
+		BlockVisibilities: newMapOr(fm.NewVisibilities, NewVisibilitiesMap),
+	}
+	for block, factors := range fm.Factors {
+		pm, ok := maps[block]
+		if !ok {
+			return nil, fmt.Errorf("SelectMaps %q factors of unknown block %q: %w", version, block, ErrInvalidValue)
+		}
+		if err := pm.SetFactors(factors); err != nil {
+			return nil, fmt.Errorf("SelectMaps %q %s: %w", version, block, err)
+		}
+	}
+	return maps, nil
+}
+
+// SetFactors overrides the factors of the values at the indexes, e.g. 0.01
+// for a pressure which a firmware sends in hundredths. A factor of 0 turns
+// the scaling off. The entries get their own copy of the definition, the
+// catalog stays unchanged.
+func (pm DataTypeMap) SetFactors(factors map[int]float32) error {
+	for idx, f := range factors {
+		b, ok := pm[idx]
+		if !ok {
+			return fmt.Errorf("DataTypeMap.SetFactors index %d: %w", idx, ErrUnknownIndex)
+		}
+		d := *b.Definition
+		d.factor = f
+		b.Definition = &d
+	}
+	return nil
+}
+
+// firstValues drops the values from index n on, e.g. for firmwares which
+// send shorter frames than the catalog.
+func (pm DataTypeMap) firstValues(n int) DataTypeMap {
+	for idx := range pm {
+		if idx >= n {
+			delete(pm, idx)
+		}
+	}
+	return pm
+}
+
+func newMapOr(fn, fallback func() DataTypeMap) DataTypeMap {
+	if fn == nil {
+		return fallback()
+	}
+	return fn()
+}
+
+// Match reports whether the firmware version lies within Versions.
+func (fm FirmwareMaps) Match(version string) (bool, error) {
+	if fm.Versions == "" {
+		return true, nil
+	}
+	lo, hi, ok := strings.Cut(fm.Versions, "-")
+	if !ok {
+		hi = lo
+	}
+	from, err := parseFirmwareBound(lo)
+	if err != nil {
+		return false, err
+	}
+	to, err := parseFirmwareBound(hi)
+	if err != nil {
+		return false, err
+	}
+	v := parseFirmwareVersion(version)
+	return compareFirmware(v, from) >= 0 && compareFirmware(v, to) <= 0, nil
+}
+
+// parseFirmwareVersion returns the numeric components of a version like
+// "V3.89.1", text after the digits of a component is ignored.
+func parseFirmwareVersion(s string) []int {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "V")
+	var v []int
+	for _, part := range strings.Split(s, ".") {
+		n := 0
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				break
+			}
+			n = n*10 + int(r-'0')
+		}
+		v = append(v, n)
+	}
+	return v
+}
+
+// parseFirmwareBound parses a bound of FirmwareMaps.Versions, an "x"
+// component ends the bound.
+func parseFirmwareBound(s string) ([]int, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "V")
+	var v []int
+	for _, part := range strings.Split(s, ".") {
+		if part == "x" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid firmware range bound %q", s)
+		}
+		v = append(v, n)
+	}
+	return v, nil
+}
+
+// compareFirmware compares the version with the components of the bound
+// only, so that every version starting with the bound compares equal.
+func compareFirmware(version, bound []int) int {
+	for i, b := range bound {
+		var n int
+		if i < len(version) {
+			n = version[i]
+		}
+		switch {
+		case n < b:
+			return -1
+		case n > b:
+			return 1
+		}
+	}
+	return 0
+}
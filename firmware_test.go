@@ -0,0 +1,131 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirmwareMaps_Match(t *testing.T) {
+	tests := []struct {
+		versions string
+		version  string
+		want     bool
+	}{
+		{"", "V1.2", true},
+		{"V2.88", "V2.88", true},
+		{"V2.88", "V2.88.1", true},
+		{"V2.88", "V2.89", false},
+		{"V3.x", "V3.89.1", true},
+		{"V3.x", "V2.88", false},
+		{"V3.80-V3.89", "V3.89.4-Beta", true},
+		{"V3.80-V3.89", "V3.90", false},
+		{"V3.80-V3.89", "V3.8", false},
+		{"V3.8", "V3.80", false},
+	}
+	for _, tt := range tests {
+		got, err := FirmwareMaps{Versions: tt.versions}.Match(tt.version)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s in %s", tt.version, tt.versions)
+	}
+
+	_, err := FirmwareMaps{Versions: "V3.a"}.Match("V3.1")
+	assert.Error(t, err)
+}
+
+func TestSelectMaps(t *testing.T) {
+	defer func(catalog []FirmwareMaps) { FirmwareCatalog = catalog }(FirmwareCatalog)
+	FirmwareCatalog = []FirmwareMaps{
+		{Versions: "V2.88", NewCalculations: func() DataTypeMap {
+			return DataTypeMap{10: NewCelsius("ID_WEB_Temperatur_TVL_alt", false)}
+		}},
+		{Versions: "V2.x", NewCalculations: func() DataTypeMap { return DataTypeMap{} }},
+	}
+
+	maps, err := SelectMaps("V2.88.2")
+	require.NoError(t, err)
+	assert.Equal(t, "ID_WEB_Temperatur_TVL_alt", maps[BlockCalculations][10].Name())
+	assert.Len(t, maps[BlockParameters], len(NewParameterMap()))
+
+	maps, err = SelectMaps("V2.70")
+	require.NoError(t, err)
+	assert.Empty(t, maps[BlockCalculations])
+
+	maps, err = SelectMaps("V3.89")
+	require.NoError(t, err)
+	assert.Len(t, maps[BlockCalculations], len(NewCalculationsMap()))
+	assert.Len(t, maps, 3)
+}
+
+func TestSelectMaps_Factors(t *testing.T) {
+	defer func(catalog []FirmwareMaps) { FirmwareCatalog = catalog }(FirmwareCatalog)
+	FirmwareCatalog = []FirmwareMaps{
+		{Versions: "V3.90-V3.x", Factors: map[string]map[int]float32{
+			BlockCalculations: {CalcLINND: 0.1},
+		}},
+		{Versions: "V2.x", Factors: map[string]map[int]float32{
+			BlockCalculations: {9999: 0.01},
+		}},
+	}
+
+	maps, err := SelectMaps("V3.92.1")
+	require.NoError(t, err)
+	pressure := maps[BlockCalculations][CalcLINND]
+	pressure.SetRaw(1234)
+	assert.Equal(t, float32(0.1), pressure.Factor())
+	assert.Equal(t, float32(123.4), pressure.FromHeatPump())
+	assert.Equal(t, float32(0.01), NewCalculationsMap()[CalcLINND].Factor(), "catalog unchanged")
+
+	_, err = SelectMaps("V2.88")
+	assert.ErrorIs(t, err, ErrUnknownIndex)
+}
+
+func TestFirmwareCatalog(t *testing.T) {
+	tests := []struct {
+		version                 string
+		params, calcs, visibles int
+	}{
+		{"V2.88", 1056, 248, 370},
+		{"V3.89.2", len(NewParameterMap()), len(NewCalculationsMap()), len(NewVisibilitiesMap())},
+		{"V4.01", len(NewParameterMap()), len(NewCalculationsMap()), len(NewVisibilitiesMap())},
+	}
+	for _, tt := range tests {
+		maps, err := SelectMaps(tt.version)
+		require.NoError(t, err)
+		assert.Len(t, maps[BlockParameters], tt.params, tt.version)
+		assert.Len(t, maps[BlockCalculations], tt.calcs, tt.version)
+		assert.Len(t, maps[BlockVisibilities], tt.visibles, tt.version)
+	}
+}
+
+func TestPoller_SelectMaps(t *testing.T) {
+	c := MustNewClient("127.0.0.1:8889", Options{TolerantFrames: true})
+	c.conn = serveFrames(t, fixtureFrames(t, "synthetic-v2.88"))
+	defer c.Close()
+
+	p := NewPoller(c, PollerOptions{})
+	assert.Len(t, p.Map(BlockCalculations), len(NewCalculationsMap()), "catalog until the firmware is known")
+	require.NoError(t, p.Poll(context.Background()))
+	assert.Equal(t, "V2.88", c.Firmware())
+	assert.Len(t, p.Map(BlockParameters), 1056)
+	assert.Len(t, p.Map(BlockCalculations), 248)
+	assert.Len(t, p.Map(BlockVisibilities), 370)
+	_, b, ok := p.Map(BlockCalculations).Lookup("ID_WEB_Temperatur_TA")
+	require.True(t, ok)
+	assert.Equal(t, float32(-12), b.FromHeatPump(), "calculations read again into the selected map")
+}
+
+func TestDevice_SelectMaps(t *testing.T) {
+	c := MustNewClient("127.0.0.1:8889", Options{})
+	c.conn = serveFrames(t, fixtureFrames(t, "synthetic-v2.88"))
+	defer c.Close()
+
+	d := NewDevice(c)
+	require.NoError(t, d.Refresh(context.Background()), "strict frames fit the selected maps")
+	assert.Len(t, d.Values(BlockParameters), 1056)
+	v, err := d.Get("ID_WEB_Temperatur_TA")
+	require.NoError(t, err)
+	assert.Equal(t, float32(-12), v.Value)
+}
@@ -0,0 +1,213 @@
+package luxtronik
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// formRanges bound the number inputs of the parameter forms per unit if the
+// parameter has no range of its own. The controller itself accepts far
+// more, these are plausibility limits which catch typos before they reach
+// the heat pump.
+var formRanges = map[string][2]float64{
+	"°C":  {-20, 80},
+	"K":   {-10, 20},
+	"h":   {0, 24},
+	"min": {0, 1440},
+}
+
+type FormsOptions struct {
+	// Token authorizes writes, sent as bearer token or as form field token.
+	// Without a token the forms are read-only, see Forms.
+	Token  string
+	Logger *zap.Logger
+}
+
+// Forms serves an HTML page with a form per writeable parameter of a Device:
+// selections as dropdowns of their codes and numbers as inputs bounded by
+// formRanges. It only writes parameters which are marked writeable, values
+// are validated before anything gets sent. Writes need a token and a client
+// in SafeMode, the forms are read-only otherwise.
+type Forms struct {
+	device *Device
+	opts   FormsOptions
+}
+
+func NewForms(d *Device, opts FormsOptions) *Forms {
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	return &Forms{device: d, opts: opts}
+}
+
+type formField struct {
+	Name     string
+	Unit     string
+	Value    string
+	Codes    []string
+	Min, Max string
+	Step     string
+	Number   bool
+}
+
+type formPage struct {
+	Fields   []formField
+	ReadOnly bool
+	Message  string
+	Error    bool
+}
+
+var formsTemplate = template.Must(template.New("forms").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Luxtronik parameters</title></head>
+<body>
+<h1>Parameters</h1>
+{{if .Message}}<p{{if .Error}} style="color:red"{{end}}>{{.Message}}</p>{{end}}
+{{if .ReadOnly}}<p>Read-only, writes need a token and a client in safe mode.</p>{{end}}
+{{range .Fields}}<form method="post"><p>
+<label>{{.Name}}
+{{if .Codes}}<select name="value"{{if $.ReadOnly}} disabled{{end}}>{{$v := .Value}}{{range .Codes}}<option{{if eq . $v}} selected{{end}}>{{.}}</option>{{end}}</select>
+{{else if .Number}}<input type="number" name="value" value="{{.Value}}" min="{{.Min}}" max="{{.Max}}" step="{{.Step}}"{{if $.ReadOnly}} disabled{{end}}>
+{{else}}<input type="text" name="value" value="{{.Value}}"{{if $.ReadOnly}} disabled{{end}}>{{end}} {{.Unit}}</label>
+{{if not $.ReadOnly}}<input type="hidden" name="name" value="{{.Name}}"><input type="password" name="token" placeholder="token"> <button>Write</button>{{end}}
+</p></form>
+{{end}}</body>
+</html>
+`))
+
+func (f *Forms) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if err := f.device.Refresh(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		page := formPage{}
+		if name := r.URL.Query().Get("written"); name != "" {
+			page.Message = name + " written"
+		}
+		f.render(w, http.StatusOK, page)
+	case http.MethodPost:
+		f.write(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *Forms) write(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	if auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = auth
+	}
+	if f.readOnly() || subtle.ConstantTimeCompare([]byte(token), []byte(f.opts.Token)) != 1 {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	name, value := r.FormValue("name"), strings.TrimSpace(r.FormValue("value"))
+	if err := f.validate(name, value); err != nil {
+		f.render(w, http.StatusBadRequest, formPage{Message: err.Error(), Error: true})
+		return
+	}
+	if err := f.device.Set(r.Context(), name, value); err != nil {
+		f.render(w, http.StatusBadGateway, formPage{Message: err.Error(), Error: true})
+		return
+	}
+	f.opts.Logger.Info("parameter written via form", zap.String("name", name), zap.String("value", value))
+	http.Redirect(w, r, r.URL.Path+"?written="+name, http.StatusSeeOther)
+}
+
+// validate checks the value against the form of the parameter.
+func (f *Forms) validate(name, value string) error {
+	f.device.mu.RLock()
+	defer f.device.mu.RUnlock()
+	_, b, ok := f.device.blocks[BlockParameters].Lookup(name)
+	if !ok || !b.writeable || isIndex(name) {
+		return fmt.Errorf("%q is no writeable parameter", name)
+	}
+	if low, high, _, ok := formRange(b); ok {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %q is no number", name, value)
+		}
+		if v < low || v > high {
+			return fmt.Errorf("%s: %s out of range %s to %s %s", name, value, formatFloat(low), formatFloat(high), b.Unit())
+		}
+	}
+	if _, err := b.ToHeatPump(value); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// readOnly is true without a token or a client in SafeMode.
+func (f *Forms) readOnly() bool {
+	return f.opts.Token == "" || !f.device.client.opts.SafeMode
+}
+
+func (f *Forms) render(w http.ResponseWriter, status int, page formPage) {
+	page.ReadOnly = f.readOnly()
+	f.device.mu.RLock()
+	f.device.blocks[BlockParameters].IterateSorted(func(_ int, b *Base) {
+		if !b.writeable {
+			return
+		}
+		field := formField{
+			Name:  b.luxtronikName,
+			Unit:  b.Unit(),
+			Value: FormatValue(b.FromHeatPump()),
+		}
+		for _, code := range b.Codes() {
+			if code != "" {
+				field.Codes = append(field.Codes, code)
+			}
+		}
+		if low, high, step, ok := formRange(b); ok {
+			field.Number = true
+			field.Min, field.Max = formatFloat(low), formatFloat(high)
+			field.Step = "any"
+			if step > 0 {
+				field.Step = formatFloat(step)
+			}
+		}
+		page.Fields = append(page.Fields, field)
+	})
+	f.device.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_ = formsTemplate.Execute(w, page)
+}
+
+// formRange returns the range of the parameter, see Base.Range, or the one
+// of formRanges for its unit.
+func formRange(b *Base) (low, high, step float64, ok bool) {
+	if b.codes != nil {
+		return 0, 0, 0, false
+	}
+	if low, high, step, ok = b.Range(); ok {
+		return low, high, step, true
+	}
+	rng, ok := formRanges[b.unit]
+	if !ok {
+		return 0, 0, 0, false
+	}
+	step = 1
+	switch {
+	case b.Unit() != b.unit:
+		step = 0
+	case b.scaled():
+		step = float64(b.factor)
+	}
+	return b.fromMetric(rng[0]), b.fromMetric(rng[1]), step, true
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 32)
+}
@@ -0,0 +1,72 @@
+package luxtronik
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForms(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.parameters[ParamHotWaterTarget] = 480
+	d := NewDevice(MustNewClient(hp.addr(), Options{SafeMode: true}))
+	defer d.Close()
+
+	srv := httptest.NewServer(NewForms(d, FormsOptions{Token: "s3cret"}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	page := string(body)
+	assert.Contains(t, page, `<label>ID_Einst_BWS_akt
+<input type="number" name="value" value="48" min="30" max="65" step="0.1">`)
+	assert.Contains(t, page, `<select name="value"><option selected>Automatic</option>`, "selections are dropdowns")
+	assert.NotContains(t, page, "ID_WEB_Temperatur_TA", "calculations are not writeable")
+
+	post := func(token, name, value string) *http.Response {
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		res, err := client.PostForm(srv.URL, url.Values{"token": {token}, "name": {name}, "value": {value}})
+		require.NoError(t, err)
+		res.Body.Close()
+		return res
+	}
+	assert.Equal(t, http.StatusForbidden, post("wrong", "ID_Einst_BWS_akt", "50").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, post("s3cret", "ID_Einst_BWS_akt", "500").StatusCode, "out of range")
+	assert.Equal(t, http.StatusBadRequest, post("s3cret", "ID_Einst_BWS_akt", "warm").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, post("s3cret", "ID_Einst_Unknown", "1").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, post("s3cret", "2", "50").StatusCode, "indexes are ambiguous")
+
+	res = post("s3cret", "ID_Einst_BWS_akt", "50.5")
+	assert.Equal(t, http.StatusSeeOther, res.StatusCode)
+	assert.Equal(t, "/?written=ID_Einst_BWS_akt", res.Header.Get("Location"))
+	hp.mu.Lock()
+	assert.Equal(t, uint32(505), hp.parameters[ParamHotWaterTarget])
+	hp.mu.Unlock()
+
+	ro := httptest.NewServer(NewForms(d, FormsOptions{}))
+	defer ro.Close()
+	res, err = http.PostForm(ro.URL, url.Values{"name": {"ID_Einst_BWS_akt"}, "value": {"50"}})
+	require.NoError(t, err)
+	body, _ = io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, "read-only without a token")
+	assert.False(t, strings.Contains(string(body), "Write"))
+
+	unsafe := NewDevice(MustNewClient(hp.addr(), Options{}))
+	defer unsafe.Close()
+	ro = httptest.NewServer(NewForms(unsafe, FormsOptions{Token: "s3cret"}))
+	defer ro.Close()
+	res, err = http.PostForm(ro.URL, url.Values{"token": {"s3cret"}, "name": {"ID_Einst_BWS_akt"}, "value": {"50"}})
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, "read-only without SafeMode")
+}
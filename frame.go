@@ -0,0 +1,228 @@
+package luxtronik
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// FrameMeta is the header of a block frame. It gets decoded and checked
+// before the values.
+type FrameMeta struct {
+	Block string
+	Cmd   int32
+	// Status is only sent with calculations.
+	Status uint32
+	// Length is the number of values reported by the controller.
+	Length int
+	// Expected is the number of values known for the firmware.
+	Expected int
+	// Firmware is empty until the calculations have been read once.
+	Firmware string
+	Time     time.Time
+}
+
+// Mismatch reports whether the controller sends another number of values
+// than expected, e.g. after a firmware update.
+func (m FrameMeta) Mismatch() bool {
+	return m.Length != m.Expected
+}
+
+// FrameLengths are the number of values per block of a firmware.
+type FrameLengths struct {
+	Parameters   int
+	Calculations int
+	Visibilities int
+}
+
+// KnownFrameLengths maps firmware version prefixes, e.g. "V3.89", to the
+// frame lengths of firmwares which differ from their maps in the
+// FirmwareCatalog, e.g. a release which sends trailing values that are not
+// known yet. The longest matching prefix wins, without a match the size of
+// the map of the firmware is expected, see SelectMaps.
+var KnownFrameLengths = map[string]FrameLengths{}
+
+var blockNames = map[int32]string{
+	ParametersRead:   BlockParameters,
+	CalculationsRead: BlockCalculations,
+	VisibilitiesRead: BlockVisibilities,
+}
+
+// expectedFrameLength returns the length for the firmware, from
+// KnownFrameLengths or the FirmwareCatalog, or the catalog length if the
+// firmware is not known.
+func expectedFrameLength(firmware string, cmd int32, catalog int) int {
+	var (
+		best   string
+		found  bool
+		expect FrameLengths
+	)
+	for prefix, l := range KnownFrameLengths {
+		if strings.HasPrefix(firmware, prefix) && (!found || len(prefix) > len(best)) {
+			best, found, expect = prefix, true, l
+		}
+	}
+	if !found {
+		return firmwareFrameLength(firmware, cmd, catalog)
+	}
+	n := map[int32]int{
+		ParametersRead:   expect.Parameters,
+		CalculationsRead: expect.Calculations,
+		VisibilitiesRead: expect.Visibilities,
+	}[cmd]
+	if n == 0 {
+		return firmwareFrameLength(firmware, cmd, catalog)
+	}
+	return n
+}
+
+// firmwareFrameLength returns the size of the map of the block in the first
+// FirmwareCatalog entry matching the firmware, the catalog length if the
+// entry keeps the catalog map.
+func firmwareFrameLength(firmware string, cmd int32, catalog int) int {
+	if firmware == "" {
+		return catalog
+	}
+	for _, e := range FirmwareCatalog {
+		if ok, err := e.Match(firmware); err != nil || !ok {
+			continue
+		}
+		newMap := map[int32]func() DataTypeMap{
+			ParametersRead:   e.NewParameters,
+			CalculationsRead: e.NewCalculations,
+			VisibilitiesRead: e.NewVisibilities,
+		}[cmd]
+		if newMap == nil {
+			return catalog
+		}
+		return len(newMap())
+	}
+	return catalog
+}
+
+// readFrameHeader decodes the command echo, the status of the calculations
+// and the length of a frame.
+func (c *Client) readFrameHeader(cmd int32) (FrameMeta, error) {
+	echo, err := c.readUint32()
+	if err != nil {
+		return FrameMeta{}, fmt.Errorf("readFromHeatPump.readUint32.cmd failed: %w", err)
+	}
+	m := FrameMeta{Block: blockNames[cmd], Cmd: cmd, Time: time.Now()}
+	if cmd == CalculationsRead {
+		if m.Status, err = c.readUint32(); err != nil {
+			return m, fmt.Errorf("readFromHeatPump.readUint32.status failed: %w", err)
+		}
+		c.log.Debug("calculations status", zap.Uint32("status", m.Status))
+	}
+	if echo != uint32(cmd) {
+		return m, &ProtocolError{Cmd: cmd, Index: -1, Err: fmt.Errorf("%w: received %d", ErrInvalidCommand, echo)}
+	}
+	length, err := c.readUint32()
+	if err != nil {
+		return m, fmt.Errorf("readFromHeatPump.readUint32.length failed: %w", err)
+	}
+	m.Length = int(length)
+	return m, nil
+}
+
+// checkFrame sets the firmware and the expected length of a frame after its
+// values have been read. The firmware is taken from the values of the
+// calculations, so a frame announces the lengths of its own firmware.
+func (c *Client) checkFrame(m FrameMeta, catalog int, raw []uint32) FrameMeta {
+	if m.Cmd == CalculationsRead {
+		if v := rawFirmware(raw); v != "" {
+			c.firmware = v
+		}
+	}
+	m.Firmware = c.firmware
+	m.Expected = expectedFrameLength(c.firmware, m.Cmd, catalog)
+
+	if c.frames == nil {
+		c.frames = map[int32]FrameMeta{}
+	}
+	c.frames[m.Cmd] = m
+	if m.Mismatch() {
+		c.log.Warn("frame length does not match the firmware",
+			zap.String("block", m.Block), zap.Int("expected", m.Expected), zap.Int("actual", m.Length),
+			zap.String("firmware", m.Firmware))
+	}
+	return m
+}
+
+// rawFirmware returns the firmware version in the raw values of the
+// calculations, see DefaultInfoLayout. The values are decoded with the
+// catalog definitions before the frame is decoded into a map.
+func rawFirmware(raw []uint32) string {
+	defs := calculationsDefinitions()
+	pm := make(DataTypeMap, len(DefaultInfoLayout.Version))
+	for _, idx := range DefaultInfoLayout.Version {
+		if b, ok := defs[idx]; ok && idx < len(raw) {
+			cb := *b
+			cb.SetRaw(raw[idx])
+			pm[idx] = &cb
+		}
+	}
+	return pm.version(DefaultInfoLayout.Version)
+}
+
+// FrameMetas returns the header of the last frame of each block read by the
+// client ordered by command, e.g. for issue reports.
+func (c *Client) FrameMetas() []FrameMeta {
+	metas := make([]FrameMeta, 0, len(c.frames))
+	for _, m := range c.frames {
+		metas = append(metas, m)
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Cmd < metas[j].Cmd })
+	return metas
+}
+
+// Firmware returns the firmware version of the last calculations read, empty
+// before.
+func (c *Client) Firmware() string {
+	return c.firmware
+}
+
+// WriteFrameHex writes a frame as annotated hex dump like it was sent by the
+// controller: the command, the status of the calculations, the length and
+// the values with their index and catalog name, e.g. to reverse engineer
+// the values of a new firmware.
+func WriteFrameHex(w io.Writer, meta FrameMeta, raw []uint32) error {
+	var (
+		offset int
+		err    error
+	)
+	line := func(b []byte, note string) {
+		if err != nil {
+			return
+		}
+		hex := fmt.Sprintf("% x", b)
+		_, err = fmt.Fprintf(w, "%08x  %-11s  %s\n", offset, hex, note)
+		offset += len(b)
+	}
+	word := func(v uint32) []byte {
+		return []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	}
+
+	line(word(uint32(meta.Cmd)), fmt.Sprintf("command %d %s", meta.Cmd, meta.Block))
+	if meta.Cmd == CalculationsRead {
+		line(word(meta.Status), fmt.Sprintf("status %d", meta.Status))
+	}
+	line(word(uint32(len(raw))), fmt.Sprintf("length %d", len(raw)))
+	pm := newBlockMap(meta.Block)
+	for idx, v := range raw {
+		note := fmt.Sprintf("[%d] %d", idx, v)
+		if b, ok := pm[idx]; ok {
+			note += " " + b.luxtronikName
+		}
+		if meta.Cmd == VisibilitiesRead {
+			line([]byte{byte(v)}, note)
+		} else {
+			line(word(v), note)
+		}
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestClient_FrameMetas(t *testing.T) {
+	hp := newMockHeatPump(t)
+	for i, ch := range "V3.89" {
+		hp.calculations[81+i] = uint32(ch)
+	}
+	KnownFrameLengths["V3.8"] = FrameLengths{Parameters: 1}
+	KnownFrameLengths["V3.89"] = FrameLengths{Parameters: 1200}
+	defer func() {
+		delete(KnownFrameLengths, "V3.8")
+		delete(KnownFrameLengths, "V3.89")
+	}()
+
+	core, logs := observer.New(zap.WarnLevel)
+	c := MustNewClient(hp.addr(), Options{Logger: zap.New(core)})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	pm := NewParameterMap()
+	require.NoError(t, c.ReadParameters(pm))
+	assert.Zero(t, logs.Len(), "firmware not known yet")
+	require.NoError(t, c.ReadCalculations(NewCalculationsMap()))
+	assert.Equal(t, "V3.89", c.Firmware())
+	require.NoError(t, c.ReadParameters(pm))
+
+	metas := c.FrameMetas()
+	require.Len(t, metas, 2)
+	assert.Equal(t, BlockParameters, metas[0].Block)
+	assert.Equal(t, len(pm), metas[0].Length)
+	assert.Equal(t, 1200, metas[0].Expected)
+	assert.True(t, metas[0].Mismatch())
+	assert.Equal(t, BlockCalculations, metas[1].Block)
+	assert.False(t, metas[1].Mismatch())
+
+	require.Equal(t, 1, logs.Len())
+	e := logs.All()[0]
+	assert.Equal(t, "frame length does not match the firmware", e.Message)
+	assert.Equal(t, map[string]any{
+		"host": "127.0.0.1", "block": BlockParameters, "expected": int64(1200), "actual": int64(len(pm)), "firmware": "V3.89",
+	}, e.ContextMap())
+}
+
+func TestClient_FrameMetas_Firmware(t *testing.T) {
+	hp := newMockHeatPump(t)
+	for i, ch := range "V2.88" {
+		hp.calculations[81+i] = uint32(ch)
+	}
+	core, logs := observer.New(zap.WarnLevel)
+	c := MustNewClient(hp.addr(), Options{Logger: zap.New(core), TolerantFrames: true})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	// the mock sends the frames of the catalog, longer than those of V2.88
+	require.NoError(t, c.ReadCalculations(NewCalculationsMap()))
+	assert.Equal(t, "V2.88", c.Firmware())
+	require.NoError(t, c.ReadVisibilities(NewVisibilitiesMap()))
+
+	metas := c.FrameMetas()
+	require.Len(t, metas, 2)
+	assert.Equal(t, BlockCalculations, metas[0].Block)
+	assert.Equal(t, 248, metas[0].Expected, "firmware taken from the frame itself")
+	assert.Equal(t, BlockVisibilities, metas[1].Block)
+	assert.Equal(t, 370, metas[1].Expected)
+	assert.True(t, metas[1].Mismatch())
+	assert.Equal(t, 2, logs.FilterMessage("frame length does not match the firmware").Len())
+}
+
+func TestWriteFrameHex(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[10] = 215
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+
+	meta, raw, err := c.ReadFrame(context.Background(), CalculationsRead)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WriteFrameHex(&buf, meta, raw[:11]))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "00000000  00 00 0b bc  command 3004 calculations", lines[0])
+	assert.Equal(t, "00000004  00 00 00 00  status 0", lines[1])
+	assert.Equal(t, "00000008  00 00 00 0b  length 11", lines[2])
+	assert.Equal(t, "00000034  00 00 00 d7  [10] 215 ID_WEB_Temperatur_TVL", lines[13])
+
+	meta, raw, err = c.ReadFrame(context.Background(), VisibilitiesRead)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteFrameHex(&buf, meta, raw[:1]))
+	assert.Equal(t, "00000008  00           [0] 0 ID_Visi_NieAnzeigen", strings.Split(buf.String(), "\n")[2])
+}
@@ -0,0 +1,31 @@
+package luxtronik
+
+import "sync"
+
+// The maps are generated from the CSV files in the data directory, edit
+// those and run go generate to change a definition.
+
+//go:generate go run ./internal/genmaps -in data/parameters.csv -func parameterCatalog -prefix Param -out parameters.go
+//go:generate go run ./internal/genmaps -in data/calculations.csv -func calculationsCatalog -prefix Calc -out calculations.go
+//go:generate go run ./internal/genmaps -in data/visibilities.csv -func visibilitiesCatalog -prefix Vis -out visibilities.go
+//go:generate go run ./internal/genenums -in data/enums.csv -out enums.go
+
+// The catalogs are built once, the maps handed out share their definitions
+// and only copy the readings.
+var (
+	parameterDefinitions    = sync.OnceValue(parameterCatalog)
+	calculationsDefinitions = sync.OnceValue(calculationsCatalog)
+	visibilitiesDefinitions = sync.OnceValue(visibilitiesCatalog)
+)
+
+func NewParameterMap() DataTypeMap {
+	return parameterDefinitions().Clone()
+}
+
+func NewCalculationsMap() DataTypeMap {
+	return calculationsDefinitions().Clone()
+}
+
+func NewVisibilitiesMap() DataTypeMap {
+	return visibilitiesDefinitions().Clone()
+}
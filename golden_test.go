@@ -0,0 +1,160 @@
+package luxtronik
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goldenFixtures are the values the frames in testdata/frames have to decode
+// to with the maps of their firmware, by block and name, formatted with
+// FormatValue.
+var goldenFixtures = []struct {
+	dir      string
+	firmware string
+	values   map[string]map[string]string
+}{
+	{
+		dir:      "synthetic-v3.89",
+		firmware: "V3.89.2",
+		values: map[string]map[string]string{
+			BlockParameters: {
+				"ID_Einst_BWS_akt":     "48",
+				"ID_Ba_Hz_akt":         "Automatic",
+				"ID_Ba_Bw_akt":         "Second heatsource",
+				"ID_Einst_HzHwHKE_akt": "35",
+			},
+			BlockCalculations: {
+				"ID_WEB_Temperatur_TVL": "21.5",
+				"ID_WEB_Temperatur_TRL": "18.9",
+				"ID_WEB_Temperatur_TA":  "-3.5",
+				"ID_WEB_Temperatur_TBW": "47.8",
+				"ID_WEB_WP_BZ_akt":      "heating",
+				"ID_WEB_AdresseIP_akt":  "192.168.0.121",
+				"ID_WEB_WMZ_Heizung":    "12345.6",
+			},
+			BlockVisibilities: {
+				"ID_Visi_NieAnzeigen":   "0",
+				"ID_Visi_ImmerAnzeigen": "1",
+			},
+		},
+	},
+	{
+		dir:      "synthetic-v2.88",
+		firmware: "V2.88",
+		values: map[string]map[string]string{
+			BlockParameters: {
+				"ID_Einst_BWS_akt":     "50",
+				"ID_Ba_Hz_akt":         "Off",
+				"ID_Ba_Bw_akt":         "Automatic",
+				"ID_Einst_HzHwHKE_akt": "40",
+			},
+			BlockCalculations: {
+				"ID_WEB_Temperatur_TVL": "30",
+				"ID_WEB_Temperatur_TRL": "25",
+				"ID_WEB_Temperatur_TA":  "-12",
+				"ID_WEB_Temperatur_TBW": "51.2",
+				"ID_WEB_WP_BZ_akt":      "hot water",
+				"ID_WEB_AdresseIP_akt":  "192.168.178.10",
+				"ID_WEB_WMZ_Heizung":    "500",
+				"ID_WEB_LIN_HD":         "18.5",
+				"ID_WEB_AnalogIn":       "5.12",
+			},
+			BlockVisibilities: {
+				"ID_Visi_NieAnzeigen":   "0",
+				"ID_Visi_ImmerAnzeigen": "1",
+			},
+		},
+	},
+}
+
+func TestGoldenFrames(t *testing.T) {
+	for _, fx := range goldenFixtures {
+		t.Run(fx.dir, func(t *testing.T) {
+			c := MustNewClient("127.0.0.1:8889", Options{})
+			c.conn = serveFrames(t, fixtureFrames(t, fx.dir))
+			defer c.Close()
+
+			// the firmware comes with the calculations, a strict read fails
+			// if the frame does not fit the catalog
+			_ = c.readFromHeatPump(context.Background(), NewCalculationsMap(), CalculationsRead, 0)
+			assert.Equal(t, fx.firmware, c.Firmware())
+			maps, err := SelectMaps(c.Firmware())
+			require.NoError(t, err)
+
+			for _, block := range []string{BlockParameters, BlockCalculations, BlockVisibilities} {
+				pm := maps[block]
+				require.NoError(t, c.readFromHeatPump(context.Background(), pm, blockCommands[block], 0), block)
+				for name, want := range fx.values[block] {
+					_, b, ok := pm.Lookup(name)
+					require.True(t, ok, name)
+					assert.Equal(t, want, FormatValue(b.FromHeatPump()), name)
+				}
+			}
+			for _, meta := range c.FrameMetas() {
+				assert.False(t, meta.Mismatch(), meta.Block)
+				assert.Equal(t, len(maps[meta.Block]), meta.Expected, meta.Block)
+			}
+		})
+	}
+}
+
+// fixtureFrames reads the frames of all blocks of a directory in
+// testdata/frames.
+func fixtureFrames(t *testing.T, dir string) map[int32][]byte {
+	frames := map[int32][]byte{}
+	for cmd, block := range blockNames {
+		frames[cmd] = readFrameHexFile(t, filepath.Join("testdata", "frames", dir, block+".hex"))
+	}
+	return frames
+}
+
+// readFrameHexFile reads the offset and hex columns of a file written by
+// WriteFrameHex.
+func readFrameHexFile(t *testing.T, file string) []byte {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var frame []byte
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		_, rest, ok := strings.Cut(sc.Text(), "  ")
+		require.True(t, ok, "%s:%d has no offset", file, line)
+		cols, _, _ := strings.Cut(rest, "  ")
+		b, err := hex.DecodeString(strings.ReplaceAll(cols, " ", ""))
+		require.NoError(t, err, "%s:%d", file, line)
+		frame = append(frame, b...)
+	}
+	require.NoError(t, sc.Err())
+	return frame
+}
+
+// serveFrames answers each read command with its frame.
+func serveFrames(t *testing.T, frames map[int32][]byte) net.Conn {
+	server, conn := net.Pipe()
+	go func() {
+		defer server.Close()
+		for {
+			var req [8]byte
+			if _, err := io.ReadFull(server, req[:]); err != nil {
+				return
+			}
+			if _, err := server.Write(frames[int32(binary.BigEndian.Uint32(req[:]))]); err != nil {
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() { _ = server.Close() })
+	return conn
+}
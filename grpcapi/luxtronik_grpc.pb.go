@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: luxtronik.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Luxtronik_Read_FullMethodName   = "/luxtronik.v1.Luxtronik/Read"
+	Luxtronik_Watch_FullMethodName  = "/luxtronik.v1.Luxtronik/Watch"
+	Luxtronik_Write_FullMethodName  = "/luxtronik.v1.Luxtronik/Write"
+	Luxtronik_Schema_FullMethodName = "/luxtronik.v1.Luxtronik/Schema"
+)
+
+// LuxtronikClient is the client API for Luxtronik service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LuxtronikClient interface {
+	// Read returns the current values, all values without names and blocks.
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	// Watch streams the changed values, starting with all values which are
+	// not zero.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Luxtronik_WatchClient, error)
+	// Write converts the value like the CLI does and writes the parameter.
+	// Only writeable parameters are accepted, with the bearer token of the
+	// server in the authorization metadata.
+	Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
+	// Schema describes the values of the firmware of the heat pump, their
+	// types, units, codes and ranges.
+	Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error)
+}
+
+type luxtronikClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLuxtronikClient(cc grpc.ClientConnInterface) LuxtronikClient {
+	return &luxtronikClient{cc}
+}
+
+func (c *luxtronikClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, Luxtronik_Read_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *luxtronikClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Luxtronik_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Luxtronik_ServiceDesc.Streams[0], Luxtronik_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &luxtronikWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Luxtronik_WatchClient interface {
+	Recv() (*ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type luxtronikWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *luxtronikWatchClient) Recv() (*ChangeEvent, error) {
+	m := new(ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *luxtronikClient) Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
+	out := new(WriteResponse)
+	err := c.cc.Invoke(ctx, Luxtronik_Write_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *luxtronikClient) Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error) {
+	out := new(SchemaResponse)
+	err := c.cc.Invoke(ctx, Luxtronik_Schema_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LuxtronikServer is the server API for Luxtronik service.
+// All implementations must embed UnimplementedLuxtronikServer
+// for forward compatibility
+type LuxtronikServer interface {
+	// Read returns the current values, all values without names and blocks.
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	// Watch streams the changed values, starting with all values which are
+	// not zero.
+	Watch(*WatchRequest, Luxtronik_WatchServer) error
+	// Write converts the value like the CLI does and writes the parameter.
+	// Only writeable parameters are accepted, with the bearer token of the
+	// server in the authorization metadata.
+	Write(context.Context, *WriteRequest) (*WriteResponse, error)
+	// Schema describes the values of the firmware of the heat pump, their
+	// types, units, codes and ranges.
+	Schema(context.Context, *SchemaRequest) (*SchemaResponse, error)
+	mustEmbedUnimplementedLuxtronikServer()
+}
+
+// UnimplementedLuxtronikServer must be embedded to have forward compatible implementations.
+type UnimplementedLuxtronikServer struct {
+}
+
+func (UnimplementedLuxtronikServer) Read(context.Context, *ReadRequest) (*ReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedLuxtronikServer) Watch(*WatchRequest, Luxtronik_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedLuxtronikServer) Write(context.Context, *WriteRequest) (*WriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Write not implemented")
+}
+func (UnimplementedLuxtronikServer) Schema(context.Context, *SchemaRequest) (*SchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schema not implemented")
+}
+func (UnimplementedLuxtronikServer) mustEmbedUnimplementedLuxtronikServer() {}
+
+// UnsafeLuxtronikServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LuxtronikServer will
+// result in compilation errors.
+type UnsafeLuxtronikServer interface {
+	mustEmbedUnimplementedLuxtronikServer()
+}
+
+func RegisterLuxtronikServer(s grpc.ServiceRegistrar, srv LuxtronikServer) {
+	s.RegisterService(&Luxtronik_ServiceDesc, srv)
+}
+
+func _Luxtronik_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LuxtronikServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Luxtronik_Read_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LuxtronikServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Luxtronik_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LuxtronikServer).Watch(m, &luxtronikWatchServer{stream})
+}
+
+type Luxtronik_WatchServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}
+
+type luxtronikWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *luxtronikWatchServer) Send(m *ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Luxtronik_Write_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LuxtronikServer).Write(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Luxtronik_Write_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LuxtronikServer).Write(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Luxtronik_Schema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LuxtronikServer).Schema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Luxtronik_Schema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LuxtronikServer).Schema(ctx, req.(*SchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Luxtronik_ServiceDesc is the grpc.ServiceDesc for Luxtronik service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Luxtronik_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "luxtronik.v1.Luxtronik",
+	HandlerType: (*LuxtronikServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Read",
+			Handler:    _Luxtronik_Read_Handler,
+		},
+		{
+			MethodName: "Write",
+			Handler:    _Luxtronik_Write_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _Luxtronik_Schema_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Luxtronik_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "luxtronik.proto",
+}
@@ -0,0 +1,247 @@
+// Package grpcapi serves a Device via gRPC, see luxtronik.proto. It lives in
+// its own package so that users of the library do not pull in gRPC.
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative luxtronik.proto
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Options struct {
+	// Interval of the refreshes of Run, defaults to 30s.
+	Interval time.Duration
+	// WatchBuffer is the number of changes buffered per watcher, further
+	// changes get dropped while the watcher is slow. Defaults to 1000.
+	WatchBuffer int
+	// Token authorizes Write, sent as metadata "authorization: Bearer
+	// <token>". Without a token the server is read-only.
+	Token  string
+	Logger *zap.Logger
+}
+
+// Server implements the Luxtronik service on top of a Device. Read and
+// Watch serve the values of the latest refresh, see Run.
+type Server struct {
+	UnimplementedLuxtronikServer
+
+	device *luxtronik.Device
+	opts   Options
+}
+
+func NewServer(d *luxtronik.Device, opts Options) *Server {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.WatchBuffer <= 0 {
+		opts.WatchBuffer = 1000
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	return &Server{device: d, opts: opts}
+}
+
+// Run refreshes the device every interval until ctx is done. Failed
+// refreshes are logged and retried with the next interval.
+func (s *Server) Run(ctx context.Context) {
+	tkr := time.NewTicker(s.opts.Interval)
+	defer tkr.Stop()
+	for {
+		if err := s.device.Refresh(ctx); err != nil && ctx.Err() == nil {
+			s.opts.Logger.Error("refresh failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tkr.C:
+		}
+	}
+}
+
+func (s *Server) Read(_ context.Context, req *ReadRequest) (*ReadResponse, error) {
+	res := &ReadResponse{Time: timestamppb.Now()}
+	if len(req.Names) == 0 {
+		if err := checkBlocks(req.Blocks); err != nil {
+			return nil, err
+		}
+		for _, v := range s.device.Values(req.Blocks...) {
+			res.Values = append(res.Values, toValue(v))
+		}
+		return res, nil
+	}
+	for _, name := range req.Names {
+		v, err := s.device.Get(name)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		res.Values = append(res.Values, toValue(v))
+	}
+	return res, nil
+}
+
+func (s *Server) Watch(req *WatchRequest, stream Luxtronik_WatchServer) error {
+	type key struct {
+		block string
+		index int
+	}
+	wanted := map[key]bool{}
+	for _, name := range req.Names {
+		v, err := s.device.Get(name)
+		if err != nil {
+			return toStatus(err)
+		}
+		wanted[key{v.Block, v.Index}] = true
+	}
+	changes, stop := s.device.Subscribe(s.opts.WatchBuffer)
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case c := <-changes:
+			if len(wanted) > 0 && !wanted[key{c.Value.Block, c.Value.Index}] {
+				continue
+			}
+			err := stream.Send(&ChangeEvent{
+				Time:     timestamppb.New(c.Time),
+				Value:    toValue(c.Value),
+				Previous: toScalar(c.Previous),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) Write(ctx context.Context, req *WriteRequest) (*WriteResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.device.Set(ctx, req.Name, req.Value); err != nil {
+		return nil, toStatus(err)
+	}
+	v, err := s.device.Get(req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	s.opts.Logger.Info("parameter written via gRPC", zap.String("name", req.Name), zap.String("value", req.Value))
+	return &WriteResponse{Value: toValue(v)}, nil
+}
+
+// Schema describes the values with the maps of the latest refresh, those of
+// the firmware of the heat pump.
+func (s *Server) Schema(_ context.Context, req *SchemaRequest) (*SchemaResponse, error) {
+	if err := checkBlocks(req.Blocks); err != nil {
+		return nil, err
+	}
+	res := &SchemaResponse{}
+	for _, e := range s.device.Schema(req.Blocks...) {
+		res.Entries = append(res.Entries, &SchemaEntry{
+			Block:      e.Block,
+			Index:      int32(e.Index),
+			Name:       e.Name,
+			Type:       e.Type,
+			ValueType:  e.ValueType,
+			Group:      e.Group,
+			Unit:       e.Unit,
+			Writeable:  e.Writeable,
+			Signed:     e.Signed,
+			Codes:      e.Codes,
+			Min:        e.Min,
+			Max:        e.Max,
+			Step:       e.Step,
+			Aliases:    e.Aliases,
+			Visibility: e.Visibility,
+		})
+	}
+	return res, nil
+}
+
+// checkBlocks rejects unknown block names.
+func checkBlocks(blocks []string) error {
+	for _, block := range blocks {
+		if !slices.Contains([]string{luxtronik.BlockParameters, luxtronik.BlockCalculations, luxtronik.BlockVisibilities}, block) {
+			return status.Errorf(codes.InvalidArgument, "unknown block %q", block)
+		}
+	}
+	return nil
+}
+
+// authorize checks the bearer token of a write.
+func (s *Server) authorize(ctx context.Context) error {
+	if s.opts.Token == "" {
+		return status.Error(codes.PermissionDenied, "read-only, no token configured")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid or missing token")
+}
+
+func toValue(v luxtronik.Value) *Value {
+	return &Value{
+		Block: v.Block,
+		Index: int32(v.Index),
+		Name:  v.Name,
+		Unit:  v.Unit,
+		Class: v.Class,
+		Raw:   v.Raw,
+		Value: toScalar(v.Value),
+	}
+}
+
+func toScalar(v any) *Scalar {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case bool:
+		return &Scalar{Kind: &Scalar_Flag{Flag: v}}
+	case string:
+		return &Scalar{Kind: &Scalar_Text{Text: v}}
+	case time.Duration:
+		return &Scalar{Kind: &Scalar_Number{Number: v.Seconds()}}
+	case time.Time:
+		if v.IsZero() {
+			return &Scalar{Kind: &Scalar_Text{}}
+		}
+		return &Scalar{Kind: &Scalar_Text{Text: v.Format(time.RFC3339)}}
+	case fmt.Stringer:
+		// codes like luxtronik.HeatingMode
+		return &Scalar{Kind: &Scalar_Text{Text: v.String()}}
+	}
+	if f, err := cast.ToFloat64E(v); err == nil {
+		return &Scalar{Kind: &Scalar_Number{Number: f}}
+	}
+	return &Scalar{Kind: &Scalar_Text{Text: fmt.Sprint(v)}}
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, luxtronik.ErrUnknownIndex):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, luxtronik.ErrWritingNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, luxtronik.ErrInvalidValue):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SchumacherFM/luxtronik"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// heatPump serves the parameters and calculations of the controller protocol,
+// see mockHeatPump of the luxtronik package.
+type heatPump struct {
+	mu           sync.Mutex
+	parameters   []uint32
+	calculations []uint32
+	visibilities []uint32
+}
+
+func newHeatPump(t *testing.T) (*heatPump, string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	hp := &heatPump{
+		parameters:   make([]uint32, len(luxtronik.NewParameterMap())),
+		calculations: make([]uint32, len(luxtronik.NewCalculationsMap())),
+		visibilities: make([]uint32, len(luxtronik.NewVisibilitiesMap())),
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go hp.handle(conn)
+		}
+	}()
+	return hp, ln.Addr().String()
+}
+
+func (hp *heatPump) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		var req [2]int32
+		if err := binary.Read(conn, binary.BigEndian, &req); err != nil {
+			return
+		}
+		hp.mu.Lock()
+		var resp []uint32
+		switch req[0] {
+		case luxtronik.ParametersWrite:
+			var val uint32
+			_ = binary.Read(conn, binary.BigEndian, &val)
+			hp.parameters[req[1]] = val
+			resp = []uint32{luxtronik.ParametersWrite, uint32(req[1])}
+		case luxtronik.ParametersRead:
+			resp = append([]uint32{luxtronik.ParametersRead, uint32(len(hp.parameters))}, hp.parameters...)
+		case luxtronik.CalculationsRead:
+			resp = append([]uint32{luxtronik.CalculationsRead, 0, uint32(len(hp.calculations))}, hp.calculations...)
+		case luxtronik.VisibilitiesRead:
+			resp = []uint32{luxtronik.VisibilitiesRead, uint32(len(hp.visibilities))}
+		}
+		err := binary.Write(conn, binary.BigEndian, resp)
+		if req[0] == luxtronik.VisibilitiesRead && err == nil {
+			_, err = conn.Write(make([]byte, len(hp.visibilities)))
+		}
+		hp.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	hp, addr := newHeatPump(t)
+	hp.calculations[luxtronik.CalcOutdoorTemperature] = 55
+	d := luxtronik.NewDevice(luxtronik.MustNewClient(addr, luxtronik.Options{SafeMode: true}))
+	defer d.Close()
+
+	srv := NewServer(d, Options{Interval: 10 * time.Millisecond, Token: "secret"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, d.Refresh(ctx))
+
+	ln := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	RegisterLuxtronikServer(gs, srv)
+	go func() { _ = gs.Serve(ln) }()
+	defer gs.Stop()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := NewLuxtronikClient(conn)
+
+	res, err := client.Read(ctx, &ReadRequest{Names: []string{"ID_WEB_Temperatur_TA"}})
+	require.NoError(t, err)
+	require.Len(t, res.Values, 1)
+	assert.Equal(t, "calculations", res.Values[0].Block)
+	assert.InDelta(t, 5.5, res.Values[0].Value.GetNumber(), 0.001)
+
+	res, err = client.Read(ctx, &ReadRequest{Blocks: []string{luxtronik.BlockParameters}})
+	require.NoError(t, err)
+	assert.Len(t, res.Values, len(luxtronik.NewParameterMap()))
+
+	_, err = client.Read(ctx, &ReadRequest{Names: []string{"ID_Unknown"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	schema, err := client.Schema(ctx, &SchemaRequest{Blocks: []string{luxtronik.BlockParameters}})
+	require.NoError(t, err)
+	want := luxtronik.Schema(map[string]luxtronik.DataTypeMap{luxtronik.BlockParameters: luxtronik.NewParameterMap()})
+	require.Len(t, schema.Entries, len(want))
+	e, we := schema.Entries[luxtronik.ParamHotWaterTarget], want[luxtronik.ParamHotWaterTarget]
+	assert.Equal(t, "ID_Einst_BWS_akt", e.Name)
+	assert.Equal(t, we.ValueType, e.ValueType)
+	assert.Equal(t, we.Unit, e.Unit)
+	assert.True(t, e.Writeable)
+	require.NotNil(t, we.Min)
+	assert.Equal(t, *we.Min, e.GetMin())
+	assert.Equal(t, *we.Max, e.GetMax())
+	schema, err = client.Schema(ctx, &SchemaRequest{})
+	require.NoError(t, err)
+	assert.Len(t, schema.Entries, len(luxtronik.NewParameterMap())+len(luxtronik.NewCalculationsMap())+len(luxtronik.NewVisibilitiesMap()))
+	_, err = client.Schema(ctx, &SchemaRequest{Blocks: []string{"sensors"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err := client.Watch(ctx, &WatchRequest{Names: []string{"ID_WEB_Temperatur_TA"}})
+	require.NoError(t, err)
+	// the stream is registered asynchronously
+	time.Sleep(50 * time.Millisecond)
+	hp.mu.Lock()
+	hp.calculations[luxtronik.CalcOutdoorTemperature] = 60
+	hp.mu.Unlock()
+	go srv.Run(ctx)
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "ID_WEB_Temperatur_TA", ev.Value.Name)
+	assert.InDelta(t, 6.0, ev.Value.Value.GetNumber(), 0.001)
+	assert.InDelta(t, 5.5, ev.Previous.GetNumber(), 0.001)
+
+	_, err = client.Write(ctx, &WriteRequest{Name: "ID_Einst_BWS_akt", Value: "48.5"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	wrong := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer guess")
+	_, err = client.Write(wrong, &WriteRequest{Name: "ID_Einst_BWS_akt", Value: "48.5"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	wres, err := client.Write(ctx, &WriteRequest{Name: "ID_Einst_BWS_akt", Value: "48.5"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(485), wres.Value.Raw)
+	hp.mu.Lock()
+	assert.Equal(t, uint32(485), hp.parameters[luxtronik.ParamHotWaterTarget])
+	hp.mu.Unlock()
+
+	_, err = client.Write(ctx, &WriteRequest{Name: "ID_WEB_Temperatur_TA", Value: "1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.Write(ctx, &WriteRequest{Name: "ID_Einst_BWS_akt", Value: "warm"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	srv.opts.Token = ""
+	_, err = client.Write(ctx, &WriteRequest{Name: "ID_Einst_BWS_akt", Value: "48.5"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "read-only without a token")
+}
@@ -0,0 +1,83 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Ping checks that the controller answers by reading the visibilities, the
+// shortest block, and returns the round trip time including a connect. The
+// deadline of ctx bounds the check. A failed check closes the connection.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if err := c.connect(ctx); err != nil {
+		return 0, fmt.Errorf("Client.Ping %s: %w", c.Name(), err)
+	}
+	if dl, ok := ctx.Deadline(); ok {
+		_ = c.SetDeadline(dl)
+		defer func() { _ = c.SetDeadline(time.Time{}) }()
+	}
+	if err := c.readFromHeatPump(ctx, NewVisibilitiesMap(), VisibilitiesRead, 0); err != nil {
+		_ = c.Close()
+		return 0, fmt.Errorf("Client.Ping %s: %w", c.Name(), err)
+	}
+	return time.Since(start), nil
+}
+
+// HealthStatus is the result of a Ping.
+type HealthStatus struct {
+	Pump      string        `json:"pump"`
+	Reachable bool          `json:"reachable"`
+	Latency   time.Duration `json:"latency_ns"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// CheckHealth pings all clients concurrently, each bounded by timeout.
+func CheckHealth(ctx context.Context, timeout time.Duration, clients ...*Client) []HealthStatus {
+	res := make([]HealthStatus, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			res[i] = HealthStatus{Pump: c.Name()}
+			latency, err := c.Ping(ctx)
+			if err != nil {
+				res[i].Error = err.Error()
+				return
+			}
+			res[i].Reachable, res[i].Latency = true, latency
+		}(i, c)
+	}
+	wg.Wait()
+	return res
+}
+
+// HealthHandler serves the result of CheckHealth as JSON for readiness
+// probes. It responds 200 if all heat pumps are reachable, otherwise 503.
+// The clients must not be used by anyone else, e.g. a Poller, because a
+// client does not support concurrent requests.
+func HealthHandler(timeout time.Duration, clients ...*Client) http.Handler {
+	var mu sync.Mutex // one check at a time per client
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		res := CheckHealth(r.Context(), timeout, clients...)
+		mu.Unlock()
+
+		code := http.StatusOK
+		for _, s := range res {
+			if !s.Reachable {
+				code = http.StatusServiceUnavailable
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
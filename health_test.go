@@ -0,0 +1,52 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Ping(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+
+	latency, err := c.Ping(context.Background())
+	require.NoError(t, err)
+	assert.Positive(t, latency)
+}
+
+func TestHealthHandler(t *testing.T) {
+	hp := newMockHeatPump(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gone := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	up := MustNewClient(hp.addr(), Options{Alias: "cellar"})
+	down := MustNewClient(gone, Options{Alias: "garage"})
+	defer up.Close()
+
+	rec := httptest.NewRecorder()
+	HealthHandler(time.Second, up).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	HealthHandler(time.Second, up, down).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var res []HealthStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	require.Len(t, res, 2)
+	assert.Equal(t, "cellar", res[0].Pump)
+	assert.True(t, res[0].Reachable)
+	assert.Equal(t, "garage", res[1].Pump)
+	assert.False(t, res[1].Reachable)
+	assert.Contains(t, res[1].Error, "connection error during dial")
+}
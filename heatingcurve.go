@@ -0,0 +1,87 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// HeatingCurve bundles the parameters of the heating circuit's curve. The
+// controller derives the return temperature target from the outdoor
+// temperature along a line from the offset to the end point.
+type HeatingCurve struct {
+	// EndPoint is the return temperature target in °C at an outdoor
+	// temperature of -20 °C.
+	EndPoint float64
+	// Offset shifts the whole curve in K, the parallel shift of the
+	// controller. Not to be confused with ParamHeatingOffset, the quick
+	// adjustment of the display.
+	Offset float64
+	// NightSetback lowers the target in K during the setback times.
+	NightSetback float64
+}
+
+// ReadHeatingCurve reads the parameters and returns the heating curve.
+func (c *Client) ReadHeatingCurve() (HeatingCurve, error) {
+	return c.ReadHeatingCurveContext(context.Background())
+}
+
+// ReadHeatingCurveContext is ReadHeatingCurve with a context, see
+// ReadParametersContext.
+func (c *Client) ReadHeatingCurveContext(ctx context.Context) (HeatingCurve, error) {
+	pm := NewParameterMap()
+	if err := c.ReadParametersContext(ctx, pm); err != nil {
+		return HeatingCurve{}, fmt.Errorf("ReadHeatingCurve: %w", err)
+	}
+	return HeatingCurveOf(pm), nil
+}
+
+// HeatingCurveOf returns the heating curve of already read parameters.
+func HeatingCurveOf(pm DataTypeMap) HeatingCurve {
+	value := func(idx int) float64 {
+		b := pm[idx]
+		return math.Round(b.number(b.reading.Raw)*float64(b.factor)*10) / 10
+	}
+	return HeatingCurve{
+		EndPoint:     value(ParamHeatingCurveEndPoint),
+		Offset:       value(ParamHeatingCurveOffset),
+		NightSetback: value(ParamHeatingCurveNightSetback),
+	}
+}
+
+// WriteHeatingCurve reads the parameters and writes the values of hc which
+// differ from the current ones, unchanged values are not written to spare
+// the controller's flash. The end point must lie above the offset and the
+// night setback must not be negative.
+func (c *Client) WriteHeatingCurve(hc HeatingCurve) error {
+	return c.WriteHeatingCurveContext(context.Background(), hc)
+}
+
+// WriteHeatingCurveContext is WriteHeatingCurve with a context, see
+// ReadParametersContext.
+func (c *Client) WriteHeatingCurveContext(ctx context.Context, hc HeatingCurve) error {
+	if hc.EndPoint <= hc.Offset {
+		return fmt.Errorf("WriteHeatingCurve end point %.1f °C not above the offset %.1f: %w", hc.EndPoint, hc.Offset, ErrInvalidValue)
+	}
+	if hc.NightSetback < 0 {
+		return fmt.Errorf("WriteHeatingCurve negative night setback %.1f K: %w", hc.NightSetback, ErrInvalidValue)
+	}
+	pm := NewParameterMap()
+	if err := c.ReadParametersContext(ctx, pm); err != nil {
+		return fmt.Errorf("WriteHeatingCurve: %w", err)
+	}
+	writes := []struct {
+		idx int
+		val float64
+	}{
+		{ParamHeatingCurveEndPoint, hc.EndPoint},
+		{ParamHeatingCurveOffset, hc.Offset},
+		{ParamHeatingCurveNightSetback, hc.NightSetback},
+	}
+	for _, w := range writes {
+		if err := c.writeChanged(ctx, pm, w.idx, w.val); err != nil {
+			return fmt.Errorf("WriteHeatingCurve: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeatingCurve(t *testing.T) {
+	m := newMockHeatPump(t)
+	m.parameters[ParamHeatingCurveEndPoint] = 350
+	m.parameters[ParamHeatingCurveOffset] = 220
+	m.parameters[ParamHeatingCurveNightSetback] = 20
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	hc, err := c.ReadHeatingCurve()
+	require.NoError(t, err)
+	assert.Equal(t, HeatingCurve{EndPoint: 35, Offset: 22, NightSetback: 2}, hc)
+
+	hc.EndPoint = 38.5
+	hc.NightSetback = 0
+	require.NoError(t, c.WriteHeatingCurve(hc))
+	m.mu.Lock()
+	assert.Equal(t, uint32(385), m.parameters[ParamHeatingCurveEndPoint])
+	assert.Equal(t, uint32(220), m.parameters[ParamHeatingCurveOffset])
+	assert.Equal(t, uint32(0), m.parameters[ParamHeatingCurveNightSetback])
+	m.mu.Unlock()
+
+	assert.ErrorIs(t, c.WriteHeatingCurve(HeatingCurve{EndPoint: 20, Offset: 25}), ErrInvalidValue)
+	assert.ErrorIs(t, c.WriteHeatingCurve(HeatingCurve{EndPoint: 35, Offset: 22, NightSetback: -1}), ErrInvalidValue)
+}
@@ -0,0 +1,265 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// historyBucket holds one nested bucket per series, named host/block/name.
+// Keys are the big endian unix nanoseconds, values the raw value followed by
+// the JSON of the converted value.
+var historyBucket = []byte("history")
+
+type HistoryOptions struct {
+	// Path of the database file, created if missing.
+	Path string
+	// Retention drops older points, zero keeps them forever.
+	Retention time.Duration
+	// Deadbands suppress small changes, see ChangeDetector.
+	Deadbands Deadbands
+	Logger    *zap.Logger
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// HistoryPoint is a stored value.
+type HistoryPoint struct {
+	Time  time.Time `json:"time"`
+	Raw   uint32    `json:"raw"`
+	Value any       `json:"value"`
+}
+
+// HistorySeries are the points of one value of one heat pump.
+type HistorySeries struct {
+	Host   string         `json:"host"`
+	Block  string         `json:"block"`
+	Name   string         `json:"name"`
+	Points []HistoryPoint `json:"points"`
+}
+
+// HistoryStore is a Sink which persists every changed value with its
+// timestamp in an embedded bbolt database, so that a history is available
+// without an external time-series database. The first value of each series
+// after a start is always stored.
+type HistoryStore struct {
+	opts    HistoryOptions
+	db      *bolt.DB
+	changes *ChangeDetector
+
+	mu        sync.Mutex
+	seen      map[string]bool
+	lastPrune time.Time
+}
+
+func NewHistoryStore(opts HistoryOptions) (*HistoryStore, error) {
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	db, err := bolt.Open(opts.Path, 0o640, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("NewHistoryStore %s: %w", opts.Path, err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(historyBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("NewHistoryStore %s: %w", opts.Path, err)
+	}
+	return &HistoryStore{
+		opts:    opts,
+		db:      db,
+		changes: NewChangeDetector(opts.Deadbands),
+		seen:    map[string]bool{},
+	}, nil
+}
+
+func historySeriesKey(host, block, name string) []byte {
+	return []byte(host + "/" + block + "/" + name)
+}
+
+func historyTimeKey(t time.Time) []byte {
+	var k [8]byte
+	binary.BigEndian.PutUint64(k[:], uint64(t.UnixNano()))
+	return k[:]
+}
+
+func (h *HistoryStore) Write(_ context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	err := h.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(historyBucket)
+		var err error
+		pm.IterateSorted(func(idx int, b *Base) {
+			key := historySeriesKey(host, block, b.luxtronikName)
+			_, changed := h.changes.Changed(host, block, idx, b)
+			if err != nil || (!changed && h.seen[string(key)]) {
+				return
+			}
+			h.seen[string(key)] = true
+			var val []byte
+			if val, err = json.Marshal(b.FromHeatPump()); err != nil {
+				return
+			}
+			var series *bolt.Bucket
+			if series, err = root.CreateBucketIfNotExists(key); err != nil {
+				return
+			}
+			rec := binary.BigEndian.AppendUint32(nil, b.reading.Raw)
+			err = series.Put(historyTimeKey(ts), append(rec, val...))
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("HistoryStore.Write %s %s: %w", host, block, err)
+	}
+	return h.prune()
+}
+
+// prune drops the points older than the retention at most once per hour, the
+// caller holds the lock.
+func (h *HistoryStore) prune() error {
+	now := h.opts.Now()
+	if h.opts.Retention <= 0 || now.Sub(h.lastPrune) < time.Hour {
+		return nil
+	}
+	h.lastPrune = now
+	limit := historyTimeKey(now.Add(-h.opts.Retention))
+	var dropped int
+	err := h.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(historyBucket).ForEachBucket(func(name []byte) error {
+			series := tx.Bucket(historyBucket).Bucket(name)
+			// deleting while iterating would skip keys
+			var old [][]byte
+			c := series.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = c.Next() {
+				old = append(old, append([]byte(nil), k...))
+			}
+			for _, k := range old {
+				if err := series.Delete(k); err != nil {
+					return err
+				}
+			}
+			dropped += len(old)
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("HistoryStore.prune: %w", err)
+	}
+	h.opts.Logger.Debug("history pruned", zap.Int("points", dropped))
+	return nil
+}
+
+// Query returns the points of all series with the luxtronik name between from
+// and to, both inclusive. The name is matched case-insensitive, an empty host
+// matches all heat pumps.
+func (h *HistoryStore) Query(host, name string, from, to time.Time) ([]HistorySeries, error) {
+	var res []HistorySeries
+	err := h.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(historyBucket)
+		return root.ForEachBucket(func(key []byte) error {
+			parts := strings.SplitN(string(key), "/", 3)
+			if len(parts) != 3 || !strings.EqualFold(parts[2], name) || (host != "" && parts[0] != host) {
+				return nil
+			}
+			s := HistorySeries{Host: parts[0], Block: parts[1], Name: parts[2], Points: []HistoryPoint{}}
+			c := root.Bucket(key).Cursor()
+			end := historyTimeKey(to)
+			for k, v := c.Seek(historyTimeKey(from)); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+				if len(v) < 4 {
+					continue
+				}
+				p := HistoryPoint{
+					Time: time.Unix(0, int64(binary.BigEndian.Uint64(k))),
+					Raw:  binary.BigEndian.Uint32(v),
+				}
+				if err := json.Unmarshal(v[4:], &p.Value); err != nil {
+					return fmt.Errorf("series %s: %w", key, err)
+				}
+				s.Points = append(s.Points, p)
+			}
+			res = append(res, s)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("HistoryStore.Query: %w", err)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Host != res[j].Host {
+			return res[i].Host < res[j].Host
+		}
+		return res[i].Block < res[j].Block
+	})
+	return res, nil
+}
+
+// ServeHTTP answers GET requests like ?name=ID_WEB_Temperatur_TA&from=24h
+// with the series as JSON. from and to are either RFC 3339 timestamps or
+// durations before now, from defaults to 24h and to to now. host restricts
+// the result to one heat pump.
+func (h *HistoryStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	name := q.Get("name")
+	if name == "" {
+		http.Error(w, "missing name", http.StatusBadRequest)
+		return
+	}
+	now := h.opts.Now()
+	from, err := historyTimeParam(q.Get("from"), now, now.Add(-24*time.Hour))
+	if err != nil {
+		http.Error(w, "invalid from: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := historyTimeParam(q.Get("to"), now, now)
+	if err != nil {
+		http.Error(w, "invalid to: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	res, err := h.Query(q.Get("host"), name, from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(res) == 0 {
+		http.Error(w, "unknown name "+name, http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func historyTimeParam(v string, now, def time.Time) (time.Time, error) {
+	if v == "" {
+		return def, nil
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func (h *HistoryStore) Close() error {
+	return h.db.Close()
+}
@@ -0,0 +1,61 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryStore(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	h, err := NewHistoryStore(HistoryOptions{
+		Path:      filepath.Join(t.TempDir(), "history.db"),
+		Retention: 48 * time.Hour,
+		Now:       func() time.Time { return now },
+	})
+	require.NoError(t, err)
+	defer h.Close()
+
+	pm := NewCalculationsMap()
+	write := func(ts time.Time, outdoor uint32) {
+		pm[CalcOutdoorTemperature].SetRaw(outdoor)
+		require.NoError(t, h.Write(context.Background(), "hp", ts, BlockCalculations, pm))
+	}
+	write(now.Add(-72*time.Hour), 10)
+	write(now.Add(-2*time.Hour), uint32(0xFFFFFFFF-19)) // -2.0 °C
+	write(now.Add(-time.Hour), uint32(0xFFFFFFFF-19))   // unchanged
+	write(now, 15)
+
+	series, err := h.Query("", "id_web_temperatur_ta", now.Add(-100*time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Equal(t, "ID_WEB_Temperatur_TA", series[0].Name)
+	assert.Equal(t, BlockCalculations, series[0].Block)
+	// the first point is older than the retention and pruned at the last write
+	require.Len(t, series[0].Points, 2)
+	assert.Equal(t, now.Add(-2*time.Hour), series[0].Points[0].Time.UTC())
+	assert.Equal(t, -2.0, series[0].Points[0].Value)
+	assert.Equal(t, 1.5, series[0].Points[1].Value)
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	res, err := http.Get(srv.URL + "?name=ID_WEB_Temperatur_TA&from=90m")
+	require.NoError(t, err)
+	var got []HistorySeries
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	res.Body.Close()
+	require.Len(t, got, 1)
+	assert.Len(t, got[0].Points, 1)
+
+	res, err = http.Get(srv.URL + "?name=nope")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,69 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimestamp(t *testing.T) {
+	b := NewTimestamp("ID_SU_FrkdHz", true)
+	want := time.Date(2024, 7, 14, 0, 0, 0, 0, time.Local)
+	for _, v := range []any{"2024-07-14", "2024-07-14 00:00:00", want} {
+		raw, err := b.ToHeatPump(v)
+		require.NoError(t, err, v)
+		assert.Equal(t, uint32(want.Unix()), raw, v)
+	}
+	b.SetRaw(uint32(want.Unix()))
+	assert.Equal(t, want, b.FromHeatPump())
+	assert.Equal(t, "2024-07-14 00:00:00", FormatValue(b.FromHeatPump()))
+	b.SetRaw(0)
+	assert.Equal(t, time.Time{}, b.FromHeatPump())
+	assert.Empty(t, FormatValue(b.FromHeatPump()))
+
+	raw, err := b.ToHeatPump(time.Time{})
+	require.NoError(t, err)
+	assert.Zero(t, raw)
+	_, err = b.ToHeatPump("14.07.2024")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestHoliday(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	h, err := c.ReadHoliday()
+	require.NoError(t, err)
+	assert.Equal(t, Holiday{}, h)
+
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 7, 14, 0, 0, 0, 0, time.Local)
+	require.NoError(t, c.WriteHoliday(Holiday{Start: start, End: end, Heating: true}))
+	m.mu.Lock()
+	assert.Equal(t, uint32(start.Unix()), m.parameters[ParamHolidayStartHeating])
+	assert.Equal(t, uint32(end.Unix()), m.parameters[ParamHolidayEndHeating])
+	assert.Equal(t, uint32(3), m.parameters[ParamHeatingMode], "Holidays")
+	assert.Zero(t, m.parameters[ParamHolidayEndHotWater])
+	assert.Zero(t, m.parameters[ParamHotWaterMode])
+	m.mu.Unlock()
+
+	h, err = c.ReadHoliday()
+	require.NoError(t, err)
+	assert.True(t, h.Start.Equal(start))
+	assert.True(t, h.End.Equal(end))
+	assert.True(t, h.Heating)
+	assert.False(t, h.HotWater)
+	assert.True(t, h.Active(start.Add(time.Hour)))
+	assert.False(t, h.Active(end))
+
+	require.NoError(t, c.WriteHoliday(Holiday{}))
+	m.mu.Lock()
+	assert.Zero(t, m.parameters[ParamHeatingMode], "back to Automatic")
+	m.mu.Unlock()
+
+	assert.ErrorIs(t, c.WriteHoliday(Holiday{Start: end, End: start, HotWater: true}), ErrInvalidValue)
+}
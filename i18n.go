@@ -0,0 +1,165 @@
+package luxtronik
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Language selects the message catalog of the selection codes, see
+// Options.Language.
+type Language string
+
+const (
+	// LanguageEnglish returns the codes as defined in the data types.
+	LanguageEnglish Language = "en"
+	LanguageGerman  Language = "de"
+)
+
+// catalogs translate the English codes, keyed by language.
+var catalogs = struct {
+	sync.RWMutex
+	m map[Language]map[string]string
+}{m: map[Language]map[string]string{LanguageGerman: catalogGerman}}
+
+// RegisterCatalog adds or replaces the catalog of a language. The messages
+// map the English codes to their translation, missing codes stay English.
+func RegisterCatalog(lang Language, messages map[string]string) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	catalogs.m[lang] = messages
+}
+
+// ParseLanguage returns the language of a tag like de or de-DE, which must
+// be English or have a catalog.
+func ParseLanguage(s string) (Language, error) {
+	base, _, _ := strings.Cut(strings.ToLower(s), "-")
+	lang := Language(base)
+	if lang == "" || lang == LanguageEnglish {
+		return LanguageEnglish, nil
+	}
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	if _, ok := catalogs.m[lang]; !ok {
+		return LanguageEnglish, fmt.Errorf("ParseLanguage no catalog for %q: %w", s, ErrInvalidValue)
+	}
+	return lang, nil
+}
+
+// SetLanguage sets the language of the codes of FromHeatPump, which then
+// returns strings instead of the enum types like HeatingMode. ToHeatPump
+// accepts the translated and the English codes.
+func (b *Base) SetLanguage(lang Language) {
+	b.lang = lang
+}
+
+// SetLanguage sets the language of all values, see Options.Language.
+func (pm DataTypeMap) SetLanguage(lang Language) {
+	for _, b := range pm {
+		b.lang = lang
+	}
+}
+
+// translated reports whether the codes of b are translated.
+func (b *Base) translated() bool {
+	return b.lang != "" && b.lang != LanguageEnglish
+}
+
+// translate returns the code in the language of b.
+func (b *Base) translate(code string) string {
+	if !b.translated() || code == "" {
+		return code
+	}
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	if msg, ok := catalogs.m[b.lang][code]; ok {
+		return msg
+	}
+	return code
+}
+
+// Codes returns the selection codes in the language of b, indexed by their
+// raw value. Unused raw values are empty.
+func (b *Base) Codes() []string {
+	if b.codes == nil {
+		return nil
+	}
+	codes := make([]string, len(b.codes))
+	for i, code := range b.codes {
+		codes[i] = b.translate(code)
+	}
+	return codes
+}
+
+// catalogGerman follows the wording of the controller's German display.
+var catalogGerman = map[string]string{
+	// operating modes
+	"Automatic":         "Automatik",
+	"Second heatsource": "Zweiter Wärmeerzeuger",
+	"Party":             "Party",
+	"Holidays":          "Ferien",
+	"Off":               "Aus",
+
+	// access levels
+	"user":                "Benutzer",
+	"after sales service": "Kundendienst",
+	"manufacturer":        "Hersteller",
+	"installer":           "Installateur",
+
+	// bivalence levels
+	"one compressor allowed to run":            "ein Verdichter darf laufen",
+	"two compressors allowed to run":           "zwei Verdichter dürfen laufen",
+	"additional heat generator allowed to run": "zusätzlicher Wärmeerzeuger darf mitlaufen",
+
+	// operation and status
+	"heating":                 "Heizen",
+	"hot water":               "Warmwasser",
+	"swimming pool/solar":     "Schwimmbad/Photovoltaik",
+	"evu":                     "EVU",
+	"defrost":                 "Abtauen",
+	"no request":              "keine Anforderung",
+	"heating external source": "Heizen ext. Energiequelle",
+	"cooling":                 "Kühlen",
+
+	// switch-off reasons
+	"heatpump error":                       "Wärmepumpe Störung",
+	"system error":                         "Anlagen Störung",
+	"evu lock":                             "EVU Sperre",
+	"operation mode second heat generator": "Betriebsart Zweiter Wärmeerzeuger",
+	"air defrost":                          "Luftabtauung",
+	"maximal usage temperature":            "Temperatur Einsatzgrenze maximal",
+	"minimal usage temperature":            "Temperatur Einsatzgrenze minimal",
+	"lower usage limit":                    "Untere Einsatzgrenze",
+	"flow rate":                            "Durchfluss",
+	"PV max":                               "PV Max",
+
+	// status lines of the main menu
+	"heatpump running":                      "Wärmepumpe läuft",
+	"heatpump idle":                         "Wärmepumpe steht",
+	"heatpump coming":                       "Wärmepumpe kommt",
+	"errorcode slot 0":                      "Fehlercode Slot 0",
+	"waiting on LIN connection":             "Warte auf LIN-Verbindung",
+	"compressor heating up":                 "Verdichter heizt auf",
+	"pump forerun":                          "Pumpenvorlauf",
+	"since":                                 "seit",
+	"in":                                    "in",
+	"grid switch on delay":                  "Netz-Einschaltverzögerung",
+	"cycle lock":                            "Schaltspielsperre",
+	"lock time":                             "Sperrzeit",
+	"domestic water":                        "Brauchwasser",
+	"info bake out program":                 "Info Ausheizprogramm",
+	"thermal desinfection":                  "Thermische Desinfektion",
+	"heating external energy source":        "Heizen ext. Energiequelle",
+	"domestic water external energy source": "Brauchwasser ext. Energiequelle",
+	"flow monitoring":                       "Durchflussüberwachung",
+	"second heat generator 1 active":        "Zweiter Wärmeerzeuger 1 Betrieb",
+
+	// secondary operation modes
+	"off":              "Aus",
+	"fault":            "Störung",
+	"transition":       "Übergang",
+	"waiting":          "Warten",
+	"stop":             "Stopp",
+	"manual":           "Manuell",
+	"simulation start": "Simulation Start",
+}
@@ -0,0 +1,84 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase_SetLanguage(t *testing.T) {
+	b := NewHeatingMode("ID_Ba_Hz_akt", true)
+	b.SetRaw(3)
+	b.SetLanguage(LanguageGerman)
+	assert.Equal(t, "Ferien", b.FromHeatPump())
+	assert.Equal(t, HeatingModeHolidays, b.canonical().FromHeatPump())
+	assert.Equal(t, []string{"Automatik", "Zweiter Wärmeerzeuger", "Party", "Ferien", "Aus"}, b.Codes())
+
+	for _, v := range []string{"Aus", "Off"} {
+		raw, err := b.ToHeatPump(v)
+		require.NoError(t, err, v)
+		assert.Equal(t, uint32(4), raw, v)
+	}
+	_, err := b.ToHeatPump("Urlaub")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	pool := NewPoolMode("ID_Ba_Sw_akt", true)
+	_, err = pool.ToHeatPump("")
+	assert.ErrorIs(t, err, ErrInvalidValue, "unused codes are not writeable")
+}
+
+func TestCatalogGerman(t *testing.T) {
+	for _, pm := range []DataTypeMap{NewParameterMap(), NewCalculationsMap(), NewVisibilitiesMap()} {
+		for _, b := range pm {
+			if b.name == "HeatpumpCode" {
+				continue
+			}
+			for _, code := range b.codes {
+				_, ok := catalogGerman[code]
+				assert.True(t, code == "" || ok, "%s: %q", b.luxtronikName, code)
+			}
+		}
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	lang, err := ParseLanguage("de-DE")
+	require.NoError(t, err)
+	assert.Equal(t, LanguageGerman, lang)
+	lang, err = ParseLanguage("")
+	require.NoError(t, err)
+	assert.Equal(t, LanguageEnglish, lang)
+	_, err = ParseLanguage("fr")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	RegisterCatalog("fr", map[string]string{"Holidays": "Vacances"})
+	defer func() {
+		catalogs.Lock()
+		delete(catalogs.m, "fr")
+		catalogs.Unlock()
+	}()
+	lang, err = ParseLanguage("fr")
+	require.NoError(t, err)
+	b := NewHeatingMode("ID_Ba_Hz_akt", true)
+	b.SetRaw(3)
+	b.SetLanguage(lang)
+	assert.Equal(t, "Vacances", b.FromHeatPump())
+	b.SetRaw(0)
+	assert.Equal(t, "Automatic", b.FromHeatPump(), "missing messages stay English")
+}
+
+func TestHoliday_Language(t *testing.T) {
+	m := newMockHeatPump(t)
+	m.parameters[ParamHeatingMode] = 3
+	c := MustNewClient(m.addr(), Options{Language: LanguageGerman})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	pm := NewParameterMap()
+	require.NoError(t, c.ReadParameters(pm))
+	assert.Equal(t, "Ferien", pm[ParamHeatingMode].FromHeatPump())
+	h, err := c.ReadHoliday()
+	require.NoError(t, err)
+	assert.True(t, h.Heating)
+}
@@ -0,0 +1,248 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type InfluxOptions struct {
+	// URL of the InfluxDB server, e.g. http://localhost:8086
+	URL string
+
+	// InfluxDB v1 settings.
+	Database        string
+	RetentionPolicy string
+	Username        string
+	Password        string
+
+	// InfluxDB v2 settings. Setting a Token selects the v2 write API.
+	Org    string
+	Bucket string
+	Token  string
+
+	// BatchSize triggers a flush once that many lines are buffered.
+	BatchSize int
+	// FlushInterval flushes the buffered lines periodically.
+	FlushInterval time.Duration
+	// Spool buffers batches on disk while the server is unreachable.
+	Spool      *Spool
+	HTTPClient *http.Client
+	Logger     *zap.Logger
+}
+
+// InfluxSink writes all values in the InfluxDB line protocol. The block name
+// is used as measurement and each value gets the tags host, class and name
+// and the fields raw and value. Values which are not numeric are written into
+// the field text because InfluxDB does not allow mixed field types within a
+// measurement.
+type InfluxSink struct {
+	opts     InfluxOptions
+	writeURL string
+
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	lines int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewInfluxSink(opts InfluxOptions) (*InfluxSink, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("NewInfluxSink failed to parse URL %q: %w", opts.URL, err)
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 5000
+	}
+	if opts.FlushInterval < 1 {
+		opts.FlushInterval = 10 * time.Second
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+
+	q := url.Values{}
+	q.Set("precision", "s")
+	if opts.Token != "" {
+		u = u.JoinPath("api", "v2", "write")
+		q.Set("org", opts.Org)
+		q.Set("bucket", opts.Bucket)
+	} else {
+		u = u.JoinPath("write")
+		q.Set("db", opts.Database)
+		if opts.RetentionPolicy != "" {
+			q.Set("rp", opts.RetentionPolicy)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	s := &InfluxSink{
+		opts:     opts,
+		writeURL: u.String(),
+		done:     make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.flushLoop()
+	return s, nil
+}
+
+func (s *InfluxSink) flushLoop() {
+	defer s.wg.Done()
+	tkr := time.NewTicker(s.opts.FlushInterval)
+	defer tkr.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-tkr.C:
+			if err := s.Flush(context.Background()); err != nil {
+				s.opts.Logger.Error("influx flush failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *InfluxSink) Write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	s.mu.Lock()
+	pm.IterateSorted(func(_ int, b *Base) {
+		appendInfluxLine(&s.buf, host, block, ts, b)
+		s.lines++
+	})
+	full := s.lines >= s.opts.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush sends all buffered lines to the server. Without a Spool the buffer
+// gets discarded even if the request fails.
+func (s *InfluxSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	if s.lines == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	body := bytes.Clone(s.buf.Bytes())
+	s.buf.Reset()
+	s.lines = 0
+	s.mu.Unlock()
+
+	return s.opts.Spool.Deliver(body, func(b []byte) error {
+		return s.post(ctx, b)
+	})
+}
+
+func (s *InfluxSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.writeURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("InfluxSink.post failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	switch {
+	case s.opts.Token != "":
+		req.Header.Set("Authorization", "Token "+s.opts.Token)
+	case s.opts.Username != "":
+		req.SetBasicAuth(s.opts.Username, s.opts.Password)
+	}
+
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("InfluxSink.post request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("InfluxSink.post %w", &statusError{code: resp.StatusCode, msg: bytes.TrimSpace(msg)})
+		if permanent(err) {
+			// e.g. a line the server cannot parse, retrying it from the
+			// Spool would block the later batches
+			return &PermanentError{Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *InfluxSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return s.Flush(context.Background())
+}
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	influxTagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	influxStringEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+func appendInfluxLine(buf *bytes.Buffer, host, block string, ts time.Time, b *Base) {
+	buf.WriteString(influxMeasurementEscaper.Replace(block))
+	if host != "" {
+		buf.WriteString(",host=")
+		buf.WriteString(influxTagEscaper.Replace(host))
+	}
+	buf.WriteString(",class=")
+	buf.WriteString(influxTagEscaper.Replace(b.class))
+	buf.WriteString(",name=")
+	buf.WriteString(influxTagEscaper.Replace(b.luxtronikName))
+
+	buf.WriteString(" raw=")
+	buf.WriteString(strconv.FormatUint(uint64(b.reading.Raw), 10))
+	buf.WriteByte('i')
+
+	switch v := b.FromHeatPump().(type) {
+	case float32:
+		buf.WriteString(",value=")
+		buf.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		buf.WriteString(",value=")
+		buf.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case uint32:
+		buf.WriteString(",value=")
+		buf.WriteString(strconv.FormatUint(uint64(v), 10))
+	case ErrorCode:
+		buf.WriteString(",value=")
+		buf.WriteString(strconv.FormatUint(uint64(v), 10))
+		if msg := v.Message(); msg != "" {
+			buf.WriteString(`,text="`)
+			buf.WriteString(influxStringEscaper.Replace(msg))
+			buf.WriteByte('"')
+		}
+	case bool:
+		buf.WriteString(",value=")
+		if v {
+			buf.WriteString("1")
+		} else {
+			buf.WriteString("0")
+		}
+	case time.Duration:
+		buf.WriteString(",value=")
+		buf.WriteString(strconv.FormatFloat(v.Seconds(), 'f', -1, 64))
+	default:
+		buf.WriteString(`,text="`)
+		buf.WriteString(influxStringEscaper.Replace(FormatValue(v)))
+		buf.WriteByte('"')
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.FormatInt(ts.Unix(), 10))
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,66 @@
+package luxtronik
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfluxSink(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	pm := DataTypeMap{
+		0: NewCelsius("ID_WEB_Temperatur_TVL", false),
+		1: NewOperationMode("ID_WEB_WP_BZ_akt"),
+		2: NewBool("ID_WEB_EVUin", false),
+		3: NewErrorcode("ID_WEB_ERROR_Nr0"),
+	}
+	require.NoError(t, pm.SetRawValues([]uint32{325, 1, 1, 701}))
+
+	runTest := func(opts InfluxOptions, wantPath string, checkAuth func(*testing.T, *http.Request)) func(*testing.T) {
+		return func(t *testing.T) {
+			var body string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, wantPath, r.URL.Path)
+				checkAuth(t, r)
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			opts.URL = srv.URL
+			s, err := NewInfluxSink(opts)
+			require.NoError(t, err)
+			require.NoError(t, s.Write(context.Background(), "heat pump", ts, BlockCalculations, pm))
+			require.NoError(t, s.Close())
+
+			assert.Equal(t, strings.Join([]string{
+				`calculations,host=heat\ pump,class=temperature,name=ID_WEB_Temperatur_TVL raw=325i,value=32.5 1700000000`,
+				`calculations,host=heat\ pump,class=selection,name=ID_WEB_WP_BZ_akt raw=1i,text="hot water" 1700000000`,
+				`calculations,host=heat\ pump,class=boolean,name=ID_WEB_EVUin raw=1i,value=1 1700000000`,
+				`calculations,host=heat\ pump,class=value,name=ID_WEB_ERROR_Nr0 raw=701i,value=701,text="low pressure fault" 1700000000`,
+			}, "\n")+"\n", body)
+		}
+	}
+
+	t.Run("v1", runTest(InfluxOptions{Database: "lux", Username: "u", Password: "p"}, "/write",
+		func(t *testing.T, r *http.Request) {
+			assert.Equal(t, "lux", r.URL.Query().Get("db"))
+			u, p, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "u", u)
+			assert.Equal(t, "p", p)
+		}))
+	t.Run("v2", runTest(InfluxOptions{Org: "home", Bucket: "lux", Token: "secret"}, "/api/v2/write",
+		func(t *testing.T, r *http.Request) {
+			assert.Equal(t, "lux", r.URL.Query().Get("bucket"))
+			assert.Equal(t, "Token secret", r.Header.Get("Authorization"))
+		}))
+}
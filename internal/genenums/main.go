@@ -0,0 +1,187 @@
+// Command genenums generates the enum types of the selection codes from
+// data/enums.csv, see generate.go in the root package.
+//
+// Each row defines one code of a type:
+//
+//	type,value,const,code
+//	HeatingMode,3,,Holidays
+//
+// value is the raw value of the controller and code the English text which
+// FromHeatPump returned before the types existed. The constant is named by
+// the type and const, without const by the code in camel case, e.g.
+// HeatingModeSecondHeatsource for Second heatsource. Set const if codes of a
+// type repeat.
+//
+// Every type gets String, a Parse function and the text marshalling of its
+// code, the codes are kept in a slice for the data types.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var header = []string{"type", "value", "const", "code"}
+
+var nameRe = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+type enum struct {
+	typ   string
+	codes []code
+}
+
+type code struct {
+	value    int
+	constant string
+	text     string
+}
+
+func main() {
+	in := flag.String("in", "", "CSV file with the codes")
+	out := flag.String("out", "", "Go file to write")
+	pkg := flag.String("package", "luxtronik", "package of the generated file")
+	flag.Parse()
+	if *in == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	enums, err := readEnums(f)
+	_ = f.Close()
+	if err != nil {
+		log.Fatalf("%s: %s", *in, err)
+	}
+	src, err := generate(*pkg, filepath.ToSlash(*in), enums)
+	if err != nil {
+		log.Fatalf("%s: %s", *in, err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readEnums returns the types in the order of their first row.
+func readEnums(r io.Reader) ([]*enum, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(header)
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || strings.Join(rows[0], ",") != strings.Join(header, ",") {
+		return nil, fmt.Errorf("header must be %s", strings.Join(header, ","))
+	}
+
+	var (
+		enums  []*enum
+		errs   []error
+		byType = map[string]*enum{}
+		values = map[string]int{}
+		consts = map[string]int{}
+	)
+	for i, row := range rows[1:] {
+		line := i + 2
+		if !nameRe.MatchString(row[0]) {
+			errs = append(errs, fmt.Errorf("line %d: invalid type %q", line, row[0]))
+			continue
+		}
+		v, err := strconv.Atoi(row[1])
+		if err != nil || v < 0 {
+			errs = append(errs, fmt.Errorf("line %d: invalid value %q", line, row[1]))
+			continue
+		}
+		if row[3] == "" {
+			errs = append(errs, fmt.Errorf("line %d: empty code", line))
+			continue
+		}
+		key := row[0] + "/" + row[1]
+		if prev, ok := values[key]; ok {
+			errs = append(errs, fmt.Errorf("line %d: value %d of %s already defined in line %d", line, v, row[0], prev))
+			continue
+		}
+		values[key] = line
+
+		c := code{value: v, constant: row[2], text: row[3]}
+		if c.constant == "" {
+			c.constant = constName(c.text)
+		}
+		if !nameRe.MatchString(c.constant) {
+			errs = append(errs, fmt.Errorf("line %d: invalid const %q", line, c.constant))
+		}
+		c.constant = row[0] + c.constant
+		if prev, ok := consts[c.constant]; ok {
+			errs = append(errs, fmt.Errorf("line %d: const %s already defined in line %d, set the const column", line, c.constant, prev))
+		}
+		consts[c.constant] = line
+
+		e, ok := byType[row[0]]
+		if !ok {
+			e = &enum{typ: row[0]}
+			byType[row[0]] = e
+			enums = append(enums, e)
+		}
+		e.codes = append(e.codes, c)
+	}
+	return enums, errors.Join(errs...)
+}
+
+func generate(pkg, source string, enums []*enum) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genenums from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+	for _, e := range enums {
+		codes := codesVar(e.typ)
+		fmt.Fprintf(&buf, "\n// %s is a code returned by FromHeatPump, see Parse%s.\n", e.typ, e.typ)
+		fmt.Fprintf(&buf, "type %s uint32\n\nconst (\n", e.typ)
+		for _, c := range e.codes {
+			fmt.Fprintf(&buf, "\t%s %s = %d // %s\n", c.constant, e.typ, c.value, c.text)
+		}
+		fmt.Fprintf(&buf, ")\n\nvar %s = []string{\n", codes)
+		for _, c := range e.codes {
+			fmt.Fprintf(&buf, "\t%d: %q,\n", c.value, c.text)
+		}
+		buf.WriteString("}\n\n")
+
+		fmt.Fprintf(&buf, "func (v %s) String() string {\n\treturn codeString(%s, uint32(v))\n}\n\n", e.typ, codes)
+		fmt.Fprintf(&buf, "// Parse%s parses the English code of a %s, e.g. %s.\n", e.typ, e.typ, e.codes[0].text)
+		fmt.Fprintf(&buf, "func Parse%s(s string) (%s, error) {\n", e.typ, e.typ)
+		fmt.Fprintf(&buf, "\tv, err := parseCode(%q, %s, s)\n\treturn %s(v), err\n}\n\n", "Parse"+e.typ, codes, e.typ)
+		fmt.Fprintf(&buf, "func (v %s) MarshalText() ([]byte, error) {\n\treturn []byte(v.String()), nil\n}\n\n", e.typ)
+		fmt.Fprintf(&buf, "func (v *%s) UnmarshalText(text []byte) error {\n", e.typ)
+		fmt.Fprintf(&buf, "\tp, err := Parse%s(string(text))\n\tif err != nil {\n\t\treturn err\n\t}\n\t*v = p\n\treturn nil\n}\n", e.typ)
+	}
+	return format.Source(buf.Bytes())
+}
+
+// codesVar returns the name of the slice of codes of typ.
+func codesVar(typ string) string {
+	return strings.ToLower(typ[:1]) + typ[1:] + "Codes"
+}
+
+// constName joins the words of a code in camel case, e.g. swimming
+// pool/solar becomes SwimmingPoolSolar.
+func constName(text string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	enums, err := readEnums(strings.NewReader("type,value,const,code\n" +
+		"TestMode,0,,Automatic\n" +
+		"TestMode,2,,swimming pool/solar\n" +
+		"TestMode,3,Automatic2,automatic\n"))
+	require.NoError(t, err)
+
+	src, err := generate("luxtronik", "data/test.csv", enums)
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by genenums from data/test.csv; DO NOT EDIT.
+
+package luxtronik
+
+// TestMode is a code returned by FromHeatPump, see ParseTestMode.
+type TestMode uint32
+
+const (
+	TestModeAutomatic         TestMode = 0 // Automatic
+	TestModeSwimmingPoolSolar TestMode = 2 // swimming pool/solar
+	TestModeAutomatic2        TestMode = 3 // automatic
+)
+
+var testModeCodes = []string{
+	0: "Automatic",
+	2: "swimming pool/solar",
+	3: "automatic",
+}
+
+func (v TestMode) String() string {
+	return codeString(testModeCodes, uint32(v))
+}
+
+// ParseTestMode parses the English code of a TestMode, e.g. Automatic.
+func ParseTestMode(s string) (TestMode, error) {
+	v, err := parseCode("ParseTestMode", testModeCodes, s)
+	return TestMode(v), err
+}
+
+func (v TestMode) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *TestMode) UnmarshalText(text []byte) error {
+	p, err := ParseTestMode(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+`, string(src))
+}
+
+func TestReadEnums_Errors(t *testing.T) {
+	_, err := readEnums(strings.NewReader("type,value,const,code\n" +
+		"testMode,0,,Off\n" +
+		"TestMode,x,,Off\n" +
+		"TestMode,1,,Off\n" +
+		"TestMode,1,,On\n" +
+		"TestMode,2,,off\n" +
+		"TestMode,3,,\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `line 2: invalid type "testMode"`)
+	assert.Contains(t, err.Error(), `line 3: invalid value "x"`)
+	assert.Contains(t, err.Error(), "line 5: value 1 of TestMode already defined in line 4")
+	assert.Contains(t, err.Error(), "line 6: const TestModeOff already defined in line 4")
+	assert.Contains(t, err.Error(), "line 7: empty code")
+
+	_, err = readEnums(strings.NewReader("type,value,code\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,271 @@
+// Command genmaps generates the constructors of the data type maps from the
+// CSV files in the data directory, see generate.go in the root package.
+//
+// Each row defines one index:
+//
+//	index,type,name,const,visibility,writeable,range,access,comment,doc
+//	10,Celsius,ID_WEB_Temperatur_TVL,FlowTemperature,ID_Visi_Temp_Vorlauf,false,,,vorlauf,
+//
+// type is the name of the constructor without New, writeable must be empty
+// for constructors without that argument. visibility names the entry of the
+// visibilities which tells whether the controller shows the value. range
+// bounds the values which may be written as min..max or min..max/step in
+// the units of the controller. access is the level needed to write the
+// value, one of the keys of accessLevels, empty means user. comment gets
+// appended to the line, doc is written above it and may span several lines.
+//
+// Every known index also gets a constant named by the -prefix flag and const,
+// e.g. CalcFlowTemperature. Without const the name is derived from the
+// luxtronik name, ID_WEB_Temperatur_TRL_ext becomes CalcTemperaturTRLExt.
+// Unknown indexes get no constant.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var header = []string{"index", "type", "name", "const", "visibility", "writeable", "range", "access", "comment", "doc"}
+
+// accessLevels maps the access column to the constants of the package.
+var accessLevels = map[string]string{
+	"user":         "",
+	"installer":    "AccessInstaller",
+	"after-sales":  "AccessAfterSales",
+	"manufacturer": "AccessManufacturer",
+}
+
+var (
+	typeRe  = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	constRe = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+)
+
+// namePrefixes are removed from luxtronik names to derive constants.
+var namePrefixes = []string{"ID_WEB_", "ID_Visi_", "ID_"}
+
+type entry struct {
+	index      int
+	typ        string
+	name       string
+	constant   string
+	visibility string
+	writeable  string
+	// rng holds min, max and step of the range column.
+	rng []string
+	// access is the constant of the access column, empty for user.
+	access  string
+	comment string
+	doc     string
+}
+
+func main() {
+	in := flag.String("in", "", "CSV file with the definitions")
+	out := flag.String("out", "", "Go file to write")
+	fn := flag.String("func", "", "name of the generated constructor, e.g. NewParameterMap")
+	prefix := flag.String("prefix", "", "prefix of the index constants, e.g. Calc")
+	pkg := flag.String("package", "luxtronik", "package of the generated file")
+	flag.Parse()
+	if *in == "" || *out == "" || *fn == "" || *prefix == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	entries, err := readEntries(f, *prefix)
+	_ = f.Close()
+	if err != nil {
+		log.Fatalf("%s: %s", *in, err)
+	}
+	src, err := generate(*pkg, *fn, filepath.ToSlash(*in), entries)
+	if err != nil {
+		log.Fatalf("%s: %s", *in, err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readEntries(r io.Reader, prefix string) ([]entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(header)
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || strings.Join(rows[0], ",") != strings.Join(header, ",") {
+		return nil, fmt.Errorf("header must be %s", strings.Join(header, ","))
+	}
+
+	var (
+		entries []entry
+		errs    []error
+		seen    = map[int]int{}
+		consts  = map[string]int{}
+	)
+	for i, row := range rows[1:] {
+		line := i + 2
+		idx, err := strconv.Atoi(row[0])
+		if err != nil || idx < 0 {
+			errs = append(errs, fmt.Errorf("line %d: invalid index %q", line, row[0]))
+			continue
+		}
+		if prev, ok := seen[idx]; ok {
+			errs = append(errs, fmt.Errorf("line %d: index %d already defined in line %d", line, idx, prev))
+			continue
+		}
+		seen[idx] = line
+		if !typeRe.MatchString(row[1]) {
+			errs = append(errs, fmt.Errorf("line %d: invalid type %q", line, row[1]))
+		}
+		if row[5] != "" && row[5] != "true" && row[5] != "false" {
+			errs = append(errs, fmt.Errorf("line %d: writeable must be empty, true or false, got %q", line, row[5]))
+		}
+		e := entry{
+			index:      idx,
+			typ:        row[1],
+			name:       row[2],
+			constant:   row[3],
+			visibility: row[4],
+			writeable:  row[5],
+			comment:    row[8],
+			doc:        row[9],
+		}
+		if row[7] != "" {
+			access, ok := accessLevels[row[7]]
+			if !ok {
+				errs = append(errs, fmt.Errorf("line %d: invalid access %q", line, row[7]))
+			}
+			e.access = access
+		}
+		if row[6] != "" {
+			rng, err := parseRange(row[6])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			}
+			e.rng = rng
+		}
+		if e.constant == "" && !strings.HasPrefix(e.name, "Unknown_") {
+			e.constant = constName(e.name)
+		}
+		if e.constant != "" {
+			if !constRe.MatchString(e.constant) {
+				errs = append(errs, fmt.Errorf("line %d: invalid const %q", line, e.constant))
+			}
+			e.constant = prefix + e.constant
+			if prev, ok := consts[e.constant]; ok {
+				errs = append(errs, fmt.Errorf("line %d: const %s already defined in line %d, set the const column", line, e.constant, prev))
+			}
+			consts[e.constant] = line
+		}
+		entries = append(entries, e)
+	}
+	return entries, errors.Join(errs...)
+}
+
+func generate(pkg, fn, source string, entries []entry) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genmaps from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "func %s() DataTypeMap {\n\treturn DataTypeMap{\n", fn)
+	buf.WriteString("\t\t// the index number is really important because it assigns a value from\n")
+	buf.WriteString("\t\t// the heat pump to the Base object.\n")
+	for _, e := range entries {
+		if e.doc != "" {
+			for _, l := range strings.Split(e.doc, "\n") {
+				fmt.Fprintf(&buf, "\t\t// %s\n", l)
+			}
+		}
+		args := strconv.Quote(e.name)
+		if e.writeable != "" {
+			args += ", " + e.writeable
+		}
+		fmt.Fprintf(&buf, "\t\t%d: New%s(%s)", e.index, e.typ, args)
+		if e.visibility != "" {
+			fmt.Fprintf(&buf, ".WithVisibility(%q)", e.visibility)
+		}
+		if e.rng != nil {
+			fmt.Fprintf(&buf, ".WithRange(%s)", strings.Join(e.rng, ", "))
+		}
+		if e.access != "" {
+			fmt.Fprintf(&buf, ".WithAccess(%s)", e.access)
+		}
+		buf.WriteString(",")
+		if e.comment != "" {
+			fmt.Fprintf(&buf, " // %s", e.comment)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\t}\n}\n\n")
+
+	fmt.Fprintf(&buf, "// Indexes of %s.\nconst (\n", fn)
+	for _, e := range entries {
+		if e.constant != "" {
+			fmt.Fprintf(&buf, "\t%s = %d // %s\n", e.constant, e.index, e.name)
+		}
+	}
+	buf.WriteString(")\n")
+	return format.Source(buf.Bytes())
+}
+
+// parseRange parses min..max or min..max/step into the arguments of
+// WithRange.
+func parseRange(s string) ([]string, error) {
+	bounds, step, _ := strings.Cut(s, "/")
+	low, high, ok := strings.Cut(bounds, "..")
+	if step == "" {
+		step = "0"
+	}
+	var nums [3]float64
+	for i, v := range []string{low, high, step} {
+		n, err := strconv.ParseFloat(v, 64)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid range %q, want min..max or min..max/step", s)
+		}
+		nums[i] = n
+	}
+	if nums[0] >= nums[1] || nums[2] < 0 {
+		return nil, fmt.Errorf("invalid range %q, min must be below max and step not negative", s)
+	}
+	return []string{low, high, step}, nil
+}
+
+// constName derives a constant from a luxtronik name by removing its prefix
+// and joining the parts in camel case. Parts of digits stay separated, e.g.
+// ID_Einst_SuAllTg_zeit_0_6 becomes EinstSuAllTgZeit0_6.
+func constName(name string) string {
+	for _, p := range namePrefixes {
+		if strings.HasPrefix(name, p) {
+			name = strings.TrimPrefix(name, p)
+			break
+		}
+	}
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if s := b.String(); s != "" && isDigit(s[len(s)-1]) && isDigit(part[0]) {
+			b.WriteByte('_')
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	entries, err := readEntries(strings.NewReader("index,type,name,const,visibility,writeable,range,access,comment,doc\n"+
+		"10,Celsius,ID_WEB_Temperatur_TVL,FlowTemperature,ID_Visi_Temp_Vorlauf,false,,,vorlauf,\n"+
+		"2,Unknown,ID_Einst_SuAllTg_zeit_0_6,,,,,,,\"first line\nsecond line\"\n"+
+		"3,Unknown,Unknown_Calculation_3,,,,,,,\n"+
+		"4,Celsius,ID_Einst_WK_akt,,,true,-5..5/0.5,user,,\n"+
+		"5,Kelvin,ID_Einst_BWS_Hyst_akt,,,true,,installer,,\n"), "Calc")
+	require.NoError(t, err)
+
+	src, err := generate("luxtronik", "NewTestMap", "data/test.csv", entries)
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by genmaps from data/test.csv; DO NOT EDIT.
+
+package luxtronik
+
+func NewTestMap() DataTypeMap {
+	return DataTypeMap{
+		// the index number is really important because it assigns a value from
+		// the heat pump to the Base object.
+		10: NewCelsius("ID_WEB_Temperatur_TVL", false).WithVisibility("ID_Visi_Temp_Vorlauf"), // vorlauf
+		// first line
+		// second line
+		2: NewUnknown("ID_Einst_SuAllTg_zeit_0_6"),
+		3: NewUnknown("Unknown_Calculation_3"),
+		4: NewCelsius("ID_Einst_WK_akt", true).WithRange(-5, 5, 0.5),
+		5: NewKelvin("ID_Einst_BWS_Hyst_akt", true).WithAccess(AccessInstaller),
+	}
+}
+
+// Indexes of NewTestMap.
+const (
+	CalcFlowTemperature     = 10 // ID_WEB_Temperatur_TVL
+	CalcEinstSuAllTgZeit0_6 = 2  // ID_Einst_SuAllTg_zeit_0_6
+	CalcEinstWKAkt          = 4  // ID_Einst_WK_akt
+	CalcEinstBWSHystAkt     = 5  // ID_Einst_BWS_Hyst_akt
+)
+`, string(src))
+}
+
+func TestReadEntries_Errors(t *testing.T) {
+	_, err := readEntries(strings.NewReader("index,type,name,const,visibility,writeable,range,access,comment,doc\n"+
+		"1,Celsius,A,,,yes,,,,\n"+
+		"1,Celsius,B,,,true,,,,\n"+
+		"x,celsius,C,,,,,,,\n"+
+		"5,Celsius,ID_WEB_A,,,true,,,,\n"+
+		"6,Celsius,ID_A,,,true,,,,\n"+
+		"7,Celsius,ID_B,,,true,65..30,,,\n"+
+		"8,Celsius,ID_C,,,true,30-65,,,\n"+
+		"9,Celsius,ID_D,,,true,,root,,\n"), "Calc")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: writeable must be empty, true or false")
+	assert.Contains(t, err.Error(), "line 3: index 1 already defined in line 2")
+	assert.Contains(t, err.Error(), `line 4: invalid index "x"`)
+	assert.Contains(t, err.Error(), "line 6: const CalcA already defined in line 5")
+	assert.Contains(t, err.Error(), `line 7: invalid range "65..30", min must be below max`)
+	assert.Contains(t, err.Error(), `line 8: invalid range "30-65", want min..max`)
+	assert.Contains(t, err.Error(), `line 9: invalid access "root"`)
+
+	_, err = readEntries(strings.NewReader("idx,type,name,const,visibility,writeable,range,access,comment,doc\n"), "Calc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type KafkaRESTOptions struct {
+	// URL of the REST Proxy, e.g. http://localhost:8082. The user and
+	// password of the URL authenticate with basic auth.
+	URL string
+	// Prefix starts the topics, defaults to luxtronik.
+	Prefix     string
+	HTTPClient *http.Client
+}
+
+// KafkaRESTPublisher produces the messages to Kafka via the v2 API of the
+// REST Proxy of Confluent. The topic is the prefix and the kind, e.g.
+// luxtronik.change or luxtronik.alert, the key the host and the name, e.g.
+// cellar/ID_WEB_Temperatur_TA, so that the changes of a value keep their
+// order within its partition.
+type KafkaRESTPublisher struct {
+	opts     KafkaRESTOptions
+	base     string
+	user     *url.Userinfo
+	password string
+}
+
+func NewKafkaRESTPublisher(opts KafkaRESTOptions) (*KafkaRESTPublisher, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("NewKafkaRESTPublisher: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("NewKafkaRESTPublisher unsupported scheme %q, want http or https", u.Scheme)
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = "luxtronik"
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	k := &KafkaRESTPublisher{opts: opts, user: u.User}
+	if u.User != nil {
+		k.password, _ = u.User.Password()
+	}
+	u.User = nil
+	k.base = strings.TrimSuffix(u.String(), "/")
+	return k, nil
+}
+
+type kafkaRecord struct {
+	Key   []byte `json:"key"` // base64 as the binary embedded format expects
+	Value []byte `json:"value"`
+}
+
+// Topic returns the topic of m.
+func (k *KafkaRESTPublisher) Topic(m BusMessage) string {
+	return k.opts.Prefix + "." + m.Kind
+}
+
+// Publish produces the messages with one request per topic.
+func (k *KafkaRESTPublisher) Publish(ctx context.Context, msgs []BusMessage) error {
+	var topics []string
+	records := map[string][]kafkaRecord{}
+	for _, m := range msgs {
+		topic := k.Topic(m)
+		if _, ok := records[topic]; !ok {
+			topics = append(topics, topic)
+		}
+		key := m.Host
+		if m.Name != "" {
+			key += "/" + m.Name
+		}
+		records[topic] = append(records[topic], kafkaRecord{Key: []byte(key), Value: m.Payload})
+	}
+	var errs []error
+	for _, topic := range topics {
+		if err := k.produce(ctx, topic, records[topic]); err != nil {
+			errs = append(errs, fmt.Errorf("KafkaRESTPublisher.Publish %s: %w", topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (k *KafkaRESTPublisher) produce(ctx context.Context, topic string, records []kafkaRecord) error {
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.base+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.binary.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if k.user != nil {
+		req.SetBasicAuth(k.user.Username(), k.password)
+	}
+	resp, err := k.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("received status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	var res struct {
+		Offsets []struct {
+			Error string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := json.Unmarshal(msg, &res); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	for _, o := range res.Offsets {
+		if o.Error != "" {
+			return fmt.Errorf("record rejected: %s", o.Error)
+		}
+	}
+	return nil
+}
+
+func (k *KafkaRESTPublisher) Close() error { return nil }
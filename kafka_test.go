@@ -0,0 +1,50 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaRESTPublisher(t *testing.T) {
+	got := map[string][]kafkaRecord{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "alice", user)
+		assert.Equal(t, "secret", pass)
+		assert.Equal(t, "application/vnd.kafka.binary.v2+json", r.Header.Get("Content-Type"))
+		var body struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		got[r.URL.Path] = append(got[r.URL.Path], body.Records...)
+		if r.URL.Path == "/topics/site1.alert" {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"topic not found"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":7,"error_code":null,"error":null}]}`))
+	}))
+	defer srv.Close()
+
+	p, err := NewKafkaRESTPublisher(KafkaRESTOptions{URL: "http://alice:secret@" + srv.Listener.Addr().String() + "/", Prefix: "site1"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, p.Publish(ctx, []BusMessage{
+		{Kind: EventChange, Host: "cellar", Name: "ID_WEB_Temperatur_TA", Payload: []byte("4.8")},
+		{Kind: EventChange, Host: "cellar", Name: "ID_WEB_Temperatur_TVL", Payload: []byte("31")},
+	}))
+	assert.Equal(t, []kafkaRecord{
+		{Key: []byte("cellar/ID_WEB_Temperatur_TA"), Value: []byte("4.8")},
+		{Key: []byte("cellar/ID_WEB_Temperatur_TVL"), Value: []byte("31")},
+	}, got["/topics/site1.change"])
+
+	err = p.Publish(ctx, []BusMessage{{Kind: NotificationAlert, Host: "cellar", Payload: []byte("cold")}})
+	assert.ErrorContains(t, err, "topic not found")
+	assert.Equal(t, []kafkaRecord{{Key: []byte("cellar"), Value: []byte("cold")}}, got["/topics/site1.alert"])
+}
@@ -0,0 +1,445 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+)
+
+// DefaultKNXAddress is the multicast group of KNXnet/IP routing.
+const DefaultKNXAddress = "224.0.23.12:3671"
+
+// KNX application services of group telegrams.
+const (
+	knxGroupRead     = 0x00
+	knxGroupResponse = 0x40
+	knxGroupWrite    = 0x80
+)
+
+// KNXMapping maps a value to a group address. DPT is the datapoint type:
+// 1.x for booleans, 5.x for the raw value of selections and 9.x, e.g.
+// 9.001, for temperatures and other numbers as 2-byte float. It defaults to
+// 1.001 for booleans, 5.010 for selections and 9.001 otherwise.
+type KNXMapping struct {
+	Name  string
+	Group string // e.g. 1/2/3
+	DPT   string
+}
+
+// ParseKNXMapping parses mappings like ID_Einst_BWS_akt=1/2/3 or
+// ID_WEB_Temperatur_TA=1/2/4:9.001.
+func ParseKNXMapping(s string) (KNXMapping, error) {
+	name, rest, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return KNXMapping{}, fmt.Errorf("ParseKNXMapping invalid mapping %q, want name=main/middle/sub[:dpt]", s)
+	}
+	group, dpt, _ := strings.Cut(rest, ":")
+	m := KNXMapping{Name: name, Group: group, DPT: dpt}
+	if _, err := parseGroupAddress(group); err != nil {
+		return KNXMapping{}, fmt.Errorf("ParseKNXMapping %q: %w", s, err)
+	}
+	if dpt != "" && knxDPTMain(dpt) == "" {
+		return KNXMapping{}, fmt.Errorf("ParseKNXMapping %q: unsupported DPT %s", s, dpt)
+	}
+	return m, nil
+}
+
+func parseGroupAddress(s string) (uint16, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid group address %q, want main/middle/sub", s)
+	}
+	var n [3]uint64
+	for i, max := range []uint64{31, 7, 255} {
+		v, err := strconv.ParseUint(parts[i], 10, 8)
+		if err != nil || v > max {
+			return 0, fmt.Errorf("invalid group address %q", s)
+		}
+		n[i] = v
+	}
+	return uint16(n[0]<<11 | n[1]<<8 | n[2]), nil
+}
+
+func parseIndividualAddress(s string) (uint16, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid individual address %q, want area.line.device", s)
+	}
+	var n [3]uint64
+	for i, max := range []uint64{15, 15, 255} {
+		v, err := strconv.ParseUint(parts[i], 10, 8)
+		if err != nil || v > max {
+			return 0, fmt.Errorf("invalid individual address %q", s)
+		}
+		n[i] = v
+	}
+	return uint16(n[0]<<12 | n[1]<<8 | n[2]), nil
+}
+
+// knxDPTMain returns the supported main number of a DPT, empty if it is not
+// supported.
+func knxDPTMain(dpt string) string {
+	main, _, _ := strings.Cut(dpt, ".")
+	switch main {
+	case "1", "5", "9":
+		return main
+	}
+	return ""
+}
+
+type KNXOptions struct {
+	// Address receives the group telegrams, defaults to DefaultKNXAddress.
+	// A unicast address needs Listen.
+	Address string
+	// Listen is the local address for a unicast Address.
+	Listen string
+	// Source is the individual address of the sink, defaults to 15.15.250.
+	Source   string
+	Mappings []KNXMapping
+	Logger   *zap.Logger
+}
+
+// knxValue is the payload of a group telegram, small values of up to 6 bits
+// are part of the APCI.
+type knxValue struct {
+	data  []byte
+	small bool
+}
+
+type knxPoint struct {
+	KNXMapping
+	group uint16
+	dpt   string // main number
+}
+
+// KNXSink sends the mapped values as group writes via KNXnet/IP routing
+// whenever they change and answers group reads. Group writes to writeable
+// parameters are written to the heat pump with the next poll of the
+// parameters. Without a client the sink only sends.
+type KNXSink struct {
+	client *Client
+	opts   KNXOptions
+	conn   *net.UDPConn
+	dst    *net.UDPAddr
+	source uint16
+	points map[uint16]*knxPoint
+
+	mu      sync.Mutex
+	sent    map[uint16]knxValue // last sent value per group
+	pending map[uint16][]byte   // received group writes
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewKNXSink(c *Client, opts KNXOptions) (*KNXSink, error) {
+	if opts.Address == "" {
+		opts.Address = DefaultKNXAddress
+	}
+	if opts.Source == "" {
+		opts.Source = "15.15.250"
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	source, err := parseIndividualAddress(opts.Source)
+	if err != nil {
+		return nil, fmt.Errorf("NewKNXSink: %w", err)
+	}
+	k := &KNXSink{
+		client:  c,
+		opts:    opts,
+		source:  source,
+		points:  map[uint16]*knxPoint{},
+		sent:    map[uint16]knxValue{},
+		pending: map[uint16][]byte{},
+		done:    make(chan struct{}),
+	}
+	for _, m := range opts.Mappings {
+		group, err := parseGroupAddress(m.Group)
+		if err != nil {
+			return nil, fmt.Errorf("NewKNXSink %s: %w", m.Name, err)
+		}
+		p := &knxPoint{KNXMapping: m, group: group, dpt: knxDPTMain(m.DPT)}
+		if m.DPT != "" && p.dpt == "" {
+			return nil, fmt.Errorf("NewKNXSink %s: unsupported DPT %s", m.Name, m.DPT)
+		}
+		k.points[group] = p
+	}
+
+	if k.dst, err = net.ResolveUDPAddr("udp4", opts.Address); err != nil {
+		return nil, fmt.Errorf("NewKNXSink: %w", err)
+	}
+	if k.dst.IP.IsMulticast() {
+		k.conn, err = net.ListenMulticastUDP("udp4", nil, k.dst)
+	} else {
+		var local *net.UDPAddr
+		if local, err = net.ResolveUDPAddr("udp4", opts.Listen); err == nil {
+			k.conn, err = net.ListenUDP("udp4", local)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewKNXSink: %w", err)
+	}
+	k.wg.Add(1)
+	go k.receive()
+	return k, nil
+}
+
+// LocalAddr returns the address the sink receives on.
+func (k *KNXSink) LocalAddr() net.Addr {
+	return k.conn.LocalAddr()
+}
+
+func (k *KNXSink) Write(ctx context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	if k.client != nil && host != k.client.Name() {
+		return nil
+	}
+	var errs []error
+	if block == BlockParameters {
+		errs = append(errs, k.applyPending(ctx, pm))
+	}
+	for group, p := range k.points {
+		_, b, ok := pm.Lookup(p.Name)
+		if !ok {
+			continue
+		}
+		v, err := knxEncode(p, b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("KNXSink.Write %s: %w", p.Name, err))
+			continue
+		}
+		k.mu.Lock()
+		prev, seen := k.sent[group]
+		k.sent[group] = v
+		k.mu.Unlock()
+		if seen && string(prev.data) == string(v.data) {
+			continue
+		}
+		if err := k.send(group, knxGroupWrite, v); err != nil {
+			errs = append(errs, fmt.Errorf("KNXSink.Write %s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyPending writes the received group writes to the heat pump.
+func (k *KNXSink) applyPending(ctx context.Context, pm DataTypeMap) error {
+	k.mu.Lock()
+	pending := k.pending
+	k.pending = map[uint16][]byte{}
+	k.mu.Unlock()
+
+	var errs []error
+	for group, payload := range pending {
+		p := k.points[group]
+		idx, b, ok := pm.Lookup(p.Name)
+		if !ok || !b.writeable {
+			k.opts.Logger.Warn("knx group write to a value which is not writeable", zap.String("name", p.Name), zap.String("group", p.Group))
+			continue
+		}
+		val, err := knxDecode(p, b, payload)
+		if err == nil {
+			if raw, isRaw := val.(uint32); isRaw {
+				err = k.client.WriteParameterRawContext(ctx, pm, idx, raw)
+			} else {
+				err = k.client.WriteParameterContext(ctx, pm, idx, val)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("KNXSink group write %s to %s: %w", p.Group, p.Name, err))
+			continue
+		}
+		k.opts.Logger.Info("knx group write applied", zap.String("name", p.Name), zap.String("group", p.Group), zap.Any("value", val))
+	}
+	return errors.Join(errs...)
+}
+
+func (k *KNXSink) receive() {
+	defer k.wg.Done()
+	buf := make([]byte, 512)
+	for {
+		n, _, err := k.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-k.done:
+				return
+			default:
+			}
+			k.opts.Logger.Error("knx receive failed", zap.Error(err))
+			return
+		}
+		src, group, service, payload, ok := parseKNXRouting(buf[:n])
+		if !ok || src == k.source {
+			continue
+		}
+		p, mapped := k.points[group]
+		if !mapped {
+			continue
+		}
+		switch service {
+		case knxGroupRead:
+			k.mu.Lock()
+			v, ok := k.sent[group]
+			k.mu.Unlock()
+			if ok {
+				if err := k.send(group, knxGroupResponse, v); err != nil {
+					k.opts.Logger.Error("knx group response failed", zap.String("group", p.Group), zap.Error(err))
+				}
+			}
+		case knxGroupWrite:
+			if k.client == nil {
+				continue
+			}
+			k.mu.Lock()
+			k.pending[group] = payload
+			k.mu.Unlock()
+		}
+	}
+}
+
+// send transmits a group telegram as routing indication.
+func (k *KNXSink) send(group uint16, service byte, v knxValue) error {
+	cemi := []byte{0x29, 0x00, 0xbc, 0xe0}
+	cemi = binary.BigEndian.AppendUint16(cemi, k.source)
+	cemi = binary.BigEndian.AppendUint16(cemi, group)
+	if v.small {
+		cemi = append(cemi, 1, 0x00, service|v.data[0]&0x3f)
+	} else {
+		cemi = append(cemi, byte(1+len(v.data)), 0x00, service)
+		cemi = append(cemi, v.data...)
+	}
+	frame := []byte{0x06, 0x10, 0x05, 0x30}
+	frame = binary.BigEndian.AppendUint16(frame, uint16(6+len(cemi)))
+	_, err := k.conn.WriteToUDP(append(frame, cemi...), k.dst)
+	return err
+}
+
+// parseKNXRouting returns the group telegram of a routing indication.
+func parseKNXRouting(b []byte) (src, group uint16, service byte, payload []byte, ok bool) {
+	if len(b) < 6 || b[0] != 0x06 || b[1] != 0x10 || binary.BigEndian.Uint16(b[2:]) != 0x0530 {
+		return 0, 0, 0, nil, false
+	}
+	cemi := b[6:]
+	if len(cemi) < 2 || (cemi[0] != 0x29 && cemi[0] != 0x11) {
+		return 0, 0, 0, nil, false
+	}
+	cemi = cemi[2+int(cemi[1]):]
+	if len(cemi) < 9 || cemi[1]&0x80 == 0 {
+		return 0, 0, 0, nil, false
+	}
+	src = binary.BigEndian.Uint16(cemi[2:])
+	group = binary.BigEndian.Uint16(cemi[4:])
+	length := int(cemi[6])
+	if len(cemi) < 8+length {
+		return 0, 0, 0, nil, false
+	}
+	apci := cemi[8]
+	service = apci & 0xc0
+	if length == 1 {
+		return src, group, service, []byte{apci & 0x3f}, true
+	}
+	return src, group, service, cemi[9 : 8+length], true
+}
+
+func (k *KNXSink) Close() error {
+	close(k.done)
+	err := k.conn.Close()
+	k.wg.Wait()
+	return err
+}
+
+// knxDPT returns the DPT main number of the point for b.
+func knxDPT(p *knxPoint, b *Base) string {
+	switch {
+	case p.dpt != "":
+		return p.dpt
+	case b.returnType == reflect.Bool:
+		return "1"
+	case b.codes != nil:
+		return "5"
+	}
+	return "9"
+}
+
+func knxEncode(p *knxPoint, b *Base) (knxValue, error) {
+	switch knxDPT(p, b) {
+	case "1":
+		if cast.ToBool(b.FromHeatPump()) {
+			return knxValue{data: []byte{1}, small: true}, nil
+		}
+		return knxValue{data: []byte{0}, small: true}, nil
+	case "5":
+		if b.reading.Raw > 255 {
+			return knxValue{}, fmt.Errorf("raw value %d exceeds DPT 5", b.reading.Raw)
+		}
+		return knxValue{data: []byte{byte(b.reading.Raw)}}, nil
+	}
+	v := b.canonical().FromHeatPump()
+	if c, ok := v.(ErrorCode); ok {
+		v = uint32(c)
+	}
+	f, err := cast.ToFloat64E(v)
+	if err != nil {
+		return knxValue{}, fmt.Errorf("no number: %w", err)
+	}
+	data, err := encodeDPT9(f)
+	return knxValue{data: data}, err
+}
+
+// knxDecode returns the value of a group write, raw values as uint32.
+func knxDecode(p *knxPoint, b *Base, payload []byte) (any, error) {
+	switch knxDPT(p, b) {
+	case "1":
+		return len(payload) == 1 && payload[0]&1 == 1, nil
+	case "5":
+		if len(payload) != 1 {
+			return nil, fmt.Errorf("DPT 5 needs 1 byte, got %d: %w", len(payload), ErrInvalidValue)
+		}
+		raw := uint32(payload[0])
+		if b.codes != nil && (int(raw) >= len(b.codes) || b.codes[raw] == "") {
+			return nil, fmt.Errorf("unknown code %d: %w", raw, ErrInvalidValue)
+		}
+		return raw, nil
+	}
+	if len(payload) != 2 {
+		return nil, fmt.Errorf("DPT 9 needs 2 bytes, got %d: %w", len(payload), ErrInvalidValue)
+	}
+	return decodeDPT9(payload), nil
+}
+
+// encodeDPT9 encodes the 2-byte float 0.01*M*2^E with a 12 bit mantissa.
+func encodeDPT9(v float64) ([]byte, error) {
+	if v < -671088.64 || v > 670760.96 {
+		return nil, fmt.Errorf("%v out of the DPT 9 range: %w", v, ErrInvalidValue)
+	}
+	m := math.Round(v * 100)
+	e := 0
+	for m < -2048 || m > 2047 {
+		e++
+		m = math.Round(v * 100 / float64(int(1)<<e))
+	}
+	mant := uint16(int16(m)) & 0x0fff
+	raw := (mant&0x0800)<<4 | uint16(e)<<11 | mant&0x07ff
+	return binary.BigEndian.AppendUint16(nil, raw), nil
+}
+
+func decodeDPT9(b []byte) float64 {
+	raw := binary.BigEndian.Uint16(b)
+	m := int(raw & 0x07ff)
+	if raw&0x8000 != 0 {
+		m -= 2048
+	}
+	e := int(raw>>11) & 0x0f
+	return 0.01 * float64(m) * float64(int(1)<<e)
+}
@@ -0,0 +1,111 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDPT9(t *testing.T) {
+	for _, v := range []float64{0, 21.5, -12.3, 48.5, 655.36, -671088.64, 670760.96} {
+		b, err := encodeDPT9(v)
+		require.NoError(t, err)
+		// the resolution halves with every exponent step
+		assert.InDelta(t, v, decodeDPT9(b), 0.01+math.Abs(v)/1000, "%v", v)
+	}
+	b, _ := encodeDPT9(21.5)
+	assert.Equal(t, []byte{0x0c, 0x33}, b)
+	_, err := encodeDPT9(1e6)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestParseKNXMapping(t *testing.T) {
+	m, err := ParseKNXMapping("ID_WEB_Temperatur_TA=1/2/4:9.001")
+	require.NoError(t, err)
+	assert.Equal(t, KNXMapping{Name: "ID_WEB_Temperatur_TA", Group: "1/2/4", DPT: "9.001"}, m)
+	for _, s := range []string{"1/2/3", "ID_Einst_BWS_akt=1/2", "ID_Einst_BWS_akt=32/0/0", "ID_Einst_BWS_akt=1/2/3:14.056"} {
+		_, err := ParseKNXMapping(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestKNXSink(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+	require.NoError(t, c.Connect())
+
+	peer, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer peer.Close()
+
+	k, err := NewKNXSink(c, KNXOptions{
+		Address: peer.LocalAddr().String(),
+		Listen:  "127.0.0.1:0",
+		Mappings: []KNXMapping{
+			{Name: "ID_Einst_BWS_akt", Group: "1/2/3"},
+			{Name: "ID_Ba_Hz_akt", Group: "1/2/5"},
+		},
+	})
+	require.NoError(t, err)
+	defer k.Close()
+
+	recv := func() (uint16, byte, []byte) {
+		buf := make([]byte, 512)
+		require.NoError(t, peer.SetReadDeadline(time.Now().Add(time.Second)))
+		n, err := peer.Read(buf)
+		require.NoError(t, err)
+		_, group, service, payload, ok := parseKNXRouting(buf[:n])
+		require.True(t, ok)
+		return group, service, payload
+	}
+
+	pm := NewParameterMap()
+	pm[ParamHotWaterTarget].reading.Raw = 485
+	pm[ParamHeatingMode].reading.Raw = 2
+	ctx := context.Background()
+	require.NoError(t, k.Write(ctx, c.Name(), time.Now(), BlockParameters, pm))
+	got := map[uint16][]byte{}
+	for i := 0; i < 2; i++ {
+		group, service, payload := recv()
+		assert.Equal(t, byte(knxGroupWrite), service)
+		got[group] = payload
+	}
+	assert.InDelta(t, 48.5, decodeDPT9(got[1<<11|2<<8|3]), 0.04)
+	assert.Equal(t, []byte{2}, got[1<<11|2<<8|5], "selections as DPT 5")
+
+	// unchanged values are not sent again, a group read is answered
+	require.NoError(t, k.Write(ctx, c.Name(), time.Now(), BlockParameters, pm))
+	send := func(group uint16, apci byte, data ...byte) {
+		cemi := []byte{0x29, 0x00, 0xbc, 0xe0, 0x11, 0x01}
+		cemi = binary.BigEndian.AppendUint16(cemi, group)
+		cemi = append(cemi, byte(1+len(data)), 0x00, apci)
+		cemi = append(cemi, data...)
+		frame := binary.BigEndian.AppendUint16([]byte{0x06, 0x10, 0x05, 0x30}, uint16(6+len(cemi)))
+		_, err := peer.WriteTo(append(frame, cemi...), k.LocalAddr())
+		require.NoError(t, err)
+	}
+	send(1<<11|2<<8|3, knxGroupRead)
+	group, service, payload := recv()
+	assert.Equal(t, uint16(1<<11|2<<8|3), group)
+	assert.Equal(t, byte(knxGroupResponse), service)
+	assert.InDelta(t, 48.5, decodeDPT9(payload), 0.04)
+
+	dpt9, _ := encodeDPT9(50)
+	send(1<<11|2<<8|3, knxGroupWrite, dpt9...)
+	require.Eventually(t, func() bool {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		return len(k.pending) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, k.Write(ctx, c.Name(), time.Now(), BlockParameters, pm))
+	hp.mu.Lock()
+	assert.Equal(t, uint32(500), hp.parameters[ParamHotWaterTarget])
+	hp.mu.Unlock()
+}
@@ -0,0 +1,90 @@
+package luxtronik
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type valueRange struct {
+	min, max, step float64
+}
+
+// WithRange bounds the values which ToHeatPump accepts, in the units of the
+// controller. A step of zero allows every value of the resolution. It
+// returns b for use in map definitions. The definition is copied, other
+// maps sharing it keep their range, see DataTypeMap.SetFactors.
+func (b *Base) WithRange(min, max, step float64) *Base {
+	d := *b.Definition
+	d.limits = &valueRange{min: min, max: max, step: step}
+	b.Definition = &d
+	return b
+}
+
+// Range returns the bounds and the step of the written values in the unit
+// of b, see Unit. ok is false if the value has no range.
+func (b *Base) Range() (min, max, step float64, ok bool) {
+	if b.limits == nil {
+		return 0, 0, 0, false
+	}
+	step = b.limits.step
+	if step == 0 && b.scaled() {
+		step = float64(b.factor)
+	}
+	if conv, isConv := b.conversion(); isConv {
+		// the step does not convert to a round number
+		return conv.from(b.limits.min), conv.from(b.limits.max), 0, true
+	}
+	return b.limits.min, b.limits.max, step, true
+}
+
+// checkRange validates the raw value of val against the range. The
+// controller clamps values outside silently, so they are rejected before.
+func (b *Base) checkRange(val any, raw uint32) error {
+	r := b.limits
+	if r == nil {
+		return nil
+	}
+	v := b.number(raw)
+	if b.scaled() {
+		v *= float64(b.factor)
+	}
+	if v < r.min-1e-6 || v > r.max+1e-6 {
+		return fmt.Errorf("ToHeatPump %s value %v out of range %s to %s %s: %w",
+			b.luxtronikName, val, formatLimit(b.fromMetric(r.min)), formatLimit(b.fromMetric(r.max)), b.Unit(), ErrInvalidValue)
+	}
+	if r.step > 0 {
+		if n := (v - r.min) / r.step; math.Abs(n-math.Round(n)) > 1e-6 {
+			return fmt.Errorf("ToHeatPump %s value %v not a multiple of %s %s from %s: %w",
+				b.luxtronikName, val, formatLimit(r.step), b.unit, formatLimit(r.min), ErrInvalidValue)
+		}
+	}
+	return nil
+}
+
+// clampRaw limits the raw value to the range of b, e.g. a heating offset
+// computed by adding a boost.
+func (b *Base) clampRaw(raw uint32) uint32 {
+	r := b.limits
+	if r == nil {
+		return raw
+	}
+	factor := 1.0
+	if b.scaled() {
+		factor = float64(b.factor)
+	}
+	v := b.number(raw) * factor
+	switch {
+	case v < r.min-1e-6:
+		v = r.min
+	case v > r.max+1e-6:
+		v = r.max
+	default:
+		return raw
+	}
+	return uint32(int32(math.Round(v / factor)))
+}
+
+func formatLimit(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
@@ -1,13 +1,17 @@
 package luxtronik
 
 import (
-	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"net"
+	"slices"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -27,144 +31,563 @@ const (
 // Luxtronik controller, which seems unstable otherwise.
 var globalLock = &sync.Mutex{}
 
+// maxFrameLength bounds the values of a frame far above the about 1200
+// parameters of current firmwares, a corrupt header must not allocate
+// gigabytes.
+const maxFrameLength = 1 << 16
+
+// Client speaks the protocol of a single heat pump over one TCP connection.
+// A Client must not be used concurrently, the requests and responses of two
+// goroutines would interleave on the connection. Share a Device or use one
+// client per goroutine instead.
 type Client struct {
 	opts Options
 	host string
 	port string
 	conn net.Conn
+	log  *zap.Logger
+	// tracer creates the spans of connects, reads and writes
+	tracer trace.Tracer
+	// firmware of the last calculations read
+	firmware string
+	// frames holds the header of the last frame per command
+	frames map[int32]FrameMeta
+	// connects counts the successful connects to tell reconnects apart
+	connects int
+	// bytes transferred by the current operation, see observe
+	sent, received int
+	// requested is the time of the last write, see Options.TraceWriter
+	requested time.Time
+	// snapshot serializes ReadAll, see Snapshot
+	snapshot sync.Mutex
 }
 
 type Options struct {
-	ConnCB      func(net.Conn) // gets called during connect to set conn specific params
+	// Alias names the heat pump in logs and sinks, defaults to the host.
+	Alias  string
+	ConnCB func(net.Conn) // gets called during connect to set conn specific params
+	// SafeMode only writes parameters which are marked writeable. Without
+	// it WriteParameter and WriteParameterRaw also write the others, e.g.
+	// parameters the maps do not know as writeable yet, still checking
+	// their range and access level. Forms only write through a client in
+	// SafeMode.
 	SafeMode    bool
 	DialTimeout time.Duration
-	Logger      *zap.Logger
+	// DialRetries retries a failed connect up to this many times, waiting
+	// DialBackoff before the first retry and twice as long before each
+	// further one. DialBackoff defaults to a second. Without retries a failed
+	// connect returns at once and the next operation dials again.
+	DialRetries int
+	DialBackoff time.Duration
+	// Logger receives connects at debug and reconnects at info level, the
+	// commands with frame lengths and durations at debug level and decode
+	// problems as warnings. Defaults to a no-op logger.
+	Logger *zap.Logger
+	// Metrics receives latency, byte counts, reconnects and errors of every
+	// connect, read and write, e.g. to feed Prometheus. Optional.
+	Metrics Metrics
+	// TracerProvider creates spans for connects, block reads and parameter
+	// writes. Defaults to the global provider of otel.
+	TracerProvider trace.TracerProvider
+	// TolerantFrames accepts frames which do not match the length of the
+	// data type map. Extra values get unknown entries which are added to the
+	// map, missing values keep their previous value. Both are logged when
+	// the frame length changes. By default such frames are rejected.
+	TolerantFrames bool
+	// Location is the time zone of the timestamps of read values, e.g. the
+	// one of the heat pump when the consumer runs elsewhere. Defaults to
+	// time.Local.
+	Location *time.Location
+	// Units converts the values of read maps, e.g. to °F. Written values are
+	// expected in the same units. The typed APIs like HeatingCurve always
+	// use the units of the controller.
+	Units Units
+	// Language translates the selection codes of read maps, which are then
+	// strings instead of enums like HeatingMode. Written codes may be
+	// translated or English. Defaults to English.
+	Language Language
+	// DryRun validates and converts writes and logs them at info level
+	// instead of sending them, e.g. while developing automations against a
+	// real heat pump. Reads are not affected.
+	DryRun bool
+	// TraceWriter receives a hex dump of every chunk sent to and received
+	// from the controller with its direction and the time since the last
+	// request, e.g. os.Stderr to debug the protocol. Optional.
+	TraceWriter io.Writer
 }
 
-func MustNewClient(hostPort string, opts Options) *Client {
-	host, port, err := net.SplitHostPort(hostPort)
+// MustNewClient is NewClient but panics on an invalid address, e.g. for
+// addresses which are known to be valid.
+func MustNewClient(hostPort string, opts ...Option) *Client {
+	c, err := NewClient(hostPort, opts...)
 	if err != nil {
 		panic(err)
 	}
+	return c
+}
+
+// NewClient creates the client of the heat pump at hostPort, e.g.
+// 192.168.0.121:8889 or just 192.168.0.121, see NormalizeAddr. The options
+// are applied in order, either an Options struct or functional ones like
+// WithLogger. It does not connect, see Connect. A client must not be used
+// concurrently, share a Device instead.
+func NewClient(hostPort string, options ...Option) (*Client, error) {
+	addr, err := NormalizeAddr(hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("NewClient: %w", err)
+	}
+	host, port, _ := net.SplitHostPort(addr)
+	var opts Options
+	for _, o := range options {
+		o.apply(&opts)
+	}
 	if opts.DialTimeout < 1 {
 		opts.DialTimeout = time.Minute
 	}
+	if opts.DialBackoff < 1 {
+		opts.DialBackoff = time.Second
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
 
-	return &Client{
+	c := &Client{
 		opts: opts,
 		host: host,
 		port: port,
 	}
+	c.log = opts.Logger.With(zap.String("host", c.Name()))
+	c.tracer = opts.TracerProvider.Tracer(tracerName)
+	return c, nil
+}
+
+// sibling returns a new client of the same heat pump with the same options,
+// e.g. for another connection.
+func (c *Client) sibling() *Client {
+	return MustNewClient(net.JoinHostPort(c.host, c.port), c.opts)
+}
+
+// Name returns the alias of the heat pump or its host if no alias is set.
+func (c *Client) Name() string {
+	if c.opts.Alias != "" {
+		return c.opts.Alias
+	}
+	return c.host
+}
+
+// SetDeadline bounds all following reads and writes of the connection, see
+// net.Conn.SetDeadline. The zero time removes the deadline. Without a
+// connection it does nothing.
+func (c *Client) SetDeadline(t time.Time) error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.SetDeadline(t)
 }
 
 func (c *Client) Close() error {
 	if c.conn == nil {
 		return nil
 	}
-	return c.conn.Close()
+	err := c.conn.Close()
+	c.conn = nil
+	c.log.Debug("connection closed", zap.Error(err))
+	return err
+}
+
+// Connect opens the connection if it is not open yet.
+func (c *Client) Connect() error {
+	return c.connect(context.Background())
+}
+
+// ConnectContext is Connect with a context which bounds the dial.
+func (c *Client) ConnectContext(ctx context.Context) error {
+	return c.connect(ctx)
 }
 
-func (c *Client) Connect() (err error) {
+func (c *Client) connect(ctx context.Context) (err error) {
 	if c.conn == nil {
-		c.conn, err = net.DialTimeout("tcp", c.host+":"+c.port, c.opts.DialTimeout)
+		_, span := c.startSpan(ctx, "luxtronik.Connect")
+		defer func() { endSpan(span, err) }()
+
+		addr := net.JoinHostPort(c.host, c.port)
+		start := time.Now()
+		d := net.Dialer{Timeout: c.opts.DialTimeout}
+		backoff := c.opts.DialBackoff
+		for attempt := 1; ; attempt++ {
+			c.conn, err = d.DialContext(ctx, "tcp", addr)
+			if err == nil || attempt > c.opts.DialRetries {
+				break
+			}
+			c.log.Debug("connect failed, retrying", zap.String("addr", addr), zap.Int("attempt", attempt),
+				zap.Duration("backoff", backoff), zap.Error(err))
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				err = fmt.Errorf("%w: %w", err, ctx.Err())
+			case <-timer.C:
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			backoff *= 2
+		}
 		if err != nil {
+			c.log.Warn("connect failed", zap.String("addr", addr), zap.Duration("duration", time.Since(start)), zap.Error(err))
+			c.conn = nil
+			err = &ConnectionError{Op: "dial", Addr: addr, Err: err}
+			c.observe(OpConnect, start, err)
 			return err
 		}
 		if c.opts.ConnCB != nil {
 			c.opts.ConnCB(c.conn)
 		}
+		c.observe(OpConnect, start, nil)
+		c.connects++
+		fields := []zap.Field{zap.String("addr", addr), zap.Duration("duration", time.Since(start))}
+		if c.connects > 1 {
+			c.log.Info("reconnected", append(fields, zap.Int("connects", c.connects))...)
+		} else {
+			c.log.Debug("connected", fields...)
+		}
 	}
 
 	return err
 }
 
-func (c *Client) readParameters(pm DataTypeMap) error {
-	return c.readFromHeatPump(pm, ParametersRead, 0)
+// ReadParameters reads all parameters into pm, see NewParameterMap.
+func (c *Client) ReadParameters(pm DataTypeMap) error {
+	return c.readFromHeatPump(context.Background(), pm, ParametersRead, 0)
 }
 
-func (c *Client) readCalculations(pm DataTypeMap) error {
-	return c.readFromHeatPump(pm, CalculationsRead, 0)
+// ReadCalculations reads all calculations into pm, see NewCalculationsMap.
+func (c *Client) ReadCalculations(pm DataTypeMap) error {
+	return c.readFromHeatPump(context.Background(), pm, CalculationsRead, 0)
 }
 
-func (c *Client) readVisibilities(pm DataTypeMap) error {
-	return c.readFromHeatPump(pm, VisibilitiesRead, 0)
+// ReadVisibilities reads all visibilities into pm, see NewVisibilitiesMap.
+func (c *Client) ReadVisibilities(pm DataTypeMap) error {
+	return c.readFromHeatPump(context.Background(), pm, VisibilitiesRead, 0)
 }
 
-func (c *Client) readFromHeatPump(pm DataTypeMap, data ...int32) error {
-	if len(data) < 2 {
-		return fmt.Errorf("")
+// ReadParametersContext is ReadParameters with a context. Cancelling the
+// context aborts the read, the connection must be closed afterwards.
+func (c *Client) ReadParametersContext(ctx context.Context, pm DataTypeMap) error {
+	return c.readFromHeatPump(ctx, pm, ParametersRead, 0)
+}
+
+// ReadCalculationsContext is ReadCalculations with a context, see
+// ReadParametersContext.
+func (c *Client) ReadCalculationsContext(ctx context.Context, pm DataTypeMap) error {
+	return c.readFromHeatPump(ctx, pm, CalculationsRead, 0)
+}
+
+// ReadVisibilitiesContext is ReadVisibilities with a context, see
+// ReadParametersContext.
+func (c *Client) ReadVisibilitiesContext(ctx context.Context, pm DataTypeMap) error {
+	return c.readFromHeatPump(ctx, pm, VisibilitiesRead, 0)
+}
+
+// WriteParameter converts val with the definition of the parameter at index
+// idx in pm and writes it to the heat pump. Parameters which are not
+// writeable are rejected in SafeMode before anything gets sent.
+func (c *Client) WriteParameter(pm DataTypeMap, idx int, val any) error {
+	return c.WriteParameterContext(context.Background(), pm, idx, val)
+}
+
+// WriteParameterContext is WriteParameter with a context, see
+// ReadParametersContext.
+func (c *Client) WriteParameterContext(ctx context.Context, pm DataTypeMap, idx int, val any) error {
+	b, ok := pm[idx]
+	if !ok {
+		return fmt.Errorf("WriteParameter parameter index %d: %w", idx, ErrUnknownIndex)
+	}
+	var raw uint32
+	var err error
+	if b.writeable || c.opts.SafeMode {
+		raw, err = b.ToHeatPump(val)
+	} else if raw, err = b.toRaw(val); err == nil {
+		err = b.checkRange(val, raw)
 	}
-	_, err := c.netWrite(data...)
 	if err != nil {
-		return fmt.Errorf("readFromHeatPump.netWrite to send %d failed: %w", data[0], err)
+		return fmt.Errorf("WriteParameter.ToHeatPump %q failed: %w", b.luxtronikName, err)
+	}
+	if err := checkAccess(pm, b); err != nil {
+		return fmt.Errorf("WriteParameter %w", err)
 	}
+	return c.writeParameterRaw(ctx, b, idx, raw)
+}
 
-	cmd, err := c.readUint32()
+// WriteParameterRaw writes an already converted value, e.g. from a backup.
+// Values outside the range of the parameter are rejected, like parameters
+// which are not writeable in SafeMode.
+func (c *Client) WriteParameterRaw(pm DataTypeMap, idx int, raw uint32) error {
+	return c.WriteParameterRawContext(context.Background(), pm, idx, raw)
+}
+
+// WriteParameterRawContext is WriteParameterRaw with a context, see
+// ReadParametersContext.
+func (c *Client) WriteParameterRawContext(ctx context.Context, pm DataTypeMap, idx int, raw uint32) error {
+	b, ok := pm[idx]
+	if !ok {
+		return fmt.Errorf("WriteParameterRaw parameter index %d: %w", idx, ErrUnknownIndex)
+	}
+	if !b.writeable && c.opts.SafeMode {
+		return fmt.Errorf("WriteParameterRaw %q: %w", b.luxtronikName, ErrWritingNotAllowed)
+	}
+	if err := b.checkRange(b.FromHeatPumpRaw(raw), raw); err != nil {
+		return fmt.Errorf("WriteParameterRaw %w", err)
+	}
+	if err := checkAccess(pm, b); err != nil {
+		return fmt.Errorf("WriteParameterRaw %w", err)
+	}
+	return c.writeParameterRaw(ctx, b, idx, raw)
+}
+
+// writeChanged converts val and writes it unless the parameter already has
+// the value.
+func (c *Client) writeChanged(ctx context.Context, pm DataTypeMap, idx int, val any) error {
+	b := pm[idx]
+	raw, err := b.canonical().ToHeatPump(val)
 	if err != nil {
-		return fmt.Errorf("readFromHeatPump.readUint32.cmd failed: %w", err)
+		return fmt.Errorf("%s: %w", b.luxtronikName, err)
 	}
+	if raw == b.reading.Raw {
+		return nil
+	}
+	return c.WriteParameterRawContext(ctx, pm, idx, raw)
+}
 
-	if data[0] == CalculationsRead {
-		var stat uint32
-		stat, err = c.readUint32()
-		if err != nil {
-			return fmt.Errorf("readFromHeatPump.readUint32.cmd failed: %w", err)
-		}
-		_ = stat
+// writeParameterRaw sends the value unless the client runs dry, every write
+// ends up here.
+func (c *Client) writeParameterRaw(ctx context.Context, b *Base, idx int, raw uint32) error {
+	if c.opts.DryRun {
+		c.log.Info("dry run, parameter not written", zap.String("name", b.luxtronikName),
+			zap.Int("index", idx), zap.Uint32("raw", raw), zap.Any("value", b.FromHeatPumpRaw(raw)))
+		return nil
+	}
+	_, span := c.startSpan(ctx, "luxtronik.WriteParameter", attrCommand.Int(ParametersWrite), attrIndex.Int(idx))
+	start := time.Now()
+	stop := c.watchContext(ctx)
+	err := stop(c.writeParameterFrame(idx, raw))
+	c.observe(OpWriteParameter, start, err)
+	endSpan(span, err)
+	if err != nil {
+		return err
 	}
+	c.log.Info("parameter written", zap.String("name", b.luxtronikName),
+		zap.Int("index", idx), zap.Uint32("raw", raw), zap.Duration("duration", time.Since(start)))
+	return nil
+}
 
-	if cmd != uint32(data[0]) {
-		return fmt.Errorf("readFromHeatPump. received invalid command: %d want: %d", cmd, data[0])
+func (c *Client) writeParameterFrame(idx int, raw uint32) error {
+	if _, err := c.netWrite(ParametersWrite, int32(idx), int32(raw)); err != nil {
+		return fmt.Errorf("writeParameterRaw.netWrite index %d failed: %w", idx, err)
 	}
 
-	length, err := c.readUint32()
+	cmd, err := c.readUint32()
 	if err != nil {
-		return fmt.Errorf("readFromHeatPump.readUint32.length failed: %w", err)
+		return fmt.Errorf("writeParameterRaw.readUint32.cmd failed: %w", err)
+	}
+	if cmd != ParametersWrite {
+		return &ProtocolError{Cmd: ParametersWrite, Index: idx, Err: fmt.Errorf("%w: received %d", ErrInvalidCommand, cmd)}
 	}
 
-	rawValues := make([]uint32, length)
-	for i := uint32(0); i < length; i++ {
-		if data[0] == VisibilitiesRead {
-			char, err := c.readChar()
-			if err != nil {
-				return fmt.Errorf("readFromHeatPump.readUint32.paramID at index %d failed: %w", i, err)
-			}
-			rawValues[i] = uint32(char) // 0 or 1
-		} else {
-			paramID, err := c.readUint32()
-			if err != nil {
-				return fmt.Errorf("readFromHeatPump.readUint32.paramID at index %d failed: %w", i, err)
-			}
+	echo, err := c.readUint32()
+	if err != nil {
+		return fmt.Errorf("writeParameterRaw.readUint32.index failed: %w", err)
+	}
+	if echo != uint32(idx) {
+		return &ProtocolError{Cmd: ParametersWrite, Index: idx, Err: fmt.Errorf("%w: received %d", ErrInvalidIndex, echo)}
+	}
+	return nil
+}
+
+// unknownPrefixes name the entries added by TolerantFrames like the unknown
+// entries of the catalog.
+var unknownPrefixes = map[int32]string{
+	ParametersRead:   "Unknown_Parameter_",
+	CalculationsRead: "Unknown_Calculation_",
+	VisibilitiesRead: "Unknown_Visibility_",
+}
 
-			rawValues[i] = paramID
+var readOps = map[int32]string{
+	ParametersRead:   OpReadParameters,
+	CalculationsRead: OpReadCalculations,
+	VisibilitiesRead: OpReadVisibilities,
+}
+
+// watchContext aborts the pending reads and writes by a deadline in the past
+// once ctx is done. The returned function ends the watch and adds the error
+// of ctx to err if the operation got aborted.
+func (c *Client) watchContext(ctx context.Context) func(err error) error {
+	conn := c.conn
+	if ctx.Done() == nil || conn == nil {
+		return func(err error) error { return err }
+	}
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			_ = conn.SetDeadline(time.Unix(1, 0))
+		case <-done:
 		}
+	}()
+	return func(err error) error {
+		close(done)
+		<-exited
+		if err != nil && ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		}
+		return err
 	}
+}
 
-	return pm.SetRawValues(rawValues)
+func (c *Client) readFromHeatPump(ctx context.Context, pm DataTypeMap, data ...int32) error {
+	return c.readFrame(ctx, data, len(pm), func(meta FrameMeta, changed bool, rawValues []uint32) error {
+		return c.decode(pm, meta, changed, rawValues)
+	})
 }
 
-func (c *Client) readUint32() (uint32, error) {
-	var buf [SocketReadSizeInteger]byte
-	n, err := c.conn.Read(buf[:])
-	if err != nil {
-		return 0, err
+// ReadRaw reads the raw values of the block of cmd, see DataSource. It
+// connects if needed. The values are neither decoded nor checked against the
+// catalog.
+func (c *Client) ReadRaw(ctx context.Context, cmd int32) ([]uint32, error) {
+	_, raw, err := c.ReadFrame(ctx, cmd)
+	return raw, err
+}
+
+// ReadFrame is ReadRaw which also returns the header of the frame, e.g. for
+// WriteFrameHex.
+func (c *Client) ReadFrame(ctx context.Context, cmd int32) (FrameMeta, []uint32, error) {
+	if err := c.connect(ctx); err != nil {
+		return FrameMeta{}, nil, err
 	}
+	var (
+		meta FrameMeta
+		raw  []uint32
+	)
+	err := c.readFrame(ctx, []int32{cmd, 0}, catalogLength(cmd), func(m FrameMeta, _ bool, rawValues []uint32) error {
+		meta, raw = m, slices.Clone(rawValues)
+		return nil
+	})
+	return meta, raw, err
+}
 
-	return binary.BigEndian.Uint32(buf[:n]), nil
+// readFrame sends the read command of data and passes the values of the
+// answer to fn. changed reports a new length of the frame. The values go
+// back to a pool after fn returns.
+func (c *Client) readFrame(ctx context.Context, data []int32, catalog int, fn func(meta FrameMeta, changed bool, rawValues []uint32) error) error {
+	if len(data) < 2 {
+		return fmt.Errorf("readFromHeatPump requires a command and a parameter, got %d values", len(data))
+	}
+	_, span := c.startSpan(ctx, "luxtronik.readFromHeatPump", attrCommand.Int(int(data[0])))
+	start := time.Now()
+	stop := c.watchContext(ctx)
+	err := stop(c.readBlock(start, span, data, catalog, fn))
+	c.observe(readOps[data[0]], start, err)
+	endSpan(span, err)
+	return err
 }
 
-func (c *Client) readChar() (byte, error) {
-	var buf [SocketReadSizeChar]byte
-	n, err := c.conn.Read(buf[:])
+func (c *Client) readBlock(start time.Time, span trace.Span, data []int32, catalog int, fn func(FrameMeta, bool, []uint32) error) error {
+	_, err := c.netWrite(data...)
 	if err != nil {
-		return 0, err
+		return fmt.Errorf("readFromHeatPump.netWrite to send %d failed: %w", data[0], err)
 	}
-	if n != SocketReadSizeChar {
-		return 0, fmt.Errorf("read length %d is not equal to char size of %d", n, SocketReadSizeChar)
+
+	prev, seen := c.frames[data[0]]
+	meta, err := c.readFrameHeader(data[0])
+	if err != nil {
+		return err
+	}
+	length := uint32(meta.Length)
+	if length > maxFrameLength {
+		return &ProtocolError{Cmd: data[0], Index: -1, Err: fmt.Errorf("%w: %d values exceed %d", ErrLengthMismatch, length, maxFrameLength)}
 	}
 
-	// res := binary.BigEndian.Uint32()
-	return buf[0], nil
+	span.SetAttributes(attrLength.Int(int(length)))
+	// visibilities are single bytes, 0 or 1
+	size := SocketReadSizeInteger
+	if data[0] == VisibilitiesRead {
+		size = SocketReadSizeChar
+	}
+	// one read of the whole frame instead of a syscall per value
+	bufp := getBytes(int(length) * size)
+	defer putBytes(bufp)
+	buf := *bufp
+	if n, err := c.netRead(buf); err != nil {
+		return fmt.Errorf("readFromHeatPump.netRead value at index %d failed: %w", n/size, err)
+	}
+	// the values go back to the pool, fn has to copy them
+	rawp := getUint32s(int(length))
+	defer putUint32s(rawp)
+	rawValues := *rawp
+	for i := range rawValues {
+		if size == SocketReadSizeChar {
+			rawValues[i] = uint32(buf[i])
+		} else {
+			rawValues[i] = binary.BigEndian.Uint32(buf[i*size:])
+		}
+	}
+
+	meta = c.checkFrame(meta, catalog, rawValues)
+
+	c.log.Debug("block read",
+		zap.Int32("cmd", data[0]),
+		zap.Uint32("length", length),
+		zap.Duration("duration", time.Since(start)))
+	return fn(meta, !seen || prev.Length != meta.Length, rawValues)
+}
+
+// decode sets the values of a frame in pm with the options of the client.
+// SetRaw copies the values.
+func (c *Client) decode(pm DataTypeMap, meta FrameMeta, changed bool, rawValues []uint32) error {
+	if c.opts.TolerantFrames {
+		added, missing := pm.SetRawValuesTolerant(rawValues, unknownPrefixes[meta.Cmd])
+		if changed {
+			if len(added) > 0 {
+				c.log.Info("added unknown entries for extra values", zap.String("block", meta.Block),
+					zap.Int("count", len(added)), zap.Int("first_index", added[0]))
+			}
+			if len(missing) > 0 {
+				c.log.Warn("values missing in frame", zap.String("block", meta.Block), zap.Ints("indexes", missing))
+			}
+		}
+	} else if err := pm.SetRawValues(rawValues); err != nil {
+		return &ProtocolError{Cmd: meta.Cmd, Index: -1, Err: err}
+	}
+	if c.opts.Location != nil {
+		pm.SetLocation(c.opts.Location)
+	}
+	if c.opts.Units != UnitsMetric {
+		pm.SetUnits(c.opts.Units)
+	}
+	if c.opts.Language != "" {
+		pm.SetLanguage(c.opts.Language)
+	}
+	// unknown codes are only reported when they show up to keep polls quiet
+	for idx, b := range pm {
+		if b.codes != nil && b.HasChanges() && int(b.reading.Raw) >= len(b.codes) {
+			c.log.Warn("unknown code", zap.Int32("cmd", meta.Cmd), zap.Int("index", idx),
+				zap.String("name", b.luxtronikName), zap.Uint32("raw", b.reading.Raw))
+		}
+	}
+	return nil
+}
+
+func (c *Client) readUint32() (uint32, error) {
+	var buf [SocketReadSizeInteger]byte
+	if _, err := c.netRead(buf[:]); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(buf[:]), nil
 }
 
 func (c *Client) netRead(b []byte) (int, error) {
@@ -173,16 +596,23 @@ func (c *Client) netRead(b []byte) (int, error) {
 		err         error
 	)
 	end = len(b)
+	if c.conn == nil {
+		return 0, c.connError("read", ErrNotConnected)
+	}
 	for {
 		if n, err = c.conn.Read(b[cur:end]); err != nil {
 			cur += n
-			return cur, fmt.Errorf("netRead failed to read with error: %w", err)
+			c.received += cur
+			c.traceWire(traceReceived, b[:cur])
+			return cur, c.connError("read", err)
 		}
 		cur += n
 		if cur == end {
 			break
 		}
 	}
+	c.received += end
+	c.traceWire(traceReceived, b)
 	return end, nil
 }
 
@@ -205,10 +635,26 @@ func (c *Client) netWrite(data ...int32) (int, error) {
 	globalLock.Lock()
 	defer globalLock.Unlock()
 
-	var buf bytes.Buffer // refactor later
-	if err := binary.Write(&buf, binary.BigEndian, data); err != nil {
-		return 0, fmt.Errorf("netWrite failed to encode: %#v with error: %w", data, err)
+	if c.conn == nil {
+		return 0, c.connError("write", ErrNotConnected)
+	}
+	bufp := getBytes(len(data) * SocketReadSizeInteger)
+	defer putBytes(bufp)
+	buf := *bufp
+	for i, v := range data {
+		binary.BigEndian.PutUint32(buf[i*SocketReadSizeInteger:], uint32(v))
+	}
+
+	n, err := c.conn.Write(buf)
+	c.sent += n
+	c.requested = time.Now()
+	c.traceWire(traceSent, buf[:n])
+	if err != nil {
+		return n, c.connError("write", err)
 	}
+	return n, nil
+}
 
-	return c.conn.Write(buf.Bytes())
+func (c *Client) connError(op string, err error) error {
+	return &ConnectionError{Op: op, Addr: net.JoinHostPort(c.host, c.port), Err: err}
 }
@@ -1,23 +1,33 @@
 package luxtronik
 
 import (
+	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net"
 	"os"
-	"os/signal"
-	"strings"
 	"testing"
 	"text/tabwriter"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestIntegration_Client(t *testing.T) {
 	heatPumpIP := os.Getenv("HEATPUMP_IP")
 	if heatPumpIP == "" {
-		heatPumpIP = "192.168.0.121" + ":" + DefaultPort
+		t.Skip("set HEATPUMP_IP=192.168.0.121:" + DefaultPort + " to run the integration test")
 	}
 
 	runTest := func(newMap func() DataTypeMap, readFromNet func(*Client, DataTypeMap) error) func(t *testing.T) {
@@ -37,7 +47,7 @@ func TestIntegration_Client(t *testing.T) {
 			tw := tabwriter.NewWriter(os.Stdout, 12, 1, 1, ' ', 0)
 			printFn := func(w io.Writer) func(i int, p *Base) {
 				return func(i int, p *Base) {
-					if p.rawValue == 0 {
+					if p.RawValue() == 0 {
 						return
 					}
 
@@ -45,10 +55,10 @@ func TestIntegration_Client(t *testing.T) {
 						w,
 						"Number: %d\tName: %s\tType: %s\tValue: %v\tUnit: %s\n",
 						i,
-						p.luxtronikName,
-						p.class,
+						p.Name(),
+						p.Class(),
 						checkStringer(p.FromHeatPump()),
-						p.unit,
+						p.Unit(),
 					)
 				}
 			}
@@ -58,73 +68,417 @@ func TestIntegration_Client(t *testing.T) {
 	}
 
 	t.Run("Parameter", runTest(NewParameterMap, func(c *Client, pm DataTypeMap) error {
-		return c.readParameters(pm)
+		return c.ReadParameters(pm)
 	}))
 	t.Run("Visibilities", runTest(NewVisibilitiesMap, func(c *Client, pm DataTypeMap) error {
-		return c.readVisibilities(pm)
+		return c.ReadVisibilities(pm)
 	}))
 	t.Run("Calculations", runTest(NewCalculationsMap, func(c *Client, pm DataTypeMap) error {
-		return c.readCalculations(pm)
+		return c.ReadCalculations(pm)
 	}))
 }
 
-func TestIntegration_Refreshed_Calculations(t *testing.T) {
-	c := MustNewClient("192.168.0.121:"+DefaultPort, Options{
-		SafeMode: true,
-	})
+func checkStringer(v any) any {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	switch tv := v.(type) {
+	case float32:
+		return fmt.Sprintf("%.3f", tv)
+	default:
+		return v
+	}
+}
 
+func TestClient_WriteParameter(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{SafeMode: true})
 	require.NoError(t, c.Connect())
 	defer func() {
 		assert.NoError(t, c.Close())
 	}()
 
+	pm := NewParameterMap()
+	idx, b, ok := pm.Lookup("id_einst_bws_akt")
+	require.True(t, ok)
+	require.Equal(t, 2, idx)
+
+	require.NoError(t, c.WriteParameter(pm, idx, 48.5))
+	require.NoError(t, c.ReadParameters(pm))
+	assert.Equal(t, float32(48.5), b.FromHeatPump())
+
+	require.NoError(t, c.WriteParameter(pm, 3, "Party"))
+	require.NoError(t, c.ReadParameters(pm))
+	assert.Equal(t, HeatingModeParty, pm[3].FromHeatPump())
+
+	assert.Error(t, c.WriteParameter(pm, 3, "Fiesta"))
+	assert.Error(t, c.WriteParameter(pm, 0, 1), "non-writeable parameter")
+	assert.Error(t, c.WriteParameter(pm, 2, 3e8), "out of range")
+}
+
+func TestClient_DryRun(t *testing.T) {
+	hp := newMockHeatPump(t)
+	core, logs := observer.New(zap.InfoLevel)
+	c := MustNewClient(hp.addr(), Options{DryRun: true, SafeMode: true, Logger: zap.New(core)})
+	defer c.Close()
+
+	pm := NewParameterMap()
+	require.NoError(t, c.WriteParameter(pm, ParamHotWaterTarget, 48.5), "needs no connection")
+	assert.ErrorIs(t, c.WriteParameter(pm, ParamHotWaterTarget, 90), ErrInvalidValue)
+	assert.ErrorIs(t, c.WriteParameterRaw(pm, 0, 1), ErrWritingNotAllowed)
+
+	require.NoError(t, c.Connect())
+	require.NoError(t, c.ReadParameters(pm))
+	assert.Equal(t, float32(0), pm[ParamHotWaterTarget].FromHeatPump())
+
+	entries := logs.FilterMessage("dry run, parameter not written").AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ID_Einst_BWS_akt", entries[0].ContextMap()["name"])
+	assert.Equal(t, uint32(485), entries[0].ContextMap()["raw"])
+}
+
+func TestClient_SafeMode(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+	require.NoError(t, c.Connect())
+
+	pm := NewParameterMap()
+	require.False(t, pm[5].writeable)
+	require.NoError(t, c.WriteParameterRaw(pm, 5, 7), "parameters not marked writeable are written without SafeMode")
+	require.NoError(t, c.WriteParameter(pm, 5, 8))
+	assert.ErrorIs(t, c.WriteParameter(pm, ParamHotWaterTarget, 90), ErrInvalidValue, "ranges apply anyway")
+	assert.ErrorIs(t, c.WriteParameter(pm, ParamEinstBWSHystAkt, 5), ErrInsufficientAccess, "access levels apply anyway")
+	hp.mu.Lock()
+	assert.Equal(t, uint32(8), hp.parameters[5])
+	hp.mu.Unlock()
+
+	c.opts.SafeMode = true
+	assert.ErrorIs(t, c.WriteParameterRaw(pm, 5, 7), ErrWritingNotAllowed)
+	assert.ErrorIs(t, c.WriteParameter(pm, 5, 7), ErrWritingNotAllowed)
+}
+
+func TestClient_Logger(t *testing.T) {
+	hp := newMockHeatPump(t)
+	core, logs := observer.New(zap.DebugLevel)
+	c := MustNewClient(hp.addr(), Options{Alias: "cellar", Logger: zap.New(core)})
+
+	require.NoError(t, c.Connect())
+	require.NoError(t, c.ReadCalculations(NewCalculationsMap()))
+	require.NoError(t, c.Close())
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+		assert.Equal(t, "cellar", e.ContextMap()["host"])
+	}
+	assert.Equal(t, []string{"connected", "calculations status", "block read", "connection closed", "reconnected"}, msgs)
+}
+
+func TestClient_Errors(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{SafeMode: true})
+	require.NoError(t, c.Connect())
+
+	pm := NewParameterMap()
+	assert.ErrorIs(t, c.WriteParameter(pm, -1, 1), ErrUnknownIndex)
+	assert.ErrorIs(t, c.WriteParameter(pm, 3, "Fiesta"), ErrInvalidValue)
+	assert.ErrorIs(t, c.WriteParameter(pm, 0, 1), ErrWritingNotAllowed)
+
+	err := c.ReadParameters(DataTypeMap{0: pm[0]})
+	var pErr *ProtocolError
+	require.ErrorAs(t, err, &pErr)
+	assert.Equal(t, int32(ParametersRead), pErr.Cmd)
+	assert.ErrorIs(t, err, ErrLengthMismatch)
+
+	require.NoError(t, c.Close())
+	err = c.ReadParameters(pm)
+	var cErr *ConnectionError
+	require.ErrorAs(t, err, &cErr)
+	assert.Equal(t, "write", cErr.Op)
+	assert.ErrorIs(t, err, ErrNotConnected)
+
+	// a controller which answers with the wrong command
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req [3]int32
+		_ = binary.Read(conn, binary.BigEndian, &req)
+		_ = binary.Write(conn, binary.BigEndian, []uint32{ParametersRead, 0})
+	}()
+	c = MustNewClient(ln.Addr().String(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+	err = c.WriteParameterRaw(pm, 1, 10)
+	require.ErrorAs(t, err, &pErr)
+	assert.Equal(t, 1, pErr.Index)
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+	assert.False(t, errors.As(err, &cErr))
+}
+
+func TestClient_Metrics(t *testing.T) {
+	hp := newMockHeatPump(t)
+	var ops []OperationMetrics
+	c := MustNewClient(hp.addr(), Options{Alias: "cellar", Metrics: MetricsFunc(func(m OperationMetrics) {
+		ops = append(ops, m)
+	})})
+
+	require.NoError(t, c.Connect())
+	cm := NewCalculationsMap()
+	require.NoError(t, c.ReadCalculations(cm))
+	require.NoError(t, c.WriteParameterRaw(NewParameterMap(), 1, 10))
+	require.NoError(t, c.Close())
+	require.NoError(t, c.Connect())
+	require.NoError(t, c.Close())
+	assert.Error(t, c.ReadVisibilities(NewVisibilitiesMap()))
+
+	require.Len(t, ops, 5)
+	for _, op := range ops {
+		assert.Equal(t, "cellar", op.Host)
+		assert.Positive(t, op.Duration)
+	}
+	assert.Equal(t, OpConnect, ops[0].Op)
+	assert.False(t, ops[0].Reconnect)
+	assert.Equal(t, OpReadCalculations, ops[1].Op)
+	assert.Equal(t, 8, ops[1].BytesSent)
+	assert.Equal(t, 4*(3+len(cm)), ops[1].BytesReceived)
+	assert.Equal(t, OpWriteParameter, ops[2].Op)
+	assert.Equal(t, 12, ops[2].BytesSent)
+	assert.Equal(t, 8, ops[2].BytesReceived)
+	assert.Equal(t, OpConnect, ops[3].Op)
+	assert.True(t, ops[3].Reconnect)
+	assert.Equal(t, OpReadVisibilities, ops[4].Op)
+	assert.ErrorIs(t, ops[4].Err, ErrNotConnected)
+}
+
+func TestClient_TracerProvider(t *testing.T) {
+	hp := newMockHeatPump(t)
+	rec := tracetest.NewSpanRecorder()
+	c := MustNewClient(hp.addr(), Options{
+		Alias:          "cellar",
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)),
+	})
+
+	require.NoError(t, c.Connect())
+	cm := NewCalculationsMap()
+	require.NoError(t, c.ReadCalculations(cm))
+	require.NoError(t, c.WriteParameterRaw(NewParameterMap(), 1, 10))
+	require.NoError(t, c.Close())
+	assert.Error(t, c.ReadVisibilities(NewVisibilitiesMap()))
+
+	spans := rec.Ended()
+	require.Len(t, spans, 4)
+	var names []string
+	for _, s := range spans {
+		names = append(names, s.Name())
+		assert.Equal(t, trace.SpanKindClient, s.SpanKind())
+		assert.Contains(t, s.Attributes(), attribute.String("luxtronik.host", "cellar"))
+	}
+	assert.Equal(t, []string{"luxtronik.Connect", "luxtronik.readFromHeatPump", "luxtronik.WriteParameter", "luxtronik.readFromHeatPump"}, names)
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("luxtronik.command", CalculationsRead))
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("luxtronik.length", len(cm)))
+	assert.Contains(t, spans[2].Attributes(), attribute.Int("luxtronik.index", 1))
+	assert.Equal(t, codes.Error, spans[3].Status().Code)
+}
+
+func TestClient_TolerantFrames(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations = append(hp.calculations, 42, 43)
+
+	c := MustNewClient(hp.addr(), Options{})
+	require.NoError(t, c.Connect())
+	assert.ErrorIs(t, c.ReadCalculations(NewCalculationsMap()), ErrLengthMismatch)
+	require.NoError(t, c.Close())
+
+	core, logs := observer.New(zap.InfoLevel)
+	c = MustNewClient(hp.addr(), Options{TolerantFrames: true, Logger: zap.New(core)})
+	require.NoError(t, c.Connect())
+	defer c.Close()
 	pm := NewCalculationsMap()
+	n := len(pm)
+	require.NoError(t, c.ReadCalculations(pm))
+	require.NoError(t, c.ReadCalculations(pm))
+	require.Len(t, pm, n+2)
+	assert.Equal(t, uint32(43), pm[n+1].RawValue())
+	assert.Equal(t, fmt.Sprintf("Unknown_Calculation_%d", n+1), pm[n+1].Name())
 
-	sigChan := make(chan os.Signal)
-	signal.Notify(sigChan, os.Interrupt)
-	tkr := time.NewTicker(3 * time.Second)
+	added := logs.FilterMessage("added unknown entries for extra values").All()
+	require.Len(t, added, 1, "logged once per frame length")
+	assert.Equal(t, int64(n), added[0].ContextMap()["first_index"])
+}
 
-	for {
+func TestClient_ReadBrokenFrames(t *testing.T) {
+	frames := [][]uint32{
+		{ParametersRead, 1 << 20},
+		{ParametersRead, 10, 1, 2, 3},
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for _, frame := range frames {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			var req [2]int32
+			_ = binary.Read(conn, binary.BigEndian, &req)
+			_ = binary.Write(conn, binary.BigEndian, frame)
+			_ = conn.Close()
+		}
+	}()
+
+	c := MustNewClient(ln.Addr().String(), Options{})
+	require.NoError(t, c.Connect())
+	assert.ErrorIs(t, c.ReadParameters(NewParameterMap()), ErrLengthMismatch, "huge length")
+	require.NoError(t, c.Close())
+
+	require.NoError(t, c.Connect())
+	defer c.Close()
+	err = c.ReadParameters(NewParameterMap())
+	assert.ErrorContains(t, err, "value at index 3 failed")
+}
 
-		require.NoError(t, c.readCalculations(pm))
+func TestNewClient(t *testing.T) {
+	c, err := NewClient("192.168.0.121:8889", Options{Alias: "cellar"})
+	require.NoError(t, err)
+	assert.Equal(t, "cellar", c.Name())
 
-		tw := tabwriter.NewWriter(os.Stdout, 12, 1, 1, ' ', 0)
+	c, err = NewClient("192.168.0.121", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPort, c.port)
 
-		pm.IterateSorted(func(i int, p *Base) {
-			if p.rawValue == 0 || !p.HasChanges() {
+	_, err = NewClient("192.168.0.121:port", Options{})
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+}
+
+func TestClient_ReadContext(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[10] = 215
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+
+	ctx := context.Background()
+	require.NoError(t, c.ConnectContext(ctx))
+	pm := NewCalculationsMap()
+	require.NoError(t, c.ReadCalculationsContext(ctx, pm))
+	assert.Equal(t, float32(21.5), pm[CalcFlowTemperature].FromHeatPump())
+
+	// a controller which accepts connections but never answers
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
 				return
 			}
+			defer conn.Close()
+		}
+	}()
 
-			fmt.Fprintf(
-				tw,
-				"Number: %d\tName: %s\tType: %s\tValue: %v\tUnit: %s\n",
-				i,
-				p.luxtronikName,
-				p.class,
-				checkStringer(p.FromHeatPump()),
-				p.unit,
-			)
-		})
+	silent := MustNewClient(ln.Addr().String(), Options{})
+	defer silent.Close()
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, silent.ConnectContext(ctx))
+	err = silent.ReadParametersContext(ctx, NewParameterMap())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var cErr *ConnectionError
+	assert.ErrorAs(t, err, &cErr)
+}
 
-		require.NoError(t, tw.Flush())
-		select {
-		case <-sigChan:
-			return
-		case tm := <-tkr.C:
-			println(tm.Format(time.DateTime), strings.Repeat("=", 200))
-			continue
+func BenchmarkClient_ReadCalculations(b *testing.B) {
+	hp := newMockHeatPump(b)
+	c := MustNewClient(hp.addr(), Options{})
+	require.NoError(b, c.Connect())
+	defer c.Close()
+	pm := NewCalculationsMap()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.ReadCalculations(pm); err != nil {
+			b.Fatal(err)
 		}
 	}
 }
 
-func checkStringer(v any) any {
-	if s, ok := v.(fmt.Stringer); ok {
-		return s.String()
+func BenchmarkClient_ReadFromHeatPump(b *testing.B) {
+	hp := newMockHeatPump(b)
+	for _, bm := range []struct {
+		name string
+		cmd  int32
+		opts Options
+	}{
+		{"parameters", ParametersRead, Options{}},
+		{"visibilities", VisibilitiesRead, Options{}},
+		{"calculations/tolerant", CalculationsRead, Options{TolerantFrames: true}},
+		{"calculations/converted", CalculationsRead, Options{Units: UnitsImperial, Language: LanguageGerman}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			c := MustNewClient(hp.addr(), bm.opts)
+			require.NoError(b, c.Connect())
+			defer c.Close()
+			pm := newBlockMap(blockNames[bm.cmd])
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := c.readFromHeatPump(context.Background(), pm, bm.cmd, 0); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
 	}
-	switch tv := v.(type) {
-	case float32:
-		return fmt.Sprintf("%.3f", tv)
-	default:
-		return v
+}
+
+// FuzzClient_ReadFrame feeds arbitrary answers to the frame parser of all
+// blocks.
+func FuzzClient_ReadFrame(f *testing.F) {
+	frame := func(header []uint32, values []uint32) []byte {
+		b := binary.BigEndian.AppendUint32(nil, header[0])
+		for _, v := range append(header[1:], values...) {
+			b = binary.BigEndian.AppendUint32(b, v)
+		}
+		return b
 	}
+	calcs := make([]uint32, len(NewCalculationsMap()))
+	calcs[CalcSoftStand0] = 'V'
+	f.Add(uint8(1), false, frame([]uint32{CalculationsRead, 0, uint32(len(calcs))}, calcs))
+	f.Add(uint8(1), true, frame([]uint32{CalculationsRead, 0, 3}, calcs[:3]))
+	f.Add(uint8(0), true, frame([]uint32{ParametersRead, 2}, []uint32{math.MaxUint32, 1}))
+	f.Add(uint8(0), false, frame([]uint32{ParametersRead, maxFrameLength + 1}, nil))
+	f.Add(uint8(2), true, append(frame([]uint32{VisibilitiesRead, 3}, nil), 1, 0, 1))
+	f.Add(uint8(2), false, []byte{0, 0, 0x0b})
+
+	cmds := []int32{ParametersRead, CalculationsRead, VisibilitiesRead}
+	f.Fuzz(func(t *testing.T, sel uint8, tolerant bool, answer []byte) {
+		server, conn := net.Pipe()
+		go func() {
+			defer server.Close()
+			var req [8]byte
+			if _, err := io.ReadFull(server, req[:]); err == nil {
+				_, _ = server.Write(answer)
+			}
+		}()
+		c := MustNewClient("127.0.0.1:8889", Options{TolerantFrames: tolerant})
+		c.conn = conn
+		defer c.Close()
+
+		cmd := cmds[int(sel)%len(cmds)]
+		pm := newBlockMap(blockNames[cmd])
+		if err := c.readFromHeatPump(context.Background(), pm, cmd, 0); err == nil {
+			pm.IterateSorted(func(_ int, b *Base) { _ = b.FromHeatPump() })
+		}
+	})
 }
@@ -0,0 +1,189 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// MDNSServices are the service types browsed by DiscoverMDNS. Network
+// modules which announce another type can be found by adding it.
+var MDNSServices = []string{"_luxtronik._tcp.local."}
+
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// DiscoverMDNS browses the local network for MDNSServices via multicast DNS
+// and collects the answers until the timeout expires. It sends a one-shot
+// query from an ephemeral port, so the responders answer by unicast. The
+// returned controllers contain the address and the instance name as Model.
+func DiscoverMDNS(ctx context.Context, timeout time.Duration) ([]Controller, error) {
+	query, err := mdnsQuery(MDNSServices)
+	if err != nil {
+		return nil, fmt.Errorf("DiscoverMDNS failed to build the query: %w", err)
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, fmt.Errorf("DiscoverMDNS failed to listen: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.WriteToUDP(query, mdnsGroup); err != nil {
+		return nil, fmt.Errorf("DiscoverMDNS failed to send to %s: %w", mdnsGroup, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var found []Controller
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var nErr net.Error
+			if errors.As(err, &nErr) && nErr.Timeout() {
+				break
+			}
+			return found, fmt.Errorf("DiscoverMDNS failed to read: %w", err)
+		}
+		for _, c := range parseMDNSResponse(src.IP, buf[:n], MDNSServices) {
+			if !seen[c.Addr] {
+				seen[c.Addr] = true
+				found = append(found, c)
+			}
+		}
+	}
+
+	sortControllers(found)
+	return found, nil
+}
+
+// Discover runs DiscoverMDNS and DiscoverBroadcast in parallel and merges
+// their answers, e.g. to find the heat pump when no address is configured.
+// It only fails if both fail.
+func Discover(ctx context.Context, timeout time.Duration) ([]Controller, error) {
+	var (
+		wg         sync.WaitGroup
+		mdns, bcst []Controller
+		mErr, bErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		mdns, mErr = DiscoverMDNS(ctx, timeout)
+	}()
+	go func() {
+		defer wg.Done()
+		bcst, bErr = DiscoverBroadcast(ctx, timeout)
+	}()
+	wg.Wait()
+	if mErr != nil && bErr != nil {
+		return nil, errors.Join(mErr, bErr)
+	}
+
+	found := mdns
+	for _, c := range bcst {
+		merged := false
+		for i := range found {
+			if found[i].Addr == c.Addr {
+				found[i].Source += "+" + c.Source
+				merged = true
+			}
+		}
+		if !merged {
+			found = append(found, c)
+		}
+	}
+	sortControllers(found)
+	return found, nil
+}
+
+// mdnsQuery asks for the PTR records of the services.
+func mdnsQuery(services []string) ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	for _, s := range services {
+		name, err := dnsmessage.NewName(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}); err != nil {
+			return nil, err
+		}
+	}
+	return b.Finish()
+}
+
+// parseMDNSResponse returns the instances of the services in an answer.
+// The address is the A record of the SRV target, or the sender if the
+// answer has none, with the port of the SRV record.
+func parseMDNSResponse(src net.IP, msg []byte, services []string) []Controller {
+	var m dnsmessage.Message
+	if err := m.Unpack(msg); err != nil {
+		return nil
+	}
+	type srv struct {
+		target string
+		port   uint16
+	}
+	var (
+		instances []string
+		srvs      = map[string]srv{}
+		hosts     = map[string]net.IP{}
+	)
+	// the records of the instances usually come as additionals
+	for _, r := range append(m.Answers, m.Additionals...) {
+		name := strings.ToLower(r.Header.Name.String())
+		switch body := r.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if isService(name, services) {
+				instances = append(instances, strings.ToLower(body.PTR.String()))
+			}
+		case *dnsmessage.SRVResource:
+			srvs[name] = srv{target: strings.ToLower(body.Target.String()), port: body.Port}
+		case *dnsmessage.AResource:
+			hosts[name] = net.IP(body.A[:])
+		}
+	}
+
+	var found []Controller
+	for _, inst := range instances {
+		s, ok := srvs[inst]
+		if !ok {
+			continue
+		}
+		ip := src
+		if a, ok := hosts[s.target]; ok {
+			ip = a
+		}
+		label, _, _ := strings.Cut(inst, ".")
+		found = append(found, Controller{
+			Addr:   net.JoinHostPort(ip.String(), strconv.Itoa(int(s.port))),
+			Name:   label,
+			Source: "mdns",
+		})
+	}
+	return found
+}
+
+func isService(name string, services []string) bool {
+	for _, s := range services {
+		if strings.EqualFold(name, s) {
+			return true
+		}
+	}
+	return false
+}
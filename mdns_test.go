@@ -0,0 +1,52 @@
+package luxtronik
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestParseMDNSResponse(t *testing.T) {
+	name := dnsmessage.MustNewName
+	hdr := func(n string, typ dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name(n), Type: typ, Class: dnsmessage.ClassINET, TTL: 120}
+	}
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true, Authoritative: true},
+		Answers: []dnsmessage.Resource{
+			{Header: hdr("_luxtronik._tcp.local.", dnsmessage.TypePTR), Body: &dnsmessage.PTRResource{PTR: name("Cellar._luxtronik._tcp.local.")}},
+			{Header: hdr("_luxtronik._tcp.local.", dnsmessage.TypePTR), Body: &dnsmessage.PTRResource{PTR: name("garage._luxtronik._tcp.local.")}},
+			{Header: hdr("_printer._tcp.local.", dnsmessage.TypePTR), Body: &dnsmessage.PTRResource{PTR: name("office._printer._tcp.local.")}},
+		},
+		Additionals: []dnsmessage.Resource{
+			{Header: hdr("cellar._luxtronik._tcp.local.", dnsmessage.TypeSRV), Body: &dnsmessage.SRVResource{Target: name("wp-cellar.local."), Port: 8889}},
+			{Header: hdr("wp-cellar.local.", dnsmessage.TypeA), Body: &dnsmessage.AResource{A: [4]byte{192, 168, 0, 121}}},
+			// without an A record the sender is used
+			{Header: hdr("garage._luxtronik._tcp.local.", dnsmessage.TypeSRV), Body: &dnsmessage.SRVResource{Target: name("wp-garage.local."), Port: 9000}},
+			{Header: hdr("office._printer._tcp.local.", dnsmessage.TypeSRV), Body: &dnsmessage.SRVResource{Target: name("office.local."), Port: 631}},
+		},
+	}
+	packed, err := msg.Pack()
+	require.NoError(t, err)
+
+	found := parseMDNSResponse(net.IPv4(192, 168, 0, 5), packed, MDNSServices)
+	assert.Equal(t, []Controller{
+		{Addr: "192.168.0.121:8889", Name: "cellar", Source: "mdns"},
+		{Addr: "192.168.0.5:9000", Name: "garage", Source: "mdns"},
+	}, found)
+
+	assert.Empty(t, parseMDNSResponse(net.IPv4(192, 168, 0, 5), []byte("garbage"), MDNSServices))
+}
+
+func TestMDNSQuery(t *testing.T) {
+	query, err := mdnsQuery(MDNSServices)
+	require.NoError(t, err)
+	var msg dnsmessage.Message
+	require.NoError(t, msg.Unpack(query))
+	require.Len(t, msg.Questions, 1)
+	assert.Equal(t, "_luxtronik._tcp.local.", msg.Questions[0].Name.String())
+	assert.Equal(t, dnsmessage.TypePTR, msg.Questions[0].Type)
+}
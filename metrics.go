@@ -0,0 +1,59 @@
+package luxtronik
+
+import "time"
+
+// Operation names reported to Metrics.
+const (
+	OpConnect          = "connect"
+	OpReadParameters   = "read_parameters"
+	OpReadCalculations = "read_calculations"
+	OpReadVisibilities = "read_visibilities"
+	OpWriteParameter   = "write_parameter"
+)
+
+// OperationMetrics describes a single finished operation of a Client.
+type OperationMetrics struct {
+	// Host is the name of the client, see Client.Name.
+	Host          string
+	Op            string
+	Duration      time.Duration
+	BytesSent     int
+	BytesReceived int
+	// Reconnect is set for connects after a connection has been lost, i.e.
+	// the retries after failed operations.
+	Reconnect bool
+	// Err is nil on success, see ConnectionError and ProtocolError.
+	Err error
+}
+
+// Metrics receives the measurements of a client, see Options.Metrics. The
+// calls happen synchronously after each operation, implementations should
+// return quickly and must be safe for concurrent use if shared between
+// clients.
+type Metrics interface {
+	Observe(m OperationMetrics)
+}
+
+// MetricsFunc adapts a function to the Metrics interface.
+type MetricsFunc func(m OperationMetrics)
+
+func (f MetricsFunc) Observe(m OperationMetrics) { f(m) }
+
+// observe reports the operation which started at start and resets the byte
+// counters.
+func (c *Client) observe(op string, start time.Time, err error) {
+	sent, received := c.sent, c.received
+	c.sent, c.received = 0, 0
+	if c.opts.Metrics == nil {
+		return
+	}
+	c.opts.Metrics.Observe(OperationMetrics{
+		Host:          c.Name(),
+		Op:            op,
+		Duration:      time.Since(start),
+		BytesSent:     sent,
+		BytesReceived: received,
+		Reconnect:     op == OpConnect && c.connects > 0,
+		Err:           err,
+	})
+}
@@ -0,0 +1,102 @@
+package luxtronik
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockHeatPump speaks the TCP protocol of the controller on a random local
+// port and serves the raw values of its three blocks.
+type mockHeatPump struct {
+	ln net.Listener
+
+	mu           sync.Mutex
+	parameters   []uint32
+	calculations []uint32
+	visibilities []uint32
+}
+
+func newMockHeatPump(t testing.TB) *mockHeatPump {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	m := &mockHeatPump{
+		ln:           ln,
+		parameters:   make([]uint32, len(NewParameterMap())),
+		calculations: make([]uint32, len(NewCalculationsMap())),
+		visibilities: make([]uint32, len(NewVisibilitiesMap())),
+	}
+	go m.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return m
+}
+
+func (m *mockHeatPump) addr() string {
+	return m.ln.Addr().String()
+}
+
+func (m *mockHeatPump) serve() {
+	for {
+		conn, err := m.ln.Accept()
+		if err != nil {
+			return
+		}
+		go m.handle(conn)
+	}
+}
+
+func (m *mockHeatPump) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		var req [2]int32
+		if err := binary.Read(conn, binary.BigEndian, &req); err != nil {
+			return
+		}
+		if err := m.respond(conn, req[0], req[1]); err != nil {
+			return
+		}
+	}
+}
+
+func (m *mockHeatPump) respond(conn net.Conn, cmd, arg int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch cmd {
+	case ParametersWrite:
+		var val uint32
+		if err := binary.Read(conn, binary.BigEndian, &val); err != nil {
+			return err
+		}
+		if int(arg) < len(m.parameters) {
+			m.parameters[arg] = val
+		}
+		return binary.Write(conn, binary.BigEndian, []uint32{ParametersWrite, uint32(arg)})
+	case ParametersRead:
+		return writeMockBlock(conn, []uint32{ParametersRead}, m.parameters)
+	case CalculationsRead:
+		return writeMockBlock(conn, []uint32{CalculationsRead, 0}, m.calculations)
+	case VisibilitiesRead:
+		chars := make([]byte, len(m.visibilities))
+		for i, v := range m.visibilities {
+			chars[i] = byte(v)
+		}
+		if err := binary.Write(conn, binary.BigEndian, []uint32{VisibilitiesRead, uint32(len(chars))}); err != nil {
+			return err
+		}
+		_, err := conn.Write(chars)
+		return err
+	}
+	return errors.New("mockHeatPump: unknown command")
+}
+
+func writeMockBlock(w io.Writer, header, values []uint32) error {
+	frame := append(append(header[:len(header):len(header)], uint32(len(values))), values...)
+	return binary.Write(w, binary.BigEndian, frame)
+}
@@ -0,0 +1,259 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Topic classes of MQTTOptions.Retain.
+const (
+	MQTTValues        = "values"
+	MQTTNotifications = "notifications"
+	MQTTAvailability  = "availability"
+)
+
+// DefaultMQTTTopic is the topic template of the values and notifications,
+// e.g. luxtronik/cellar/calculations/ID_WEB_Temperatur_TA or
+// luxtronik/cellar/alert/cold.
+const DefaultMQTTTopic = "luxtronik/{{.Host}}/{{.Kind}}/{{.Name}}"
+
+// MQTTTopic is the data of the topic template. Kind is the block of a value
+// or the kind of a notification, Name the name of a value, the rule of an
+// alert or the code of an error. Index and Class belong to values only.
+type MQTTTopic struct {
+	Host  string
+	Kind  string
+	Name  string
+	Index int
+	Class string
+}
+
+type MQTTOptions struct {
+	// Topic is a text/template executed with an MQTTTopic, defaults to
+	// DefaultMQTTTopic. It may add fixed levels like the site or room, e.g.
+	// home/cellar/{{.Kind}}/{{.Name | lower}}.
+	Topic string
+	// Retain lists the topic classes published with the retain flag, see
+	// MQTTValues, MQTTNotifications and MQTTAvailability.
+	Retain []string
+	// Publish sends a message, e.g. with the QoS of the paho client.
+	Publish func(topic string, payload []byte, retain bool) error
+	// Deadbands hide small changes, see ChangeDetector.
+	Deadbands Deadbands
+	// Spool buffers the messages on disk while the broker is unreachable and
+	// publishes them in order once it is back.
+	Spool *Spool
+}
+
+// mqttMessage is a message of the MQTTSink, JSON encoded in the records of
+// the Spool.
+type mqttMessage struct {
+	Topic   string `json:"topic"`
+	Payload []byte `json:"payload"`
+	Retain  bool   `json:"retain,omitempty"`
+}
+
+// MQTTSink publishes the values as plain text, all of a block on its first
+// poll and then the changed ones. As Notifier of a Dispatcher it publishes
+// alerts and new entries of the error memory as JSON.
+type MQTTSink struct {
+	opts    MQTTOptions
+	topic   *template.Template
+	retain  map[string]bool
+	changes *ChangeDetector
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func NewMQTTSink(opts MQTTOptions) (*MQTTSink, error) {
+	if opts.Publish == nil {
+		return nil, errors.New("NewMQTTSink needs Publish")
+	}
+	if opts.Topic == "" {
+		opts.Topic = DefaultMQTTTopic
+	}
+	tpl, err := template.New("topic").Funcs(template.FuncMap{"lower": strings.ToLower}).Option("missingkey=error").Parse(opts.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("NewMQTTSink topic: %w", err)
+	}
+	s := &MQTTSink{
+		opts:    opts,
+		topic:   tpl,
+		retain:  map[string]bool{},
+		changes: NewChangeDetector(opts.Deadbands),
+		seen:    map[string]bool{},
+	}
+	for _, class := range opts.Retain {
+		switch class {
+		case MQTTValues, MQTTNotifications, MQTTAvailability:
+			s.retain[class] = true
+		default:
+			return nil, fmt.Errorf("NewMQTTSink unknown topic class %q, want %s, %s or %s", class, MQTTValues, MQTTNotifications, MQTTAvailability)
+		}
+	}
+	if _, err := s.Topic(MQTTTopic{Host: "host", Kind: BlockCalculations, Name: "ID_WEB_Temperatur_TA"}); err != nil {
+		return nil, fmt.Errorf("NewMQTTSink: %w", err)
+	}
+	return s, nil
+}
+
+// Retained reports whether the topic class is published with the retain
+// flag.
+func (s *MQTTSink) Retained(class string) bool {
+	return s.retain[class]
+}
+
+// Topic executes the topic template. Topics must not be empty or contain
+// the wildcards + and #.
+func (s *MQTTSink) Topic(t MQTTTopic) (string, error) {
+	var b strings.Builder
+	if err := s.topic.Execute(&b, t); err != nil {
+		return "", fmt.Errorf("MQTTSink.Topic: %w", err)
+	}
+	topic := b.String()
+	if topic == "" || strings.ContainsAny(topic, "+#") {
+		return "", fmt.Errorf("MQTTSink.Topic invalid topic %q", topic)
+	}
+	return topic, nil
+}
+
+// Write publishes the values which changed, all values on the first poll
+// of a block. A value counts as reported once its message is published or
+// spooled, a failed publish reports it again with the next poll.
+func (s *MQTTSink) Write(_ context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	key := host + "/" + block
+	s.mu.Lock()
+	first := !s.seen[key]
+	s.mu.Unlock()
+
+	type change struct {
+		idx     int
+		name    string
+		prev    uint32
+		changed bool
+	}
+	var (
+		msgs    []mqttMessage
+		changes []change
+		errs    []error
+	)
+	pm.IterateSorted(func(idx int, b *Base) {
+		prev, changed := s.changes.Changed(host, block, idx, b)
+		if !changed && !first {
+			return
+		}
+		topic, err := s.Topic(MQTTTopic{Host: host, Kind: block, Name: b.luxtronikName, Index: idx, Class: b.class})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("MQTTSink.Write %s: %w", b.luxtronikName, err))
+			return
+		}
+		msgs = append(msgs, mqttMessage{Topic: topic, Payload: mqttPayload(b), Retain: s.retain[MQTTValues]})
+		changes = append(changes, change{idx: idx, name: b.luxtronikName, prev: prev, changed: changed})
+	})
+
+	failed := false
+	revert := func(c change) {
+		failed = true
+		if c.changed {
+			s.changes.Revert(host, block, c.idx, c.prev)
+		}
+	}
+	if s.opts.Spool == nil {
+		for i, m := range msgs {
+			if err := s.opts.Publish(m.Topic, m.Payload, m.Retain); err != nil {
+				revert(changes[i])
+				errs = append(errs, fmt.Errorf("MQTTSink.Write %s: %w", changes[i].name, err))
+			}
+		}
+	} else if err := s.deliver(msgs); err != nil {
+		for _, c := range changes {
+			revert(c)
+		}
+		errs = append(errs, fmt.Errorf("MQTTSink.Write %s: %w", block, err))
+	}
+	if !failed {
+		s.mu.Lock()
+		s.seen[key] = true
+		s.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// deliver publishes msgs through the Spool, if any, as one record.
+func (s *MQTTSink) deliver(msgs []mqttMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if s.opts.Spool == nil {
+		return s.publish(msgs)
+	}
+	record, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	return s.opts.Spool.Deliver(record, func(record []byte) error {
+		var msgs []mqttMessage
+		if err := json.Unmarshal(record, &msgs); err != nil {
+			// a corrupt record would block the spool
+			return nil
+		}
+		return s.publish(msgs)
+	})
+}
+
+// publish sends msgs in order and stops at the first error.
+func (s *MQTTSink) publish(msgs []mqttMessage) error {
+	for _, m := range msgs {
+		if err := s.opts.Publish(m.Topic, m.Payload, m.Retain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mqttPayload returns the value in metric units as plain text, e.g. 4.8 or
+// Heating.
+func mqttPayload(b *Base) []byte {
+	switch v := DumpValue(b.canonical().FromHeatPump()).(type) {
+	case string:
+		return []byte(v)
+	case float32:
+		return strconv.AppendFloat(nil, float64(v), 'f', -1, 32)
+	case bool:
+		return strconv.AppendBool(nil, v)
+	case nil:
+		return nil
+	default:
+		payload, _ := json.Marshal(v)
+		return payload
+	}
+}
+
+// Notify publishes an alert or an error of the error memory as JSON.
+func (s *MQTTSink) Notify(_ context.Context, n Notification) error {
+	name := n.Rule
+	if n.Kind == NotificationError {
+		name = strconv.FormatUint(uint64(n.Code), 10)
+	}
+	topic, err := s.Topic(MQTTTopic{Host: n.Host, Kind: n.Kind, Name: name})
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("MQTTSink.Notify: %w", err)
+	}
+	return s.deliver([]mqttMessage{{Topic: topic, Payload: payload, Retain: s.retain[MQTTNotifications]}})
+}
+
+func (s *MQTTSink) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type published struct {
+	topic   string
+	payload string
+	retain  bool
+}
+
+func TestMQTTSink(t *testing.T) {
+	var msgs []published
+	s, err := NewMQTTSink(MQTTOptions{
+		Retain: []string{MQTTValues},
+		Publish: func(topic string, payload []byte, retain bool) error {
+			msgs = append(msgs, published{topic, string(payload), retain})
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, s.Retained(MQTTValues))
+	assert.False(t, s.Retained(MQTTNotifications))
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	pm := DataTypeMap{
+		CalcOutdoorTemperature: NewCalculationsMap()[CalcOutdoorTemperature],
+		CalcEVUin:              NewCalculationsMap()[CalcEVUin],
+	}
+	pm[CalcOutdoorTemperature].reading.Raw = 53
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	assert.Equal(t, []published{
+		{"luxtronik/cellar/calculations/ID_WEB_Temperatur_TA", "5.3", true},
+		{"luxtronik/cellar/calculations/ID_WEB_EVUin", "false", true},
+	}, msgs, "the first poll publishes all values")
+
+	msgs = nil
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	assert.Empty(t, msgs)
+	pm[CalcOutdoorTemperature].reading.Raw = 48
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	assert.Equal(t, []published{{"luxtronik/cellar/calculations/ID_WEB_Temperatur_TA", "4.8", true}}, msgs)
+
+	msgs = nil
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationAlert, Host: "cellar", Time: ts, Rule: "cold", Value: 4.8}))
+	require.NoError(t, s.Notify(ctx, Notification{Kind: NotificationError, Host: "cellar", Time: ts, Code: 701}))
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "luxtronik/cellar/alert/cold", msgs[0].topic)
+	assert.JSONEq(t, `{"kind":"alert","host":"cellar","time":"2023-11-14T22:13:20Z","message":"","rule":"cold","value":4.8}`, msgs[0].payload)
+	assert.False(t, msgs[0].retain)
+	assert.Equal(t, "luxtronik/cellar/error/701", msgs[1].topic)
+}
+
+func TestMQTTSink_Failed(t *testing.T) {
+	var (
+		msgs []published
+		down bool
+	)
+	publish := func(topic string, payload []byte, retain bool) error {
+		if down {
+			return errors.New("broker down")
+		}
+		msgs = append(msgs, published{topic, string(payload), retain})
+		return nil
+	}
+	spool, err := OpenSpool(SpoolOptions{Dir: t.TempDir()})
+	require.NoError(t, err)
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	topic := "luxtronik/cellar/calculations/ID_WEB_Temperatur_TA"
+
+	for _, spooled := range []bool{false, true} {
+		opts := MQTTOptions{Publish: publish}
+		if spooled {
+			opts.Spool = spool
+		}
+		s, err := NewMQTTSink(opts)
+		require.NoError(t, err)
+		pm := DataTypeMap{CalcOutdoorTemperature: NewCalculationsMap()[CalcOutdoorTemperature]}
+		pm[CalcOutdoorTemperature].SetRaw(53)
+		msgs, down = nil, false
+		require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+
+		msgs, down = nil, true
+		pm[CalcOutdoorTemperature].SetRaw(48)
+		err = s.Write(ctx, "cellar", ts, BlockCalculations, pm)
+		down = false
+		want := []published{{topic, "4.8", false}}
+		if spooled {
+			require.NoError(t, err)
+			assert.Equal(t, 1, spool.Len())
+			// the spool is replayed before the next change
+			pm[CalcOutdoorTemperature].SetRaw(50)
+			want = append(want, published{topic, "5", false})
+		} else {
+			// the value stays the same, it is published again as it failed
+			require.Error(t, err)
+		}
+		require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+		assert.Equal(t, want, msgs, "spooled %t", spooled)
+		assert.Zero(t, spool.Len())
+	}
+}
+
+func TestMQTTSink_Topic(t *testing.T) {
+	publish := func(string, []byte, bool) error { return nil }
+	s, err := NewMQTTSink(MQTTOptions{Topic: "home/{{.Host}}/{{.Kind}}/{{.Name | lower}}", Publish: publish})
+	require.NoError(t, err)
+	topic, err := s.Topic(MQTTTopic{Host: "cellar", Kind: BlockCalculations, Name: "ID_WEB_Temperatur_TA"})
+	require.NoError(t, err)
+	assert.Equal(t, "home/cellar/calculations/id_web_temperatur_ta", topic)
+	_, err = s.Topic(MQTTTopic{Host: "cellar", Kind: BlockCalculations, Name: "#"})
+	assert.Error(t, err, "wildcards")
+
+	for _, opts := range []MQTTOptions{
+		{Topic: "{{.Host", Publish: publish},
+		{Topic: "{{.Room}}", Publish: publish},
+		{Topic: "luxtronik/+/{{.Name}}", Publish: publish},
+		{Retain: []string{"errors"}, Publish: publish},
+		{},
+	} {
+		_, err := NewMQTTSink(opts)
+		assert.Error(t, err, "%+v", opts.Topic)
+	}
+}
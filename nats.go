@@ -0,0 +1,238 @@
+package luxtronik
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultNATSPort is the client port of a NATS server.
+const DefaultNATSPort = "4222"
+
+type NATSOptions struct {
+	// URL of the server, nats://host:4222 or tls://host:4222. The user and
+	// password of the URL authenticate, a user without password is sent as
+	// token.
+	URL string
+	// Prefix starts the subjects, defaults to luxtronik.
+	Prefix string
+	// TLSConfig of tls:// URLs, defaults to the system CAs.
+	TLSConfig *tls.Config
+	// Timeout of connecting and of a Publish, defaults to 10s.
+	Timeout time.Duration
+	Logger  *zap.Logger
+}
+
+// NATSPublisher publishes to a NATS server with the core protocol: at most
+// once and without JetStream. The subject is the prefix, kind, host and
+// name, e.g. luxtronik.change.cellar.ID_WEB_Temperatur_TA or
+// luxtronik.alert.cellar. Every Publish waits for the server to answer a
+// PING so that rejected messages are reported. It connects with the first
+// Publish and reconnects once if the connection went stale in between.
+type NATSPublisher struct {
+	opts NATSOptions
+	addr string
+	tls  bool
+	auth map[string]string
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// natsError is an -ERR sent by the server.
+type natsError string
+
+func (e natsError) Error() string { return "nats server: " + string(e) }
+
+func NewNATSPublisher(opts NATSOptions) (*NATSPublisher, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("NewNATSPublisher: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("NewNATSPublisher unsupported scheme %q, want nats or tls", u.Scheme)
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = "luxtronik"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	n := &NATSPublisher{opts: opts, addr: u.Host, tls: u.Scheme == "tls", auth: map[string]string{}}
+	if u.Port() == "" {
+		n.addr = net.JoinHostPort(u.Hostname(), DefaultNATSPort)
+	}
+	if n.tls && n.opts.TLSConfig == nil {
+		n.opts.TLSConfig = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			n.auth["user"], n.auth["pass"] = u.User.Username(), pass
+		} else {
+			n.auth["auth_token"] = u.User.Username()
+		}
+	}
+	return n, nil
+}
+
+var natsSubjectEscaper = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "\t", "_", "\r", "_", "\n", "_")
+
+// Subject returns the subject of m.
+func (n *NATSPublisher) Subject(m BusMessage) string {
+	subject := n.opts.Prefix + "." + natsSubjectEscaper.Replace(m.Kind)
+	for _, token := range []string{m.Host, m.Name} {
+		if token != "" {
+			subject += "." + natsSubjectEscaper.Replace(token)
+		}
+	}
+	return subject
+}
+
+func (n *NATSPublisher) Publish(ctx context.Context, msgs []BusMessage) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for {
+		fresh := n.conn == nil
+		if fresh {
+			if err := n.connect(ctx); err != nil {
+				return fmt.Errorf("NATSPublisher.Publish: %w", err)
+			}
+		}
+		err := n.publish(ctx, msgs)
+		if err == nil {
+			return nil
+		}
+		n.closeConn()
+		var serverErr natsError
+		if fresh || errors.As(err, &serverErr) || ctx.Err() != nil {
+			return fmt.Errorf("NATSPublisher.Publish: %w", err)
+		}
+		n.opts.Logger.Warn("nats connection lost, reconnecting", zap.String("addr", n.addr), zap.Error(err))
+	}
+}
+
+func (n *NATSPublisher) connect(ctx context.Context) error {
+	d := net.Dialer{Timeout: n.opts.Timeout}
+	conn, err := d.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(n.opts.Timeout))
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return fmt.Errorf("no NATS server at %s: %q", n.addr, strings.TrimSpace(line))
+	}
+	if n.tls {
+		tc := tls.Client(conn, n.opts.TLSConfig)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return err
+		}
+		conn, r = tc, bufio.NewReader(tc)
+	}
+
+	params := map[string]any{
+		"verbose":      false,
+		"pedantic":     false,
+		"tls_required": n.tls,
+		"name":         "luxtronik",
+		"lang":         "go",
+		"version":      "1",
+		"protocol":     1,
+	}
+	for k, v := range n.auth {
+		params[k] = v
+	}
+	connect, err := json.Marshal(params)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if _, err := conn.Write([]byte("CONNECT " + string(connect) + "\r\n")); err != nil {
+		conn.Close()
+		return err
+	}
+	n.conn, n.r = conn, r
+	return nil
+}
+
+// publish sends the messages and a PING and waits for the PONG, errors of
+// CONNECT or of the messages arrive before it.
+func (n *NATSPublisher) publish(ctx context.Context, msgs []BusMessage) error {
+	deadline := time.Now().Add(n.opts.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := n.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, m := range msgs {
+		buf.WriteString("PUB ")
+		buf.WriteString(n.Subject(m))
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.Itoa(len(m.Payload)))
+		buf.WriteString("\r\n")
+		buf.Write(m.Payload)
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString("PING\r\n")
+	if _, err := n.conn.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	for {
+		line, err := n.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return natsError(strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+	}
+}
+
+func (n *NATSPublisher) closeConn() {
+	if n.conn != nil {
+		n.conn.Close()
+		n.conn, n.r = nil, nil
+	}
+}
+
+func (n *NATSPublisher) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.closeConn()
+	return nil
+}
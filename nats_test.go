@@ -0,0 +1,133 @@
+package luxtronik
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATS speaks enough of the NATS protocol to receive publishes.
+type fakeNATS struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	connects []string
+	pubs     []string // subject and payload
+	reject   string   // sent as -ERR instead of the PONG
+}
+
+func newFakeNATS(t *testing.T) *fakeNATS {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeNATS{ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeNATS) handle(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, `INFO {"server_id":"fake","max_payload":1048576}`+"\r\n")
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch op {
+		case "CONNECT":
+			f.mu.Lock()
+			f.connects = append(f.connects, args)
+			f.mu.Unlock()
+		case "PUB":
+			subject, size, _ := strings.Cut(args, " ")
+			n, _ := strconv.Atoi(size)
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.pubs = append(f.pubs, subject+" "+string(payload[:n]))
+			f.mu.Unlock()
+		case "PING":
+			f.mu.Lock()
+			reject := f.reject
+			f.mu.Unlock()
+			if reject != "" {
+				fmt.Fprintf(conn, "-ERR '%s'\r\n", reject)
+				return
+			}
+			fmt.Fprint(conn, "PING\r\nPONG\r\n")
+		}
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	f := newFakeNATS(t)
+	p, err := NewNATSPublisher(NATSOptions{URL: "nats://alice:secret@" + f.ln.Addr().String(), Timeout: time.Second})
+	require.NoError(t, err)
+	defer p.Close()
+
+	ctx := context.Background()
+	require.NoError(t, p.Publish(ctx, []BusMessage{
+		{Kind: EventChange, Host: "cellar.local", Name: "ID_WEB_Temperatur_TA", Payload: []byte(`{"value":4.8}`)},
+		{Kind: NotificationAlert, Host: "cellar", Payload: []byte("cold")},
+	}))
+	f.mu.Lock()
+	assert.Equal(t, []string{
+		`luxtronik.change.cellar_local.ID_WEB_Temperatur_TA {"value":4.8}`,
+		"luxtronik.alert.cellar cold",
+	}, f.pubs)
+	require.Len(t, f.connects, 1)
+	assert.Contains(t, f.connects[0], `"user":"alice"`)
+	assert.Contains(t, f.connects[0], `"pass":"secret"`)
+	f.mu.Unlock()
+
+	// a stale connection is replaced
+	p.mu.Lock()
+	p.conn.Close()
+	p.mu.Unlock()
+	require.NoError(t, p.Publish(ctx, []BusMessage{{Kind: EventChange, Host: "cellar", Payload: []byte("1")}}))
+	f.mu.Lock()
+	assert.Len(t, f.connects, 2)
+	assert.Len(t, f.pubs, 3)
+	f.reject = "Authorization Violation"
+	f.mu.Unlock()
+
+	p.Close()
+	err = p.Publish(ctx, []BusMessage{{Kind: EventChange, Host: "cellar", Payload: []byte("1")}})
+	var serverErr natsError
+	require.ErrorAs(t, err, &serverErr)
+	assert.Equal(t, natsError("Authorization Violation"), serverErr)
+}
+
+func TestNewNATSPublisher(t *testing.T) {
+	p, err := NewNATSPublisher(NATSOptions{URL: "tls://token@nats.example.com", Prefix: "site1"})
+	require.NoError(t, err)
+	assert.Equal(t, "nats.example.com:4222", p.addr)
+	assert.True(t, p.tls)
+	assert.Equal(t, map[string]string{"auth_token": "token"}, p.auth)
+	assert.Equal(t, "site1.error.cellar", p.Subject(BusMessage{Kind: NotificationError, Host: "cellar"}))
+
+	_, err = NewNATSPublisher(NATSOptions{URL: "http://localhost"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NotifierConfig selects and configures a built-in notifier in the alert
+// config, see AlertConfig.
+type NotifierConfig struct {
+	// Type is webhook, telegram or pushover.
+	Type string `yaml:"type"`
+	// URL and Secret of a webhook.
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+	// Format of a webhook, json or cloudevents.
+	Format string `yaml:"format"`
+	// Token of the Telegram bot or the Pushover application.
+	Token string `yaml:"token"`
+	// ChatID receives the Telegram messages.
+	ChatID string `yaml:"chat_id"`
+	// User key of the Pushover recipient.
+	User string `yaml:"user"`
+	// Spool of a webhook, see WebhookOptions.
+	Spool *Spool `yaml:"-"`
+}
+
+// Notifier creates the configured notifier.
+func (c NotifierConfig) Notifier() (Notifier, error) {
+	switch strings.ToLower(c.Type) {
+	case "webhook":
+		switch c.Format {
+		case "", EventFormatJSON, EventFormatCloudEvents:
+		default:
+			return nil, fmt.Errorf("NotifierConfig.Notifier unknown webhook format %q, want %s or %s", c.Format, EventFormatJSON, EventFormatCloudEvents)
+		}
+		return NewWebhookNotifier(WebhookOptions{URL: c.URL, Secret: c.Secret, CloudEvents: c.Format == EventFormatCloudEvents, Spool: c.Spool})
+	case "telegram":
+		return NewTelegramNotifier(TelegramOptions{Token: c.Token, ChatID: c.ChatID})
+	case "pushover":
+		return NewPushoverNotifier(PushoverOptions{Token: c.Token, User: c.User})
+	}
+	return nil, fmt.Errorf("NotifierConfig.Notifier unknown type %q, want webhook, telegram or pushover", c.Type)
+}
+
+type TelegramOptions struct {
+	// Token of the bot as issued by the BotFather.
+	Token  string
+	ChatID string
+	// APIURL defaults to https://api.telegram.org.
+	APIURL     string
+	Retries    int
+	Backoff    time.Duration
+	HTTPClient *http.Client
+}
+
+// TelegramNotifier sends the message of a notification via a Telegram bot.
+type TelegramNotifier struct {
+	opts TelegramOptions
+}
+
+func NewTelegramNotifier(opts TelegramOptions) (*TelegramNotifier, error) {
+	if opts.Token == "" || opts.ChatID == "" {
+		return nil, errors.New("NewTelegramNotifier missing token or chat ID")
+	}
+	if opts.APIURL == "" {
+		opts.APIURL = "https://api.telegram.org"
+	}
+	opts.Retries, opts.Backoff, opts.HTTPClient = notifierDefaults(opts.Retries, opts.Backoff, opts.HTTPClient)
+	return &TelegramNotifier{opts: opts}, nil
+}
+
+func (t *TelegramNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(map[string]string{"chat_id": t.opts.ChatID, "text": n.Message})
+	if err != nil {
+		return fmt.Errorf("TelegramNotifier.Notify: %w", err)
+	}
+	endpoint := strings.TrimSuffix(t.opts.APIURL, "/") + "/bot" + t.opts.Token + "/sendMessage"
+	err = postRetry(ctx, t.opts.HTTPClient, t.opts.Retries, t.opts.Backoff, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		// the URL contains the token
+		return fmt.Errorf("TelegramNotifier.Notify: %s", strings.ReplaceAll(err.Error(), t.opts.Token, "***"))
+	}
+	return nil
+}
+
+type PushoverOptions struct {
+	// Token of the application and User key of the recipient.
+	Token string
+	User  string
+	// APIURL defaults to https://api.pushover.net/1/messages.json.
+	APIURL     string
+	Retries    int
+	Backoff    time.Duration
+	HTTPClient *http.Client
+}
+
+// PushoverNotifier sends notifications via Pushover. Errors and firing
+// alerts are sent with high priority, which bypasses the quiet hours.
+type PushoverNotifier struct {
+	opts PushoverOptions
+}
+
+func NewPushoverNotifier(opts PushoverOptions) (*PushoverNotifier, error) {
+	if opts.Token == "" || opts.User == "" {
+		return nil, errors.New("NewPushoverNotifier missing token or user key")
+	}
+	if opts.APIURL == "" {
+		opts.APIURL = "https://api.pushover.net/1/messages.json"
+	}
+	opts.Retries, opts.Backoff, opts.HTTPClient = notifierDefaults(opts.Retries, opts.Backoff, opts.HTTPClient)
+	return &PushoverNotifier{opts: opts}, nil
+}
+
+func (p *PushoverNotifier) Notify(ctx context.Context, n Notification) error {
+	form := url.Values{}
+	form.Set("token", p.opts.Token)
+	form.Set("user", p.opts.User)
+	form.Set("title", "Heat pump "+n.Host)
+	form.Set("message", n.Message)
+	form.Set("timestamp", fmt.Sprint(n.Time.Unix()))
+	if !n.Resolved {
+		form.Set("priority", "1")
+	}
+	body := form.Encode()
+	err := postRetry(ctx, p.opts.HTTPClient, p.opts.Retries, p.opts.Backoff, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.opts.APIURL, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("PushoverNotifier.Notify: %w", err)
+	}
+	return nil
+}
+
+func notifierDefaults(retries int, backoff time.Duration, client *http.Client) (int, time.Duration, *http.Client) {
+	if retries == 0 {
+		retries = 3
+	}
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return retries, backoff, client
+}
@@ -0,0 +1,81 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramNotifier(t *testing.T) {
+	var path string
+	var msg map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+
+	n, err := NotifierConfig{Type: "telegram", Token: "123:abc", ChatID: "42"}.Notifier()
+	require.NoError(t, err)
+	n.(*TelegramNotifier).opts.APIURL = srv.URL
+	require.NoError(t, n.Notify(context.Background(), Notification{Kind: NotificationError, Message: "hp error 701: low pressure fault"}))
+	assert.Equal(t, "/bot123:abc/sendMessage", path)
+	assert.Equal(t, map[string]string{"chat_id": "42", "text": "hp error 701: low pressure fault"}, msg)
+
+	srv.Close()
+	tn, err := NewTelegramNotifier(TelegramOptions{Token: "123:abc", ChatID: "42", APIURL: srv.URL, Retries: -1})
+	require.NoError(t, err)
+	err = tn.Notify(context.Background(), Notification{})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "123:abc", "the token must not leak into logs")
+}
+
+func TestPushoverNotifier(t *testing.T) {
+	var form map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		form = map[string]string{}
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := NewPushoverNotifier(PushoverOptions{Token: "app", User: "me", APIURL: srv.URL})
+	require.NoError(t, err)
+	require.NoError(t, p.Notify(context.Background(), Notification{
+		Kind:    NotificationAlert,
+		Host:    "hp",
+		Time:    time.Unix(1700000000, 0),
+		Message: "hp firing: low brine (value 0.4)",
+	}))
+	assert.Equal(t, map[string]string{
+		"token":     "app",
+		"user":      "me",
+		"title":     "Heat pump hp",
+		"message":   "hp firing: low brine (value 0.4)",
+		"timestamp": "1700000000",
+		"priority":  "1",
+	}, form)
+
+	require.NoError(t, p.Notify(context.Background(), Notification{Kind: NotificationAlert, Resolved: true}))
+	assert.NotContains(t, form, "priority", "resolved alerts use the normal priority")
+}
+
+func TestNotifierConfig(t *testing.T) {
+	for _, c := range []NotifierConfig{
+		{Type: "sms"},
+		{Type: "telegram", Token: "123:abc"},
+		{Type: "pushover", User: "me"},
+		{Type: "webhook"},
+	} {
+		_, err := c.Notifier()
+		assert.Error(t, err, c.Type)
+	}
+}
@@ -0,0 +1,311 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Kinds of a Notification.
+const (
+	NotificationAlert = "alert"
+	NotificationError = "error"
+)
+
+// Notification is an alert event or a new entry in the error memory of a heat
+// pump.
+type Notification struct {
+	Kind    string    `json:"kind"`
+	Host    string    `json:"host"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+	// Rule, Value and Resolved are set for alerts.
+	Rule     string  `json:"rule,omitempty"`
+	Value    float64 `json:"value,omitempty"`
+	Resolved bool    `json:"resolved,omitempty"`
+	// Code is set for errors.
+	Code uint32 `json:"code,omitempty"`
+}
+
+// AlertNotification converts an alert event.
+func AlertNotification(e AlertEvent) Notification {
+	return Notification{
+		Kind:     NotificationAlert,
+		Host:     e.Host,
+		Time:     e.Time,
+		Message:  e.String(),
+		Rule:     e.Rule.String(),
+		Value:    e.Value,
+		Resolved: e.Resolved,
+	}
+}
+
+// ErrorNotification converts an entry of the error memory.
+func ErrorNotification(host string, e ErrorEntry) Notification {
+	return Notification{
+		Kind:    NotificationError,
+		Host:    host,
+		Time:    e.Time,
+		Message: fmt.Sprintf("%s error %d: %s", host, e.Code, e.Message),
+		Code:    e.Code,
+	}
+}
+
+// Notifier delivers a notification, e.g. to a webhook.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, n Notification) error
+
+func (f NotifierFunc) Notify(ctx context.Context, n Notification) error { return f(ctx, n) }
+
+// Dispatcher delivers notifications in the background to all notifiers, so
+// that slow receivers do not delay the polls. It is a Sink which notifies
+// about new entries in the error memory of the read calculations, the
+// entries present at the first poll are not reported. Its Alert method
+// serves as AlertOptions.Notify.
+type Dispatcher struct {
+	notifiers []Notifier
+	logger    *zap.Logger
+
+	mu     sync.Mutex
+	closed bool
+	latest map[string]time.Time // newest known error per host
+	queue  chan Notification
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher starts the delivery. Up to 100 notifications are queued,
+// further ones get dropped while the notifiers are busy.
+func NewDispatcher(logger *zap.Logger, notifiers ...Notifier) *Dispatcher {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	d := &Dispatcher{
+		notifiers: notifiers,
+		logger:    logger,
+		latest:    map[string]time.Time{},
+		queue:     make(chan Notification, 100),
+	}
+	d.wg.Add(1)
+	go d.loop()
+	return d
+}
+
+func (d *Dispatcher) loop() {
+	defer d.wg.Done()
+	for n := range d.queue {
+		for _, nf := range d.notifiers {
+			if err := nf.Notify(context.Background(), n); err != nil {
+				d.logger.Error("notification failed", zap.String("kind", n.Kind), zap.String("host", n.Host), zap.Error(err))
+			}
+		}
+	}
+}
+
+// Send queues the notification.
+func (d *Dispatcher) Send(n Notification) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+	select {
+	case d.queue <- n:
+	default:
+		d.logger.Warn("notification dropped, queue full", zap.String("kind", n.Kind), zap.String("host", n.Host))
+	}
+}
+
+// Alert queues the notification of an alert event.
+func (d *Dispatcher) Alert(e AlertEvent) {
+	d.Send(AlertNotification(e))
+}
+
+func (d *Dispatcher) Write(_ context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	if block != BlockCalculations {
+		return nil
+	}
+	entries := ErrorMemory(pm)
+	var newest time.Time
+	if len(entries) > 0 {
+		newest = entries[0].Time
+	}
+
+	d.mu.Lock()
+	last, seen := d.latest[host]
+	d.latest[host] = newest
+	d.mu.Unlock()
+	if !seen {
+		return nil
+	}
+	// oldest first
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Time.After(last) {
+			d.Send(ErrorNotification(host, entries[i]))
+		}
+	}
+	return nil
+}
+
+// Close delivers the queued notifications.
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+	d.wg.Wait()
+	return nil
+}
+
+// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of the body,
+// prefixed with "sha256=", if a secret is configured.
+const WebhookSignatureHeader = "X-Luxtronik-Signature"
+
+type WebhookOptions struct {
+	URL string
+	// Secret signs the body, see WebhookSignatureHeader.
+	Secret string
+	// CloudEvents posts the notifications as CloudEvent in the structured
+	// mode, see NotificationCloudEvent.
+	CloudEvents bool
+	// Retries of failed deliveries, defaults to 3, negative disables them.
+	// Client errors except 429 are not retried.
+	Retries int
+	// Backoff before the first retry, doubled for each further one, defaults
+	// to one second.
+	Backoff    time.Duration
+	HTTPClient *http.Client
+	// Spool buffers the notifications on disk while the URL is unreachable
+	// and delivers them in order once it is back. Notifications rejected
+	// with a client error are not spooled, spooled ones are dropped.
+	Spool *Spool
+}
+
+// WebhookNotifier posts notifications as JSON, optionally as CloudEvents.
+type WebhookNotifier struct {
+	opts WebhookOptions
+}
+
+func NewWebhookNotifier(opts WebhookOptions) (*WebhookNotifier, error) {
+	if opts.URL == "" {
+		return nil, errors.New("NewWebhookNotifier missing URL")
+	}
+	opts.Retries, opts.Backoff, opts.HTTPClient = notifierDefaults(opts.Retries, opts.Backoff, opts.HTTPClient)
+	return &WebhookNotifier{opts: opts}, nil
+}
+
+// SignWebhook returns the signature of body as sent in WebhookSignatureHeader.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	var doc any = n
+	contentType := "application/json"
+	if w.opts.CloudEvents {
+		doc, contentType = NotificationCloudEvent(n), CloudEventsContentType
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("WebhookNotifier.Notify: %w", err)
+	}
+	err = w.opts.Spool.Deliver(body, func(body []byte) error {
+		err := w.post(ctx, body, contentType)
+		if permanent(err) {
+			return &PermanentError{Err: err}
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("WebhookNotifier.Notify %s: %w", w.opts.URL, err)
+	}
+	return nil
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, body []byte, contentType string) error {
+	return postRetry(ctx, w.opts.HTTPClient, w.opts.Retries, w.opts.Backoff, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		if w.opts.Secret != "" {
+			req.Header.Set(WebhookSignatureHeader, SignWebhook(w.opts.Secret, body))
+		}
+		return req, nil
+	})
+}
+
+// statusError is a response other than 2xx.
+type statusError struct {
+	code int
+	msg  []byte
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("received status %d: %s", e.code, e.msg)
+}
+
+// permanent reports whether err is a client error which a retry does not
+// fix.
+func permanent(err error) bool {
+	var se *statusError
+	return errors.As(err, &se) && se.code < 500 && se.code != http.StatusTooManyRequests
+}
+
+// postRetry sends the requests of newReq until one succeeds, retrying
+// network errors, server errors and 429 with exponential backoff.
+func postRetry(ctx context.Context, client *http.Client, retries int, backoff time.Duration, newReq func() (*http.Request, error)) error {
+	for attempt := 0; ; attempt++ {
+		retry, err := postOnce(client, newReq)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= max(retries, 0) {
+			return fmt.Errorf("failed after %d attempts: %w", attempt+1, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postOnce sends one request and reports whether a failure is worth a retry.
+func postOnce(client *http.Client, newReq func() (*http.Request, error)) (bool, error) {
+	req, err := newReq()
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := &statusError{code: resp.StatusCode, msg: bytes.TrimSpace(msg)}
+		return !permanent(err), err
+	}
+	return false, nil
+}
@@ -0,0 +1,125 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var attempts int
+	var got Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, SignWebhook("s3cret", body), r.Header.Get(WebhookSignatureHeader))
+		if attempts < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		assert.NoError(t, json.Unmarshal(body, &got))
+	}))
+	defer srv.Close()
+
+	w, err := NewWebhookNotifier(WebhookOptions{URL: srv.URL, Secret: "s3cret", Backoff: time.Millisecond})
+	require.NoError(t, err)
+	n := ErrorNotification("hp", ErrorEntry{Time: time.Unix(1700000000, 0), Code: 701, Message: "low pressure fault"})
+	require.NoError(t, w.Notify(context.Background(), n))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, NotificationError, got.Kind)
+	assert.Equal(t, uint32(701), got.Code)
+	assert.Equal(t, "hp error 701: low pressure fault", got.Message)
+
+	attempts = 0
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "no", http.StatusBadRequest)
+	}))
+	defer bad.Close()
+	w, err = NewWebhookNotifier(WebhookOptions{URL: bad.URL, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	assert.ErrorContains(t, w.Notify(context.Background(), n), "status 400")
+	assert.Equal(t, 1, attempts, "client errors are not retried")
+
+	_, err = NewWebhookNotifier(WebhookOptions{})
+	assert.Error(t, err)
+}
+
+func TestWebhookNotifier_Spool(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusServiceUnavailable
+	var codes []uint32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if status != http.StatusOK {
+			http.Error(w, "down", status)
+			return
+		}
+		var n Notification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		codes = append(codes, n.Code)
+	}))
+	defer srv.Close()
+
+	spool, err := OpenSpool(SpoolOptions{Dir: t.TempDir()})
+	require.NoError(t, err)
+	w, err := NewWebhookNotifier(WebhookOptions{URL: srv.URL, Retries: -1, Spool: spool})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, w.Notify(ctx, ErrorNotification("hp", ErrorEntry{Code: 701})), "spooled")
+	assert.Equal(t, 1, spool.Len())
+
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	require.NoError(t, w.Notify(ctx, ErrorNotification("hp", ErrorEntry{Code: 702})))
+	assert.Equal(t, []uint32{701, 702}, codes, "delivered in order")
+	assert.Zero(t, spool.Len())
+
+	mu.Lock()
+	status = http.StatusBadRequest
+	mu.Unlock()
+	assert.ErrorContains(t, w.Notify(ctx, ErrorNotification("hp", ErrorEntry{Code: 703})), "status 400")
+	assert.Zero(t, spool.Len(), "rejected notifications are not spooled")
+}
+
+func TestDispatcher(t *testing.T) {
+	var mu sync.Mutex
+	var got []Notification
+	d := NewDispatcher(nil, NotifierFunc(func(_ context.Context, n Notification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, n)
+		return nil
+	}))
+
+	pm := NewCalculationsMap()
+	pm[CalcERRORTime0].reading.Raw = 1700000000
+	pm[CalcERRORNr0].reading.Raw = 701
+	ctx := context.Background()
+	require.NoError(t, d.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+
+	pm[CalcERRORTime1].reading.Raw = 1710000000
+	pm[CalcERRORNr1].reading.Raw = 714
+	require.NoError(t, d.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+	require.NoError(t, d.Write(ctx, "hp", time.Now(), BlockCalculations, pm))
+	d.Alert(AlertEvent{Rule: AlertRule{Name: "low brine"}, Host: "hp", Value: 0.4})
+	require.NoError(t, d.Close())
+	d.Alert(AlertEvent{Rule: AlertRule{Name: "after close"}})
+
+	require.Len(t, got, 2, "the error present at the first poll is not reported")
+	assert.Equal(t, NotificationError, got[0].Kind)
+	assert.Equal(t, uint32(714), got[0].Code)
+	assert.Equal(t, NotificationAlert, got[1].Kind)
+	assert.Equal(t, "low brine", got[1].Rule)
+	assert.Equal(t, "hp firing: low brine (value 0.4)", got[1].Message)
+}
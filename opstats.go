@@ -0,0 +1,248 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OperatingStats are the operating counters of the controller.
+type OperatingStats struct {
+	Time              time.Time     `json:"time"`
+	CompressorRuntime time.Duration `json:"compressor_runtime"` // both compressors
+	CompressorStarts  uint32        `json:"compressor_starts"`  // both compressors
+	HeatPumpRuntime   time.Duration `json:"heat_pump_runtime"`
+	HeatingRuntime    time.Duration `json:"heating_runtime"`
+	HotWaterRuntime   time.Duration `json:"hot_water_runtime"`
+	CoolingRuntime    time.Duration `json:"cooling_runtime"`
+	AuxHeaterRuntime  time.Duration `json:"aux_heater_runtime"` // second heat generators
+	HeatingEnergy     float64       `json:"heating_energy"`     // kWh heat quantity
+	HotWaterEnergy    float64       `json:"hot_water_energy"`   // kWh heat quantity
+	PoolEnergy        float64       `json:"pool_energy"`        // kWh heat quantity
+	TotalEnergy       float64       `json:"total_energy"`       // kWh heat quantity
+}
+
+// Stats extracts the operating counters from a read calculations block.
+func Stats(ts time.Time, calculations DataTypeMap) OperatingStats {
+	seconds := func(idxs ...int) (d time.Duration) {
+		for _, idx := range idxs {
+			if b, ok := calculations[idx]; ok {
+				d += time.Duration(b.reading.Raw) * time.Second
+			}
+		}
+		return d
+	}
+	count := func(idxs ...int) (n uint32) {
+		for _, idx := range idxs {
+			if b, ok := calculations[idx]; ok {
+				n += b.reading.Raw
+			}
+		}
+		return n
+	}
+	kWh := func(idx int) float64 {
+		return float64(count(idx)) / 10
+	}
+	return OperatingStats{
+		Time:              ts,
+		CompressorRuntime: seconds(CalcZaehlerBetrZeitVD1, CalcZaehlerBetrZeitVD2),
+		CompressorStarts:  count(CalcCompressorStarts, CalcZaehlerBetrZeitImpVD2),
+		HeatPumpRuntime:   seconds(CalcZaehlerBetrZeitWP),
+		HeatingRuntime:    seconds(CalcHeatingRuntime),
+		HotWaterRuntime:   seconds(CalcZaehlerBetrZeitBW),
+		CoolingRuntime:    seconds(CalcZaehlerBetrZeitKue),
+		AuxHeaterRuntime:  seconds(CalcZaehlerBetrZeitZWE1, CalcZaehlerBetrZeitZWE2, CalcZaehlerBetrZeitZWE3),
+		HeatingEnergy:     kWh(CalcHeatQuantityHeating),
+		HotWaterEnergy:    kWh(CalcHeatQuantityHotWater),
+		PoolEnergy:        kWh(CalcWMZSchwimmbad),
+		TotalEnergy:       kWh(CalcWMZSeit),
+	}
+}
+
+// Sub returns the increase of the counters since prev. Counters which have
+// been reset in between count as zero.
+func (s OperatingStats) Sub(prev OperatingStats) OperatingStats {
+	dur := func(a, b time.Duration) time.Duration { return max(a-b, 0) }
+	num := func(a, b float64) float64 { return max(a-b, 0) }
+	d := OperatingStats{
+		Time:              s.Time,
+		CompressorRuntime: dur(s.CompressorRuntime, prev.CompressorRuntime),
+		HeatPumpRuntime:   dur(s.HeatPumpRuntime, prev.HeatPumpRuntime),
+		HeatingRuntime:    dur(s.HeatingRuntime, prev.HeatingRuntime),
+		HotWaterRuntime:   dur(s.HotWaterRuntime, prev.HotWaterRuntime),
+		CoolingRuntime:    dur(s.CoolingRuntime, prev.CoolingRuntime),
+		AuxHeaterRuntime:  dur(s.AuxHeaterRuntime, prev.AuxHeaterRuntime),
+		HeatingEnergy:     num(s.HeatingEnergy, prev.HeatingEnergy),
+		HotWaterEnergy:    num(s.HotWaterEnergy, prev.HotWaterEnergy),
+		PoolEnergy:        num(s.PoolEnergy, prev.PoolEnergy),
+		TotalEnergy:       num(s.TotalEnergy, prev.TotalEnergy),
+	}
+	if s.CompressorStarts > prev.CompressorStarts {
+		d.CompressorStarts = s.CompressorStarts - prev.CompressorStarts
+	}
+	return d
+}
+
+// DailyStats is the increase of the operating counters during one day.
+type DailyStats struct {
+	Host  string
+	Date  time.Time
+	Delta OperatingStats
+	// Partial is set for the day the history started, for the last day whose
+	// counters still increase and for days followed by a gap, whose delta
+	// spans several days.
+	Partial bool
+}
+
+type StatsHistoryOptions struct {
+	// Path of the JSON file which keeps the history across restarts, empty
+	// keeps it in memory only.
+	Path string
+	// MaxDays limits the number of stored days per heat pump, defaults to
+	// 400.
+	MaxDays int
+	// Location defines the day boundaries, defaults to time.Local.
+	Location *time.Location
+}
+
+// StatsHistory is a Sink which stores the operating counters at the start of
+// each day, so that Daily can compute per-day deltas. The Poller must read
+// the calculations.
+type StatsHistory struct {
+	opts StatsHistoryOptions
+
+	mu    sync.Mutex
+	hosts map[string]*statsHost
+}
+
+type statsHost struct {
+	// Days holds the first counters of each day, keyed by YYYY-MM-DD.
+	Days  map[string]OperatingStats `json:"days"`
+	Last  OperatingStats            `json:"last"`
+	Since time.Time                 `json:"since"`
+}
+
+// NewStatsHistory creates the history and loads the file of opts.Path if it
+// exists.
+func NewStatsHistory(opts StatsHistoryOptions) (*StatsHistory, error) {
+	if opts.MaxDays <= 0 {
+		opts.MaxDays = 400
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	h := &StatsHistory{opts: opts, hosts: map[string]*statsHost{}}
+	if opts.Path == "" {
+		return h, nil
+	}
+	data, err := os.ReadFile(opts.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewStatsHistory: %w", err)
+	}
+	if err := json.Unmarshal(data, &h.hosts); err != nil {
+		return nil, fmt.Errorf("NewStatsHistory %s: %w", opts.Path, err)
+	}
+	return h, nil
+}
+
+func (h *StatsHistory) Write(_ context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	if block != BlockCalculations {
+		return nil
+	}
+	s := Stats(ts, pm)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hh, ok := h.hosts[host]
+	if !ok {
+		hh = &statsHost{Days: map[string]OperatingStats{}, Since: ts}
+		h.hosts[host] = hh
+	}
+	hh.Last = s
+	day := ts.In(h.opts.Location).Format(time.DateOnly)
+	if _, ok := hh.Days[day]; ok {
+		return nil
+	}
+	hh.Days[day] = s
+	if len(hh.Days) > h.opts.MaxDays {
+		days := sortedDays(hh.Days)
+		for _, d := range days[:len(days)-h.opts.MaxDays] {
+			delete(hh.Days, d)
+		}
+	}
+	return h.save()
+}
+
+// Daily returns the per-day deltas of all heat pumps, sorted by host and
+// date. The delta of a day reaches up to the first counters of the next
+// stored day or, for the last day, up to the latest counters.
+func (h *StatsHistory) Daily() []DailyStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hosts := make([]string, 0, len(h.hosts))
+	for host := range h.hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var res []DailyStats
+	for _, host := range hosts {
+		hh := h.hosts[host]
+		days := sortedDays(hh.Days)
+		for i, day := range days {
+			date, _ := time.ParseInLocation(time.DateOnly, day, h.opts.Location)
+			end, partial := hh.Last, true
+			if i+1 < len(days) {
+				end = hh.Days[days[i+1]]
+				partial = days[i+1] != date.AddDate(0, 0, 1).Format(time.DateOnly)
+			}
+			partial = partial || day == hh.Since.In(h.opts.Location).Format(time.DateOnly)
+			res = append(res, DailyStats{Host: host, Date: date, Delta: end.Sub(hh.Days[day]), Partial: partial})
+		}
+	}
+	return res
+}
+
+func sortedDays(days map[string]OperatingStats) []string {
+	keys := make([]string, 0, len(days))
+	for d := range days {
+		keys = append(keys, d)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// save writes the history file atomically, the caller holds the lock.
+func (h *StatsHistory) save() error {
+	if h.opts.Path == "" {
+		return nil
+	}
+	data, err := json.Marshal(h.hosts)
+	if err != nil {
+		return fmt.Errorf("StatsHistory.save: %w", err)
+	}
+	tmp := h.opts.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return fmt.Errorf("StatsHistory.save: %w", err)
+	}
+	if err := os.Rename(tmp, h.opts.Path); err != nil {
+		return fmt.Errorf("StatsHistory.save: %w", err)
+	}
+	return nil
+}
+
+// Close stores the latest counters.
+func (h *StatsHistory) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.save()
+}
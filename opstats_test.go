@@ -0,0 +1,66 @@
+package luxtronik
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	pm := NewCalculationsMap()
+	pm[CalcZaehlerBetrZeitVD1].SetRaw(7200)
+	pm[CalcZaehlerBetrZeitVD2].SetRaw(3600)
+	pm[CalcCompressorStarts].SetRaw(12)
+	pm[CalcHeatingRuntime].SetRaw(5400)
+	pm[CalcHeatQuantityHeating].SetRaw(1234)
+
+	s := Stats(time.Unix(0, 0), pm)
+	assert.Equal(t, 3*time.Hour, s.CompressorRuntime)
+	assert.Equal(t, uint32(12), s.CompressorStarts)
+	assert.Equal(t, 90*time.Minute, s.HeatingRuntime)
+	assert.InDelta(t, 123.4, s.HeatingEnergy, 0.001)
+
+	prev := s
+	prev.CompressorStarts = 20
+	prev.HeatingEnergy = 100
+	d := s.Sub(prev)
+	assert.Zero(t, d.CompressorStarts, "counter reset")
+	assert.InDelta(t, 23.4, d.HeatingEnergy, 0.001)
+}
+
+func TestStatsHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	h, err := NewStatsHistory(StatsHistoryOptions{Path: path, Location: time.UTC})
+	require.NoError(t, err)
+
+	pm := NewCalculationsMap()
+	write := func(ts time.Time, heat, starts uint32) {
+		pm[CalcHeatQuantityHeating].SetRaw(heat)
+		pm[CalcCompressorStarts].SetRaw(starts)
+		require.NoError(t, h.Write(context.Background(), "hp", ts, BlockCalculations, pm))
+	}
+	day := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	write(day.Add(10*time.Hour), 1000, 100)
+	write(day.Add(24*time.Hour), 1100, 110)
+	write(day.Add(30*time.Hour), 1150, 112)
+	write(day.Add(48*time.Hour), 1300, 120)
+	write(day.Add(50*time.Hour), 1320, 121)
+	require.NoError(t, h.Close())
+
+	h, err = NewStatsHistory(StatsHistoryOptions{Path: path, Location: time.UTC})
+	require.NoError(t, err, "reloads the file")
+	daily := h.Daily()
+	require.Len(t, daily, 3)
+	assert.True(t, daily[0].Partial, "history started during the day")
+	assert.InDelta(t, 10, daily[0].Delta.HeatingEnergy, 0.001)
+	assert.False(t, daily[1].Partial)
+	assert.Equal(t, day.AddDate(0, 0, 1), daily[1].Date)
+	assert.InDelta(t, 20, daily[1].Delta.HeatingEnergy, 0.001)
+	assert.Equal(t, uint32(10), daily[1].Delta.CompressorStarts)
+	assert.True(t, daily[2].Partial, "today")
+	assert.InDelta(t, 2, daily[2].Delta.HeatingEnergy, 0.001)
+}
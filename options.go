@@ -0,0 +1,41 @@
+package luxtronik
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Option configures a Client, see NewClient. Options is an Option itself
+// which replaces all settings of the options before it.
+type Option interface {
+	apply(*Options)
+}
+
+func (o Options) apply(dst *Options) {
+	*dst = o
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithLogger sets Options.Logger.
+func WithLogger(l *zap.Logger) Option {
+	return optionFunc(func(o *Options) { o.Logger = l })
+}
+
+// WithTimeout bounds the dial of a connect, see Options.DialTimeout.
+func WithTimeout(d time.Duration) Option {
+	return optionFunc(func(o *Options) { o.DialTimeout = d })
+}
+
+// WithReconnect retries failed connects, see Options.DialRetries.
+func WithReconnect(retries int, backoff time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		o.DialRetries = retries
+		o.DialBackoff = backoff
+	})
+}
@@ -0,0 +1,50 @@
+package luxtronik
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	_, err := NewClient("192.168.0.121:http")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+
+	logger := zap.NewExample()
+	c, err := NewClient("192.168.0.121:8889", Options{Alias: "cellar", DialTimeout: time.Hour},
+		WithLogger(logger), WithTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "cellar", c.Name())
+	assert.Same(t, logger, c.opts.Logger)
+	assert.Equal(t, time.Second, c.opts.DialTimeout)
+	assert.Equal(t, time.Second, c.opts.DialBackoff)
+
+	// a later Options struct replaces the settings before
+	c = MustNewClient("192.168.0.121:8889", WithTimeout(time.Second), Options{})
+	assert.Equal(t, time.Minute, c.opts.DialTimeout)
+}
+
+func TestClient_DialRetries(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	core, logs := observer.New(zap.DebugLevel)
+	c := MustNewClient(addr, WithLogger(zap.New(core)), WithReconnect(2, time.Millisecond))
+	err = c.Connect()
+	var cErr *ConnectionError
+	require.ErrorAs(t, err, &cErr)
+	assert.Equal(t, 2, logs.FilterMessage("connect failed, retrying").Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c = MustNewClient(addr, WithReconnect(5, time.Hour))
+	assert.ErrorIs(t, c.ConnectContext(ctx), context.Canceled)
+}
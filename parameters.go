@@ -1,38 +1,39 @@
+// Code generated by genmaps from data/parameters.csv; DO NOT EDIT.
+
 package luxtronik
 
-func NewParameterMap() DataTypeMap {
+func parameterCatalog() DataTypeMap {
 	return DataTypeMap{
-		// the index number is really import because it assigns a value from the
-		// heat pump to the Base object. The heat pump only sends the values
-		// from 0 to 1053.
+		// the index number is really important because it assigns a value from
+		// the heat pump to the Base object.
 		0:   NewUnknown("ID_Transfert_LuxNet"),
-		1:   NewCelsius("ID_Einst_WK_akt", true),
-		2:   NewCelsius("ID_Einst_BWS_akt", true),
+		1:   NewCelsius("ID_Einst_WK_akt", true).WithRange(-5, 5, 0.5),
+		2:   NewCelsius("ID_Einst_BWS_akt", true).WithRange(30, 65, 0),
 		3:   NewHeatingMode("ID_Ba_Hz_akt", true),
 		4:   NewHotWaterMode("ID_Ba_Bw_akt", true),
 		5:   NewUnknown("ID_Ba_Al_akt"),
-		6:   NewUnknown("ID_SU_FrkdHz"),
-		7:   NewUnknown("ID_SU_FrkdBw"),
+		6:   NewTimestamp("ID_SU_FrkdHz", true), // end of the heating holiday
+		7:   NewTimestamp("ID_SU_FrkdBw", true), // end of the hot water holiday
 		8:   NewUnknown("ID_SU_FrkdAl"),
 		9:   NewUnknown("ID_Einst_HReg_akt"),
 		10:  NewUnknown("ID_Einst_HzHwMAt_akt"),
-		11:  NewCelsius("ID_Einst_HzHwHKE_akt", true),
-		12:  NewCelsius("ID_Einst_HzHKRANH_akt", true),
-		13:  NewCelsius("ID_Einst_HzHKRABS_akt", true),
-		14:  NewCelsius("ID_Einst_HzMK1E_akt", true),
-		15:  NewCelsius("ID_Einst_HzMK1ANH_akt", true),
-		16:  NewCelsius("ID_Einst_HzMK1ABS_akt", true),
+		11:  NewCelsius("ID_Einst_HzHwHKE_akt", true).WithRange(20, 70, 0), // heating curve end point
+		12:  NewCelsius("ID_Einst_HzHKRANH_akt", true).WithRange(5, 35, 0), // heating curve parallel shift
+		13:  NewCelsius("ID_Einst_HzHKRABS_akt", true).WithRange(0, 20, 0), // heating curve night setback
+		14:  NewCelsius("ID_Einst_HzMK1E_akt", true).WithRange(20, 70, 0),
+		15:  NewCelsius("ID_Einst_HzMK1ANH_akt", true).WithRange(5, 35, 0),
+		16:  NewCelsius("ID_Einst_HzMK1ABS_akt", true).WithRange(0, 20, 0),
 		17:  NewUnknown("ID_Einst_HzFtRl_akt"),
 		18:  NewUnknown("ID_Einst_HzFtMK1Vl_akt"),
 		19:  NewUnknown("ID_Einst_SUBW_akt"),
-		20:  NewUnknown("ID_Einst_BwTDI_akt_MO"),
-		21:  NewUnknown("ID_Einst_BwTDI_akt_DI"),
-		22:  NewUnknown("ID_Einst_BwTDI_akt_MI"),
-		23:  NewUnknown("ID_Einst_BwTDI_akt_DO"),
-		24:  NewUnknown("ID_Einst_BwTDI_akt_FR"),
-		25:  NewUnknown("ID_Einst_BwTDI_akt_SA"),
-		26:  NewUnknown("ID_Einst_BwTDI_akt_SO"),
-		27:  NewUnknown("ID_Einst_BwTDI_akt_AL"),
+		20:  NewBool("ID_Einst_BwTDI_akt_MO", true),
+		21:  NewBool("ID_Einst_BwTDI_akt_DI", true),
+		22:  NewBool("ID_Einst_BwTDI_akt_MI", true),
+		23:  NewBool("ID_Einst_BwTDI_akt_DO", true),
+		24:  NewBool("ID_Einst_BwTDI_akt_FR", true),
+		25:  NewBool("ID_Einst_BwTDI_akt_SA", true),
+		26:  NewBool("ID_Einst_BwTDI_akt_SO", true),
+		27:  NewBool("ID_Einst_BwTDI_akt_AL", true),
 		28:  NewUnknown("ID_Einst_AnlKonf_akt"),
 		29:  NewUnknown("ID_Einst_Sprache_akt"),
 		30:  NewUnknown("ID_Switchoff_Zahler"),
@@ -52,7 +53,7 @@ func NewParameterMap() DataTypeMap {
 		44:  NewUnknown("ID_Einst_TLAbt_akt"),
 		45:  NewUnknown("ID_Einst_LAbtTime_akt"),
 		46:  NewUnknown("ID_Einst_ASDTyp_akt"),
-		47:  NewCelsius("ID_Einst_LGST_akt", true),
+		47:  NewCelsius("ID_Einst_LGST_akt", true).WithRange(50, 75, 0), // thermal disinfection target
 		48:  NewUnknown("ID_Einst_BwWpTime_akt"),
 		49:  NewUnknown("ID_Einst_Popt_akt"),
 		50:  NewUnknown("ID_Einst_Kurzprog_akt"),
@@ -79,12 +80,12 @@ func NewParameterMap() DataTypeMap {
 		71:  NewUnknown("ID_Einst_TVL_Std_8"),
 		72:  NewUnknown("ID_Einst_TVL_Std_9"),
 		73:  NewUnknown("ID_Einst_TVL_Std_10"),
-		74:  NewKelvin("ID_Einst_BWS_Hyst_akt", true),
+		74:  NewKelvin("ID_Einst_BWS_Hyst_akt", true).WithRange(1, 30, 0).WithAccess(AccessInstaller),
 		75:  NewUnknown("ID_Temp_TBW_BwHD_saved"),
 		76:  NewUnknown("ID_Einst_ABT1_akt"),
 		77:  NewUnknown("ID_Einst_LABTpaus_akt"),
 		78:  NewUnknown("ID_AHZ_state_akt"),
-		79:  NewCelsius("ID_Sollwert_TRL_HZ_AHZ", true),
+		79:  NewCelsius("ID_Sollwert_TRL_HZ_AHZ", true).WithRange(15, 70, 0),
 		80:  NewUnknown("ID_AHP_valid_records"),
 		81:  NewUnknown("ID_Timer_AHZ_akt"),
 		82:  NewUnknown("ID_Einst_BWTINP_akt"),
@@ -93,9 +94,9 @@ func NewParameterMap() DataTypeMap {
 		85:  NewUnknown("ID_Einst_BWZIP_akt"),
 		86:  NewUnknown("ID_Einst_ERRmZWE_akt"),
 		87:  NewUnknown("ID_Einst_TRBegr_akt"),
-		88:  NewKelvin("ID_Einst_HRHyst_akt", true),
-		89:  NewKelvin("ID_Einst_TRErhmax_akt", true),
-		90:  NewCelsius("ID_Einst_ZWEFreig_akt", true),
+		88:  NewKelvin("ID_Einst_HRHyst_akt", true).WithRange(0.5, 5, 0).WithAccess(AccessInstaller),
+		89:  NewKelvin("ID_Einst_TRErhmax_akt", true).WithAccess(AccessInstaller),
+		90:  NewCelsius("ID_Einst_ZWEFreig_akt", true).WithAccess(AccessInstaller),
 		91:  NewUnknown("ID_Einst_TAmax_akt"),
 		92:  NewUnknown("ID_Einst_TAmin_akt"),
 		93:  NewUnknown("ID_Einst_TWQmin_akt"),
@@ -110,13 +111,13 @@ func NewParameterMap() DataTypeMap {
 		102: NewUnknown("ID_Einst_UeVd_akt"),
 		103: NewUnknown("ID_Einst_RTyp_akt"),
 		104: NewUnknown("ID_Einst_AhpM_akt"),
-		105: NewCelsius("ID_Soll_BWS_akt", true),
+		105: NewCelsius("ID_Soll_BWS_akt", true).WithRange(30, 65, 0),
 		106: NewUnknown("ID_Timer_Password"),
 		107: NewAccessLevel("ID_Einst_Zugangscode", true),
 		108: NewCoolingMode("ID_Einst_BA_Kuehl_akt", true),
 		109: NewUnknown("ID_Sollwert_Kuehl1_akt"),
 		110: NewCelsius("ID_Einst_KuehlFreig_akt", true),
-		111: NewCelsius("ID_Einst_TAbsMin_akt", true),
+		111: NewCelsius("ID_Einst_TAbsMin_akt", true).WithAccess(AccessInstaller),
 		112: NewUnknown("ID_TWQmin_saved"),
 		113: NewUnknown("ID_CWP_saved"),
 		114: NewUnknown("ID_Einst_Anode_akt"),
@@ -129,7 +130,7 @@ func NewParameterMap() DataTypeMap {
 		121: NewUnknown("ID_Einst_RTypMK2_akt"),
 		122: NewKelvin("ID_Einst_TDC_Ein_akt", false),
 		123: NewKelvin("ID_Einst_TDC_Aus_akt", false),
-		124: NewCelsius("ID_Einst_TDC_Max_akt", true),
+		124: NewCelsius("ID_Einst_TDC_Max_akt", true).WithAccess(AccessInstaller),
 		125: NewUnknown("ID_Einst_HysHzExEn_akt"),
 		126: NewUnknown("ID_Einst_HysBwExEn_akt"),
 		127: NewUnknown("ID_Einst_ZWE3Art_akt"),
@@ -146,9 +147,9 @@ func NewParameterMap() DataTypeMap {
 		138: NewUnknown("ID_Einst_TV2VDSWB_akt"),
 		139: NewUnknown("ID_Einst_MinSwan_Time_akt"),
 		140: NewUnknown("ID_Einst_SuMk2_akt"),
-		141: NewCelsius("ID_Einst_HzMK2E_akt", true),
-		142: NewCelsius("ID_Einst_HzMK2ANH_akt", true),
-		143: NewCelsius("ID_Einst_HzMK2ABS_akt", true),
+		141: NewCelsius("ID_Einst_HzMK2E_akt", true).WithRange(20, 70, 0),
+		142: NewCelsius("ID_Einst_HzMK2ANH_akt", true).WithRange(5, 35, 0),
+		143: NewCelsius("ID_Einst_HzMK2ABS_akt", true).WithRange(0, 20, 0),
 		144: NewUnknown("ID_Einst_HzMK2Hgr_akt"),
 		145: NewUnknown("ID_Einst_HzFtMK2Vl_akt"),
 		146: NewUnknown("ID_Temp_THG_BwHD_saved"),
@@ -744,8 +745,8 @@ func NewParameterMap() DataTypeMap {
 		728:  NewSeconds("ID_Zaehler_BetrZeitHz"),
 		729:  NewSeconds("ID_Zaehler_BetrZeitBW"),
 		730:  NewSeconds("ID_Zaehler_BetrZeitKue"),
-		731:  NewUnknown("ID_SU_FstdHz"),
-		732:  NewUnknown("ID_SU_FstdBw"),
+		731:  NewTimestamp("ID_SU_FstdHz", true), // start of the heating holiday
+		732:  NewTimestamp("ID_SU_FstdBw", true), // start of the hot water holiday
 		733:  NewUnknown("ID_SU_FstdSwb"),
 		734:  NewUnknown("ID_SU_FstdMK1"),
 		735:  NewUnknown("ID_SU_FstdMK2"),
@@ -763,10 +764,10 @@ func NewParameterMap() DataTypeMap {
 		747:  NewUnknown("ID_Ba_Sw_saved"),
 		748:  NewUnknown("ID_Ba_Hz_MK1_saved"),
 		749:  NewUnknown("ID_Ba_Hz_MK2_saved"),
-		750:  NewIPV4Address("ID_AdresseIP_akt"),
-		751:  NewIPV4Address("ID_SubNetMask_akt"),
-		752:  NewIPV4Address("ID_Add_Broadcast_akt"),
-		753:  NewIPV4Address("ID_Add_StdGateway_akt"),
+		750:  NewIPV4Parameter("ID_AdresseIP_akt", true).WithAccess(AccessInstaller), // IP address of the controller
+		751:  NewNetmask("ID_SubNetMask_akt", true).WithAccess(AccessInstaller),
+		752:  NewIPV4Parameter("ID_Add_Broadcast_akt", true).WithAccess(AccessInstaller),
+		753:  NewIPV4Parameter("ID_Add_StdGateway_akt", true).WithAccess(AccessInstaller),
 		754:  NewUnknown("ID_DHCPServerAktiv_akt"),
 		755:  NewUnknown("ID_WebserverPasswort_1_akt"),
 		756:  NewUnknown("ID_WebserverPasswort_2_akt"),
@@ -787,9 +788,9 @@ func NewParameterMap() DataTypeMap {
 		771:  NewUnknown("ID_IP_PB_Slave_5"),
 		772:  NewUnknown("ID_Einst_BwHup_akt_backup"),
 		773:  NewUnknown("ID_Einst_SuMk3_akt"),
-		774:  NewCelsius("ID_Einst_HzMK3E_akt", true),
-		775:  NewCelsius("ID_Einst_HzMK3ANH_akt", true),
-		776:  NewCelsius("ID_Einst_HzMK3ABS_akt", true),
+		774:  NewCelsius("ID_Einst_HzMK3E_akt", true).WithRange(20, 70, 0),
+		775:  NewCelsius("ID_Einst_HzMK3ANH_akt", true).WithRange(5, 35, 0),
+		776:  NewCelsius("ID_Einst_HzMK3ABS_akt", true).WithRange(0, 20, 0),
 		777:  NewUnknown("ID_Einst_HzMK3Hgr_akt"),
 		778:  NewUnknown("ID_Einst_HzFtMK3Vl_akt"),
 		779:  NewMixedCircuitMode("ID_Ba_Hz_MK3_akt", true),
@@ -877,7 +878,7 @@ func NewParameterMap() DataTypeMap {
 		861:  NewIPV4Address("ID_AdresseIPServ_akt"),
 		862:  NewUnknown("ID_Einst_TA_EG_akt"),
 		863:  NewUnknown("ID_Einst_TVLmax_EG_akt"),
-		864:  NewMinutes("ID_Einst_Popt_Nachlauf_akt", true),
+		864:  NewMinutes("ID_Einst_Popt_Nachlauf_akt", true).WithAccess(AccessInstaller),
 		865:  NewUnknown("ID_FernwartungVertrag_akt"),
 		866:  NewUnknown("ID_FernwartungAktuZeit"),
 		867:  NewUnknown("ID_Einst_Effizienzpumpe_Nominal_akt"),
@@ -887,8 +888,8 @@ func NewParameterMap() DataTypeMap {
 		871:  NewUnknown("ID_Einst_Wm_Versorgung_Korrektur_akt"),
 		872:  NewUnknown("ID_Einst_Wm_Auswertung_Korrektur_akt"),
 		873:  NewUnknown("ID_SoftwareUpdateJetztGemacht_akt"),
-		874:  NewUnknown("ID_WP_SerienNummer_DATUM"),
-		875:  NewUnknown("ID_WP_SerienNummer_HEX"),
+		874:  NewCount("ID_WP_SerienNummer_DATUM"), // first part of the serial number
+		875:  NewCount("ID_WP_SerienNummer_HEX"),   // second part of the serial number in hex
 		876:  NewUnknown("ID_WP_SerienNummer_INDEX"),
 		877:  NewUnknown("ID_ProgWerteWebSrvBeobarten"),
 		878:  NewEnergy("ID_Waermemenge_BW"),
@@ -981,7 +982,7 @@ func NewParameterMap() DataTypeMap {
 		965:  NewUnknown("ID_Timer_Fil_WoAkt"),
 		966:  NewCelsius("ID_Sollwert_KuCft3_akt", true),
 		967:  NewCelsius("ID_Sollwert_AtDif3_akt", true),
-		968:  NewUnknown("ID_Bitmaske_0"),
+		968:  NewBitfield("ID_Bitmaske_0", false),
 		969:  NewUnknown("ID_Einst_Lueftungsstufen"),
 		970:  NewUnknown("ID_SysEin_Meldung_TDI"),
 		971:  NewUnknown("ID_SysEin_Typ_WZW"),
@@ -992,7 +993,7 @@ func NewParameterMap() DataTypeMap {
 		976:  NewUnknown("ID_Einst_Photovoltaik_akt"),
 		977:  NewUnknown("ID_Einst_Multispeicher_akt"),
 		978:  NewUnknown("ID_Einst_PKuehlTime_akt"),
-		979:  NewCelsius("ID_Einst_Minimale_Ruecklaufsolltemperatur", true),
+		979:  NewCelsius("ID_Einst_Minimale_Ruecklaufsolltemperatur", true).WithRange(15, 30, 0).WithAccess(AccessInstaller),
 		980:  NewUnknown("ID_RBE_Einflussfaktor_RT_akt"),
 		981:  NewUnknown("ID_RBE_Freigabe_Kuehlung_akt"),
 		982:  NewUnknown("ID_RBE_Waermeverteilsystem_akt"),
@@ -1005,7 +1006,7 @@ func NewParameterMap() DataTypeMap {
 		989:  NewUnknown("ID_Einst_Luf_Reduziert_Faktor_akt"),
 		990:  NewUnknown("ID_Einst_Luf_Nennlueftung_Faktor_akt"),
 		991:  NewUnknown("ID_Einst_Luf_Intensivlueftung_Faktor_akt"),
-		992:  NewMinutes("ID_Einst_Freigabe_Zeit_ZWE", true),
+		992:  NewMinutes("ID_Einst_Freigabe_Zeit_ZWE", true).WithAccess(AccessInstaller),
 		993:  NewUnknown("ID_Einst_min_VL_Kuehl"),
 		994:  NewBool("ID_Einst_Warmwasser_Nachheizung", true),
 		995:  NewUnknown("ID_Switchoff_file_LWD2_0_0"),
@@ -1171,3 +1172,1093 @@ func NewParameterMap() DataTypeMap {
 		1155: NewUnknown("Unknown_Parameter_1155"),
 	}
 }
+
+// Indexes of parameterCatalog.
+const (
+	ParamTransfertLuxNet                      = 0    // ID_Transfert_LuxNet
+	ParamHeatingOffset                        = 1    // ID_Einst_WK_akt
+	ParamHotWaterTarget                       = 2    // ID_Einst_BWS_akt
+	ParamHeatingMode                          = 3    // ID_Ba_Hz_akt
+	ParamHotWaterMode                         = 4    // ID_Ba_Bw_akt
+	ParamBaAlAkt                              = 5    // ID_Ba_Al_akt
+	ParamHolidayEndHeating                    = 6    // ID_SU_FrkdHz
+	ParamHolidayEndHotWater                   = 7    // ID_SU_FrkdBw
+	ParamSUFrkdAl                             = 8    // ID_SU_FrkdAl
+	ParamEinstHRegAkt                         = 9    // ID_Einst_HReg_akt
+	ParamEinstHzHwMAtAkt                      = 10   // ID_Einst_HzHwMAt_akt
+	ParamHeatingCurveEndPoint                 = 11   // ID_Einst_HzHwHKE_akt
+	ParamHeatingCurveOffset                   = 12   // ID_Einst_HzHKRANH_akt
+	ParamHeatingCurveNightSetback             = 13   // ID_Einst_HzHKRABS_akt
+	ParamEinstHzMK1EAkt                       = 14   // ID_Einst_HzMK1E_akt
+	ParamEinstHzMK1ANHAkt                     = 15   // ID_Einst_HzMK1ANH_akt
+	ParamEinstHzMK1ABSAkt                     = 16   // ID_Einst_HzMK1ABS_akt
+	ParamEinstHzFtRlAkt                       = 17   // ID_Einst_HzFtRl_akt
+	ParamEinstHzFtMK1VlAkt                    = 18   // ID_Einst_HzFtMK1Vl_akt
+	ParamEinstSUBWAkt                         = 19   // ID_Einst_SUBW_akt
+	ParamDisinfectionMonday                   = 20   // ID_Einst_BwTDI_akt_MO
+	ParamDisinfectionTuesday                  = 21   // ID_Einst_BwTDI_akt_DI
+	ParamDisinfectionWednesday                = 22   // ID_Einst_BwTDI_akt_MI
+	ParamDisinfectionThursday                 = 23   // ID_Einst_BwTDI_akt_DO
+	ParamDisinfectionFriday                   = 24   // ID_Einst_BwTDI_akt_FR
+	ParamDisinfectionSaturday                 = 25   // ID_Einst_BwTDI_akt_SA
+	ParamDisinfectionSunday                   = 26   // ID_Einst_BwTDI_akt_SO
+	ParamDisinfectionContinuous               = 27   // ID_Einst_BwTDI_akt_AL
+	ParamEinstAnlKonfAkt                      = 28   // ID_Einst_AnlKonf_akt
+	ParamEinstSpracheAkt                      = 29   // ID_Einst_Sprache_akt
+	ParamSwitchoffZahler                      = 30   // ID_Switchoff_Zahler
+	ParamSwitchoffIndex                       = 31   // ID_Switchoff_index
+	ParamEinstEvuTypAkt                       = 32   // ID_Einst_EvuTyp_akt
+	ParamEinstRFVEinbAkt                      = 33   // ID_Einst_RFVEinb_akt
+	ParamEinstAbtZykMaxAkt                    = 34   // ID_Einst_AbtZykMax_akt
+	ParamEinstHREinbAkt                       = 35   // ID_Einst_HREinb_akt
+	ParamEinstZWE1ArtAkt                      = 36   // ID_Einst_ZWE1Art_akt
+	ParamEinstZWE1FktAkt                      = 37   // ID_Einst_ZWE1Fkt_akt
+	ParamEinstZWE2ArtAkt                      = 38   // ID_Einst_ZWE2Art_akt
+	ParamEinstZWE2FktAkt                      = 39   // ID_Einst_ZWE2Fkt_akt
+	ParamEinstBWBerAkt                        = 40   // ID_Einst_BWBer_akt
+	ParamEinstEnInst                          = 41   // ID_Einst_En_Inst
+	ParamEinstMK1TypAkt                       = 42   // ID_Einst_MK1Typ_akt
+	ParamEinstABTLuftAkt                      = 43   // ID_Einst_ABTLuft_akt
+	ParamEinstTLAbtAkt                        = 44   // ID_Einst_TLAbt_akt
+	ParamEinstLAbtTimeAkt                     = 45   // ID_Einst_LAbtTime_akt
+	ParamEinstASDTypAkt                       = 46   // ID_Einst_ASDTyp_akt
+	ParamDisinfectionTarget                   = 47   // ID_Einst_LGST_akt
+	ParamEinstBwWpTimeAkt                     = 48   // ID_Einst_BwWpTime_akt
+	ParamEinstPoptAkt                         = 49   // ID_Einst_Popt_akt
+	ParamEinstKurzprogAkt                     = 50   // ID_Einst_Kurzprog_akt
+	ParamTimerKurzprogAkt                     = 51   // ID_Timer_Kurzprog_akt
+	ParamEinstManAbtAkt                       = 52   // ID_Einst_ManAbt_akt
+	ParamEinstAhzAkt                          = 53   // ID_Einst_Ahz_akt
+	ParamEinstTVLAhz1                         = 54   // ID_Einst_TVL_Ahz_1
+	ParamEinstTVLAhz2                         = 55   // ID_Einst_TVL_Ahz_2
+	ParamEinstTVLAhz3                         = 56   // ID_Einst_TVL_Ahz_3
+	ParamEinstTVLAhz4                         = 57   // ID_Einst_TVL_Ahz_4
+	ParamEinstTVLAhz5                         = 58   // ID_Einst_TVL_Ahz_5
+	ParamEinstTVLAhz6                         = 59   // ID_Einst_TVL_Ahz_6
+	ParamEinstTVLAhz7                         = 60   // ID_Einst_TVL_Ahz_7
+	ParamEinstTVLAhz8                         = 61   // ID_Einst_TVL_Ahz_8
+	ParamEinstTVLAhz9                         = 62   // ID_Einst_TVL_Ahz_9
+	ParamEinstTVLAhz10                        = 63   // ID_Einst_TVL_Ahz_10
+	ParamEinstTVLStd1                         = 64   // ID_Einst_TVL_Std_1
+	ParamEinstTVLStd2                         = 65   // ID_Einst_TVL_Std_2
+	ParamEinstTVLStd3                         = 66   // ID_Einst_TVL_Std_3
+	ParamEinstTVLStd4                         = 67   // ID_Einst_TVL_Std_4
+	ParamEinstTVLStd5                         = 68   // ID_Einst_TVL_Std_5
+	ParamEinstTVLStd6                         = 69   // ID_Einst_TVL_Std_6
+	ParamEinstTVLStd7                         = 70   // ID_Einst_TVL_Std_7
+	ParamEinstTVLStd8                         = 71   // ID_Einst_TVL_Std_8
+	ParamEinstTVLStd9                         = 72   // ID_Einst_TVL_Std_9
+	ParamEinstTVLStd10                        = 73   // ID_Einst_TVL_Std_10
+	ParamEinstBWSHystAkt                      = 74   // ID_Einst_BWS_Hyst_akt
+	ParamTempTBWBwHDSaved                     = 75   // ID_Temp_TBW_BwHD_saved
+	ParamEinstABT1Akt                         = 76   // ID_Einst_ABT1_akt
+	ParamEinstLABTpausAkt                     = 77   // ID_Einst_LABTpaus_akt
+	ParamAHZStateAkt                          = 78   // ID_AHZ_state_akt
+	ParamSollwertTRLHZAHZ                     = 79   // ID_Sollwert_TRL_HZ_AHZ
+	ParamAHPValidRecords                      = 80   // ID_AHP_valid_records
+	ParamTimerAHZAkt                          = 81   // ID_Timer_AHZ_akt
+	ParamEinstBWTINPAkt                       = 82   // ID_Einst_BWTINP_akt
+	ParamEinstZUPTYPAkt                       = 83   // ID_Einst_ZUPTYP_akt
+	ParamSollwertTLGMax                       = 84   // ID_Sollwert_TLG_max
+	ParamEinstBWZIPAkt                        = 85   // ID_Einst_BWZIP_akt
+	ParamEinstERRmZWEAkt                      = 86   // ID_Einst_ERRmZWE_akt
+	ParamEinstTRBegrAkt                       = 87   // ID_Einst_TRBegr_akt
+	ParamEinstHRHystAkt                       = 88   // ID_Einst_HRHyst_akt
+	ParamEinstTRErhmaxAkt                     = 89   // ID_Einst_TRErhmax_akt
+	ParamEinstZWEFreigAkt                     = 90   // ID_Einst_ZWEFreig_akt
+	ParamEinstTAmaxAkt                        = 91   // ID_Einst_TAmax_akt
+	ParamEinstTAminAkt                        = 92   // ID_Einst_TAmin_akt
+	ParamEinstTWQminAkt                       = 93   // ID_Einst_TWQmin_akt
+	ParamEinstTHGmaxAkt                       = 94   // ID_Einst_THGmax_akt
+	ParamEinstFRGT2VDAkt                      = 95   // ID_Einst_FRGT2VD_akt
+	ParamEinstTV2VDBWAkt                      = 96   // ID_Einst_TV2VDBW_akt
+	ParamEinstSuAllAkt                        = 97   // ID_Einst_SuAll_akt
+	ParamEinstTAbtEndAkt                      = 98   // ID_Einst_TAbtEnd_akt
+	ParamEinstNrKlingelAkt                    = 99   // ID_Einst_NrKlingel_akt
+	ParamEinstBWStypAkt                       = 100  // ID_Einst_BWStyp_akt
+	ParamEinstABT2Akt                         = 101  // ID_Einst_ABT2_akt
+	ParamEinstUeVdAkt                         = 102  // ID_Einst_UeVd_akt
+	ParamEinstRTypAkt                         = 103  // ID_Einst_RTyp_akt
+	ParamEinstAhpMAkt                         = 104  // ID_Einst_AhpM_akt
+	ParamSollBWSAkt                           = 105  // ID_Soll_BWS_akt
+	ParamTimerPassword                        = 106  // ID_Timer_Password
+	ParamAccessLevel                          = 107  // ID_Einst_Zugangscode
+	ParamEinstBAKuehlAkt                      = 108  // ID_Einst_BA_Kuehl_akt
+	ParamSollwertKuehl1Akt                    = 109  // ID_Sollwert_Kuehl1_akt
+	ParamEinstKuehlFreigAkt                   = 110  // ID_Einst_KuehlFreig_akt
+	ParamEinstTAbsMinAkt                      = 111  // ID_Einst_TAbsMin_akt
+	ParamTWQminSaved                          = 112  // ID_TWQmin_saved
+	ParamCWPSaved                             = 113  // ID_CWP_saved
+	ParamEinstAnodeAkt                        = 114  // ID_Einst_Anode_akt
+	ParamTimerPexoffAkt                       = 115  // ID_Timer_pexoff_akt
+	ParamEinstAnlPrioHzakt                    = 116  // ID_Einst_AnlPrio_Hzakt
+	ParamEinstAnlPrioBwakt                    = 117  // ID_Einst_AnlPrio_Bwakt
+	ParamEinstAnlPrioSwakt                    = 118  // ID_Einst_AnlPrio_Swakt
+	ParamBaSwAkt                              = 119  // ID_Ba_Sw_akt
+	ParamEinstRTypMK1Akt                      = 120  // ID_Einst_RTypMK1_akt
+	ParamEinstRTypMK2Akt                      = 121  // ID_Einst_RTypMK2_akt
+	ParamEinstTDCEinAkt                       = 122  // ID_Einst_TDC_Ein_akt
+	ParamEinstTDCAusAkt                       = 123  // ID_Einst_TDC_Aus_akt
+	ParamEinstTDCMaxAkt                       = 124  // ID_Einst_TDC_Max_akt
+	ParamEinstHysHzExEnAkt                    = 125  // ID_Einst_HysHzExEn_akt
+	ParamEinstHysBwExEnAkt                    = 126  // ID_Einst_HysBwExEn_akt
+	ParamEinstZWE3ArtAkt                      = 127  // ID_Einst_ZWE3Art_akt
+	ParamEinstZWE3FktAkt                      = 128  // ID_Einst_ZWE3Fkt_akt
+	ParamEinstHzSupAkt                        = 129  // ID_Einst_HzSup_akt
+	ParamEinstMK2TypAkt                       = 130  // ID_Einst_MK2Typ_akt
+	ParamEinstKuTypAkt                        = 131  // ID_Einst_KuTyp_akt
+	ParamSollwertKuCft1Akt                    = 132  // ID_Sollwert_KuCft1_akt
+	ParamSollwertKuCft2Akt                    = 133  // ID_Sollwert_KuCft2_akt
+	ParamSollwertAtDif1Akt                    = 134  // ID_Sollwert_AtDif1_akt
+	ParamSollwertAtDif2Akt                    = 135  // ID_Sollwert_AtDif2_akt
+	ParamSUFrkdSwb                            = 136  // ID_SU_FrkdSwb
+	ParamEinstSwbBerAkt                       = 137  // ID_Einst_SwbBer_akt
+	ParamEinstTV2VDSWBAkt                     = 138  // ID_Einst_TV2VDSWB_akt
+	ParamEinstMinSwanTimeAkt                  = 139  // ID_Einst_MinSwan_Time_akt
+	ParamEinstSuMk2Akt                        = 140  // ID_Einst_SuMk2_akt
+	ParamEinstHzMK2EAkt                       = 141  // ID_Einst_HzMK2E_akt
+	ParamEinstHzMK2ANHAkt                     = 142  // ID_Einst_HzMK2ANH_akt
+	ParamEinstHzMK2ABSAkt                     = 143  // ID_Einst_HzMK2ABS_akt
+	ParamEinstHzMK2HgrAkt                     = 144  // ID_Einst_HzMK2Hgr_akt
+	ParamEinstHzFtMK2VlAkt                    = 145  // ID_Einst_HzFtMK2Vl_akt
+	ParamTempTHGBwHDSaved                     = 146  // ID_Temp_THG_BwHD_saved
+	ParamTempTABwHDSaved                      = 147  // ID_Temp_TA_BwHD_saved
+	ParamEinstBwHupAkt                        = 148  // ID_Einst_BwHup_akt
+	ParamEinstTVLmaxAkt                       = 149  // ID_Einst_TVLmax_akt
+	ParamEinstMK1LzFaktorAkt                  = 150  // ID_Einst_MK1LzFaktor_akt
+	ParamEinstMK2LzFaktorAkt                  = 151  // ID_Einst_MK2LzFaktor_akt
+	ParamEinstMK1PerFaktorAkt                 = 152  // ID_Einst_MK1PerFaktor_akt
+	ParamEinstMK2PerFaktorAkt                 = 153  // ID_Einst_MK2PerFaktor_akt
+	ParamEntlZyklusAkt                        = 154  // ID_Entl_Zyklus_akt
+	ParamEinstEntlTimeAkt                     = 155  // ID_Einst_Entl_time_akt
+	ParamEntlPause                            = 156  // ID_Entl_Pause
+	ParamEntlTimer                            = 157  // ID_Entl_timer
+	ParamEinstEntlAkt                         = 158  // ID_Einst_Entl_akt
+	ParamAhzHLeistConfirmed                   = 159  // ID_Ahz_HLeist_confirmed
+	ParamFirstInitAkt                         = 160  // ID_FirstInit_akt
+	ParamEinstSuAllAkt2                       = 161  // ID_Einst_SuAll_akt2
+	ParamEinstSuAllWoZeit0_0                  = 162  // ID_Einst_SuAllWo_zeit_0_0
+	ParamEinstSuAllWoZeit0_1                  = 163  // ID_Einst_SuAllWo_zeit_0_1
+	ParamEinstSuAllWoZeit1_0                  = 164  // ID_Einst_SuAllWo_zeit_1_0
+	ParamEinstSuAllWoZeit1_1                  = 165  // ID_Einst_SuAllWo_zeit_1_1
+	ParamEinstSuAllWoZeit2_0                  = 166  // ID_Einst_SuAllWo_zeit_2_0
+	ParamEinstSuAllWoZeit2_1                  = 167  // ID_Einst_SuAllWo_zeit_2_1
+	ParamEinstSuAll25Zeit0_0                  = 168  // ID_Einst_SuAll25_zeit_0_0
+	ParamEinstSuAll25Zeit0_1                  = 169  // ID_Einst_SuAll25_zeit_0_1
+	ParamEinstSuAll25Zeit1_0                  = 170  // ID_Einst_SuAll25_zeit_1_0
+	ParamEinstSuAll25Zeit1_1                  = 171  // ID_Einst_SuAll25_zeit_1_1
+	ParamEinstSuAll25Zeit2_0                  = 172  // ID_Einst_SuAll25_zeit_2_0
+	ParamEinstSuAll25Zeit2_1                  = 173  // ID_Einst_SuAll25_zeit_2_1
+	ParamEinstSuAll25Zeit0_2                  = 174  // ID_Einst_SuAll25_zeit_0_2
+	ParamEinstSuAll25Zeit0_3                  = 175  // ID_Einst_SuAll25_zeit_0_3
+	ParamEinstSuAll25Zeit1_2                  = 176  // ID_Einst_SuAll25_zeit_1_2
+	ParamEinstSuAll25Zeit1_3                  = 177  // ID_Einst_SuAll25_zeit_1_3
+	ParamEinstSuAll25Zeit2_2                  = 178  // ID_Einst_SuAll25_zeit_2_2
+	ParamEinstSuAll25Zeit2_3                  = 179  // ID_Einst_SuAll25_zeit_2_3
+	ParamEinstSuAllTgZeit0_0                  = 180  // ID_Einst_SuAllTg_zeit_0_0
+	ParamEinstSuAllTgZeit0_1                  = 181  // ID_Einst_SuAllTg_zeit_0_1
+	ParamEinstSuAllTgZeit1_0                  = 182  // ID_Einst_SuAllTg_zeit_1_0
+	ParamEinstSuAllTgZeit1_1                  = 183  // ID_Einst_SuAllTg_zeit_1_1
+	ParamEinstSuAllTgZeit2_0                  = 184  // ID_Einst_SuAllTg_zeit_2_0
+	ParamEinstSuAllTgZeit2_1                  = 185  // ID_Einst_SuAllTg_zeit_2_1
+	ParamEinstSuAllTgZeit0_2                  = 186  // ID_Einst_SuAllTg_zeit_0_2
+	ParamEinstSuAllTgZeit0_3                  = 187  // ID_Einst_SuAllTg_zeit_0_3
+	ParamEinstSuAllTgZeit1_2                  = 188  // ID_Einst_SuAllTg_zeit_1_2
+	ParamEinstSuAllTgZeit1_3                  = 189  // ID_Einst_SuAllTg_zeit_1_3
+	ParamEinstSuAllTgZeit2_2                  = 190  // ID_Einst_SuAllTg_zeit_2_2
+	ParamEinstSuAllTgZeit2_3                  = 191  // ID_Einst_SuAllTg_zeit_2_3
+	ParamEinstSuAllTgZeit0_4                  = 192  // ID_Einst_SuAllTg_zeit_0_4
+	ParamEinstSuAllTgZeit0_5                  = 193  // ID_Einst_SuAllTg_zeit_0_5
+	ParamEinstSuAllTgZeit1_4                  = 194  // ID_Einst_SuAllTg_zeit_1_4
+	ParamEinstSuAllTgZeit1_5                  = 195  // ID_Einst_SuAllTg_zeit_1_5
+	ParamEinstSuAllTgZeit2_4                  = 196  // ID_Einst_SuAllTg_zeit_2_4
+	ParamEinstSuAllTgZeit2_5                  = 197  // ID_Einst_SuAllTg_zeit_2_5
+	ParamEinstSuAllTgZeit0_6                  = 198  // ID_Einst_SuAllTg_zeit_0_6
+	ParamEinstSuAllTgZeit0_7                  = 199  // ID_Einst_SuAllTg_zeit_0_7
+	ParamEinstSuAllTgZeit1_6                  = 200  // ID_Einst_SuAllTg_zeit_1_6
+	ParamEinstSuAllTgZeit1_7                  = 201  // ID_Einst_SuAllTg_zeit_1_7
+	ParamEinstSuAllTgZeit2_6                  = 202  // ID_Einst_SuAllTg_zeit_2_6
+	ParamEinstSuAllTgZeit2_7                  = 203  // ID_Einst_SuAllTg_zeit_2_7
+	ParamEinstSuAllTgZeit0_8                  = 204  // ID_Einst_SuAllTg_zeit_0_8
+	ParamEinstSuAllTgZeit0_9                  = 205  // ID_Einst_SuAllTg_zeit_0_9
+	ParamEinstSuAllTgZeit1_8                  = 206  // ID_Einst_SuAllTg_zeit_1_8
+	ParamEinstSuAllTgZeit1_9                  = 207  // ID_Einst_SuAllTg_zeit_1_9
+	ParamEinstSuAllTgZeit2_8                  = 208  // ID_Einst_SuAllTg_zeit_2_8
+	ParamEinstSuAllTgZeit2_9                  = 209  // ID_Einst_SuAllTg_zeit_2_9
+	ParamEinstSuAllTgZeit0_10                 = 210  // ID_Einst_SuAllTg_zeit_0_10
+	ParamEinstSuAllTgZeit0_11                 = 211  // ID_Einst_SuAllTg_zeit_0_11
+	ParamEinstSuAllTgZeit1_10                 = 212  // ID_Einst_SuAllTg_zeit_1_10
+	ParamEinstSuAllTgZeit1_11                 = 213  // ID_Einst_SuAllTg_zeit_1_11
+	ParamEinstSuAllTgZeit2_10                 = 214  // ID_Einst_SuAllTg_zeit_2_10
+	ParamEinstSuAllTgZeit2_11                 = 215  // ID_Einst_SuAllTg_zeit_2_11
+	ParamEinstSuAllTgZeit0_12                 = 216  // ID_Einst_SuAllTg_zeit_0_12
+	ParamEinstSuAllTgZeit0_13                 = 217  // ID_Einst_SuAllTg_zeit_0_13
+	ParamEinstSuAllTgZeit1_12                 = 218  // ID_Einst_SuAllTg_zeit_1_12
+	ParamEinstSuAllTgZeit1_13                 = 219  // ID_Einst_SuAllTg_zeit_1_13
+	ParamEinstSuAllTgZeit2_12                 = 220  // ID_Einst_SuAllTg_zeit_2_12
+	ParamEinstSuAllTgZeit2_13                 = 221  // ID_Einst_SuAllTg_zeit_2_13
+	ParamEinstSuHkrAkt                        = 222  // ID_Einst_SuHkr_akt
+	ParamEinstSuHkrW0Zeit0_0                  = 223  // ID_Einst_SuHkrW0_zeit_0_0
+	ParamEinstSuHkrW0Zeit0_1                  = 224  // ID_Einst_SuHkrW0_zeit_0_1
+	ParamEinstSuHkrW0Zeit1_0                  = 225  // ID_Einst_SuHkrW0_zeit_1_0
+	ParamEinstSuHkrW0Zeit1_1                  = 226  // ID_Einst_SuHkrW0_zeit_1_1
+	ParamEinstSuHkrW0Zeit2_0                  = 227  // ID_Einst_SuHkrW0_zeit_2_0
+	ParamEinstSuHkrW0Zeit2_1                  = 228  // ID_Einst_SuHkrW0_zeit_2_1
+	ParamEinstSuHkr25Zeit0_0                  = 229  // ID_Einst_SuHkr25_zeit_0_0
+	ParamEinstSuHkr25Zeit0_1                  = 230  // ID_Einst_SuHkr25_zeit_0_1
+	ParamEinstSuHkr25Zeit1_0                  = 231  // ID_Einst_SuHkr25_zeit_1_0
+	ParamEinstSuHkr25Zeit1_1                  = 232  // ID_Einst_SuHkr25_zeit_1_1
+	ParamEinstSuHkr25Zeit2_0                  = 233  // ID_Einst_SuHkr25_zeit_2_0
+	ParamEinstSuHkr25Zeit2_1                  = 234  // ID_Einst_SuHkr25_zeit_2_1
+	ParamEinstSuHkr25Zeit0_2                  = 235  // ID_Einst_SuHkr25_zeit_0_2
+	ParamEinstSuHkr25Zeit0_3                  = 236  // ID_Einst_SuHkr25_zeit_0_3
+	ParamEinstSuHkr25Zeit1_2                  = 237  // ID_Einst_SuHkr25_zeit_1_2
+	ParamEinstSuHkr25Zeit1_3                  = 238  // ID_Einst_SuHkr25_zeit_1_3
+	ParamEinstSuHkr25Zeit2_2                  = 239  // ID_Einst_SuHkr25_zeit_2_2
+	ParamEinstSuHkr25Zeit2_3                  = 240  // ID_Einst_SuHkr25_zeit_2_3
+	ParamEinstSuHkrTGZeit0_0                  = 241  // ID_Einst_SuHkrTG_zeit_0_0
+	ParamEinstSuHkrTGZeit0_1                  = 242  // ID_Einst_SuHkrTG_zeit_0_1
+	ParamEinstSuHkrTGZeit1_0                  = 243  // ID_Einst_SuHkrTG_zeit_1_0
+	ParamEinstSuHkrTGZeit1_1                  = 244  // ID_Einst_SuHkrTG_zeit_1_1
+	ParamEinstSuHkrTGZeit2_0                  = 245  // ID_Einst_SuHkrTG_zeit_2_0
+	ParamEinstSuHkrTGZeit2_1                  = 246  // ID_Einst_SuHkrTG_zeit_2_1
+	ParamEinstSuHkrTGZeit0_2                  = 247  // ID_Einst_SuHkrTG_zeit_0_2
+	ParamEinstSuHkrTGZeit0_3                  = 248  // ID_Einst_SuHkrTG_zeit_0_3
+	ParamEinstSuHkrTGZeit1_2                  = 249  // ID_Einst_SuHkrTG_zeit_1_2
+	ParamEinstSuHkrTGZeit1_3                  = 250  // ID_Einst_SuHkrTG_zeit_1_3
+	ParamEinstSuHkrTGZeit2_2                  = 251  // ID_Einst_SuHkrTG_zeit_2_2
+	ParamEinstSuHkrTGZeit2_3                  = 252  // ID_Einst_SuHkrTG_zeit_2_3
+	ParamEinstSuHkrTGZeit0_4                  = 253  // ID_Einst_SuHkrTG_zeit_0_4
+	ParamEinstSuHkrTGZeit0_5                  = 254  // ID_Einst_SuHkrTG_zeit_0_5
+	ParamEinstSuHkrTGZeit1_4                  = 255  // ID_Einst_SuHkrTG_zeit_1_4
+	ParamEinstSuHkrTGZeit1_5                  = 256  // ID_Einst_SuHkrTG_zeit_1_5
+	ParamEinstSuHkrTGZeit2_4                  = 257  // ID_Einst_SuHkrTG_zeit_2_4
+	ParamEinstSuHkrTGZeit2_5                  = 258  // ID_Einst_SuHkrTG_zeit_2_5
+	ParamEinstSuHkrTGZeit0_6                  = 259  // ID_Einst_SuHkrTG_zeit_0_6
+	ParamEinstSuHkrTGZeit0_7                  = 260  // ID_Einst_SuHkrTG_zeit_0_7
+	ParamEinstSuHkrTGZeit1_6                  = 261  // ID_Einst_SuHkrTG_zeit_1_6
+	ParamEinstSuHkrTGZeit1_7                  = 262  // ID_Einst_SuHkrTG_zeit_1_7
+	ParamEinstSuHkrTGZeit2_6                  = 263  // ID_Einst_SuHkrTG_zeit_2_6
+	ParamEinstSuHkrTGZeit2_7                  = 264  // ID_Einst_SuHkrTG_zeit_2_7
+	ParamEinstSuHkrTGZeit0_8                  = 265  // ID_Einst_SuHkrTG_zeit_0_8
+	ParamEinstSuHkrTGZeit0_9                  = 266  // ID_Einst_SuHkrTG_zeit_0_9
+	ParamEinstSuHkrTGZeit1_8                  = 267  // ID_Einst_SuHkrTG_zeit_1_8
+	ParamEinstSuHkrTGZeit1_9                  = 268  // ID_Einst_SuHkrTG_zeit_1_9
+	ParamEinstSuHkrTGZeit2_8                  = 269  // ID_Einst_SuHkrTG_zeit_2_8
+	ParamEinstSuHkrTGZeit2_9                  = 270  // ID_Einst_SuHkrTG_zeit_2_9
+	ParamEinstSuHkrTGZeit0_10                 = 271  // ID_Einst_SuHkrTG_zeit_0_10
+	ParamEinstSuHkrTGZeit0_11                 = 272  // ID_Einst_SuHkrTG_zeit_0_11
+	ParamEinstSuHkrTGZeit1_10                 = 273  // ID_Einst_SuHkrTG_zeit_1_10
+	ParamEinstSuHkrTGZeit1_11                 = 274  // ID_Einst_SuHkrTG_zeit_1_11
+	ParamEinstSuHkrTGZeit2_10                 = 275  // ID_Einst_SuHkrTG_zeit_2_10
+	ParamEinstSuHkrTGZeit2_11                 = 276  // ID_Einst_SuHkrTG_zeit_2_11
+	ParamEinstSuHkrTGZeit0_12                 = 277  // ID_Einst_SuHkrTG_zeit_0_12
+	ParamEinstSuHkrTGZeit0_13                 = 278  // ID_Einst_SuHkrTG_zeit_0_13
+	ParamEinstSuHkrTGZeit1_12                 = 279  // ID_Einst_SuHkrTG_zeit_1_12
+	ParamEinstSuHkrTGZeit1_13                 = 280  // ID_Einst_SuHkrTG_zeit_1_13
+	ParamEinstSuHkrTGZeit2_12                 = 281  // ID_Einst_SuHkrTG_zeit_2_12
+	ParamEinstSuHkrTGZeit2_13                 = 282  // ID_Einst_SuHkrTG_zeit_2_13
+	ParamEinstSuMk1Akt                        = 283  // ID_Einst_SuMk1_akt
+	ParamEinstSuMk1W0Zeit0_0                  = 284  // ID_Einst_SuMk1W0_zeit_0_0
+	ParamEinstSuMk1W0Zeit0_1                  = 285  // ID_Einst_SuMk1W0_zeit_0_1
+	ParamEinstSuMk1W0Zeit1_0                  = 286  // ID_Einst_SuMk1W0_zeit_1_0
+	ParamEinstSuMk1W0Zeit1_1                  = 287  // ID_Einst_SuMk1W0_zeit_1_1
+	ParamEinstSuMk1W0Zeit2_0                  = 288  // ID_Einst_SuMk1W0_zeit_2_0
+	ParamEinstSuMk1W0Zeit2_1                  = 289  // ID_Einst_SuMk1W0_zeit_2_1
+	ParamEinstSuMk125Zeit0_0                  = 290  // ID_Einst_SuMk125_zeit_0_0
+	ParamEinstSuMk125Zeit0_1                  = 291  // ID_Einst_SuMk125_zeit_0_1
+	ParamEinstSuMk125Zeit1_0                  = 292  // ID_Einst_SuMk125_zeit_1_0
+	ParamEinstSuMk125Zeit1_1                  = 293  // ID_Einst_SuMk125_zeit_1_1
+	ParamEinstSuMk125Zeit2_0                  = 294  // ID_Einst_SuMk125_zeit_2_0
+	ParamEinstSuMk125Zeit2_1                  = 295  // ID_Einst_SuMk125_zeit_2_1
+	ParamEinstSuMk125Zeit0_2                  = 296  // ID_Einst_SuMk125_zeit_0_2
+	ParamEinstSuMk125Zeit0_3                  = 297  // ID_Einst_SuMk125_zeit_0_3
+	ParamEinstSuMk125Zeit1_2                  = 298  // ID_Einst_SuMk125_zeit_1_2
+	ParamEinstSuMk125Zeit1_3                  = 299  // ID_Einst_SuMk125_zeit_1_3
+	ParamEinstSuMk125Zeit2_2                  = 300  // ID_Einst_SuMk125_zeit_2_2
+	ParamEinstSuMk125Zeit2_3                  = 301  // ID_Einst_SuMk125_zeit_2_3
+	ParamEinstSuMk1TGZeit0_0                  = 302  // ID_Einst_SuMk1TG_zeit_0_0
+	ParamEinstSuMk1TGZeit0_1                  = 303  // ID_Einst_SuMk1TG_zeit_0_1
+	ParamEinstSuMk1TGZeit1_0                  = 304  // ID_Einst_SuMk1TG_zeit_1_0
+	ParamEinstSuMk1TGZeit1_1                  = 305  // ID_Einst_SuMk1TG_zeit_1_1
+	ParamEinstSuMk1TGZeit2_0                  = 306  // ID_Einst_SuMk1TG_zeit_2_0
+	ParamEinstSuMk1TGZeit2_1                  = 307  // ID_Einst_SuMk1TG_zeit_2_1
+	ParamEinstSuMk1TGZeit0_2                  = 308  // ID_Einst_SuMk1TG_zeit_0_2
+	ParamEinstSuMk1TGZeit0_3                  = 309  // ID_Einst_SuMk1TG_zeit_0_3
+	ParamEinstSuMk1TGZeit1_2                  = 310  // ID_Einst_SuMk1TG_zeit_1_2
+	ParamEinstSuMk1TGZeit1_3                  = 311  // ID_Einst_SuMk1TG_zeit_1_3
+	ParamEinstSuMk1TGZeit2_2                  = 312  // ID_Einst_SuMk1TG_zeit_2_2
+	ParamEinstSuMk1TGZeit2_3                  = 313  // ID_Einst_SuMk1TG_zeit_2_3
+	ParamEinstSuMk1TGZeit0_4                  = 314  // ID_Einst_SuMk1TG_zeit_0_4
+	ParamEinstSuMk1TGZeit0_5                  = 315  // ID_Einst_SuMk1TG_zeit_0_5
+	ParamEinstSuMk1TGZeit1_4                  = 316  // ID_Einst_SuMk1TG_zeit_1_4
+	ParamEinstSuMk1TGZeit1_5                  = 317  // ID_Einst_SuMk1TG_zeit_1_5
+	ParamEinstSuMk1TGZeit2_4                  = 318  // ID_Einst_SuMk1TG_zeit_2_4
+	ParamEinstSuMk1TGZeit2_5                  = 319  // ID_Einst_SuMk1TG_zeit_2_5
+	ParamEinstSuMk1TGZeit0_6                  = 320  // ID_Einst_SuMk1TG_zeit_0_6
+	ParamEinstSuMk1TGZeit0_7                  = 321  // ID_Einst_SuMk1TG_zeit_0_7
+	ParamEinstSuMk1TGZeit1_6                  = 322  // ID_Einst_SuMk1TG_zeit_1_6
+	ParamEinstSuMk1TGZeit1_7                  = 323  // ID_Einst_SuMk1TG_zeit_1_7
+	ParamEinstSuMk1TGZeit2_6                  = 324  // ID_Einst_SuMk1TG_zeit_2_6
+	ParamEinstSuMk1TGZeit2_7                  = 325  // ID_Einst_SuMk1TG_zeit_2_7
+	ParamEinstSuMk1TGZeit0_8                  = 326  // ID_Einst_SuMk1TG_zeit_0_8
+	ParamEinstSuMk1TGZeit0_9                  = 327  // ID_Einst_SuMk1TG_zeit_0_9
+	ParamEinstSuMk1TGZeit1_8                  = 328  // ID_Einst_SuMk1TG_zeit_1_8
+	ParamEinstSuMk1TGZeit1_9                  = 329  // ID_Einst_SuMk1TG_zeit_1_9
+	ParamEinstSuMk1TGZeit2_8                  = 330  // ID_Einst_SuMk1TG_zeit_2_8
+	ParamEinstSuMk1TGZeit2_9                  = 331  // ID_Einst_SuMk1TG_zeit_2_9
+	ParamEinstSuMk1TGZeit0_10                 = 332  // ID_Einst_SuMk1TG_zeit_0_10
+	ParamEinstSuMk1TGZeit0_11                 = 333  // ID_Einst_SuMk1TG_zeit_0_11
+	ParamEinstSuMk1TGZeit1_10                 = 334  // ID_Einst_SuMk1TG_zeit_1_10
+	ParamEinstSuMk1TGZeit1_11                 = 335  // ID_Einst_SuMk1TG_zeit_1_11
+	ParamEinstSuMk1TGZeit2_10                 = 336  // ID_Einst_SuMk1TG_zeit_2_10
+	ParamEinstSuMk1TGZeit2_11                 = 337  // ID_Einst_SuMk1TG_zeit_2_11
+	ParamEinstSuMk1TGZeit0_12                 = 338  // ID_Einst_SuMk1TG_zeit_0_12
+	ParamEinstSuMk1TGZeit0_13                 = 339  // ID_Einst_SuMk1TG_zeit_0_13
+	ParamEinstSuMk1TGZeit1_12                 = 340  // ID_Einst_SuMk1TG_zeit_1_12
+	ParamEinstSuMk1TGZeit1_13                 = 341  // ID_Einst_SuMk1TG_zeit_1_13
+	ParamEinstSuMk1TGZeit2_12                 = 342  // ID_Einst_SuMk1TG_zeit_2_12
+	ParamEinstSuMk1TGZeit2_13                 = 343  // ID_Einst_SuMk1TG_zeit_2_13
+	ParamEinstSuMk2Akt2                       = 344  // ID_Einst_SuMk2_akt2
+	ParamEinstSuMk2WoZeit0_0                  = 345  // ID_Einst_SuMk2Wo_zeit_0_0
+	ParamEinstSuMk2WoZeit0_1                  = 346  // ID_Einst_SuMk2Wo_zeit_0_1
+	ParamEinstSuMk2WoZeit1_0                  = 347  // ID_Einst_SuMk2Wo_zeit_1_0
+	ParamEinstSuMk2WoZeit1_1                  = 348  // ID_Einst_SuMk2Wo_zeit_1_1
+	ParamEinstSuMk2WoZeit2_0                  = 349  // ID_Einst_SuMk2Wo_zeit_2_0
+	ParamEinstSuMk2WoZeit2_1                  = 350  // ID_Einst_SuMk2Wo_zeit_2_1
+	ParamEinstSuMk225Zeit0_0                  = 351  // ID_Einst_SuMk225_zeit_0_0
+	ParamEinstSuMk225Zeit0_1                  = 352  // ID_Einst_SuMk225_zeit_0_1
+	ParamEinstSuMk225Zeit1_0                  = 353  // ID_Einst_SuMk225_zeit_1_0
+	ParamEinstSuMk225Zeit1_1                  = 354  // ID_Einst_SuMk225_zeit_1_1
+	ParamEinstSuMk225Zeit2_0                  = 355  // ID_Einst_SuMk225_zeit_2_0
+	ParamEinstSuMk225Zeit2_1                  = 356  // ID_Einst_SuMk225_zeit_2_1
+	ParamEinstSuMk225Zeit0_2                  = 357  // ID_Einst_SuMk225_zeit_0_2
+	ParamEinstSuMk225Zeit0_3                  = 358  // ID_Einst_SuMk225_zeit_0_3
+	ParamEinstSuMk225Zeit1_2                  = 359  // ID_Einst_SuMk225_zeit_1_2
+	ParamEinstSuMk225Zeit1_3                  = 360  // ID_Einst_SuMk225_zeit_1_3
+	ParamEinstSuMk225Zeit2_2                  = 361  // ID_Einst_SuMk225_zeit_2_2
+	ParamEinstSuMk225Zeit2_3                  = 362  // ID_Einst_SuMk225_zeit_2_3
+	ParamEinstSuMk2TgZeit0_0                  = 363  // ID_Einst_SuMk2Tg_zeit_0_0
+	ParamEinstSuMk2TgZeit0_1                  = 364  // ID_Einst_SuMk2Tg_zeit_0_1
+	ParamEinstSuMk2TgZeit1_0                  = 365  // ID_Einst_SuMk2Tg_zeit_1_0
+	ParamEinstSuMk2TgZeit1_1                  = 366  // ID_Einst_SuMk2Tg_zeit_1_1
+	ParamEinstSuMk2TgZeit2_0                  = 367  // ID_Einst_SuMk2Tg_zeit_2_0
+	ParamEinstSuMk2TgZeit2_1                  = 368  // ID_Einst_SuMk2Tg_zeit_2_1
+	ParamEinstSuMk2TgZeit0_2                  = 369  // ID_Einst_SuMk2Tg_zeit_0_2
+	ParamEinstSuMk2TgZeit0_3                  = 370  // ID_Einst_SuMk2Tg_zeit_0_3
+	ParamEinstSuMk2TgZeit1_2                  = 371  // ID_Einst_SuMk2Tg_zeit_1_2
+	ParamEinstSuMk2TgZeit1_3                  = 372  // ID_Einst_SuMk2Tg_zeit_1_3
+	ParamEinstSuMk2TgZeit2_2                  = 373  // ID_Einst_SuMk2Tg_zeit_2_2
+	ParamEinstSuMk2TgZeit2_3                  = 374  // ID_Einst_SuMk2Tg_zeit_2_3
+	ParamEinstSuMk2TgZeit0_4                  = 375  // ID_Einst_SuMk2Tg_zeit_0_4
+	ParamEinstSuMk2TgZeit0_5                  = 376  // ID_Einst_SuMk2Tg_zeit_0_5
+	ParamEinstSuMk2TgZeit1_4                  = 377  // ID_Einst_SuMk2Tg_zeit_1_4
+	ParamEinstSuMk2TgZeit1_5                  = 378  // ID_Einst_SuMk2Tg_zeit_1_5
+	ParamEinstSuMk2TgZeit2_4                  = 379  // ID_Einst_SuMk2Tg_zeit_2_4
+	ParamEinstSuMk2TgZeit2_5                  = 380  // ID_Einst_SuMk2Tg_zeit_2_5
+	ParamEinstSuMk2TgZeit0_6                  = 381  // ID_Einst_SuMk2Tg_zeit_0_6
+	ParamEinstSuMk2TgZeit0_7                  = 382  // ID_Einst_SuMk2Tg_zeit_0_7
+	ParamEinstSuMk2TgZeit1_6                  = 383  // ID_Einst_SuMk2Tg_zeit_1_6
+	ParamEinstSuMk2TgZeit1_7                  = 384  // ID_Einst_SuMk2Tg_zeit_1_7
+	ParamEinstSuMk2TgZeit2_6                  = 385  // ID_Einst_SuMk2Tg_zeit_2_6
+	ParamEinstSuMk2TgZeit2_7                  = 386  // ID_Einst_SuMk2Tg_zeit_2_7
+	ParamEinstSuMk2TgZeit0_8                  = 387  // ID_Einst_SuMk2Tg_zeit_0_8
+	ParamEinstSuMk2TgZeit0_9                  = 388  // ID_Einst_SuMk2Tg_zeit_0_9
+	ParamEinstSuMk2TgZeit1_8                  = 389  // ID_Einst_SuMk2Tg_zeit_1_8
+	ParamEinstSuMk2TgZeit1_9                  = 390  // ID_Einst_SuMk2Tg_zeit_1_9
+	ParamEinstSuMk2TgZeit2_8                  = 391  // ID_Einst_SuMk2Tg_zeit_2_8
+	ParamEinstSuMk2TgZeit2_9                  = 392  // ID_Einst_SuMk2Tg_zeit_2_9
+	ParamEinstSuMk2TgZeit0_10                 = 393  // ID_Einst_SuMk2Tg_zeit_0_10
+	ParamEinstSuMk2TgZeit0_11                 = 394  // ID_Einst_SuMk2Tg_zeit_0_11
+	ParamEinstSuMk2TgZeit1_10                 = 395  // ID_Einst_SuMk2Tg_zeit_1_10
+	ParamEinstSuMk2TgZeit1_11                 = 396  // ID_Einst_SuMk2Tg_zeit_1_11
+	ParamEinstSuMk2TgZeit2_10                 = 397  // ID_Einst_SuMk2Tg_zeit_2_10
+	ParamEinstSuMk2TgZeit2_11                 = 398  // ID_Einst_SuMk2Tg_zeit_2_11
+	ParamEinstSuMk2TgZeit0_12                 = 399  // ID_Einst_SuMk2Tg_zeit_0_12
+	ParamEinstSuMk2TgZeit0_13                 = 400  // ID_Einst_SuMk2Tg_zeit_0_13
+	ParamEinstSuMk2TgZeit1_12                 = 401  // ID_Einst_SuMk2Tg_zeit_1_12
+	ParamEinstSuMk2TgZeit1_13                 = 402  // ID_Einst_SuMk2Tg_zeit_1_13
+	ParamEinstSuMk2TgZeit2_12                 = 403  // ID_Einst_SuMk2Tg_zeit_2_12
+	ParamEinstSuMk2TgZeit2_13                 = 404  // ID_Einst_SuMk2Tg_zeit_2_13
+	ParamEinstSUBWAkt2                        = 405  // ID_Einst_SUBW_akt2
+	ParamEinstSuBwWOZeit0_0                   = 406  // ID_Einst_SuBwWO_zeit_0_0
+	ParamEinstSuBwWOZeit0_1                   = 407  // ID_Einst_SuBwWO_zeit_0_1
+	ParamEinstSuBwWOZeit1_0                   = 408  // ID_Einst_SuBwWO_zeit_1_0
+	ParamEinstSuBwWOZeit1_1                   = 409  // ID_Einst_SuBwWO_zeit_1_1
+	ParamEinstSuBwWOZeit2_0                   = 410  // ID_Einst_SuBwWO_zeit_2_0
+	ParamEinstSuBwWOZeit2_1                   = 411  // ID_Einst_SuBwWO_zeit_2_1
+	ParamEinstSuBwWOZeit3_0                   = 412  // ID_Einst_SuBwWO_zeit_3_0
+	ParamEinstSuBwWOZeit3_1                   = 413  // ID_Einst_SuBwWO_zeit_3_1
+	ParamEinstSuBwWOZeit4_0                   = 414  // ID_Einst_SuBwWO_zeit_4_0
+	ParamEinstSuBwWOZeit4_1                   = 415  // ID_Einst_SuBwWO_zeit_4_1
+	ParamEinstSuBw25Zeit0_0                   = 416  // ID_Einst_SuBw25_zeit_0_0
+	ParamEinstSuBw25Zeit0_1                   = 417  // ID_Einst_SuBw25_zeit_0_1
+	ParamEinstSuBw25Zeit1_0                   = 418  // ID_Einst_SuBw25_zeit_1_0
+	ParamEinstSuBw25Zeit1_1                   = 419  // ID_Einst_SuBw25_zeit_1_1
+	ParamEinstSuBw25Zeit2_0                   = 420  // ID_Einst_SuBw25_zeit_2_0
+	ParamEinstSuBw25Zeit2_1                   = 421  // ID_Einst_SuBw25_zeit_2_1
+	ParamEinstSuBw25Zeit3_0                   = 422  // ID_Einst_SuBw25_zeit_3_0
+	ParamEinstSuBw25Zeit3_1                   = 423  // ID_Einst_SuBw25_zeit_3_1
+	ParamEinstSuBw25Zeit4_0                   = 424  // ID_Einst_SuBw25_zeit_4_0
+	ParamEinstSuBw25Zeit4_1                   = 425  // ID_Einst_SuBw25_zeit_4_1
+	ParamEinstSuBw25Zeit0_2                   = 426  // ID_Einst_SuBw25_zeit_0_2
+	ParamEinstSuBw25Zeit0_3                   = 427  // ID_Einst_SuBw25_zeit_0_3
+	ParamEinstSuBw25Zeit1_2                   = 428  // ID_Einst_SuBw25_zeit_1_2
+	ParamEinstSuBw25Zeit1_3                   = 429  // ID_Einst_SuBw25_zeit_1_3
+	ParamEinstSuBw25Zeit2_2                   = 430  // ID_Einst_SuBw25_zeit_2_2
+	ParamEinstSuBw25Zeit2_3                   = 431  // ID_Einst_SuBw25_zeit_2_3
+	ParamEinstSuBw25Zeit3_2                   = 432  // ID_Einst_SuBw25_zeit_3_2
+	ParamEinstSuBw25Zeit3_3                   = 433  // ID_Einst_SuBw25_zeit_3_3
+	ParamEinstSuBw25Zeit4_2                   = 434  // ID_Einst_SuBw25_zeit_4_2
+	ParamEinstSuBw25Zeit4_3                   = 435  // ID_Einst_SuBw25_zeit_4_3
+	ParamEinstSuBwTGZeit0_0                   = 436  // ID_Einst_SuBwTG_zeit_0_0
+	ParamEinstSuBwTGZeit0_1                   = 437  // ID_Einst_SuBwTG_zeit_0_1
+	ParamEinstSuBwTGZeit1_0                   = 438  // ID_Einst_SuBwTG_zeit_1_0
+	ParamEinstSuBwTGZeit1_1                   = 439  // ID_Einst_SuBwTG_zeit_1_1
+	ParamEinstSuBwTGZeit2_0                   = 440  // ID_Einst_SuBwTG_zeit_2_0
+	ParamEinstSuBwTGZeit2_1                   = 441  // ID_Einst_SuBwTG_zeit_2_1
+	ParamEinstSuBwTGZeit3_0                   = 442  // ID_Einst_SuBwTG_zeit_3_0
+	ParamEinstSuBwTGZeit3_1                   = 443  // ID_Einst_SuBwTG_zeit_3_1
+	ParamEinstSuBwTGZeit4_0                   = 444  // ID_Einst_SuBwTG_zeit_4_0
+	ParamEinstSuBwTGZeit4_1                   = 445  // ID_Einst_SuBwTG_zeit_4_1
+	ParamEinstSuBwTGZeit0_2                   = 446  // ID_Einst_SuBwTG_zeit_0_2
+	ParamEinstSuBwTGZeit0_3                   = 447  // ID_Einst_SuBwTG_zeit_0_3
+	ParamEinstSuBwTGZeit1_2                   = 448  // ID_Einst_SuBwTG_zeit_1_2
+	ParamEinstSuBwTGZeit1_3                   = 449  // ID_Einst_SuBwTG_zeit_1_3
+	ParamEinstSuBwTGZeit2_2                   = 450  // ID_Einst_SuBwTG_zeit_2_2
+	ParamEinstSuBwTGZeit2_3                   = 451  // ID_Einst_SuBwTG_zeit_2_3
+	ParamEinstSuBwTGZeit3_2                   = 452  // ID_Einst_SuBwTG_zeit_3_2
+	ParamEinstSuBwTGZeit3_3                   = 453  // ID_Einst_SuBwTG_zeit_3_3
+	ParamEinstSuBwTGZeit4_2                   = 454  // ID_Einst_SuBwTG_zeit_4_2
+	ParamEinstSuBwTGZeit4_3                   = 455  // ID_Einst_SuBwTG_zeit_4_3
+	ParamEinstSuBwTGZeit0_4                   = 456  // ID_Einst_SuBwTG_zeit_0_4
+	ParamEinstSuBwTGZeit0_5                   = 457  // ID_Einst_SuBwTG_zeit_0_5
+	ParamEinstSuBwTGZeit1_4                   = 458  // ID_Einst_SuBwTG_zeit_1_4
+	ParamEinstSuBwTGZeit1_5                   = 459  // ID_Einst_SuBwTG_zeit_1_5
+	ParamEinstSuBwTGZeit2_4                   = 460  // ID_Einst_SuBwTG_zeit_2_4
+	ParamEinstSuBwTGZeit2_5                   = 461  // ID_Einst_SuBwTG_zeit_2_5
+	ParamEinstSuBwTGZeit3_4                   = 462  // ID_Einst_SuBwTG_zeit_3_4
+	ParamEinstSuBwTGZeit3_5                   = 463  // ID_Einst_SuBwTG_zeit_3_5
+	ParamEinstSuBwTGZeit4_4                   = 464  // ID_Einst_SuBwTG_zeit_4_4
+	ParamEinstSuBwTGZeit4_5                   = 465  // ID_Einst_SuBwTG_zeit_4_5
+	ParamEinstSuBwTGZeit0_6                   = 466  // ID_Einst_SuBwTG_zeit_0_6
+	ParamEinstSuBwTGZeit0_7                   = 467  // ID_Einst_SuBwTG_zeit_0_7
+	ParamEinstSuBwTGZeit1_6                   = 468  // ID_Einst_SuBwTG_zeit_1_6
+	ParamEinstSuBwTGZeit1_7                   = 469  // ID_Einst_SuBwTG_zeit_1_7
+	ParamEinstSuBwTGZeit2_6                   = 470  // ID_Einst_SuBwTG_zeit_2_6
+	ParamEinstSuBwTGZeit2_7                   = 471  // ID_Einst_SuBwTG_zeit_2_7
+	ParamEinstSuBwTGZeit3_6                   = 472  // ID_Einst_SuBwTG_zeit_3_6
+	ParamEinstSuBwTGZeit3_7                   = 473  // ID_Einst_SuBwTG_zeit_3_7
+	ParamEinstSuBwTGZeit4_6                   = 474  // ID_Einst_SuBwTG_zeit_4_6
+	ParamEinstSuBwTGZeit4_7                   = 475  // ID_Einst_SuBwTG_zeit_4_7
+	ParamEinstSuBwTGZeit0_8                   = 476  // ID_Einst_SuBwTG_zeit_0_8
+	ParamEinstSuBwTGZeit0_9                   = 477  // ID_Einst_SuBwTG_zeit_0_9
+	ParamEinstSuBwTGZeit1_8                   = 478  // ID_Einst_SuBwTG_zeit_1_8
+	ParamEinstSuBwTGZeit1_9                   = 479  // ID_Einst_SuBwTG_zeit_1_9
+	ParamEinstSuBwTGZeit2_8                   = 480  // ID_Einst_SuBwTG_zeit_2_8
+	ParamEinstSuBwTGZeit2_9                   = 481  // ID_Einst_SuBwTG_zeit_2_9
+	ParamEinstSuBwTGZeit3_8                   = 482  // ID_Einst_SuBwTG_zeit_3_8
+	ParamEinstSuBwTGZeit3_9                   = 483  // ID_Einst_SuBwTG_zeit_3_9
+	ParamEinstSuBwTGZeit4_8                   = 484  // ID_Einst_SuBwTG_zeit_4_8
+	ParamEinstSuBwTGZeit4_9                   = 485  // ID_Einst_SuBwTG_zeit_4_9
+	ParamEinstSuBwTGZeit0_10                  = 486  // ID_Einst_SuBwTG_zeit_0_10
+	ParamEinstSuBwTGZeit0_11                  = 487  // ID_Einst_SuBwTG_zeit_0_11
+	ParamEinstSuBwTGZeit1_10                  = 488  // ID_Einst_SuBwTG_zeit_1_10
+	ParamEinstSuBwTGZeit1_11                  = 489  // ID_Einst_SuBwTG_zeit_1_11
+	ParamEinstSuBwTGZeit2_10                  = 490  // ID_Einst_SuBwTG_zeit_2_10
+	ParamEinstSuBwTGZeit2_11                  = 491  // ID_Einst_SuBwTG_zeit_2_11
+	ParamEinstSuBwTGZeit3_10                  = 492  // ID_Einst_SuBwTG_zeit_3_10
+	ParamEinstSuBwTGZeit3_11                  = 493  // ID_Einst_SuBwTG_zeit_3_11
+	ParamEinstSuBwTGZeit4_10                  = 494  // ID_Einst_SuBwTG_zeit_4_10
+	ParamEinstSuBwTGZeit4_11                  = 495  // ID_Einst_SuBwTG_zeit_4_11
+	ParamEinstSuBwTGZeit0_12                  = 496  // ID_Einst_SuBwTG_zeit_0_12
+	ParamEinstSuBwTGZeit0_13                  = 497  // ID_Einst_SuBwTG_zeit_0_13
+	ParamEinstSuBwTGZeit1_12                  = 498  // ID_Einst_SuBwTG_zeit_1_12
+	ParamEinstSuBwTGZeit1_13                  = 499  // ID_Einst_SuBwTG_zeit_1_13
+	ParamEinstSuBwTGZeit2_12                  = 500  // ID_Einst_SuBwTG_zeit_2_12
+	ParamEinstSuBwTGZeit2_13                  = 501  // ID_Einst_SuBwTG_zeit_2_13
+	ParamEinstSuBwTGZeit3_12                  = 502  // ID_Einst_SuBwTG_zeit_3_12
+	ParamEinstSuBwTGZeit3_13                  = 503  // ID_Einst_SuBwTG_zeit_3_13
+	ParamEinstSuBwTGZeit4_12                  = 504  // ID_Einst_SuBwTG_zeit_4_12
+	ParamEinstSuBwTGZeit4_13                  = 505  // ID_Einst_SuBwTG_zeit_4_13
+	ParamEinstSuZIPAkt                        = 506  // ID_Einst_SuZIP_akt
+	ParamEinstSuZIPWoZeit0_0                  = 507  // ID_Einst_SuZIPWo_zeit_0_0
+	ParamEinstSuZIPWoZeit0_1                  = 508  // ID_Einst_SuZIPWo_zeit_0_1
+	ParamEinstSuZIPWoZeit1_0                  = 509  // ID_Einst_SuZIPWo_zeit_1_0
+	ParamEinstSuZIPWoZeit1_1                  = 510  // ID_Einst_SuZIPWo_zeit_1_1
+	ParamEinstSuZIPWoZeit2_0                  = 511  // ID_Einst_SuZIPWo_zeit_2_0
+	ParamEinstSuZIPWoZeit2_1                  = 512  // ID_Einst_SuZIPWo_zeit_2_1
+	ParamEinstSuZIPWoZeit3_0                  = 513  // ID_Einst_SuZIPWo_zeit_3_0
+	ParamEinstSuZIPWoZeit3_1                  = 514  // ID_Einst_SuZIPWo_zeit_3_1
+	ParamEinstSuZIPWoZeit4_0                  = 515  // ID_Einst_SuZIPWo_zeit_4_0
+	ParamEinstSuZIPWoZeit4_1                  = 516  // ID_Einst_SuZIPWo_zeit_4_1
+	ParamEinstSuZIP25Zeit0_0                  = 517  // ID_Einst_SuZIP25_zeit_0_0
+	ParamEinstSuZIP25Zeit0_1                  = 518  // ID_Einst_SuZIP25_zeit_0_1
+	ParamEinstSuZIP25Zeit1_0                  = 519  // ID_Einst_SuZIP25_zeit_1_0
+	ParamEinstSuZIP25Zeit1_1                  = 520  // ID_Einst_SuZIP25_zeit_1_1
+	ParamEinstSuZIP25Zeit2_0                  = 521  // ID_Einst_SuZIP25_zeit_2_0
+	ParamEinstSuZIP25Zeit2_1                  = 522  // ID_Einst_SuZIP25_zeit_2_1
+	ParamEinstSuZIP25Zeit3_0                  = 523  // ID_Einst_SuZIP25_zeit_3_0
+	ParamEinstSuZIP25Zeit3_1                  = 524  // ID_Einst_SuZIP25_zeit_3_1
+	ParamEinstSuZIP25Zeit4_0                  = 525  // ID_Einst_SuZIP25_zeit_4_0
+	ParamEinstSuZIP25Zeit4_1                  = 526  // ID_Einst_SuZIP25_zeit_4_1
+	ParamEinstSuZIP25Zeit0_2                  = 527  // ID_Einst_SuZIP25_zeit_0_2
+	ParamEinstSuZIP25Zeit0_3                  = 528  // ID_Einst_SuZIP25_zeit_0_3
+	ParamEinstSuZIP25Zeit1_2                  = 529  // ID_Einst_SuZIP25_zeit_1_2
+	ParamEinstSuZIP25Zeit1_3                  = 530  // ID_Einst_SuZIP25_zeit_1_3
+	ParamEinstSuZIP25Zeit2_2                  = 531  // ID_Einst_SuZIP25_zeit_2_2
+	ParamEinstSuZIP25Zeit2_3                  = 532  // ID_Einst_SuZIP25_zeit_2_3
+	ParamEinstSuZIP25Zeit3_2                  = 533  // ID_Einst_SuZIP25_zeit_3_2
+	ParamEinstSuZIP25Zeit3_3                  = 534  // ID_Einst_SuZIP25_zeit_3_3
+	ParamEinstSuZIP25Zeit4_2                  = 535  // ID_Einst_SuZIP25_zeit_4_2
+	ParamEinstSuZIP25Zeit4_3                  = 536  // ID_Einst_SuZIP25_zeit_4_3
+	ParamEinstSuZIPTgZeit0_0                  = 537  // ID_Einst_SuZIPTg_zeit_0_0
+	ParamEinstSuZIPTgZeit0_1                  = 538  // ID_Einst_SuZIPTg_zeit_0_1
+	ParamEinstSuZIPTgZeit1_0                  = 539  // ID_Einst_SuZIPTg_zeit_1_0
+	ParamEinstSuZIPTgZeit1_1                  = 540  // ID_Einst_SuZIPTg_zeit_1_1
+	ParamEinstSuZIPTgZeit2_0                  = 541  // ID_Einst_SuZIPTg_zeit_2_0
+	ParamEinstSuZIPTgZeit2_1                  = 542  // ID_Einst_SuZIPTg_zeit_2_1
+	ParamEinstSuZIPTgZeit3_0                  = 543  // ID_Einst_SuZIPTg_zeit_3_0
+	ParamEinstSuZIPTgZeit3_1                  = 544  // ID_Einst_SuZIPTg_zeit_3_1
+	ParamEinstSuZIPTgZeit4_0                  = 545  // ID_Einst_SuZIPTg_zeit_4_0
+	ParamEinstSuZIPTgZeit4_1                  = 546  // ID_Einst_SuZIPTg_zeit_4_1
+	ParamEinstSuZIPTgZeit0_2                  = 547  // ID_Einst_SuZIPTg_zeit_0_2
+	ParamEinstSuZIPTgZeit0_3                  = 548  // ID_Einst_SuZIPTg_zeit_0_3
+	ParamEinstSuZIPTgZeit1_2                  = 549  // ID_Einst_SuZIPTg_zeit_1_2
+	ParamEinstSuZIPTgZeit1_3                  = 550  // ID_Einst_SuZIPTg_zeit_1_3
+	ParamEinstSuZIPTgZeit2_2                  = 551  // ID_Einst_SuZIPTg_zeit_2_2
+	ParamEinstSuZIPTgZeit2_3                  = 552  // ID_Einst_SuZIPTg_zeit_2_3
+	ParamEinstSuZIPTgZeit3_2                  = 553  // ID_Einst_SuZIPTg_zeit_3_2
+	ParamEinstSuZIPTgZeit3_3                  = 554  // ID_Einst_SuZIPTg_zeit_3_3
+	ParamEinstSuZIPTgZeit4_2                  = 555  // ID_Einst_SuZIPTg_zeit_4_2
+	ParamEinstSuZIPTgZeit4_3                  = 556  // ID_Einst_SuZIPTg_zeit_4_3
+	ParamEinstSuZIPTgZeit0_4                  = 557  // ID_Einst_SuZIPTg_zeit_0_4
+	ParamEinstSuZIPTgZeit0_5                  = 558  // ID_Einst_SuZIPTg_zeit_0_5
+	ParamEinstSuZIPTgZeit1_4                  = 559  // ID_Einst_SuZIPTg_zeit_1_4
+	ParamEinstSuZIPTgZeit1_5                  = 560  // ID_Einst_SuZIPTg_zeit_1_5
+	ParamEinstSuZIPTgZeit2_4                  = 561  // ID_Einst_SuZIPTg_zeit_2_4
+	ParamEinstSuZIPTgZeit2_5                  = 562  // ID_Einst_SuZIPTg_zeit_2_5
+	ParamEinstSuZIPTgZeit3_4                  = 563  // ID_Einst_SuZIPTg_zeit_3_4
+	ParamEinstSuZIPTgZeit3_5                  = 564  // ID_Einst_SuZIPTg_zeit_3_5
+	ParamEinstSuZIPTgZeit4_4                  = 565  // ID_Einst_SuZIPTg_zeit_4_4
+	ParamEinstSuZIPTgZeit4_5                  = 566  // ID_Einst_SuZIPTg_zeit_4_5
+	ParamEinstSuZIPTgZeit0_6                  = 567  // ID_Einst_SuZIPTg_zeit_0_6
+	ParamEinstSuZIPTgZeit0_7                  = 568  // ID_Einst_SuZIPTg_zeit_0_7
+	ParamEinstSuZIPTgZeit1_6                  = 569  // ID_Einst_SuZIPTg_zeit_1_6
+	ParamEinstSuZIPTgZeit1_7                  = 570  // ID_Einst_SuZIPTg_zeit_1_7
+	ParamEinstSuZIPTgZeit2_6                  = 571  // ID_Einst_SuZIPTg_zeit_2_6
+	ParamEinstSuZIPTgZeit2_7                  = 572  // ID_Einst_SuZIPTg_zeit_2_7
+	ParamEinstSuZIPTgZeit3_6                  = 573  // ID_Einst_SuZIPTg_zeit_3_6
+	ParamEinstSuZIPTgZeit3_7                  = 574  // ID_Einst_SuZIPTg_zeit_3_7
+	ParamEinstSuZIPTgZeit4_6                  = 575  // ID_Einst_SuZIPTg_zeit_4_6
+	ParamEinstSuZIPTgZeit4_7                  = 576  // ID_Einst_SuZIPTg_zeit_4_7
+	ParamEinstSuZIPTgZeit0_8                  = 577  // ID_Einst_SuZIPTg_zeit_0_8
+	ParamEinstSuZIPTgZeit0_9                  = 578  // ID_Einst_SuZIPTg_zeit_0_9
+	ParamEinstSuZIPTgZeit1_8                  = 579  // ID_Einst_SuZIPTg_zeit_1_8
+	ParamEinstSuZIPTgZeit1_9                  = 580  // ID_Einst_SuZIPTg_zeit_1_9
+	ParamEinstSuZIPTgZeit2_8                  = 581  // ID_Einst_SuZIPTg_zeit_2_8
+	ParamEinstSuZIPTgZeit2_9                  = 582  // ID_Einst_SuZIPTg_zeit_2_9
+	ParamEinstSuZIPTgZeit3_8                  = 583  // ID_Einst_SuZIPTg_zeit_3_8
+	ParamEinstSuZIPTgZeit3_9                  = 584  // ID_Einst_SuZIPTg_zeit_3_9
+	ParamEinstSuZIPTgZeit4_8                  = 585  // ID_Einst_SuZIPTg_zeit_4_8
+	ParamEinstSuZIPTgZeit4_9                  = 586  // ID_Einst_SuZIPTg_zeit_4_9
+	ParamEinstSuZIPTgZeit0_10                 = 587  // ID_Einst_SuZIPTg_zeit_0_10
+	ParamEinstSuZIPTgZeit0_11                 = 588  // ID_Einst_SuZIPTg_zeit_0_11
+	ParamEinstSuZIPTgZeit1_10                 = 589  // ID_Einst_SuZIPTg_zeit_1_10
+	ParamEinstSuZIPTgZeit1_11                 = 590  // ID_Einst_SuZIPTg_zeit_1_11
+	ParamEinstSuZIPTgZeit2_10                 = 591  // ID_Einst_SuZIPTg_zeit_2_10
+	ParamEinstSuZIPTgZeit2_11                 = 592  // ID_Einst_SuZIPTg_zeit_2_11
+	ParamEinstSuZIPTgZeit3_10                 = 593  // ID_Einst_SuZIPTg_zeit_3_10
+	ParamEinstSuZIPTgZeit3_11                 = 594  // ID_Einst_SuZIPTg_zeit_3_11
+	ParamEinstSuZIPTgZeit4_10                 = 595  // ID_Einst_SuZIPTg_zeit_4_10
+	ParamEinstSuZIPTgZeit4_11                 = 596  // ID_Einst_SuZIPTg_zeit_4_11
+	ParamEinstSuZIPTgZeit0_12                 = 597  // ID_Einst_SuZIPTg_zeit_0_12
+	ParamEinstSuZIPTgZeit0_13                 = 598  // ID_Einst_SuZIPTg_zeit_0_13
+	ParamEinstSuZIPTgZeit1_12                 = 599  // ID_Einst_SuZIPTg_zeit_1_12
+	ParamEinstSuZIPTgZeit1_13                 = 600  // ID_Einst_SuZIPTg_zeit_1_13
+	ParamEinstSuZIPTgZeit2_12                 = 601  // ID_Einst_SuZIPTg_zeit_2_12
+	ParamEinstSuZIPTgZeit2_13                 = 602  // ID_Einst_SuZIPTg_zeit_2_13
+	ParamEinstSuZIPTgZeit3_12                 = 603  // ID_Einst_SuZIPTg_zeit_3_12
+	ParamEinstSuZIPTgZeit3_13                 = 604  // ID_Einst_SuZIPTg_zeit_3_13
+	ParamEinstSuZIPTgZeit4_12                 = 605  // ID_Einst_SuZIPTg_zeit_4_12
+	ParamEinstSuZIPTgZeit4_13                 = 606  // ID_Einst_SuZIPTg_zeit_4_13
+	ParamEinstSuSwbAkt                        = 607  // ID_Einst_SuSwb_akt
+	ParamEinstSuSwbWoZeit0_0                  = 608  // ID_Einst_SuSwbWo_zeit_0_0
+	ParamEinstSuSwbWoZeit0_1                  = 609  // ID_Einst_SuSwbWo_zeit_0_1
+	ParamEinstSuSwbWoZeit1_0                  = 610  // ID_Einst_SuSwbWo_zeit_1_0
+	ParamEinstSuSwbWoZeit1_1                  = 611  // ID_Einst_SuSwbWo_zeit_1_1
+	ParamEinstSuSwbWoZeit2_0                  = 612  // ID_Einst_SuSwbWo_zeit_2_0
+	ParamEinstSuSwbWoZeit2_1                  = 613  // ID_Einst_SuSwbWo_zeit_2_1
+	ParamEinstSuSwb25Zeit0_0                  = 614  // ID_Einst_SuSwb25_zeit_0_0
+	ParamEinstSuSwb25Zeit0_1                  = 615  // ID_Einst_SuSwb25_zeit_0_1
+	ParamEinstSuSwb25Zeit1_0                  = 616  // ID_Einst_SuSwb25_zeit_1_0
+	ParamEinstSuSwb25Zeit1_1                  = 617  // ID_Einst_SuSwb25_zeit_1_1
+	ParamEinstSuSwb25Zeit2_0                  = 618  // ID_Einst_SuSwb25_zeit_2_0
+	ParamEinstSuSwb25Zeit2_1                  = 619  // ID_Einst_SuSwb25_zeit_2_1
+	ParamEinstSuSwb25Zeit0_2                  = 620  // ID_Einst_SuSwb25_zeit_0_2
+	ParamEinstSuSwb25Zeit0_3                  = 621  // ID_Einst_SuSwb25_zeit_0_3
+	ParamEinstSuSwb25Zeit1_2                  = 622  // ID_Einst_SuSwb25_zeit_1_2
+	ParamEinstSuSwb25Zeit1_3                  = 623  // ID_Einst_SuSwb25_zeit_1_3
+	ParamEinstSuSwb25Zeit2_2                  = 624  // ID_Einst_SuSwb25_zeit_2_2
+	ParamEinstSuSwb25Zeit2_3                  = 625  // ID_Einst_SuSwb25_zeit_2_3
+	ParamEinstSuSwbTgZeit0_0                  = 626  // ID_Einst_SuSwbTg_zeit_0_0
+	ParamEinstSuSwbTgZeit0_1                  = 627  // ID_Einst_SuSwbTg_zeit_0_1
+	ParamEinstSuSwbTgZeit1_0                  = 628  // ID_Einst_SuSwbTg_zeit_1_0
+	ParamEinstSuSwbTgZeit1_1                  = 629  // ID_Einst_SuSwbTg_zeit_1_1
+	ParamEinstSuSwbTgZeit2_0                  = 630  // ID_Einst_SuSwbTg_zeit_2_0
+	ParamEinstSuSwbTgZeit2_1                  = 631  // ID_Einst_SuSwbTg_zeit_2_1
+	ParamEinstSuSwbTgZeit0_2                  = 632  // ID_Einst_SuSwbTg_zeit_0_2
+	ParamEinstSuSwbTgZeit0_3                  = 633  // ID_Einst_SuSwbTg_zeit_0_3
+	ParamEinstSuSwbTgZeit1_2                  = 634  // ID_Einst_SuSwbTg_zeit_1_2
+	ParamEinstSuSwbTgZeit1_3                  = 635  // ID_Einst_SuSwbTg_zeit_1_3
+	ParamEinstSuSwbTgZeit2_2                  = 636  // ID_Einst_SuSwbTg_zeit_2_2
+	ParamEinstSuSwbTgZeit2_3                  = 637  // ID_Einst_SuSwbTg_zeit_2_3
+	ParamEinstSuSwbTgZeit0_4                  = 638  // ID_Einst_SuSwbTg_zeit_0_4
+	ParamEinstSuSwbTgZeit0_5                  = 639  // ID_Einst_SuSwbTg_zeit_0_5
+	ParamEinstSuSwbTgZeit1_4                  = 640  // ID_Einst_SuSwbTg_zeit_1_4
+	ParamEinstSuSwbTgZeit1_5                  = 641  // ID_Einst_SuSwbTg_zeit_1_5
+	ParamEinstSuSwbTgZeit2_4                  = 642  // ID_Einst_SuSwbTg_zeit_2_4
+	ParamEinstSuSwbTgZeit2_5                  = 643  // ID_Einst_SuSwbTg_zeit_2_5
+	ParamEinstSuSwbTgZeit0_6                  = 644  // ID_Einst_SuSwbTg_zeit_0_6
+	ParamEinstSuSwbTgZeit0_7                  = 645  // ID_Einst_SuSwbTg_zeit_0_7
+	ParamEinstSuSwbTgZeit1_6                  = 646  // ID_Einst_SuSwbTg_zeit_1_6
+	ParamEinstSuSwbTgZeit1_7                  = 647  // ID_Einst_SuSwbTg_zeit_1_7
+	ParamEinstSuSwbTgZeit2_6                  = 648  // ID_Einst_SuSwbTg_zeit_2_6
+	ParamEinstSuSwbTgZeit2_7                  = 649  // ID_Einst_SuSwbTg_zeit_2_7
+	ParamEinstSuSwbTgZeit0_8                  = 650  // ID_Einst_SuSwbTg_zeit_0_8
+	ParamEinstSuSwbTgZeit0_9                  = 651  // ID_Einst_SuSwbTg_zeit_0_9
+	ParamEinstSuSwbTgZeit1_8                  = 652  // ID_Einst_SuSwbTg_zeit_1_8
+	ParamEinstSuSwbTgZeit1_9                  = 653  // ID_Einst_SuSwbTg_zeit_1_9
+	ParamEinstSuSwbTgZeit2_8                  = 654  // ID_Einst_SuSwbTg_zeit_2_8
+	ParamEinstSuSwbTgZeit2_9                  = 655  // ID_Einst_SuSwbTg_zeit_2_9
+	ParamEinstSuSwbTgZeit0_10                 = 656  // ID_Einst_SuSwbTg_zeit_0_10
+	ParamEinstSuSwbTgZeit0_11                 = 657  // ID_Einst_SuSwbTg_zeit_0_11
+	ParamEinstSuSwbTgZeit1_10                 = 658  // ID_Einst_SuSwbTg_zeit_1_10
+	ParamEinstSuSwbTgZeit1_11                 = 659  // ID_Einst_SuSwbTg_zeit_1_11
+	ParamEinstSuSwbTgZeit2_10                 = 660  // ID_Einst_SuSwbTg_zeit_2_10
+	ParamEinstSuSwbTgZeit2_11                 = 661  // ID_Einst_SuSwbTg_zeit_2_11
+	ParamEinstSuSwbTgZeit0_12                 = 662  // ID_Einst_SuSwbTg_zeit_0_12
+	ParamEinstSuSwbTgZeit0_13                 = 663  // ID_Einst_SuSwbTg_zeit_0_13
+	ParamEinstSuSwbTgZeit1_12                 = 664  // ID_Einst_SuSwbTg_zeit_1_12
+	ParamEinstSuSwbTgZeit1_13                 = 665  // ID_Einst_SuSwbTg_zeit_1_13
+	ParamEinstSuSwbTgZeit2_12                 = 666  // ID_Einst_SuSwbTg_zeit_2_12
+	ParamEinstSuSwbTgZeit2_13                 = 667  // ID_Einst_SuSwbTg_zeit_2_13
+	ParamZaehlerBetrZeitWP                    = 668  // ID_Zaehler_BetrZeitWP
+	ParamZaehlerBetrZeitVD1                   = 669  // ID_Zaehler_BetrZeitVD1
+	ParamZaehlerBetrZeitVD2                   = 670  // ID_Zaehler_BetrZeitVD2
+	ParamZaehlerBetrZeitZWE1                  = 671  // ID_Zaehler_BetrZeitZWE1
+	ParamZaehlerBetrZeitZWE2                  = 672  // ID_Zaehler_BetrZeitZWE2
+	ParamZaehlerBetrZeitZWE3                  = 673  // ID_Zaehler_BetrZeitZWE3
+	ParamZaehlerBetrZeitImpVD1                = 674  // ID_Zaehler_BetrZeitImpVD1
+	ParamZaehlerBetrZeitImpVD2                = 675  // ID_Zaehler_BetrZeitImpVD2
+	ParamZaehlerBetrZeitEZMVD1                = 676  // ID_Zaehler_BetrZeitEZMVD1
+	ParamZaehlerBetrZeitEZMVD2                = 677  // ID_Zaehler_BetrZeitEZMVD2
+	ParamEinstEntlTyp0                        = 678  // ID_Einst_Entl_Typ_0
+	ParamEinstEntlTyp1                        = 679  // ID_Einst_Entl_Typ_1
+	ParamEinstEntlTyp2                        = 680  // ID_Einst_Entl_Typ_2
+	ParamEinstEntlTyp3                        = 681  // ID_Einst_Entl_Typ_3
+	ParamEinstEntlTyp4                        = 682  // ID_Einst_Entl_Typ_4
+	ParamEinstEntlTyp5                        = 683  // ID_Einst_Entl_Typ_5
+	ParamEinstEntlTyp6                        = 684  // ID_Einst_Entl_Typ_6
+	ParamEinstEntlTyp7                        = 685  // ID_Einst_Entl_Typ_7
+	ParamEinstEntlTyp8                        = 686  // ID_Einst_Entl_Typ_8
+	ParamEinstEntlTyp9                        = 687  // ID_Einst_Entl_Typ_9
+	ParamEinstEntlTyp10                       = 688  // ID_Einst_Entl_Typ_10
+	ParamEinstEntlTyp11                       = 689  // ID_Einst_Entl_Typ_11
+	ParamEinstEntlTyp12                       = 690  // ID_Einst_Entl_Typ_12
+	ParamEinstVorlMaxMK1                      = 691  // ID_Einst_Vorl_max_MK1
+	ParamEinstVorlMaxMK2                      = 692  // ID_Einst_Vorl_max_MK2
+	ParamSUFrkdMK1                            = 693  // ID_SU_FrkdMK1
+	ParamSUFrkdMK2                            = 694  // ID_SU_FrkdMK2
+	ParamBaHzMK1Akt                           = 695  // ID_Ba_Hz_MK1_akt
+	ParamBaHzMK2Akt                           = 696  // ID_Ba_Hz_MK2_akt
+	ParamEinstZirkEinAkt                      = 697  // ID_Einst_Zirk_Ein_akt
+	ParamEinstZirkAusAkt                      = 698  // ID_Einst_Zirk_Aus_akt
+	ParamEinstHeizgrenze                      = 699  // ID_Einst_Heizgrenze
+	ParamEinstHeizgrenzeTemp                  = 700  // ID_Einst_Heizgrenze_Temp
+	ParamVariablenIBNgespeichert              = 701  // ID_VariablenIBNgespeichert
+	ParamSchonIBNAssistant                    = 702  // ID_SchonIBNAssistant
+	ParamHeizgrenze0                          = 703  // ID_Heizgrenze_0
+	ParamHeizgrenze1                          = 704  // ID_Heizgrenze_1
+	ParamHeizgrenze2                          = 705  // ID_Heizgrenze_2
+	ParamHeizgrenze3                          = 706  // ID_Heizgrenze_3
+	ParamHeizgrenze4                          = 707  // ID_Heizgrenze_4
+	ParamHeizgrenze5                          = 708  // ID_Heizgrenze_5
+	ParamHeizgrenze6                          = 709  // ID_Heizgrenze_6
+	ParamHeizgrenze7                          = 710  // ID_Heizgrenze_7
+	ParamHeizgrenze8                          = 711  // ID_Heizgrenze_8
+	ParamHeizgrenze9                          = 712  // ID_Heizgrenze_9
+	ParamHeizgrenze10                         = 713  // ID_Heizgrenze_10
+	ParamHeizgrenze11                         = 714  // ID_Heizgrenze_11
+	ParamSchemenIBNgewahlt                    = 715  // ID_SchemenIBNgewahlt
+	ParamSwitchoffFile0_0                     = 716  // ID_Switchoff_file_0_0
+	ParamSwitchoffFile1_0                     = 717  // ID_Switchoff_file_1_0
+	ParamSwitchoffFile2_0                     = 718  // ID_Switchoff_file_2_0
+	ParamSwitchoffFile3_0                     = 719  // ID_Switchoff_file_3_0
+	ParamSwitchoffFile4_0                     = 720  // ID_Switchoff_file_4_0
+	ParamSwitchoffFile0_1                     = 721  // ID_Switchoff_file_0_1
+	ParamSwitchoffFile1_1                     = 722  // ID_Switchoff_file_1_1
+	ParamSwitchoffFile2_1                     = 723  // ID_Switchoff_file_2_1
+	ParamSwitchoffFile3_1                     = 724  // ID_Switchoff_file_3_1
+	ParamSwitchoffFile4_1                     = 725  // ID_Switchoff_file_4_1
+	ParamDauerDatenLoggerAktiv                = 726  // ID_DauerDatenLoggerAktiv
+	ParamLaufvarHeizgrenze                    = 727  // ID_Laufvar_Heizgrenze
+	ParamZaehlerBetrZeitHz                    = 728  // ID_Zaehler_BetrZeitHz
+	ParamZaehlerBetrZeitBW                    = 729  // ID_Zaehler_BetrZeitBW
+	ParamZaehlerBetrZeitKue                   = 730  // ID_Zaehler_BetrZeitKue
+	ParamHolidayStartHeating                  = 731  // ID_SU_FstdHz
+	ParamHolidayStartHotWater                 = 732  // ID_SU_FstdBw
+	ParamSUFstdSwb                            = 733  // ID_SU_FstdSwb
+	ParamSUFstdMK1                            = 734  // ID_SU_FstdMK1
+	ParamSUFstdMK2                            = 735  // ID_SU_FstdMK2
+	ParamFerienAbsenkungHz                    = 736  // ID_FerienAbsenkungHz
+	ParamFerienAbsenkungMK1                   = 737  // ID_FerienAbsenkungMK1
+	ParamFerienAbsenkungMK2                   = 738  // ID_FerienAbsenkungMK2
+	ParamFerienModusAktivHz                   = 739  // ID_FerienModusAktivHz
+	ParamFerienModusAktivBw                   = 740  // ID_FerienModusAktivBw
+	ParamFerienModusAktivSwb                  = 741  // ID_FerienModusAktivSwb
+	ParamFerienModusAktivMk1                  = 742  // ID_FerienModusAktivMk1
+	ParamFerienModusAktivMk2                  = 743  // ID_FerienModusAktivMk2
+	ParamDisplayContrastAkt                   = 744  // ID_DisplayContrast_akt
+	ParamBaHzSaved                            = 745  // ID_Ba_Hz_saved
+	ParamBaBwSaved                            = 746  // ID_Ba_Bw_saved
+	ParamBaSwSaved                            = 747  // ID_Ba_Sw_saved
+	ParamBaHzMK1Saved                         = 748  // ID_Ba_Hz_MK1_saved
+	ParamBaHzMK2Saved                         = 749  // ID_Ba_Hz_MK2_saved
+	ParamNetworkAddress                       = 750  // ID_AdresseIP_akt
+	ParamNetworkMask                          = 751  // ID_SubNetMask_akt
+	ParamNetworkBroadcast                     = 752  // ID_Add_Broadcast_akt
+	ParamNetworkGateway                       = 753  // ID_Add_StdGateway_akt
+	ParamDHCPServerAktivAkt                   = 754  // ID_DHCPServerAktiv_akt
+	ParamWebserverPasswort1Akt                = 755  // ID_WebserverPasswort_1_akt
+	ParamWebserverPasswort2Akt                = 756  // ID_WebserverPasswort_2_akt
+	ParamWebserverPasswort3Akt                = 757  // ID_WebserverPasswort_3_akt
+	ParamWebserverPasswort4Akt                = 758  // ID_WebserverPasswort_4_akt
+	ParamWebserverPasswort5Akt                = 759  // ID_WebserverPasswort_5_akt
+	ParamWebserverPasswort6Akt                = 760  // ID_WebserverPasswort_6_akt
+	ParamWebServerWerteBekommen               = 761  // ID_WebServerWerteBekommen
+	ParamEinstParBetrAkt                      = 762  // ID_Einst_ParBetr_akt
+	ParamEinstWpAnzAkt                        = 763  // ID_Einst_WpAnz_akt
+	ParamEinstPhrTimeAkt                      = 764  // ID_Einst_PhrTime_akt
+	ParamEinstHysParAkt                       = 765  // ID_Einst_HysPar_akt
+	ParamIPPBSlave0                           = 766  // ID_IP_PB_Slave_0
+	ParamIPPBSlave1                           = 767  // ID_IP_PB_Slave_1
+	ParamIPPBSlave2                           = 768  // ID_IP_PB_Slave_2
+	ParamIPPBSlave3                           = 769  // ID_IP_PB_Slave_3
+	ParamIPPBSlave4                           = 770  // ID_IP_PB_Slave_4
+	ParamIPPBSlave5                           = 771  // ID_IP_PB_Slave_5
+	ParamEinstBwHupAktBackup                  = 772  // ID_Einst_BwHup_akt_backup
+	ParamEinstSuMk3Akt                        = 773  // ID_Einst_SuMk3_akt
+	ParamEinstHzMK3EAkt                       = 774  // ID_Einst_HzMK3E_akt
+	ParamEinstHzMK3ANHAkt                     = 775  // ID_Einst_HzMK3ANH_akt
+	ParamEinstHzMK3ABSAkt                     = 776  // ID_Einst_HzMK3ABS_akt
+	ParamEinstHzMK3HgrAkt                     = 777  // ID_Einst_HzMK3Hgr_akt
+	ParamEinstHzFtMK3VlAkt                    = 778  // ID_Einst_HzFtMK3Vl_akt
+	ParamBaHzMK3Akt                           = 779  // ID_Ba_Hz_MK3_akt
+	ParamEinstMK3TypAkt                       = 780  // ID_Einst_MK3Typ_akt
+	ParamEinstRTypMK3Akt                      = 781  // ID_Einst_RTypMK3_akt
+	ParamEinstMK3LzFaktorAkt                  = 782  // ID_Einst_MK3LzFaktor_akt
+	ParamEinstMK3PerFaktorAkt                 = 783  // ID_Einst_MK3PerFaktor_akt
+	ParamFerienModusAktivMk3                  = 784  // ID_FerienModusAktivMk3
+	ParamSUFrkdMK3                            = 785  // ID_SU_FrkdMK3
+	ParamFerienAbsenkungMK3                   = 786  // ID_FerienAbsenkungMK3
+	ParamSUFstdMK3                            = 787  // ID_SU_FstdMK3
+	ParamEinstSuMk3Akt2                       = 788  // ID_Einst_SuMk3_akt2
+	ParamEinstSuMk3WoZeit0_0                  = 789  // ID_Einst_SuMk3Wo_zeit_0_0
+	ParamEinstSuMk3WoZeit0_1                  = 790  // ID_Einst_SuMk3Wo_zeit_0_1
+	ParamEinstSuMk3WoZeit1_0                  = 791  // ID_Einst_SuMk3Wo_zeit_1_0
+	ParamEinstSuMk3WoZeit1_1                  = 792  // ID_Einst_SuMk3Wo_zeit_1_1
+	ParamEinstSuMk3WoZeit2_0                  = 793  // ID_Einst_SuMk3Wo_zeit_2_0
+	ParamEinstSuMk3WoZeit2_1                  = 794  // ID_Einst_SuMk3Wo_zeit_2_1
+	ParamEinstSuMk325Zeit0_0                  = 795  // ID_Einst_SuMk325_zeit_0_0
+	ParamEinstSuMk325Zeit0_1                  = 796  // ID_Einst_SuMk325_zeit_0_1
+	ParamEinstSuMk325Zeit1_0                  = 797  // ID_Einst_SuMk325_zeit_1_0
+	ParamEinstSuMk325Zeit1_1                  = 798  // ID_Einst_SuMk325_zeit_1_1
+	ParamEinstSuMk325Zeit2_0                  = 799  // ID_Einst_SuMk325_zeit_2_0
+	ParamEinstSuMk325Zeit2_1                  = 800  // ID_Einst_SuMk325_zeit_2_1
+	ParamEinstSuMk325Zeit0_2                  = 801  // ID_Einst_SuMk325_zeit_0_2
+	ParamEinstSuMk325Zeit0_3                  = 802  // ID_Einst_SuMk325_zeit_0_3
+	ParamEinstSuMk325Zeit1_2                  = 803  // ID_Einst_SuMk325_zeit_1_2
+	ParamEinstSuMk325Zeit1_3                  = 804  // ID_Einst_SuMk325_zeit_1_3
+	ParamEinstSuMk325Zeit2_2                  = 805  // ID_Einst_SuMk325_zeit_2_2
+	ParamEinstSuMk325Zeit2_3                  = 806  // ID_Einst_SuMk325_zeit_2_3
+	ParamEinstSuMk3TgZeit0_0                  = 807  // ID_Einst_SuMk3Tg_zeit_0_0
+	ParamEinstSuMk3TgZeit0_1                  = 808  // ID_Einst_SuMk3Tg_zeit_0_1
+	ParamEinstSuMk3TgZeit1_0                  = 809  // ID_Einst_SuMk3Tg_zeit_1_0
+	ParamEinstSuMk3TgZeit1_1                  = 810  // ID_Einst_SuMk3Tg_zeit_1_1
+	ParamEinstSuMk3TgZeit2_0                  = 811  // ID_Einst_SuMk3Tg_zeit_2_0
+	ParamEinstSuMk3TgZeit2_1                  = 812  // ID_Einst_SuMk3Tg_zeit_2_1
+	ParamEinstSuMk3TgZeit0_2                  = 813  // ID_Einst_SuMk3Tg_zeit_0_2
+	ParamEinstSuMk3TgZeit0_3                  = 814  // ID_Einst_SuMk3Tg_zeit_0_3
+	ParamEinstSuMk3TgZeit1_2                  = 815  // ID_Einst_SuMk3Tg_zeit_1_2
+	ParamEinstSuMk3TgZeit1_3                  = 816  // ID_Einst_SuMk3Tg_zeit_1_3
+	ParamEinstSuMk3TgZeit2_2                  = 817  // ID_Einst_SuMk3Tg_zeit_2_2
+	ParamEinstSuMk3TgZeit2_3                  = 818  // ID_Einst_SuMk3Tg_zeit_2_3
+	ParamEinstSuMk3TgZeit0_4                  = 819  // ID_Einst_SuMk3Tg_zeit_0_4
+	ParamEinstSuMk3TgZeit0_5                  = 820  // ID_Einst_SuMk3Tg_zeit_0_5
+	ParamEinstSuMk3TgZeit1_4                  = 821  // ID_Einst_SuMk3Tg_zeit_1_4
+	ParamEinstSuMk3TgZeit1_5                  = 822  // ID_Einst_SuMk3Tg_zeit_1_5
+	ParamEinstSuMk3TgZeit2_4                  = 823  // ID_Einst_SuMk3Tg_zeit_2_4
+	ParamEinstSuMk3TgZeit2_5                  = 824  // ID_Einst_SuMk3Tg_zeit_2_5
+	ParamEinstSuMk3TgZeit0_6                  = 825  // ID_Einst_SuMk3Tg_zeit_0_6
+	ParamEinstSuMk3TgZeit0_7                  = 826  // ID_Einst_SuMk3Tg_zeit_0_7
+	ParamEinstSuMk3TgZeit1_6                  = 827  // ID_Einst_SuMk3Tg_zeit_1_6
+	ParamEinstSuMk3TgZeit1_7                  = 828  // ID_Einst_SuMk3Tg_zeit_1_7
+	ParamEinstSuMk3TgZeit2_6                  = 829  // ID_Einst_SuMk3Tg_zeit_2_6
+	ParamEinstSuMk3TgZeit2_7                  = 830  // ID_Einst_SuMk3Tg_zeit_2_7
+	ParamEinstSuMk3TgZeit0_8                  = 831  // ID_Einst_SuMk3Tg_zeit_0_8
+	ParamEinstSuMk3TgZeit0_9                  = 832  // ID_Einst_SuMk3Tg_zeit_0_9
+	ParamEinstSuMk3TgZeit1_8                  = 833  // ID_Einst_SuMk3Tg_zeit_1_8
+	ParamEinstSuMk3TgZeit1_9                  = 834  // ID_Einst_SuMk3Tg_zeit_1_9
+	ParamEinstSuMk3TgZeit2_8                  = 835  // ID_Einst_SuMk3Tg_zeit_2_8
+	ParamEinstSuMk3TgZeit2_9                  = 836  // ID_Einst_SuMk3Tg_zeit_2_9
+	ParamEinstSuMk3TgZeit0_10                 = 837  // ID_Einst_SuMk3Tg_zeit_0_10
+	ParamEinstSuMk3TgZeit0_11                 = 838  // ID_Einst_SuMk3Tg_zeit_0_11
+	ParamEinstSuMk3TgZeit1_10                 = 839  // ID_Einst_SuMk3Tg_zeit_1_10
+	ParamEinstSuMk3TgZeit1_11                 = 840  // ID_Einst_SuMk3Tg_zeit_1_11
+	ParamEinstSuMk3TgZeit2_10                 = 841  // ID_Einst_SuMk3Tg_zeit_2_10
+	ParamEinstSuMk3TgZeit2_11                 = 842  // ID_Einst_SuMk3Tg_zeit_2_11
+	ParamEinstSuMk3TgZeit0_12                 = 843  // ID_Einst_SuMk3Tg_zeit_0_12
+	ParamEinstSuMk3TgZeit0_13                 = 844  // ID_Einst_SuMk3Tg_zeit_0_13
+	ParamEinstSuMk3TgZeit1_12                 = 845  // ID_Einst_SuMk3Tg_zeit_1_12
+	ParamEinstSuMk3TgZeit1_13                 = 846  // ID_Einst_SuMk3Tg_zeit_1_13
+	ParamEinstSuMk3TgZeit2_12                 = 847  // ID_Einst_SuMk3Tg_zeit_2_12
+	ParamEinstSuMk3TgZeit2_13                 = 848  // ID_Einst_SuMk3Tg_zeit_2_13
+	ParamBaHzMK3Saved                         = 849  // ID_Ba_Hz_MK3_saved
+	ParamEinstKuhlZeitEinAkt                  = 850  // ID_Einst_Kuhl_Zeit_Ein_akt
+	ParamEinstKuhlZeitAusAkt                  = 851  // ID_Einst_Kuhl_Zeit_Aus_akt
+	ParamWaermemengeSeit                      = 852  // ID_Waermemenge_Seit
+	ParamWaermemengeWQ                        = 853  // ID_Waermemenge_WQ
+	ParamWaermemengeHz                        = 854  // ID_Waermemenge_Hz
+	ParamWaermemengeWQGes                     = 855  // ID_Waermemenge_WQ_ges
+	ParamEinstEntlTyp13                       = 856  // ID_Einst_Entl_Typ_13
+	ParamEinstEntlTyp14                       = 857  // ID_Einst_Entl_Typ_14
+	ParamEinstEntlTyp15                       = 858  // ID_Einst_Entl_Typ_15
+	ParamZaehlerBetrZeitSW                    = 859  // ID_Zaehler_BetrZeitSW
+	ParamEinstFernwartungAkt                  = 860  // ID_Einst_Fernwartung_akt
+	ParamAdresseIPServAkt                     = 861  // ID_AdresseIPServ_akt
+	ParamEinstTAEGAkt                         = 862  // ID_Einst_TA_EG_akt
+	ParamEinstTVLmaxEGAkt                     = 863  // ID_Einst_TVLmax_EG_akt
+	ParamEinstPoptNachlaufAkt                 = 864  // ID_Einst_Popt_Nachlauf_akt
+	ParamFernwartungVertragAkt                = 865  // ID_FernwartungVertrag_akt
+	ParamFernwartungAktuZeit                  = 866  // ID_FernwartungAktuZeit
+	ParamEinstEffizienzpumpeNominalAkt        = 867  // ID_Einst_Effizienzpumpe_Nominal_akt
+	ParamEinstEffizienzpumpeMinimalAkt        = 868  // ID_Einst_Effizienzpumpe_Minimal_akt
+	ParamEinstEffizienzpumpeAkt               = 869  // ID_Einst_Effizienzpumpe_akt
+	ParamEinstWaermemengeAkt                  = 870  // ID_Einst_Waermemenge_akt
+	ParamEinstWmVersorgungKorrekturAkt        = 871  // ID_Einst_Wm_Versorgung_Korrektur_akt
+	ParamEinstWmAuswertungKorrekturAkt        = 872  // ID_Einst_Wm_Auswertung_Korrektur_akt
+	ParamSoftwareUpdateJetztGemachtAkt        = 873  // ID_SoftwareUpdateJetztGemacht_akt
+	ParamSerialNumberDate                     = 874  // ID_WP_SerienNummer_DATUM
+	ParamSerialNumberHex                      = 875  // ID_WP_SerienNummer_HEX
+	ParamWPSerienNummerINDEX                  = 876  // ID_WP_SerienNummer_INDEX
+	ParamProgWerteWebSrvBeobarten             = 877  // ID_ProgWerteWebSrvBeobarten
+	ParamWaermemengeBW                        = 878  // ID_Waermemenge_BW
+	ParamWaermemengeSW                        = 879  // ID_Waermemenge_SW
+	ParamWaermemengeDatum                     = 880  // ID_Waermemenge_Datum
+	ParamEinstSolarAkt                        = 881  // ID_Einst_Solar_akt
+	ParamBSTDSolar                            = 882  // ID_BSTD_Solar
+	ParamEinstTDCKollMaxAkt                   = 883  // ID_Einst_TDC_Koll_Max_akt
+	ParamEinstAktKuehlungAkt                  = 884  // ID_Einst_Akt_Kuehlung_akt
+	ParamEinstVorlaufVBOAkt                   = 885  // ID_Einst_Vorlauf_VBO_akt
+	ParamEinstKRHystAkt                       = 886  // ID_Einst_KRHyst_akt
+	ParamEinstAktKuehlSpeicherMinAkt          = 887  // ID_Einst_Akt_Kuehl_Speicher_min_akt
+	ParamEinstAktKuehlFreigWQEAkt             = 888  // ID_Einst_Akt_Kuehl_Freig_WQE_akt
+	ParamNDABWWAnzahl                         = 889  // ID_NDAB_WW_Anzahl
+	ParamNDSWWKDQuitt                         = 890  // ID_NDS_WW_KD_Quitt
+	ParamEinstAbtZykMinAkt                    = 891  // ID_Einst_AbtZykMin_akt
+	ParamEinstVD2ZeitMinAkt                   = 892  // ID_Einst_VD2_Zeit_Min_akt
+	ParamEinstHystereseHRVerkuerztAkt         = 893  // ID_Einst_Hysterese_HR_verkuerzt_akt
+	ParamEinstBALueftungAkt                   = 894  // ID_Einst_BA_Lueftung_akt
+	ParamEinstSuLufAkt                        = 895  // ID_Einst_SuLuf_akt
+	ParamEinstSuLufWoZeit0_0_0                = 896  // ID_Einst_SuLufWo_zeit_0_0_0
+	ParamEinstSuLufWoZeit0_1_0                = 897  // ID_Einst_SuLufWo_zeit_0_1_0
+	ParamEinstSuLufWoZeit0_2_0                = 898  // ID_Einst_SuLufWo_zeit_0_2_0
+	ParamEinstSuLuf25Zeit0_0_0                = 899  // ID_Einst_SuLuf25_zeit_0_0_0
+	ParamEinstSuLuf25Zeit0_1_0                = 900  // ID_Einst_SuLuf25_zeit_0_1_0
+	ParamEinstSuLuf25Zeit0_2_0                = 901  // ID_Einst_SuLuf25_zeit_0_2_0
+	ParamEinstSuLuf25Zeit0_0_2                = 902  // ID_Einst_SuLuf25_zeit_0_0_2
+	ParamEinstSuLuf25Zeit0_1_2                = 903  // ID_Einst_SuLuf25_zeit_0_1_2
+	ParamEinstSuLuf25Zeit0_2_2                = 904  // ID_Einst_SuLuf25_zeit_0_2_2
+	ParamEinstSuLufTgZeit0_0_0                = 905  // ID_Einst_SuLufTg_zeit_0_0_0
+	ParamEinstSuLufTgZeit0_1_0                = 906  // ID_Einst_SuLufTg_zeit_0_1_0
+	ParamEinstSuLufTgZeit0_2_0                = 907  // ID_Einst_SuLufTg_zeit_0_2_0
+	ParamEinstSuLufTgZeit0_0_2                = 908  // ID_Einst_SuLufTg_zeit_0_0_2
+	ParamEinstSuLufTgZeit0_1_2                = 909  // ID_Einst_SuLufTg_zeit_0_1_2
+	ParamEinstSuLufTgZeit0_2_2                = 910  // ID_Einst_SuLufTg_zeit_0_2_2
+	ParamEinstSuLufTgZeit0_0_4                = 911  // ID_Einst_SuLufTg_zeit_0_0_4
+	ParamEinstSuLufTgZeit0_1_4                = 912  // ID_Einst_SuLufTg_zeit_0_1_4
+	ParamEinstSuLufTgZeit0_2_4                = 913  // ID_Einst_SuLufTg_zeit_0_2_4
+	ParamEinstSuLufTgZeit0_0_6                = 914  // ID_Einst_SuLufTg_zeit_0_0_6
+	ParamEinstSuLufTgZeit0_1_6                = 915  // ID_Einst_SuLufTg_zeit_0_1_6
+	ParamEinstSuLufTgZeit0_2_6                = 916  // ID_Einst_SuLufTg_zeit_0_2_6
+	ParamEinstSuLufTgZeit0_0_8                = 917  // ID_Einst_SuLufTg_zeit_0_0_8
+	ParamEinstSuLufTgZeit0_1_8                = 918  // ID_Einst_SuLufTg_zeit_0_1_8
+	ParamEinstSuLufTgZeit0_2_8                = 919  // ID_Einst_SuLufTg_zeit_0_2_8
+	ParamEinstSuLufTgZeit0_0_10               = 920  // ID_Einst_SuLufTg_zeit_0_0_10
+	ParamEinstSuLufTgZeit0_1_10               = 921  // ID_Einst_SuLufTg_zeit_0_1_10
+	ParamEinstSuLufTgZeit0_2_10               = 922  // ID_Einst_SuLufTg_zeit_0_2_10
+	ParamEinstSuLufTgZeit0_0_12               = 923  // ID_Einst_SuLufTg_zeit_0_0_12
+	ParamEinstSuLufTgZeit0_1_12               = 924  // ID_Einst_SuLufTg_zeit_0_1_12
+	ParamEinstSuLufTgZeit0_2_12               = 925  // ID_Einst_SuLufTg_zeit_0_2_12
+	ParamEinstSuLufWoZeit1_0_0                = 926  // ID_Einst_SuLufWo_zeit_1_0_0
+	ParamEinstSuLufWoZeit1_1_0                = 927  // ID_Einst_SuLufWo_zeit_1_1_0
+	ParamEinstSuLufWoZeit1_2_0                = 928  // ID_Einst_SuLufWo_zeit_1_2_0
+	ParamEinstSuLuf25Zeit1_0_0                = 929  // ID_Einst_SuLuf25_zeit_1_0_0
+	ParamEinstSuLuf25Zeit1_1_0                = 930  // ID_Einst_SuLuf25_zeit_1_1_0
+	ParamEinstSuLuf25Zeit1_2_0                = 931  // ID_Einst_SuLuf25_zeit_1_2_0
+	ParamEinstSuLuf25Zeit1_0_2                = 932  // ID_Einst_SuLuf25_zeit_1_0_2
+	ParamEinstSuLuf25Zeit1_1_2                = 933  // ID_Einst_SuLuf25_zeit_1_1_2
+	ParamEinstSuLuf25Zeit1_2_2                = 934  // ID_Einst_SuLuf25_zeit_1_2_2
+	ParamEinstSuLufTgZeit1_0_0                = 935  // ID_Einst_SuLufTg_zeit_1_0_0
+	ParamEinstSuLufTgZeit1_1_0                = 936  // ID_Einst_SuLufTg_zeit_1_1_0
+	ParamEinstSuLufTgZeit1_2_0                = 937  // ID_Einst_SuLufTg_zeit_1_2_0
+	ParamEinstSuLufTgZeit1_0_2                = 938  // ID_Einst_SuLufTg_zeit_1_0_2
+	ParamEinstSuLufTgZeit1_1_2                = 939  // ID_Einst_SuLufTg_zeit_1_1_2
+	ParamEinstSuLufTgZeit1_2_2                = 940  // ID_Einst_SuLufTg_zeit_1_2_2
+	ParamEinstSuLufTgZeit1_0_4                = 941  // ID_Einst_SuLufTg_zeit_1_0_4
+	ParamEinstSuLufTgZeit1_1_4                = 942  // ID_Einst_SuLufTg_zeit_1_1_4
+	ParamEinstSuLufTgZeit1_2_4                = 943  // ID_Einst_SuLufTg_zeit_1_2_4
+	ParamEinstSuLufTgZeit1_0_6                = 944  // ID_Einst_SuLufTg_zeit_1_0_6
+	ParamEinstSuLufTgZeit1_1_6                = 945  // ID_Einst_SuLufTg_zeit_1_1_6
+	ParamEinstSuLufTgZeit1_2_6                = 946  // ID_Einst_SuLufTg_zeit_1_2_6
+	ParamEinstSuLufTgZeit1_0_8                = 947  // ID_Einst_SuLufTg_zeit_1_0_8
+	ParamEinstSuLufTgZeit1_1_8                = 948  // ID_Einst_SuLufTg_zeit_1_1_8
+	ParamEinstSuLufTgZeit1_2_8                = 949  // ID_Einst_SuLufTg_zeit_1_2_8
+	ParamEinstSuLufTgZeit1_0_10               = 950  // ID_Einst_SuLufTg_zeit_1_0_10
+	ParamEinstSuLufTgZeit1_1_10               = 951  // ID_Einst_SuLufTg_zeit_1_1_10
+	ParamEinstSuLufTgZeit1_2_10               = 952  // ID_Einst_SuLufTg_zeit_1_2_10
+	ParamEinstSuLufTgZeit1_0_12               = 953  // ID_Einst_SuLufTg_zeit_1_0_12
+	ParamEinstSuLufTgZeit1_1_12               = 954  // ID_Einst_SuLufTg_zeit_1_1_12
+	ParamEinstSuLufTgZeit1_2_12               = 955  // ID_Einst_SuLufTg_zeit_1_2_12
+	ParamFerienModusAktivLueftung             = 956  // ID_FerienModusAktivLueftung
+	ParamEinstBALueftungSaved                 = 957  // ID_Einst_BA_Lueftung_saved
+	ParamSUFrkdLueftung                       = 958  // ID_SU_FrkdLueftung
+	ParamSUFstdLueftung                       = 959  // ID_SU_FstdLueftung
+	ParamEinstLufFeuchteschutzAkt             = 960  // ID_Einst_Luf_Feuchteschutz_akt
+	ParamEinstLufReduziertAkt                 = 961  // ID_Einst_Luf_Reduziert_akt
+	ParamEinstLufNennlueftungAkt              = 962  // ID_Einst_Luf_Nennlueftung_akt
+	ParamEinstLufIntensivlueftungAkt          = 963  // ID_Einst_Luf_Intensivlueftung_akt
+	ParamTimerFil4Makt                        = 964  // ID_Timer_Fil_4Makt
+	ParamTimerFilWoAkt                        = 965  // ID_Timer_Fil_WoAkt
+	ParamSollwertKuCft3Akt                    = 966  // ID_Sollwert_KuCft3_akt
+	ParamSollwertAtDif3Akt                    = 967  // ID_Sollwert_AtDif3_akt
+	ParamBitmaske0                            = 968  // ID_Bitmaske_0
+	ParamEinstLueftungsstufen                 = 969  // ID_Einst_Lueftungsstufen
+	ParamSysEinMeldungTDI                     = 970  // ID_SysEin_Meldung_TDI
+	ParamSysEinTypWZW                         = 971  // ID_SysEin_Typ_WZW
+	ParamEinstGLTAktiviert                    = 972  // ID_Einst_GLT_aktiviert
+	ParamEinstBWMax                           = 973  // ID_Einst_BW_max
+	ParamEinstSollwertTRLKuehlen              = 974  // ID_Einst_Sollwert_TRL_Kuehlen
+	ParamEinstMediumWaermequelle              = 975  // ID_Einst_Medium_Waermequelle
+	ParamEinstPhotovoltaikAkt                 = 976  // ID_Einst_Photovoltaik_akt
+	ParamEinstMultispeicherAkt                = 977  // ID_Einst_Multispeicher_akt
+	ParamEinstPKuehlTimeAkt                   = 978  // ID_Einst_PKuehlTime_akt
+	ParamEinstMinimaleRuecklaufsolltemperatur = 979  // ID_Einst_Minimale_Ruecklaufsolltemperatur
+	ParamRBEEinflussfaktorRTAkt               = 980  // ID_RBE_Einflussfaktor_RT_akt
+	ParamRBEFreigabeKuehlungAkt               = 981  // ID_RBE_Freigabe_Kuehlung_akt
+	ParamRBEWaermeverteilsystemAkt            = 982  // ID_RBE_Waermeverteilsystem_akt
+	ParamRBEZeitHeizstabAktiv                 = 983  // ID_RBE_Zeit_Heizstab_aktiv
+	ParamSECNDAlarmgrenze                     = 984  // ID_SEC_ND_Alarmgrenze
+	ParamSECHDAlarmgrenze                     = 985  // ID_SEC_HD_Alarmgrenze
+	ParamSECAbtauendtemperatur                = 986  // ID_SEC_Abtauendtemperatur
+	ParamEinstMinRPMBW                        = 987  // ID_Einst_Min_RPM_BW
+	ParamEinstLufFeuchteschutzFaktorAkt       = 988  // ID_Einst_Luf_Feuchteschutz_Faktor_akt
+	ParamEinstLufReduziertFaktorAkt           = 989  // ID_Einst_Luf_Reduziert_Faktor_akt
+	ParamEinstLufNennlueftungFaktorAkt        = 990  // ID_Einst_Luf_Nennlueftung_Faktor_akt
+	ParamEinstLufIntensivlueftungFaktorAkt    = 991  // ID_Einst_Luf_Intensivlueftung_Faktor_akt
+	ParamEinstFreigabeZeitZWE                 = 992  // ID_Einst_Freigabe_Zeit_ZWE
+	ParamEinstMinVLKuehl                      = 993  // ID_Einst_min_VL_Kuehl
+	ParamEinstWarmwasserNachheizung           = 994  // ID_Einst_Warmwasser_Nachheizung
+	ParamSwitchoffFileLWD2_0_0                = 995  // ID_Switchoff_file_LWD2_0_0
+	ParamSwitchoffFileLWD2_1_0                = 996  // ID_Switchoff_file_LWD2_1_0
+	ParamSwitchoffFileLWD2_2_0                = 997  // ID_Switchoff_file_LWD2_2_0
+	ParamSwitchoffFileLWD2_3_0                = 998  // ID_Switchoff_file_LWD2_3_0
+	ParamSwitchoffFileLWD2_4_0                = 999  // ID_Switchoff_file_LWD2_4_0
+	ParamSwitchoffFileLWD2_0_1                = 1000 // ID_Switchoff_file_LWD2_0_1
+	ParamSwitchoffFileLWD2_1_1                = 1001 // ID_Switchoff_file_LWD2_1_1
+	ParamSwitchoffFileLWD2_2_1                = 1002 // ID_Switchoff_file_LWD2_2_1
+	ParamSwitchoffFileLWD2_3_1                = 1003 // ID_Switchoff_file_LWD2_3_1
+	ParamSwitchoffFileLWD2_4_1                = 1004 // ID_Switchoff_file_LWD2_4_1
+	ParamSwitchoffIndexLWD2                   = 1005 // ID_Switchoff_index_LWD2
+	ParamEinstEffizienzpumpeNominal2          = 1006 // ID_Einst_Effizienzpumpe_Nominal_2
+	ParamEinstEffizienzpumpeMinimal2          = 1007 // ID_Einst_Effizienzpumpe_Minimal_2
+	ParamEinstWmVersorgungKorrektur2          = 1008 // ID_Einst_Wm_Versorgung_Korrektur_2
+	ParamEinstWmAuswertungKorrektur2          = 1009 // ID_Einst_Wm_Auswertung_Korrektur_2
+	ParamEinstIsTwin                          = 1010 // ID_Einst_isTwin
+	ParamEinstTAmin2                          = 1011 // ID_Einst_TAmin_2
+	ParamEinstTVLmax2                         = 1012 // ID_Einst_TVLmax_2
+	ParamEinstTAEG2                           = 1013 // ID_Einst_TA_EG_2
+	ParamEinstTVLmaxEG2                       = 1014 // ID_Einst_TVLmax_EG_2
+	ParamWaermemengeHz2                       = 1015 // ID_Waermemenge_Hz_2
+	ParamWaermemengeBW2                       = 1016 // ID_Waermemenge_BW_2
+	ParamWaermemengeSW2                       = 1017 // ID_Waermemenge_SW_2
+	ParamWaermemengeSeit2                     = 1018 // ID_Waermemenge_Seit_2
+	ParamEinstEntlTyp15_2                     = 1019 // ID_Einst_Entl_Typ_15_2
+	ParamEinstWWNachheizungMax                = 1020 // ID_Einst_WW_Nachheizung_max
+	ParamEinstKuhlZeitEinRT                   = 1021 // ID_Einst_Kuhl_Zeit_Ein_RT
+	ParamEinstZWE1Pos                         = 1022 // ID_Einst_ZWE1_Pos
+	ParamEinstZWE2Pos                         = 1023 // ID_Einst_ZWE2_Pos
+	ParamEinstZWE3Pos                         = 1024 // ID_Einst_ZWE3_Pos
+	ParamEinstLeistungZWE                     = 1025 // ID_Einst_Leistung_ZWE
+	ParamWPSN2DATUM                           = 1026 // ID_WP_SN2_DATUM
+	ParamWPSN2HEX                             = 1027 // ID_WP_SN2_HEX
+	ParamWPSN2INDEX                           = 1028 // ID_WP_SN2_INDEX
+	ParamCWPSaved2                            = 1029 // ID_CWP_saved2
+	ParamEinstSmartGrid                       = 1030 // ID_Einst_SmartGrid
+	ParamEinstP155HDS                         = 1031 // ID_Einst_P155_HDS
+	ParamEinstP155PumpHeatMax                 = 1032 // ID_Einst_P155_PumpHeat_Max
+	ParamEinstP155PumpHeatCtrl                = 1033 // ID_Einst_P155_PumpHeatCtrl
+	ParamEinstP155PumpDHWCtrl                 = 1034 // ID_Einst_P155_PumpDHWCtrl
+	ParamEinstP155PumpDHWRPM                  = 1035 // ID_Einst_P155_PumpDHW_RPM
+	ParamEinstP155PumpPoolCtrl                = 1036 // ID_Einst_P155_PumpPoolCtrl
+	ParamEinstP155PumpPoolRPM                 = 1037 // ID_Einst_P155_PumpPool_RPM
+	ParamEinstP155PumpCoolRPM                 = 1038 // ID_Einst_P155_PumpCool_RPM
+	ParamEinstP155PumpVBOCtrl                 = 1039 // ID_Einst_P155_PumpVBOCtrl
+	ParamEinstP155PumpVBORPMC                 = 1040 // ID_Einst_P155_PumpVBO_RPM_C
+	ParamEinstP155PumpDHWMax                  = 1041 // ID_Einst_P155_PumpDHW_Max
+	ParamEinstP155PumpPoolMax                 = 1042 // ID_Einst_P155_PumpPool_Max
+	ParamEinstP155Sperrband1                  = 1043 // ID_Einst_P155_Sperrband_1
+	ParamEinstP155Leistungsfreigabe           = 1044 // ID_Einst_P155_Leistungsfreigabe
+	ParamEinstP155DHWFreq                     = 1045 // ID_Einst_P155_DHW_Freq
+	ParamEinstSWHUP                           = 1046 // ID_Einst_SWHUP
+	ParamEinstP155SWBFreq                     = 1047 // ID_Einst_P155_SWB_Freq
+	ParamEinstMK1Regelung                     = 1048 // ID_Einst_MK1_Regelung
+	ParamEinstMK2Regelung                     = 1049 // ID_Einst_MK2_Regelung
+	ParamEinstMK3Regelung                     = 1050 // ID_Einst_MK3_Regelung
+	ParamEinstPVWWSperrzeit                   = 1051 // ID_Einst_PV_WW_Sperrzeit
+	ParamEinstWarmwasserExtra                 = 1052 // ID_Einst_Warmwasser_extra
+	ParamEinstVorlAktKuehl                    = 1053 // ID_Einst_Vorl_akt_Kuehl
+	ParamWPSN3DATUM                           = 1054 // ID_WP_SN3_DATUM
+	ParamWPSN3HEX                             = 1055 // ID_WP_SN3_HEX
+	ParamWPSN3INDEX                           = 1056 // ID_WP_SN3_INDEX
+	ParamEinstVorlaufZUP                      = 1057 // ID_Einst_Vorlauf_ZUP
+	ParamEinstAbtauenImWarmwasser             = 1058 // ID_Einst_Abtauen_im_Warmwasser
+	ParamWaermemengeZWE                       = 1059 // ID_Waermemenge_ZWE
+	ParamWaermemengeReset                     = 1060 // ID_Waermemenge_Reset
+	ParamWaermemengeReset2                    = 1061 // ID_Waermemenge_Reset_2
+	ParamEinstBrunnenpumpeMin                 = 1062 // ID_Einst_Brunnenpumpe_min
+	ParamEinstBrunnenpumpeMax                 = 1063 // ID_Einst_Brunnenpumpe_max
+	ParamEinstSmartHomeID                     = 1064 // ID_Einst_SmartHomeID
+	ParamEinstSmartHK                         = 1065 // ID_Einst_SmartHK
+	ParamEinstSmartMK1                        = 1066 // ID_Einst_SmartMK1
+	ParamEinstSmartMK2                        = 1067 // ID_Einst_SmartMK2
+	ParamEinstSmartMK3                        = 1068 // ID_Einst_SmartMK3
+	ParamEinstSmartWW                         = 1069 // ID_Einst_SmartWW
+	ParamEinstSmartDefrost                    = 1070 // ID_Einst_SmartDefrost
+	ParamEinstEmpty1071                       = 1071 // ID_Einst_Empty1071
+	ParamEinstMinVLMK1                        = 1072 // ID_Einst_MinVLMK1
+	ParamEinstMinVLMK2                        = 1073 // ID_Einst_MinVLMK2
+	ParamEinstMinVLMK3                        = 1074 // ID_Einst_MinVLMK3
+	ParamEinstMaxVLMK1                        = 1075 // ID_Einst_MaxVLMK1
+	ParamEinstMaxVLMK2                        = 1076 // ID_Einst_MaxVLMK2
+	ParamEinstMaxVLMK3                        = 1077 // ID_Einst_MaxVLMK3
+	ParamEinstSmartPlusHz                     = 1078 // ID_Einst_SmartPlusHz
+	ParamEinstSmartMinusHz                    = 1079 // ID_Einst_SmartMinusHz
+	ParamEinstSmartPlusMK1                    = 1080 // ID_Einst_SmartPlusMK1
+	ParamEinstSmartMinusMK1                   = 1081 // ID_Einst_SmartMinusMK1
+	ParamEinstSmartPlusMK2                    = 1082 // ID_Einst_SmartPlusMK2
+	ParamEinstSmartMinusMK2                   = 1083 // ID_Einst_SmartMinusMK2
+	ParamEinstSmartPlusMK3                    = 1084 // ID_Einst_SmartPlusMK3
+	ParamEinstSmartMinusMK3                   = 1085 // ID_Einst_SmartMinusMK3
+)
@@ -0,0 +1,513 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// parquetMagic starts and ends a Parquet file.
+const parquetMagic = "PAR1"
+
+// Physical types, converted types, repetitions and encodings of the Parquet
+// format.
+const (
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetUTF8            = 0
+	parquetTimestampMillis = 9
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetPlain = 0
+	parquetRLE   = 3
+)
+
+// parquetColumn buffers the values of a column for the next row group.
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted int32 // -1 for none
+	optional  bool
+
+	values  []byte // PLAIN encoded, without the nulls
+	defined []bool // definition levels of an optional column
+	rows    int
+}
+
+func (c *parquetColumn) null() {
+	c.defined = append(c.defined, false)
+	c.rows++
+}
+
+func (c *parquetColumn) int32(v int32) {
+	c.values = binary.LittleEndian.AppendUint32(c.values, uint32(v))
+	c.add()
+}
+
+func (c *parquetColumn) int64(v int64) {
+	c.values = binary.LittleEndian.AppendUint64(c.values, uint64(v))
+	c.add()
+}
+
+func (c *parquetColumn) double(v float64) {
+	c.values = binary.LittleEndian.AppendUint64(c.values, math.Float64bits(v))
+	c.add()
+}
+
+func (c *parquetColumn) string(s string) {
+	c.values = binary.LittleEndian.AppendUint32(c.values, uint32(len(s)))
+	c.values = append(c.values, s...)
+	c.add()
+}
+
+func (c *parquetColumn) add() {
+	if c.optional {
+		c.defined = append(c.defined, true)
+	}
+	c.rows++
+}
+
+// page returns the data of a data page: the definition levels of an optional
+// column in the RLE hybrid encoding with bit width 1 and the values.
+func (c *parquetColumn) page() []byte {
+	if !c.optional {
+		return c.values
+	}
+	var levels []byte
+	for i := 0; i < len(c.defined); {
+		j := i
+		for j < len(c.defined) && c.defined[j] == c.defined[i] {
+			j++
+		}
+		levels = binary.AppendUvarint(levels, uint64(j-i)<<1)
+		if c.defined[i] {
+			levels = append(levels, 1)
+		} else {
+			levels = append(levels, 0)
+		}
+		i = j
+	}
+	page := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	return append(append(page, levels...), c.values...)
+}
+
+func (c *parquetColumn) reset() {
+	c.values, c.defined, c.rows = c.values[:0], c.defined[:0], 0
+}
+
+// ParquetWriter writes dumps as a Parquet file with one row per value and
+// the columns time, host, block, index, name, class, value, text, unit and
+// raw. Numbers and booleans go into value, everything else into text. The
+// rows are buffered until Flush, which writes them as row group, and Close
+// writes the footer. The file is uncompressed and PLAIN encoded, which every
+// reader like pandas or DuckDB understands.
+type ParquetWriter struct {
+	w         io.Writer
+	offset    int64
+	columns   []*parquetColumn
+	rowGroups [][]byte // encoded RowGroup structs
+	rows      int64
+	err       error
+}
+
+func NewParquetWriter(w io.Writer) *ParquetWriter {
+	return &ParquetWriter{
+		w: w,
+		columns: []*parquetColumn{
+			{name: "time", typ: parquetInt64, converted: parquetTimestampMillis},
+			{name: "host", typ: parquetByteArray, converted: parquetUTF8},
+			{name: "block", typ: parquetByteArray, converted: parquetUTF8},
+			{name: "index", typ: parquetInt32, converted: -1},
+			{name: "name", typ: parquetByteArray, converted: parquetUTF8},
+			{name: "class", typ: parquetByteArray, converted: parquetUTF8},
+			{name: "value", typ: parquetDouble, converted: -1, optional: true},
+			{name: "text", typ: parquetByteArray, converted: parquetUTF8, optional: true},
+			{name: "unit", typ: parquetByteArray, converted: parquetUTF8},
+			{name: "raw", typ: parquetInt64, converted: -1},
+		},
+	}
+}
+
+// Buffered returns the number of rows waiting for Flush.
+func (p *ParquetWriter) Buffered() int {
+	return p.columns[0].rows
+}
+
+// Write buffers the entries of d.
+func (p *ParquetWriter) Write(d *Dump) error {
+	if p.err != nil {
+		return p.err
+	}
+	c := p.columns
+	ms := d.Time.UnixMilli()
+	for _, e := range d.Entries {
+		c[0].int64(ms)
+		c[1].string(d.Host)
+		c[2].string(e.Block)
+		c[3].int32(int32(e.Index))
+		c[4].string(e.Name)
+		c[5].string(e.Class)
+		switch v := e.Value.(type) {
+		case nil:
+			c[6].null()
+			c[7].null()
+		case bool:
+			var f float64
+			if v {
+				f = 1
+			}
+			c[6].double(f)
+			c[7].null()
+		case float32:
+			c[6].double(widenFloat32(v))
+			c[7].null()
+		case string:
+			c[6].null()
+			c[7].string(v)
+		default:
+			if f, err := cast.ToFloat64E(v); err == nil {
+				c[6].double(f)
+				c[7].null()
+			} else {
+				c[6].null()
+				c[7].string(fmt.Sprint(v))
+			}
+		}
+		c[8].string(e.Unit)
+		c[9].int64(int64(e.Raw))
+	}
+	return nil
+}
+
+// Flush writes the buffered rows as row group.
+func (p *ParquetWriter) Flush() error {
+	if p.err != nil {
+		return p.err
+	}
+	if err := p.start(); err != nil {
+		return err
+	}
+	rows := p.Buffered()
+	if rows == 0 {
+		return nil
+	}
+
+	var rg thriftStruct
+	rg.listBegin(1, thriftStructType, len(p.columns))
+	var total int64
+	for _, c := range p.columns {
+		data := c.page()
+		var dph thriftStruct
+		dph.i32(1, int32(rows))
+		dph.i32(2, parquetPlain)
+		dph.i32(3, parquetRLE)
+		dph.i32(4, parquetRLE)
+		var ph thriftStruct
+		ph.i32(1, 0) // DATA_PAGE
+		ph.i32(2, int32(len(data)))
+		ph.i32(3, int32(len(data)))
+		ph.structField(5, dph)
+		header := ph.bytes()
+
+		pageOffset := p.offset
+		if err := p.write(header, data); err != nil {
+			return err
+		}
+		size := int64(len(header) + len(data))
+		total += size
+
+		var md thriftStruct
+		md.i32(1, c.typ)
+		md.listBegin(2, thriftI32Type, 2)
+		md.listI32(parquetPlain)
+		md.listI32(parquetRLE)
+		md.listBegin(3, thriftBinaryType, 1)
+		md.listBinary(c.name)
+		md.i32(4, 0) // UNCOMPRESSED
+		md.i64(5, int64(rows))
+		md.i64(6, size)
+		md.i64(7, size)
+		md.i64(9, pageOffset)
+		var cc thriftStruct
+		cc.i64(2, pageOffset)
+		cc.structField(3, md)
+		rg.listStruct(cc)
+		c.reset()
+	}
+	rg.i64(2, total)
+	rg.i64(3, int64(rows))
+	p.rowGroups = append(p.rowGroups, rg.bytes())
+	p.rows += int64(rows)
+	return nil
+}
+
+// Close flushes the buffered rows and writes the footer, it does not close
+// the underlying writer.
+func (p *ParquetWriter) Close() error {
+	if err := p.Flush(); err != nil {
+		return err
+	}
+	var fm thriftStruct
+	fm.i32(1, 1)
+	fm.listBegin(2, thriftStructType, len(p.columns)+1)
+	var root thriftStruct
+	root.binary(4, "schema")
+	root.i32(5, int32(len(p.columns)))
+	fm.listStruct(root)
+	for _, c := range p.columns {
+		var se thriftStruct
+		se.i32(1, c.typ)
+		if c.optional {
+			se.i32(3, parquetOptional)
+		} else {
+			se.i32(3, parquetRequired)
+		}
+		se.binary(4, c.name)
+		if c.converted >= 0 {
+			se.i32(6, c.converted)
+		}
+		fm.listStruct(se)
+	}
+	fm.i64(3, p.rows)
+	fm.listBegin(4, thriftStructType, len(p.rowGroups))
+	for _, rg := range p.rowGroups {
+		fm.listRaw(rg)
+	}
+	fm.binary(6, "luxtronik")
+	footer := fm.bytes()
+	p.err = p.write(footer, binary.LittleEndian.AppendUint32(nil, uint32(len(footer))), []byte(parquetMagic))
+	if p.err != nil {
+		return p.err
+	}
+	p.err = errors.New("ParquetWriter closed")
+	return nil
+}
+
+func (p *ParquetWriter) start() error {
+	if p.offset > 0 {
+		return nil
+	}
+	return p.write([]byte(parquetMagic))
+}
+
+func (p *ParquetWriter) write(chunks ...[]byte) error {
+	for _, b := range chunks {
+		n, err := p.w.Write(b)
+		p.offset += int64(n)
+		if err != nil {
+			p.err = fmt.Errorf("ParquetWriter: %w", err)
+			return p.err
+		}
+	}
+	return nil
+}
+
+// WriteDumpsParquet writes several dumps, e.g. of different heat pumps, as
+// one Parquet file.
+func WriteDumpsParquet(w io.Writer, dumps ...*Dump) error {
+	pw := NewParquetWriter(w)
+	for _, d := range dumps {
+		if err := pw.Write(d); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
+
+// widenFloat32 returns the shortest decimal of v as float64, e.g. 0.1
+// instead of 0.10000000149.
+func widenFloat32(v float32) float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+	return f
+}
+
+// Types of the Thrift compact protocol used by the Parquet metadata.
+const (
+	thriftI32Type    = 5
+	thriftI64Type    = 6
+	thriftBinaryType = 8
+	thriftStructType = 12
+)
+
+// thriftStruct encodes a struct in the Thrift compact protocol, fields must
+// be added in ascending order.
+type thriftStruct struct {
+	buf  []byte
+	last int16
+}
+
+func (t *thriftStruct) field(id int16, typ byte) {
+	if delta := id - t.last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.buf = binary.AppendVarint(t.buf, int64(id))
+	}
+	t.last = id
+}
+
+func (t *thriftStruct) i32(id int16, v int32) {
+	t.field(id, thriftI32Type)
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thriftStruct) i64(id int16, v int64) {
+	t.field(id, thriftI64Type)
+	t.buf = binary.AppendVarint(t.buf, v)
+}
+
+func (t *thriftStruct) binary(id int16, s string) {
+	t.field(id, thriftBinaryType)
+	t.listBinary(s)
+}
+
+func (t *thriftStruct) structField(id int16, s thriftStruct) {
+	t.field(id, thriftStructType)
+	t.buf = append(t.buf, s.bytes()...)
+}
+
+// listBegin starts a list of n elements of typ, add them with the list
+// methods.
+func (t *thriftStruct) listBegin(id int16, typ byte, n int) {
+	t.field(id, 9)
+	if n < 15 {
+		t.buf = append(t.buf, byte(n)<<4|typ)
+	} else {
+		t.buf = append(t.buf, 0xf0|typ)
+		t.buf = binary.AppendUvarint(t.buf, uint64(n))
+	}
+}
+
+func (t *thriftStruct) listI32(v int32) {
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thriftStruct) listBinary(s string) {
+	t.buf = binary.AppendUvarint(t.buf, uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
+
+func (t *thriftStruct) listStruct(s thriftStruct) {
+	t.buf = append(t.buf, s.bytes()...)
+}
+
+func (t *thriftStruct) listRaw(b []byte) {
+	t.buf = append(t.buf, b...)
+}
+
+// bytes returns the encoded struct including the stop field.
+func (t *thriftStruct) bytes() []byte {
+	return append(t.buf[:len(t.buf):len(t.buf)], 0)
+}
+
+// ParquetSinkOptions configure a ParquetSink.
+type ParquetSinkOptions struct {
+	// Dir receives the files.
+	Dir string
+	// Rotate starts a new file for every period, defaults to a day. Periods
+	// start at multiples of Rotate since the zero time, e.g. at midnight
+	// UTC for a day.
+	Rotate time.Duration
+	// RowGroupRows writes a row group once that many rows are buffered,
+	// defaults to 100000.
+	RowGroupRows int
+}
+
+// ParquetSink writes all values to rolling Parquet files in a directory,
+// one file per period, e.g. luxtronik-20240101-000030.parquet named after
+// its first poll. A file is written as hidden .part file and renamed once
+// complete, so that readers like pandas.read_parquet or DuckDB's
+// read_parquet('dir/*.parquet') only see whole files.
+type ParquetSink struct {
+	opts ParquetSinkOptions
+
+	mu     sync.Mutex
+	period time.Time
+	part   string
+	f      *os.File
+	pw     *ParquetWriter
+}
+
+func NewParquetSink(opts ParquetSinkOptions) (*ParquetSink, error) {
+	if opts.Dir == "" {
+		return nil, errors.New("NewParquetSink needs a Dir")
+	}
+	if opts.Rotate <= 0 {
+		opts.Rotate = 24 * time.Hour
+	}
+	if opts.RowGroupRows < 1 {
+		opts.RowGroupRows = 100000
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("NewParquetSink: %w", err)
+	}
+	return &ParquetSink{opts: opts}, nil
+}
+
+func (s *ParquetSink) Write(_ context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	period := ts.Truncate(s.opts.Rotate)
+	if s.pw != nil && !period.Equal(s.period) {
+		if err := s.closeFile(); err != nil {
+			return err
+		}
+	}
+	if s.pw == nil {
+		s.period = period
+		s.part = filepath.Join(s.opts.Dir, ".luxtronik-"+ts.UTC().Format("20060102-150405")+".parquet.part")
+		f, err := os.Create(s.part)
+		if err != nil {
+			return fmt.Errorf("ParquetSink.Write: %w", err)
+		}
+		s.f, s.pw = f, NewParquetWriter(f)
+	}
+
+	if err := s.pw.Write(NewDump(host, ts, map[string]DataTypeMap{block: pm})); err != nil {
+		return err
+	}
+	if s.pw.Buffered() >= s.opts.RowGroupRows {
+		return s.pw.Flush()
+	}
+	return nil
+}
+
+// closeFile writes the footer and renames the .part file.
+func (s *ParquetSink) closeFile() error {
+	err := errors.Join(s.pw.Close(), s.f.Close())
+	if err == nil {
+		dir, name := filepath.Split(s.part)
+		err = os.Rename(s.part, filepath.Join(dir, name[1:len(name)-len(".part")]))
+	}
+	s.f, s.pw = nil, nil
+	if err != nil {
+		return fmt.Errorf("ParquetSink %s: %w", s.part, err)
+	}
+	return nil
+}
+
+// Close completes the current file.
+func (s *ParquetSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pw == nil {
+		return nil
+	}
+	return s.closeFile()
+}
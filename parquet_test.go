@@ -0,0 +1,242 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes the Thrift compact protocol into maps of field ids,
+// lists become []any, binaries []byte.
+type thriftReader struct {
+	t   *testing.T
+	buf []byte
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	require.Positive(r.t, n)
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case thriftI32Type, thriftI64Type:
+		v, n := binary.Varint(r.buf)
+		require.Positive(r.t, n)
+		r.buf = r.buf[n:]
+		return v
+	case thriftBinaryType:
+		n := r.uvarint()
+		v := r.buf[:n]
+		r.buf = r.buf[n:]
+		return v
+	case 9:
+		h := r.buf[0]
+		r.buf = r.buf[1:]
+		n := uint64(h >> 4)
+		if n == 15 {
+			n = r.uvarint()
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.value(h & 0x0f)
+		}
+		return list
+	case thriftStructType:
+		s := map[int16]any{}
+		var last int16
+		for {
+			h := r.buf[0]
+			r.buf = r.buf[1:]
+			if h == 0 {
+				return s
+			}
+			if delta := int16(h >> 4); delta > 0 {
+				last += delta
+			} else {
+				id, n := binary.Varint(r.buf)
+				r.buf = r.buf[n:]
+				last = int16(id)
+			}
+			s[last] = r.value(h & 0x0f)
+		}
+	}
+	r.t.Fatalf("unexpected thrift type %d", typ)
+	return nil
+}
+
+func readParquetFooter(t *testing.T, file []byte) map[int16]any {
+	require.Equal(t, parquetMagic, string(file[:4]))
+	require.Equal(t, parquetMagic, string(file[len(file)-4:]))
+	n := binary.LittleEndian.Uint32(file[len(file)-8:])
+	r := &thriftReader{t: t, buf: file[len(file)-8-int(n) : len(file)-8]}
+	fm := r.value(thriftStructType).(map[int16]any)
+	assert.Empty(t, r.buf)
+	return fm
+}
+
+// readParquetColumn returns the PLAIN encoded values and the definition
+// levels of a column chunk.
+func readParquetColumn(t *testing.T, file []byte, chunk map[int16]any, optional bool) ([]byte, []byte) {
+	md := chunk[3].(map[int16]any)
+	r := &thriftReader{t: t, buf: file[md[9].(int64):]}
+	ph := r.value(thriftStructType).(map[int16]any)
+	data := r.buf[:ph[3].(int64)]
+	assert.Equal(t, md[6].(int64), int64(len(file)-len(r.buf)+len(data))-md[9].(int64), "chunk size")
+	if !optional {
+		return data, nil
+	}
+	n := binary.LittleEndian.Uint32(data)
+	return data[4+n:], data[4 : 4+n]
+}
+
+func TestWriteDumpsParquet(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := &Dump{Time: ts, Host: "cellar", Entries: []DumpEntry{
+		{Block: BlockCalculations, Index: 10, Name: "ID_WEB_Temperatur_TVL", Class: "temperature", Value: float32(30.1), Unit: "°C", Raw: 301},
+		{Block: BlockCalculations, Index: 80, Name: "ID_WEB_WP_BZ_akt", Class: "operation_mode", Value: "heating", Raw: 0},
+		{Block: BlockCalculations, Index: 44, Name: "ID_WEB_EVUin", Class: "onoff", Value: true, Raw: 1},
+		{Block: BlockCalculations, Index: 95, Name: "ID_WEB_ERROR_Time0", Class: "timestamp", Value: nil, Raw: 0},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, WriteDumpsParquet(&buf, d, d))
+	file := buf.Bytes()
+
+	fm := readParquetFooter(t, file)
+	assert.Equal(t, int64(8), fm[3], "num_rows")
+	schema := fm[2].([]any)
+	require.Len(t, schema, 11)
+	assert.Equal(t, int64(10), schema[0].(map[int16]any)[5], "num_children")
+	var names []string
+	for _, se := range schema[1:] {
+		names = append(names, string(se.(map[int16]any)[4].([]byte)))
+	}
+	assert.Equal(t, []string{"time", "host", "block", "index", "name", "class", "value", "text", "unit", "raw"}, names)
+	assert.Equal(t, int64(parquetTimestampMillis), schema[1].(map[int16]any)[6])
+
+	rgs := fm[4].([]any)
+	require.Len(t, rgs, 1)
+	chunks := rgs[0].(map[int16]any)[1].([]any)
+	require.Len(t, chunks, 10)
+
+	times, _ := readParquetColumn(t, file, chunks[0].(map[int16]any), false)
+	assert.Equal(t, uint64(ts.UnixMilli()), binary.LittleEndian.Uint64(times))
+	assert.Len(t, times, 8*8)
+
+	hosts, _ := readParquetColumn(t, file, chunks[1].(map[int16]any), false)
+	assert.Equal(t, []byte("\x06\x00\x00\x00cellar"), hosts[:10])
+
+	values, defs := readParquetColumn(t, file, chunks[6].(map[int16]any), true)
+	// 1, 0, 1, 0 repeated: one RLE run per value
+	assert.Equal(t, []byte{2, 1, 2, 0, 2, 1, 2, 0, 2, 1, 2, 0, 2, 1, 2, 0}, defs)
+	require.Len(t, values, 4*8)
+	assert.Equal(t, 30.1, math.Float64frombits(binary.LittleEndian.Uint64(values)))
+	assert.Equal(t, 1.0, math.Float64frombits(binary.LittleEndian.Uint64(values[8:])))
+
+	texts, _ := readParquetColumn(t, file, chunks[7].(map[int16]any), true)
+	assert.Equal(t, []byte("\x07\x00\x00\x00heating"), texts[:11])
+}
+
+// parquetRow is a row of WriteDumpsParquet as read by parquet-go.
+type parquetRow struct {
+	Time  int64    `parquet:"time"`
+	Host  string   `parquet:"host"`
+	Block string   `parquet:"block"`
+	Index int32    `parquet:"index"`
+	Name  string   `parquet:"name"`
+	Class string   `parquet:"class"`
+	Value *float64 `parquet:"value,optional"`
+	Text  *string  `parquet:"text,optional"`
+	Unit  string   `parquet:"unit"`
+	Raw   int64    `parquet:"raw"`
+}
+
+// TestWriteDumpsParquet_Reader reads the files with parquet-go, an
+// independent implementation of the format.
+func TestWriteDumpsParquet_Reader(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := &Dump{Time: ts, Host: "cellar", Entries: []DumpEntry{
+		{Block: BlockCalculations, Index: 10, Name: "ID_WEB_Temperatur_TVL", Class: "temperature", Value: float32(30.1), Unit: "°C", Raw: 301},
+		{Block: BlockCalculations, Index: 80, Name: "ID_WEB_WP_BZ_akt", Class: "operation_mode", Value: "heating", Raw: 0},
+		{Block: BlockCalculations, Index: 44, Name: "ID_WEB_EVUin", Class: "onoff", Value: true, Raw: 1},
+		{Block: BlockCalculations, Index: 95, Name: "ID_WEB_ERROR_Time0", Class: "timestamp", Value: nil, Raw: 0},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, WriteDumpsParquet(&buf, d))
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, `message schema {
+	required int64 time (TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS));
+	required binary host (STRING);
+	required binary block (STRING);
+	required int32 index;
+	required binary name (STRING);
+	required binary class (STRING);
+	optional double value;
+	optional binary text (STRING);
+	required binary unit (STRING);
+	required int64 raw;
+}`, f.Schema().String())
+
+	rows, err := parquet.Read[parquetRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	value, text := 30.1, "heating"
+	one := 1.0
+	assert.Equal(t, []parquetRow{
+		{Time: ts.UnixMilli(), Host: "cellar", Block: BlockCalculations, Index: 10, Name: "ID_WEB_Temperatur_TVL", Class: "temperature", Value: &value, Unit: "°C", Raw: 301},
+		{Time: ts.UnixMilli(), Host: "cellar", Block: BlockCalculations, Index: 80, Name: "ID_WEB_WP_BZ_akt", Class: "operation_mode", Text: &text},
+		{Time: ts.UnixMilli(), Host: "cellar", Block: BlockCalculations, Index: 44, Name: "ID_WEB_EVUin", Class: "onoff", Value: &one, Raw: 1},
+		{Time: ts.UnixMilli(), Host: "cellar", Block: BlockCalculations, Index: 95, Name: "ID_WEB_ERROR_Time0", Class: "timestamp"},
+	}, rows)
+}
+
+func TestParquetSink(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewParquetSink(ParquetSinkOptions{Dir: dir, Rotate: time.Hour, RowGroupRows: 2})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pm := DataTypeMap{CalcOutdoorTemperature: NewCalculationsMap()[CalcOutdoorTemperature]}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.Write(ctx, "cellar", ts.Add(time.Duration(i)*time.Minute), BlockCalculations, pm))
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.parquet"))
+	assert.Empty(t, files, "incomplete files are hidden")
+
+	require.NoError(t, s.Write(ctx, "cellar", ts.Add(time.Hour), BlockCalculations, pm))
+	require.NoError(t, s.Close())
+
+	files, _ = filepath.Glob(filepath.Join(dir, "*"))
+	require.Equal(t, []string{
+		filepath.Join(dir, "luxtronik-20240102-030405.parquet"),
+		filepath.Join(dir, "luxtronik-20240102-040405.parquet"),
+	}, files)
+	file, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	fm := readParquetFooter(t, file)
+	assert.Equal(t, int64(3), fm[3])
+	assert.Len(t, fm[4], 2, "row groups of two and one rows")
+
+	rows, err := parquet.Read[parquetRow](bytes.NewReader(file), int64(len(file)))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, ts.Add(2*time.Minute).UnixMilli(), rows[2].Time)
+	assert.Equal(t, "ID_WEB_Temperatur_TA", rows[2].Name)
+}
@@ -0,0 +1,500 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Block names label the three data blocks the controller delivers.
+const (
+	BlockParameters   = "parameters"
+	BlockCalculations = "calculations"
+	BlockVisibilities = "visibilities"
+)
+
+// Sink receives the decoded values of a block after every poll cycle.
+type Sink interface {
+	Write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error
+	Close() error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error
+
+func (f SinkFunc) Write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	return f(ctx, host, ts, block, pm)
+}
+
+func (f SinkFunc) Close() error { return nil }
+
+type PollerOptions struct {
+	Interval time.Duration
+	// Blocks selects which blocks get read on each cycle. Defaults to all
+	// three blocks.
+	Blocks []string
+	// Sensors adds the readings of external room sensors as BlockSensors to
+	// every heat pump.
+	Sensors *RoomSensors
+	// Prices adds the current and the day average price as BlockPrices to
+	// every heat pump.
+	Prices *PriceFeed
+	// Derived adds the values computed from the calculations as
+	// BlockDerived, e.g. the delta-T. Requires the calculations in Blocks.
+	Derived *Deriver
+	// ReadTimeout is the budget to connect to a heat pump and read all its
+	// blocks. Exceeding it cancels the read and closes the connection.
+	// Defaults to the interval.
+	ReadTimeout time.Duration
+	// SinkTimeout is the budget of a single sink write, e.g. an export or a
+	// parameter write. The context passed to the sink gets cancelled and the
+	// cycle continues without waiting for it. A sink which is still busy
+	// gets skipped in the next cycles. Sinks which use the client, like the
+	// TariffShifter, should finish well within the budget. Defaults to the
+	// interval.
+	SinkTimeout time.Duration
+	// HideInvisible leaves out the values the controller declares
+	// invisible, see DataTypeMap.ApplyVisibilities. The visibilities get
+	// read even if they are not in Blocks.
+	HideInvisible bool
+	// Connections opens up to three connections per heat pump to read the
+	// blocks concurrently, which shortens a poll on slow links. Not every
+	// firmware tolerates this, after a failed parallel read the poller falls
+	// back to a single connection for good. The default of one connection
+	// reads the blocks one after another.
+	Connections int
+	// RecentSize keeps the values of the last RecentSize polls of every
+	// value in memory, e.g. for sparklines, see Poller.Recent. Zero disables
+	// it.
+	RecentSize int
+	Logger     *zap.Logger
+}
+
+// PollerStats counts the operations of a Poller since its creation.
+type PollerStats struct {
+	Polls    int // heat pump reads
+	Failures int // failed heat pump reads including timeouts
+	// ReadTimeouts counts the heat pump reads which exceeded ReadTimeout.
+	ReadTimeouts int
+	// SinkTimeouts counts the sink writes which exceeded SinkTimeout.
+	SinkTimeouts int
+	// SinkSkips counts the writes skipped because the sink was still busy.
+	SinkSkips int
+}
+
+// Poller reads the configured blocks from one or several heat pumps in a
+// fixed interval and hands the results to all sinks. Sinks receive the name
+// of the heat pump as host.
+type Poller struct {
+	opts    PollerOptions
+	targets []*pollTarget
+	sinks   []*pollSink
+	reads   []string // blocks to read, Blocks and the visibilities for HideInvisible
+	recent  *recentValues
+
+	mu    sync.Mutex
+	stats PollerStats
+	burst BurstStatus
+	wake  chan struct{} // interrupts the wait of Run
+}
+
+// pollSink remembers whether a write of the sink is still running after its
+// budget, busy holds a token while a write is in progress.
+type pollSink struct {
+	Sink
+	busy chan struct{}
+}
+
+type pollTarget struct {
+	source DataSource
+	// client is the source if it is a Client, its connection gets managed by
+	// the poller.
+	client *Client
+	// extra are the additional connections of parallel reads, see
+	// PollerOptions.Connections.
+	extra []*Client
+	maps  map[string]DataTypeMap
+	// firmware the maps of a client were selected for, see SelectMaps
+	firmware string
+}
+
+// NewPoller polls a single heat pump, usually a Client.
+func NewPoller(src DataSource, opts PollerOptions, sinks ...Sink) *Poller {
+	return newPoller([]DataSource{src}, opts, sinks)
+}
+
+// NewPoolPoller polls all heat pumps of the pool one after another within a
+// cycle.
+func NewPoolPoller(pool *ClientPool, opts PollerOptions, sinks ...Sink) *Poller {
+	sources := make([]DataSource, 0, pool.Len())
+	for _, c := range pool.Clients() {
+		sources = append(sources, c)
+	}
+	return newPoller(sources, opts, sinks)
+}
+
+func newPoller(sources []DataSource, opts PollerOptions, sinks []Sink) *Poller {
+	if opts.Interval < 1 {
+		opts.Interval = 30 * time.Second
+	}
+	if len(opts.Blocks) == 0 {
+		opts.Blocks = []string{BlockParameters, BlockCalculations, BlockVisibilities}
+	}
+	if opts.ReadTimeout < 1 {
+		opts.ReadTimeout = opts.Interval
+	}
+	if opts.SinkTimeout < 1 {
+		opts.SinkTimeout = opts.Interval
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+
+	p := &Poller{opts: opts, wake: make(chan struct{}, 1), reads: opts.Blocks}
+	if opts.HideInvisible && !slices.Contains(opts.Blocks, BlockVisibilities) {
+		p.reads = append([]string{BlockVisibilities}, opts.Blocks...)
+	}
+	if opts.RecentSize > 0 {
+		p.recent = newRecentValues(opts.RecentSize)
+	}
+	for _, s := range sinks {
+		p.sinks = append(p.sinks, &pollSink{Sink: s, busy: make(chan struct{}, 1)})
+	}
+	for _, src := range sources {
+		t := &pollTarget{source: src, maps: make(map[string]DataTypeMap, len(p.reads))}
+		newMap := newBlockMap
+		if ms, ok := src.(MapSource); ok {
+			newMap = ms.NewMap
+		}
+		for _, block := range p.reads {
+			if pm := newMap(block); pm != nil {
+				t.maps[block] = pm
+			}
+		}
+		if c, ok := src.(*Client); ok {
+			t.client = c
+			for i := 1; i < min(opts.Connections, len(t.maps)); i++ {
+				t.extra = append(t.extra, c.sibling())
+			}
+		}
+		p.targets = append(p.targets, t)
+	}
+	return p
+}
+
+// Map returns the DataTypeMap of a block of the first heat pump or nil if the
+// block is not polled. The maps are replaced once the firmware is known.
+func (p *Poller) Map(block string) DataTypeMap {
+	return p.targets[0].maps[block]
+}
+
+// Poll performs a single read cycle and forwards the values to all sinks.
+// A failed read closes the connection so that the next cycle reconnects.
+// Failing heat pumps do not keep the others from being polled.
+func (p *Poller) Poll(ctx context.Context) error {
+	var errs []error
+	for _, t := range p.targets {
+		if err := p.poll(ctx, t); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stats returns the counters of the poller.
+func (p *Poller) Stats() PollerStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+func (p *Poller) count(fn func(s *PollerStats)) {
+	p.mu.Lock()
+	fn(&p.stats)
+	p.mu.Unlock()
+}
+
+func (p *Poller) poll(ctx context.Context, t *pollTarget) error {
+	start := time.Now()
+	p.count(func(s *PollerStats) { s.Polls++ })
+	name := t.source.Name()
+	if t.client != nil {
+		if err := t.client.connect(ctx); err != nil {
+			p.count(func(s *PollerStats) { s.Failures++ })
+			return fmt.Errorf("Poller.Poll.Connect %s failed: %w", name, err)
+		}
+	}
+
+	ts := time.Now()
+	// all blocks are read before the sinks run, so that slow sinks do not
+	// eat up the read budget
+	deadline := start.Add(p.opts.ReadTimeout)
+	if t.client != nil {
+		_ = t.client.SetDeadline(deadline)
+		p.selectMaps(t)
+	}
+	if len(t.extra) > 0 {
+		if err := p.readParallel(ctx, t, deadline); err != nil {
+			return err
+		}
+	} else {
+		for _, block := range p.reads {
+			pm, ok := t.maps[block]
+			if !ok {
+				continue
+			}
+			err := p.read(ctx, t.source, block, pm)
+			if block == BlockCalculations && t.client != nil && p.selectMaps(t) {
+				// read again into the map of the firmware the frame came from
+				err = p.read(ctx, t.source, block, t.maps[block])
+			}
+			if err != nil {
+				return p.readFailed(t, block, err)
+			}
+		}
+	}
+	if t.client != nil {
+		_ = t.client.SetDeadline(time.Time{})
+	}
+
+	var errs []error
+	for _, block := range p.opts.Blocks {
+		pm, ok := t.maps[block]
+		if !ok {
+			continue
+		}
+		if p.opts.HideInvisible {
+			pm.ApplyVisibilities(t.maps[BlockVisibilities])
+			pm = pm.Visible()
+		}
+		errs = append(errs, p.write(ctx, name, ts, block, pm)...)
+	}
+	if calcs, ok := t.maps[BlockCalculations]; ok && p.opts.Derived != nil {
+		if pm := p.opts.Derived.Derive(name, calcs); len(pm) > 0 {
+			errs = append(errs, p.write(ctx, name, ts, BlockDerived, pm)...)
+		}
+	}
+	if p.opts.Sensors != nil {
+		if pm := p.opts.Sensors.Map(); len(pm) > 0 {
+			errs = append(errs, p.write(ctx, name, ts, BlockSensors, pm)...)
+		}
+	}
+	if p.opts.Prices != nil {
+		if pm := p.opts.Prices.Map(ts); len(pm) > 0 {
+			errs = append(errs, p.write(ctx, name, ts, BlockPrices, pm)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Poller) write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) (errs []error) {
+	if p.recent != nil {
+		p.recent.add(host, ts, block, pm)
+	}
+	for _, s := range p.sinks {
+		if err := p.writeSink(ctx, s, host, ts, block, pm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// writeSink runs the write within the sink budget. A sink which does not
+// return in time keeps working on a copy of the values while the cycle
+// continues.
+func (p *Poller) writeSink(ctx context.Context, s *pollSink, host string, ts time.Time, block string, pm DataTypeMap) error {
+	select {
+	case s.busy <- struct{}{}:
+	default:
+		p.count(func(s *PollerStats) { s.SinkSkips++ })
+		return fmt.Errorf("Poller sink %T skipped %s of %s: previous write still running", s.Sink, block, host)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.opts.SinkTimeout)
+	pm = pm.Clone()
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-s.busy }()
+		defer cancel()
+		done <- s.Write(ctx, host, ts, block, pm)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			p.count(func(s *PollerStats) { s.SinkTimeouts++ })
+			p.opts.Logger.Warn("sink write exceeded its budget", zap.String("host", host),
+				zap.String("block", block), zap.String("sink", fmt.Sprintf("%T", s.Sink)),
+				zap.Duration("budget", p.opts.SinkTimeout))
+		}
+		return fmt.Errorf("Poller sink %T write %s of %s: %w", s.Sink, block, host, ctx.Err())
+	}
+}
+
+// selectMaps replaces the maps of the target by those of the firmware of the
+// heat pump once it is known or has changed, see SelectMaps. The client
+// learns the firmware with the calculations, so the maps of the catalog are
+// used until then. It reports whether the maps were replaced.
+func (p *Poller) selectMaps(t *pollTarget) bool {
+	firmware := t.client.Firmware()
+	if firmware == "" || firmware == t.firmware {
+		return false
+	}
+	t.firmware = firmware
+	maps, err := SelectMaps(firmware)
+	if err != nil {
+		p.opts.Logger.Warn("no maps for the firmware, keeping the catalog", zap.String("host", t.source.Name()),
+			zap.String("firmware", firmware), zap.Error(err))
+		return false
+	}
+	for block := range t.maps {
+		if pm, ok := maps[block]; ok {
+			t.maps[block] = pm
+		}
+	}
+	p.opts.Logger.Info("maps selected for the firmware", zap.String("host", t.source.Name()),
+		zap.String("firmware", firmware))
+	return true
+}
+
+// readFailed closes the connections of the target and counts the failure.
+func (p *Poller) readFailed(t *pollTarget, block string, err error) error {
+	_ = t.source.Close()
+	for _, c := range t.extra {
+		_ = c.Close()
+	}
+	p.count(func(s *PollerStats) { s.Failures++ })
+	var cErr *ConnectionError
+	if errors.As(err, &cErr) && cErr.Timeout() {
+		p.count(func(s *PollerStats) { s.ReadTimeouts++ })
+		p.opts.Logger.Warn("heat pump read exceeded its budget", zap.String("host", t.source.Name()),
+			zap.String("block", block), zap.Duration("budget", p.opts.ReadTimeout))
+	}
+	return fmt.Errorf("Poller.Poll.read %s of %s failed: %w", block, t.source.Name(), err)
+}
+
+// readParallel reads the blocks over all connections of the target. The
+// calculations stay on the main client which tracks the firmware. After a
+// failure the target falls back to the main client.
+func (p *Poller) readParallel(ctx context.Context, t *pollTarget, deadline time.Time) (err error) {
+	conns := append([]*Client{t.client}, t.extra...)
+	assigned := make([][]string, len(conns))
+	next := 1
+	for _, block := range p.reads {
+		if _, ok := t.maps[block]; !ok {
+			continue
+		}
+		i := 0
+		if block != BlockCalculations && next < len(conns) {
+			i = next
+			next++
+		}
+		assigned[i] = append(assigned[i], block)
+	}
+	defer func() {
+		if err != nil {
+			p.opts.Logger.Warn("parallel read failed, falling back to a single connection",
+				zap.String("host", t.client.Name()), zap.Error(err))
+			t.extra = nil
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		failures = make([]error, len(conns))
+		blocks   = make([]string, len(conns))
+	)
+	for i, c := range conns {
+		if len(assigned[i]) == 0 {
+			continue
+		}
+		if err := c.connect(ctx); err != nil {
+			return p.readFailed(t, assigned[i][0], err)
+		}
+		_ = c.SetDeadline(deadline)
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			for _, block := range assigned[i] {
+				if err := p.read(ctx, c, block, t.maps[block]); err != nil {
+					failures[i], blocks[i] = err, block
+					return
+				}
+			}
+			_ = c.SetDeadline(time.Time{})
+		}(i, c)
+	}
+	wg.Wait()
+	for i, err := range failures {
+		if err != nil {
+			return p.readFailed(t, blocks[i], err)
+		}
+	}
+	return nil
+}
+
+func (p *Poller) read(ctx context.Context, src DataSource, block string, pm DataTypeMap) error {
+	cmd, ok := blockCommands[block]
+	if !ok {
+		return fmt.Errorf("unknown block %q", block)
+	}
+	if c, ok := src.(*Client); ok {
+		// decodes with the options of the client, e.g. TolerantFrames
+		return c.readFromHeatPump(ctx, pm, cmd, 0)
+	}
+	raw, err := src.ReadRaw(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := pm.SetRawValues(raw); err != nil {
+		return &ProtocolError{Cmd: cmd, Index: -1, Err: err}
+	}
+	return nil
+}
+
+// Run polls until the context gets cancelled. Errors are logged and do not
+// stop the loop, the counters of Stats are logged at the end. The polls start
+// every interval, or every burst interval during a Burst.
+func (p *Poller) Run(ctx context.Context) error {
+	for {
+		start := time.Now()
+		if err := p.Poll(ctx); err != nil {
+			p.opts.Logger.Error("poll failed", zap.Error(err))
+		}
+		timer := time.NewTimer(time.Until(start.Add(p.interval())))
+		select {
+		case <-p.wake:
+		case <-ctx.Done():
+			timer.Stop()
+			st := p.Stats()
+			p.opts.Logger.Info("poller stopped", zap.Int("polls", st.Polls), zap.Int("failures", st.Failures),
+				zap.Int("read_timeouts", st.ReadTimeouts), zap.Int("sink_timeouts", st.SinkTimeouts),
+				zap.Int("sink_skips", st.SinkSkips))
+			return nil
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// Close closes all sinks and the sources, e.g. the client connections.
+func (p *Poller) Close() error {
+	var errs []error
+	for _, s := range p.sinks {
+		errs = append(errs, s.Close())
+	}
+	for _, t := range p.targets {
+		errs = append(errs, t.source.Close())
+		for _, c := range t.extra {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+package luxtronik
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoller_SinkTimeout(t *testing.T) {
+	hp := newMockHeatPump(t)
+	c := MustNewClient(hp.addr(), Options{})
+
+	release := make(chan struct{})
+	var calls int
+	stuck := SinkFunc(func(ctx context.Context, _ string, _ time.Time, _ string, _ DataTypeMap) error {
+		calls++
+		<-release // ignores the context like a hanging export
+		return nil
+	})
+	var fast int
+	p := NewPoller(c, PollerOptions{
+		Blocks:      []string{BlockCalculations},
+		SinkTimeout: 20 * time.Millisecond,
+	}, stuck, SinkFunc(func(context.Context, string, time.Time, string, DataTypeMap) error {
+		fast++
+		return nil
+	}))
+	defer p.Close()
+
+	start := time.Now()
+	err := p.Poll(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	err = p.Poll(context.Background())
+	assert.ErrorContains(t, err, "previous write still running")
+	close(release)
+
+	assert.Equal(t, 2, fast, "other sinks keep getting the values")
+	assert.Equal(t, PollerStats{Polls: 2, SinkTimeouts: 1, SinkSkips: 1}, p.Stats())
+	require.Eventually(t, func() bool {
+		return p.Poll(context.Background()) == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPoller_ReadTimeout(t *testing.T) {
+	// a controller which accepts connections but never answers
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	p := NewPoller(MustNewClient(ln.Addr().String(), Options{}), PollerOptions{
+		Blocks:      []string{BlockCalculations},
+		ReadTimeout: 50 * time.Millisecond,
+	})
+	defer p.Close()
+
+	err = p.Poll(context.Background())
+	var cErr *ConnectionError
+	require.ErrorAs(t, err, &cErr)
+	assert.True(t, cErr.Timeout())
+	assert.Equal(t, PollerStats{Polls: 1, Failures: 1, ReadTimeouts: 1}, p.Stats())
+}
+
+func TestPoller_Connections(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.parameters[1] = 15
+	hp.calculations[10] = 215
+	hp.visibilities[0] = 1
+
+	got := map[string]any{}
+	var mu sync.Mutex
+	sink := SinkFunc(func(_ context.Context, _ string, _ time.Time, block string, pm DataTypeMap) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got[block] = pm[map[string]int{
+			BlockParameters:   1,
+			BlockCalculations: 10,
+			BlockVisibilities: 0,
+		}[block]].FromHeatPump()
+		return nil
+	})
+	p := NewPoller(MustNewClient(hp.addr(), Options{}), PollerOptions{Connections: 3}, sink)
+	defer p.Close()
+	require.Len(t, p.targets[0].extra, 2)
+
+	require.NoError(t, p.Poll(context.Background()))
+	assert.Equal(t, map[string]any{
+		BlockParameters:   float32(1.5),
+		BlockCalculations: float32(21.5),
+		BlockVisibilities: uint32(1),
+	}, got)
+
+	// a connection dropped by the controller ends the parallel reads
+	require.NoError(t, p.targets[0].extra[0].conn.Close())
+	require.Error(t, p.Poll(context.Background()))
+	assert.Empty(t, p.targets[0].extra)
+
+	hp.mu.Lock()
+	hp.calculations[10] = 220
+	hp.mu.Unlock()
+	require.NoError(t, p.Poll(context.Background()))
+	assert.Equal(t, float32(22), got[BlockCalculations])
+	assert.Equal(t, PollerStats{Polls: 3, Failures: 1}, p.Stats())
+}
@@ -0,0 +1,116 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ClientPool manages the clients of several heat pumps. Each client is
+// addressed by its alias, see Client.Name.
+type ClientPool struct {
+	clients []*Client
+}
+
+// ParsePoolAddr splits addresses of the form "alias=host:port". Without an
+// alias the host is used, without a port DefaultPort, see NormalizeAddr.
+// Invalid addresses are returned as they are and rejected by NewClient.
+func ParsePoolAddr(s string) (alias, hostPort string) {
+	alias, hostPort, ok := strings.Cut(s, "=")
+	if !ok {
+		alias, hostPort = "", s
+	}
+	if addr, err := NormalizeAddr(hostPort); err == nil {
+		hostPort = addr
+	}
+	return alias, hostPort
+}
+
+// NewClientPool creates one client per address, see ParsePoolAddr. The
+// options apply to all clients, their Alias gets replaced.
+func NewClientPool(addrs []string, opts Options) (*ClientPool, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("NewClientPool requires at least one address")
+	}
+	p := &ClientPool{}
+	seen := map[string]bool{}
+	for _, addr := range addrs {
+		alias, hostPort := ParsePoolAddr(addr)
+		o := opts
+		o.Alias = alias
+		c, err := NewClient(hostPort, o)
+		if err != nil {
+			return nil, fmt.Errorf("NewClientPool %q: %w", addr, err)
+		}
+		if seen[c.Name()] {
+			return nil, fmt.Errorf("NewClientPool duplicate heat pump %q", c.Name())
+		}
+		seen[c.Name()] = true
+		p.clients = append(p.clients, c)
+	}
+	return p, nil
+}
+
+// Clients returns the clients in the order of their addresses.
+func (p *ClientPool) Clients() []*Client {
+	return p.clients
+}
+
+func (p *ClientPool) Len() int {
+	return len(p.clients)
+}
+
+// Client returns the client by its name.
+func (p *ClientPool) Client(name string) (*Client, bool) {
+	for _, c := range p.clients {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Select returns a pool with the named clients only. No names select all.
+func (p *ClientPool) Select(names ...string) (*ClientPool, error) {
+	if len(names) == 0 {
+		return p, nil
+	}
+	sel := &ClientPool{}
+	for _, name := range names {
+		c, ok := p.Client(name)
+		if !ok {
+			return nil, fmt.Errorf("ClientPool.Select unknown heat pump %q", name)
+		}
+		sel.clients = append(sel.clients, c)
+	}
+	return sel, nil
+}
+
+// Each calls fn concurrently for every client. The errors get prefixed with
+// the name of the heat pump and joined.
+func (p *ClientPool) Each(ctx context.Context, fn func(ctx context.Context, c *Client) error) error {
+	errs := make([]error, len(p.clients))
+	var wg sync.WaitGroup
+	for i, c := range p.clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			if err := fn(ctx, c); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", c.Name(), err)
+			}
+		}(i, c)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Close closes all client connections.
+func (p *ClientPool) Close() error {
+	var errs []error
+	for _, c := range p.clients {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
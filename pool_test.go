@@ -0,0 +1,61 @@
+package luxtronik
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePoolAddr(t *testing.T) {
+	tests := []struct {
+		in, alias, hostPort string
+	}{
+		{"192.168.0.12", "", "192.168.0.12:8889"},
+		{"192.168.0.12:9000", "", "192.168.0.12:9000"},
+		{"cellar=192.168.0.12", "cellar", "192.168.0.12:8889"},
+		{"garage=[fe80::1]:8889", "garage", "[fe80::1]:8889"},
+		{"garage=[fe80::1]", "garage", "[fe80::1]:8889"},
+		{"attic=heatpump.local", "attic", "heatpump.local:8889"},
+		{"attic=heat pump", "attic", "heat pump"},
+	}
+	for _, tt := range tests {
+		alias, hostPort := ParsePoolAddr(tt.in)
+		assert.Equal(t, tt.alias, alias, tt.in)
+		assert.Equal(t, tt.hostPort, hostPort, tt.in)
+	}
+}
+
+func TestClientPool(t *testing.T) {
+	a, b := newMockHeatPump(t), newMockHeatPump(t)
+	a.calculations[15] = 10
+	b.calculations[15] = 20
+
+	_, err := NewClientPool([]string{"hp=" + a.addr(), "hp=" + b.addr()}, Options{})
+	assert.ErrorContains(t, err, `duplicate heat pump "hp"`)
+
+	pool, err := NewClientPool([]string{"cellar=" + a.addr(), "garage=" + b.addr()}, Options{})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	sel, err := pool.Select("garage")
+	require.NoError(t, err)
+	assert.Equal(t, 1, sel.Len())
+	_, err = pool.Select("attic")
+	assert.Error(t, err)
+
+	var mu sync.Mutex
+	got := map[string]uint32{}
+	sink := SinkFunc(func(_ context.Context, host string, _ time.Time, _ string, pm DataTypeMap) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got[host] = pm[15].RawValue()
+		return nil
+	})
+	p := NewPoolPoller(pool, PollerOptions{Blocks: []string{BlockCalculations}}, sink)
+	require.NoError(t, p.Poll(context.Background()))
+	assert.Equal(t, map[string]uint32{"cellar": 10, "garage": 20}, got)
+}
@@ -0,0 +1,370 @@
+package luxtronik
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+)
+
+type PostgresOptions struct {
+	// DB is opened with a Postgres driver, e.g. "pgx" of
+	// github.com/jackc/pgx/v5/stdlib.
+	DB *sql.DB
+	// Table of the values, optionally with schema, defaults to
+	// luxtronik_values.
+	Table string
+	// Timescale turns the table into a hypertable of TimescaleDB, the
+	// extension must be available.
+	Timescale bool
+	// ChunkInterval of the hypertable, defaults to 7 days.
+	ChunkInterval time.Duration
+	// ChangesOnly stores only the values which changed since the previous
+	// poll, hiding changes within the Deadbands.
+	ChangesOnly bool
+	Deadbands   Deadbands
+	// Retention deletes older rows hourly, zero keeps them forever.
+	Retention time.Duration
+
+	// BatchSize triggers a flush once that many rows are buffered.
+	BatchSize int
+	// FlushInterval flushes the buffered rows periodically.
+	FlushInterval time.Duration
+	// Spool buffers the rows of failed flushes on disk instead of in
+	// memory, they are inserted in order once the database is back.
+	Spool  *Spool
+	Logger *zap.Logger
+}
+
+// postgresRow is a value as stored in the table. Value holds numbers and
+// booleans in metric units, Text everything else, like InfluxSink. The
+// fields are exported for the JSON records of the Spool.
+type postgresRow struct {
+	Time  time.Time
+	Host  string
+	Block string
+	Index int
+	Name  string
+	Class string
+	Raw   uint32
+	Value sql.NullFloat64
+	Text  sql.NullString
+}
+
+// postgresColumns of the table in the order of the inserts.
+const postgresColumns = "time, host, block, idx, name, class, raw, value, text"
+
+// postgresRowsPerInsert keeps an insert below the 65535 parameters of the
+// protocol.
+const postgresRowsPerInsert = 1000
+
+var postgresTableRe = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*\.)?[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PostgresSink stores all values in a PostgreSQL table, one row per value
+// and poll with the columns time, host, block, idx, name, class, raw, value
+// and text. Call Migrate before the first write.
+type PostgresSink struct {
+	opts    PostgresOptions
+	changes *ChangeDetector
+
+	mu   sync.Mutex
+	rows []postgresRow
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewPostgresSink(opts PostgresOptions) (*PostgresSink, error) {
+	if opts.DB == nil {
+		return nil, errors.New("NewPostgresSink needs a DB")
+	}
+	if opts.Table == "" {
+		opts.Table = "luxtronik_values"
+	}
+	if !postgresTableRe.MatchString(opts.Table) {
+		return nil, fmt.Errorf("NewPostgresSink invalid table %q", opts.Table)
+	}
+	if opts.ChunkInterval <= 0 {
+		opts.ChunkInterval = 7 * 24 * time.Hour
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 5000
+	}
+	if opts.FlushInterval < 1 {
+		opts.FlushInterval = 10 * time.Second
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	s := &PostgresSink{
+		opts:    opts,
+		changes: NewChangeDetector(opts.Deadbands),
+		done:    make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.flushLoop()
+	return s, nil
+}
+
+// postgresMigrations create the schema step by step, the applied ones are
+// recorded in the table luxtronik_schema_migrations. Append new steps, never
+// change released ones. %[1]s is the table, %[2]s its name without schema.
+var postgresMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS %[1]s (
+	time timestamptz NOT NULL,
+	host text NOT NULL,
+	block text NOT NULL,
+	idx integer NOT NULL,
+	name text NOT NULL,
+	class text NOT NULL,
+	raw bigint NOT NULL,
+	value double precision,
+	text text
+)`,
+	`CREATE INDEX IF NOT EXISTS %[2]s_name_time_idx ON %[1]s (name, time DESC)`,
+}
+
+// Migrate creates or updates the table and, with Timescale, the hypertable.
+func (s *PostgresSink) Migrate(ctx context.Context) error {
+	db := s.opts.DB
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS luxtronik_schema_migrations (
+	tbl text NOT NULL,
+	version integer NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (tbl, version)
+)`); err != nil {
+		return fmt.Errorf("PostgresSink.Migrate: %w", err)
+	}
+	var version int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM luxtronik_schema_migrations WHERE tbl = $1`, s.opts.Table).Scan(&version); err != nil {
+		return fmt.Errorf("PostgresSink.Migrate: %w", err)
+	}
+	if version > len(postgresMigrations) {
+		return fmt.Errorf("PostgresSink.Migrate %s has schema version %d, this version knows %d", s.opts.Table, version, len(postgresMigrations))
+	}
+	name := s.opts.Table[strings.LastIndex(s.opts.Table, ".")+1:]
+	for i := version; i < len(postgresMigrations); i++ {
+		err := s.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(postgresMigrations[i], s.opts.Table, name)); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO luxtronik_schema_migrations (tbl, version) VALUES ($1, $2)`, s.opts.Table, i+1)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("PostgresSink.Migrate %s to version %d: %w", s.opts.Table, i+1, err)
+		}
+		s.opts.Logger.Info("postgres schema migrated", zap.String("table", s.opts.Table), zap.Int("version", i+1))
+	}
+
+	if s.opts.Timescale {
+		if _, err := db.ExecContext(ctx, `CREATE EXTENSION IF NOT EXISTS timescaledb`); err != nil {
+			return fmt.Errorf("PostgresSink.Migrate: %w", err)
+		}
+		if _, err := db.ExecContext(ctx, `SELECT create_hypertable($1::regclass, 'time', chunk_time_interval => $2::interval, if_not_exists => TRUE, migrate_data => TRUE)`,
+			s.opts.Table, postgresInterval(s.opts.ChunkInterval)); err != nil {
+			return fmt.Errorf("PostgresSink.Migrate hypertable: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *PostgresSink) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.opts.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// postgresInterval formats d as interval, e.g. 604800 seconds.
+func postgresInterval(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + " seconds"
+}
+
+func (s *PostgresSink) flushLoop() {
+	defer s.wg.Done()
+	tkr := time.NewTicker(s.opts.FlushInterval)
+	defer tkr.Stop()
+	var pruned time.Time
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-tkr.C:
+			if err := s.Flush(context.Background()); err != nil {
+				s.opts.Logger.Error("postgres flush failed", zap.Error(err))
+			}
+			if s.opts.Retention > 0 && now.Sub(pruned) >= time.Hour {
+				pruned = now
+				if err := s.Prune(context.Background(), now.Add(-s.opts.Retention)); err != nil {
+					s.opts.Logger.Error("postgres retention failed", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+func (s *PostgresSink) Write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	s.mu.Lock()
+	pm.IterateSorted(func(idx int, b *Base) {
+		if _, changed := s.changes.Changed(host, block, idx, b); s.opts.ChangesOnly && !changed {
+			return
+		}
+		row := postgresRow{Time: ts, Host: host, Block: block, Index: idx, Name: b.luxtronikName, Class: b.class, Raw: b.reading.Raw}
+		switch v := DumpValue(b.canonical().FromHeatPump()).(type) {
+		case float32:
+			row.Value = sql.NullFloat64{Float64: widenFloat32(v), Valid: true}
+		case bool:
+			row.Value = sql.NullFloat64{Valid: true}
+			if v {
+				row.Value.Float64 = 1
+			}
+		case string:
+			row.Text = sql.NullString{String: v, Valid: true}
+		case nil:
+		default:
+			if f, err := cast.ToFloat64E(v); err == nil {
+				row.Value = sql.NullFloat64{Float64: f, Valid: true}
+			}
+		}
+		s.rows = append(s.rows, row)
+	})
+	full := len(s.rows) >= s.opts.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush inserts the buffered rows in one transaction. The rows are kept for
+// the next flush if it fails, up to ten batches, or in the Spool. Rows the
+// database rejects, see permanentSQL, are dropped.
+func (s *PostgresSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	rows := s.rows
+	s.rows = nil
+	s.mu.Unlock()
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if s.opts.Spool != nil {
+		record, err := json.Marshal(rows)
+		if err != nil {
+			return fmt.Errorf("PostgresSink.Flush: %w", err)
+		}
+		return s.opts.Spool.Deliver(record, func(record []byte) error {
+			var rows []postgresRow
+			if err := json.Unmarshal(record, &rows); err != nil {
+				s.opts.Logger.Error("postgres spool record dropped", zap.Error(err))
+				return nil
+			}
+			err := s.insertAll(ctx, rows)
+			if permanentSQL(err) {
+				return &PermanentError{Err: err}
+			}
+			return err
+		})
+	}
+	if err := s.insertAll(ctx, rows); err != nil {
+		if permanentSQL(err) {
+			s.opts.Logger.Error("postgres rows rejected, dropped", zap.Int("rows", len(rows)), zap.Error(err))
+			return fmt.Errorf("PostgresSink.Flush %d rows: %w", len(rows), err)
+		}
+		s.mu.Lock()
+		s.rows = append(rows, s.rows...)
+		if dropped := len(s.rows) - 10*s.opts.BatchSize; dropped > 0 {
+			s.rows = s.rows[dropped:]
+			s.opts.Logger.Warn("postgres rows dropped", zap.Int("rows", dropped))
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("PostgresSink.Flush %d rows: %w", len(rows), err)
+	}
+	return nil
+}
+
+// permanentSQL reports whether err is a data exception or an integrity
+// constraint violation, SQLSTATE class 22 or 23, which a retry does not fix.
+// The drivers lib/pq and pgx both expose the code with SQLState.
+func permanentSQL(err error) bool {
+	var se interface{ SQLState() string }
+	if !errors.As(err, &se) {
+		return false
+	}
+	state := se.SQLState()
+	return strings.HasPrefix(state, "22") || strings.HasPrefix(state, "23")
+}
+
+// insertAll inserts rows in one transaction.
+func (s *PostgresSink) insertAll(ctx context.Context, rows []postgresRow) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for start := 0; start < len(rows); start += postgresRowsPerInsert {
+			end := min(start+postgresRowsPerInsert, len(rows))
+			query, args := s.insert(rows[start:end])
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insert returns a multi-row insert of rows.
+func (s *PostgresSink) insert(rows []postgresRow) (string, []any) {
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + s.opts.Table + " (" + postgresColumns + ") VALUES ")
+	args := make([]any, 0, 9*len(rows))
+	for i, r := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := 1; j <= 9; j++ {
+			if j > 1 {
+				b.WriteString(", ")
+			}
+			b.WriteString("$" + strconv.Itoa(9*i+j))
+		}
+		b.WriteByte(')')
+		args = append(args, r.Time, r.Host, r.Block, r.Index, r.Name, r.Class, int64(r.Raw), r.Value, r.Text)
+	}
+	return b.String(), args
+}
+
+// Prune deletes the rows older than before, with Timescale by dropping
+// the chunks.
+func (s *PostgresSink) Prune(ctx context.Context, before time.Time) error {
+	var err error
+	if s.opts.Timescale {
+		_, err = s.opts.DB.ExecContext(ctx, `SELECT drop_chunks($1::regclass, older_than => $2::timestamptz)`, s.opts.Table, before)
+	} else {
+		_, err = s.opts.DB.ExecContext(ctx, "DELETE FROM "+s.opts.Table+" WHERE time < $1", before)
+	}
+	if err != nil {
+		return fmt.Errorf("PostgresSink.Prune %s: %w", s.opts.Table, err)
+	}
+	return nil
+}
+
+// Close flushes the buffered rows, the DB stays open.
+func (s *PostgresSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return s.Flush(context.Background())
+}
@@ -0,0 +1,279 @@
+package luxtronik
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDB is a database/sql driver which records the statements. Queries
+// return version as the single value, a statement containing fail errors,
+// with the SQLSTATE failState if set.
+type recordingDB struct {
+	mu        sync.Mutex
+	execs     []recordedExec
+	version   int64
+	fail      string
+	failState string
+}
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "exec failed with SQLSTATE " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+type recordedExec struct {
+	query string
+	args  []driver.NamedValue
+}
+
+var recordingDBs sync.Map
+
+func init() {
+	sql.Register("luxtronik-recording", recordingDriver{})
+}
+
+type recordingDriver struct{}
+
+func (recordingDriver) Open(name string) (driver.Conn, error) {
+	db, ok := recordingDBs.Load(name)
+	if !ok {
+		return nil, errors.New("unknown recording db")
+	}
+	return &recordingConn{db: db.(*recordingDB)}, nil
+}
+
+func newRecordingDB(t *testing.T) (*sql.DB, *recordingDB) {
+	rec := &recordingDB{}
+	recordingDBs.Store(t.Name(), rec)
+	db, err := sql.Open("luxtronik-recording", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, rec
+}
+
+func (r *recordingDB) queries() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var qs []string
+	for _, e := range r.execs {
+		qs = append(qs, strings.Join(strings.Fields(e.query), " "))
+	}
+	return qs
+}
+
+type recordingConn struct{ db *recordingDB }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *recordingConn) Close() error { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.db.mu.Lock()
+	c.db.execs = append(c.db.execs, recordedExec{query: "BEGIN"})
+	c.db.mu.Unlock()
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	c.db.mu.Lock()
+	c.db.execs = append(c.db.execs, recordedExec{query: "COMMIT"})
+	c.db.mu.Unlock()
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	c.db.mu.Lock()
+	c.db.execs = append(c.db.execs, recordedExec{query: "ROLLBACK"})
+	c.db.mu.Unlock()
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if c.db.fail != "" && strings.Contains(query, c.db.fail) {
+		if c.db.failState != "" {
+			return nil, sqlStateError(c.db.failState)
+		}
+		return nil, errors.New("exec failed")
+	}
+	c.db.execs = append(c.db.execs, recordedExec{query: query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.execs = append(c.db.execs, recordedExec{query: query, args: args})
+	return &recordingRows{value: c.db.version}, nil
+}
+
+type recordingRows struct {
+	value int64
+	done  bool
+}
+
+func (r *recordingRows) Columns() []string { return []string{"value"} }
+func (r *recordingRows) Close() error      { return nil }
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func TestPostgresSink_Migrate(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	s, err := NewPostgresSink(PostgresOptions{DB: db, Table: "heat.values", Timescale: true, ChunkInterval: 24 * time.Hour})
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Migrate(context.Background()))
+	q := rec.queries()
+	require.Len(t, q, 12)
+	assert.Contains(t, q[0], "CREATE TABLE IF NOT EXISTS luxtronik_schema_migrations")
+	assert.Contains(t, q[1], "SELECT COALESCE(MAX(version), 0) FROM luxtronik_schema_migrations")
+	assert.Equal(t, "BEGIN", q[2])
+	assert.Contains(t, q[3], "CREATE TABLE IF NOT EXISTS heat.values (")
+	assert.Contains(t, q[4], "INSERT INTO luxtronik_schema_migrations")
+	assert.Equal(t, "COMMIT", q[5])
+	assert.Equal(t, "CREATE INDEX IF NOT EXISTS values_name_time_idx ON heat.values (name, time DESC)", q[7])
+	assert.Equal(t, "CREATE EXTENSION IF NOT EXISTS timescaledb", q[10])
+	assert.Contains(t, q[11], "create_hypertable")
+	assert.Equal(t, "86400 seconds", rec.execs[11].args[1].Value)
+
+	// only the missing steps run, newer schemas are refused
+	rec.execs, rec.version = nil, 1
+	s.opts.Timescale = false
+	require.NoError(t, s.Migrate(context.Background()))
+	assert.Len(t, rec.queries(), 6)
+	rec.version = 99
+	assert.ErrorContains(t, s.Migrate(context.Background()), "schema version 99")
+}
+
+func TestPostgresSink_Write(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	s, err := NewPostgresSink(PostgresOptions{DB: db, ChangesOnly: true})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0)
+	pm := DataTypeMap{
+		CalcOutdoorTemperature: NewCalculationsMap()[CalcOutdoorTemperature],
+		CalcHeatpumpCode:       NewCalculationsMap()[CalcHeatpumpCode],
+	}
+	pm[CalcOutdoorTemperature].reading.Raw = 48
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.NoError(t, s.Flush(ctx))
+
+	q := rec.queries()
+	require.Equal(t, []string{"BEGIN", "INSERT INTO luxtronik_values (time, host, block, idx, name, class, raw, value, text) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", "COMMIT"}, q,
+		"unchanged values are left out")
+	args := rec.execs[1].args
+	assert.Equal(t, "cellar", args[1].Value)
+	assert.Equal(t, "ID_WEB_Temperatur_TA", args[4].Value)
+	assert.Equal(t, int64(48), args[6].Value)
+	assert.Equal(t, 4.8, args[7].Value)
+	assert.Nil(t, args[8].Value)
+
+	// failed rows are kept for the next flush
+	rec.execs, rec.fail = nil, "INSERT"
+	pm[CalcOutdoorTemperature].reading.Raw = 50
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	assert.Error(t, s.Flush(ctx))
+	rec.fail = ""
+	require.NoError(t, s.Close())
+	assert.Len(t, rec.execs, 5, "begin, rollback, begin, insert, commit without the failed insert")
+
+	require.NoError(t, s.Prune(ctx, ts))
+	assert.Equal(t, "DELETE FROM luxtronik_values WHERE time < $1", rec.execs[len(rec.execs)-1].query)
+
+	_, err = NewPostgresSink(PostgresOptions{DB: db, Table: "values; DROP TABLE x"})
+	assert.Error(t, err)
+}
+
+func TestPostgresSink_Spool(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	spool, err := OpenSpool(SpoolOptions{Dir: t.TempDir()})
+	require.NoError(t, err)
+	s, err := NewPostgresSink(PostgresOptions{DB: db, Spool: spool})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	pm := DataTypeMap{CalcOutdoorTemperature: NewCalculationsMap()[CalcOutdoorTemperature]}
+	pm[CalcOutdoorTemperature].reading.Raw = 48
+	rec.fail = "INSERT"
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.NoError(t, s.Flush(ctx), "spooled")
+	assert.Equal(t, 1, spool.Len())
+
+	rec.fail, rec.execs = "", nil
+	pm[CalcOutdoorTemperature].reading.Raw = 50
+	require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+	require.NoError(t, s.Close())
+	assert.Zero(t, spool.Len())
+	require.Equal(t, []string{"BEGIN", "INSERT", "COMMIT", "BEGIN", "INSERT", "COMMIT"}, firstWords(rec.queries()))
+	assert.Equal(t, ts, rec.execs[1].args[0].Value, "the spooled row comes first")
+	assert.Equal(t, 4.8, rec.execs[1].args[7].Value)
+	assert.Equal(t, 5.0, rec.execs[4].args[7].Value)
+}
+
+func TestPostgresSink_Rejected(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 0).UTC()
+	for _, spooled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("spooled=%t", spooled), func(t *testing.T) {
+			db, rec := newRecordingDB(t)
+			opts := PostgresOptions{DB: db}
+			if spooled {
+				spool, err := OpenSpool(SpoolOptions{Dir: t.TempDir()})
+				require.NoError(t, err)
+				opts.Spool = spool
+			}
+			s, err := NewPostgresSink(opts)
+			require.NoError(t, err)
+
+			pm := DataTypeMap{CalcOutdoorTemperature: NewCalculationsMap()[CalcOutdoorTemperature]}
+			pm[CalcOutdoorTemperature].reading.Raw = 48
+			rec.fail, rec.failState = "INSERT", "23505"
+			require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+			assert.ErrorContains(t, s.Flush(ctx), "23505")
+			if spooled {
+				assert.Zero(t, opts.Spool.Len(), "rejected rows are not spooled")
+			}
+
+			rec.fail, rec.execs = "", nil
+			pm[CalcOutdoorTemperature].reading.Raw = 50
+			require.NoError(t, s.Write(ctx, "cellar", ts, BlockCalculations, pm))
+			require.NoError(t, s.Close())
+			require.Equal(t, []string{"BEGIN", "INSERT", "COMMIT"}, firstWords(rec.queries()), "the rejected row is dropped")
+			assert.Equal(t, 5.0, rec.execs[1].args[7].Value)
+		})
+	}
+}
+
+func firstWords(qs []string) []string {
+	for i, q := range qs {
+		qs[i], _, _ = strings.Cut(q, " ")
+	}
+	return qs
+}
@@ -0,0 +1,357 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BlockPrices labels the current and the day average price which the Poller
+// forwards alongside the heat pump blocks, e.g. for alert rules like
+// Price_current > 0.4.
+const BlockPrices = "prices"
+
+// Indexes of the values of BlockPrices.
+const (
+	PriceCurrent    = 0 // Price_current
+	PriceDayAverage = 1 // Price_day_average
+)
+
+// Price is the electricity price of a period, usually one hour.
+type Price struct {
+	Start time.Time
+	End   time.Time
+	Value float64 // per kWh
+}
+
+type PriceFeedOptions struct {
+	// URL returns a JSON document containing a list of prices.
+	URL string
+	// Body switches to a POST request, e.g. for GraphQL APIs.
+	Body   string
+	Header http.Header
+	// Path is the dot separated path to the list of prices within the
+	// document, e.g. "data" or "data.viewer.homes.0.currentSubscription.
+	// priceInfo.today". Empty means the document is the list.
+	Path string
+	// StartField names the start of a period, either RFC 3339 or a unix
+	// timestamp in seconds or milliseconds. Defaults to "startsAt".
+	StartField string
+	// EndField is optional, without it a period lasts Resolution.
+	EndField   string
+	Resolution time.Duration
+	// ValueField names the price. Defaults to "total".
+	ValueField string
+	// Scale converts the price into per kWh, e.g. 0.001 for EUR/MWh.
+	// Defaults to 1.
+	Scale      float64
+	HTTPClient *http.Client
+	Logger     *zap.Logger
+}
+
+// PriceFeed pulls hourly electricity prices from an HTTP/JSON source like
+// the Tibber or aWATTar APIs. The field names are configurable so that most
+// day-ahead sources can be used without code changes.
+type PriceFeed struct {
+	opts PriceFeedOptions
+
+	mu     sync.RWMutex
+	prices []Price
+}
+
+func NewPriceFeed(opts PriceFeedOptions) (*PriceFeed, error) {
+	if _, err := url.ParseRequestURI(opts.URL); err != nil {
+		return nil, fmt.Errorf("NewPriceFeed failed to parse URL %q: %w", opts.URL, err)
+	}
+	if opts.StartField == "" {
+		opts.StartField = "startsAt"
+	}
+	if opts.ValueField == "" {
+		opts.ValueField = "total"
+	}
+	if opts.Resolution < 1 {
+		opts.Resolution = time.Hour
+	}
+	if opts.Scale == 0 {
+		opts.Scale = 1
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	return &PriceFeed{opts: opts}, nil
+}
+
+// Refresh fetches the prices and merges them with the known ones. Prices of
+// the same period get replaced.
+func (f *PriceFeed) Refresh(ctx context.Context) error {
+	method := http.MethodGet
+	var body io.Reader
+	if f.opts.Body != "" {
+		method = http.MethodPost
+		body = strings.NewReader(f.opts.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, f.opts.URL, body)
+	if err != nil {
+		return fmt.Errorf("PriceFeed.Refresh failed to create request: %w", err)
+	}
+	for k, v := range f.opts.Header {
+		req.Header[k] = v
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := f.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("PriceFeed.Refresh request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("PriceFeed.Refresh received status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	prices, err := f.parse(resp.Body)
+	if err != nil {
+		return fmt.Errorf("PriceFeed.Refresh: %w", err)
+	}
+	f.merge(prices)
+	return nil
+}
+
+// Run refreshes the prices in the given interval until the context gets
+// cancelled. Errors are logged.
+func (f *PriceFeed) Run(ctx context.Context, interval time.Duration) {
+	tkr := time.NewTicker(interval)
+	defer tkr.Stop()
+	for {
+		if err := f.Refresh(ctx); err != nil {
+			f.opts.Logger.Error("price refresh failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tkr.C:
+		}
+	}
+}
+
+func (f *PriceFeed) parse(r io.Reader) ([]Price, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	doc, err := jsonPath(doc, f.opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := doc.([]any)
+	if !ok {
+		return nil, fmt.Errorf("path %q is not a list", f.opts.Path)
+	}
+
+	prices := make([]Price, 0, len(list))
+	for i, item := range list {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("price %d is not an object", i)
+		}
+		start, err := parsePriceTime(obj[f.opts.StartField])
+		if err != nil {
+			return nil, fmt.Errorf("price %d field %q: %w", i, f.opts.StartField, err)
+		}
+		end := start.Add(f.opts.Resolution)
+		if f.opts.EndField != "" {
+			if end, err = parsePriceTime(obj[f.opts.EndField]); err != nil {
+				return nil, fmt.Errorf("price %d field %q: %w", i, f.opts.EndField, err)
+			}
+		}
+		n, ok := obj[f.opts.ValueField].(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("price %d field %q is not a number", i, f.opts.ValueField)
+		}
+		v, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("price %d field %q: %w", i, f.opts.ValueField, err)
+		}
+		prices = append(prices, Price{Start: start, End: end, Value: v * f.opts.Scale})
+	}
+	return prices, nil
+}
+
+// jsonPath walks the dot separated path through a decoded JSON document,
+// numeric segments index lists.
+func jsonPath(doc any, path string) (any, error) {
+	if path == "" {
+		return doc, nil
+	}
+	for _, seg := range strings.Split(path, ".") {
+		switch v := doc.(type) {
+		case map[string]any:
+			doc = v[seg]
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("invalid index %q in path %q", seg, path)
+			}
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("path %q not found", path)
+		}
+	}
+	return doc, nil
+}
+
+// parsePriceTime accepts RFC 3339 strings and unix timestamps. Timestamps
+// above 1e11 are taken as milliseconds.
+func parsePriceTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case string:
+		return time.Parse(time.RFC3339, t)
+	case json.Number:
+		n, err := t.Int64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		if n > 1e11 {
+			return time.UnixMilli(n), nil
+		}
+		return time.Unix(n, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported time %v", v)
+}
+
+func (f *PriceFeed) merge(prices []Price) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	byStart := make(map[int64]Price, len(f.prices)+len(prices))
+	for _, p := range f.prices {
+		byStart[p.Start.Unix()] = p
+	}
+	for _, p := range prices {
+		byStart[p.Start.Unix()] = p
+	}
+	// periods older than two days are not needed anymore
+	horizon := time.Now().Add(-48 * time.Hour)
+	f.prices = f.prices[:0]
+	for _, p := range byStart {
+		if p.End.After(horizon) {
+			f.prices = append(f.prices, p)
+		}
+	}
+	sort.Slice(f.prices, func(i, j int) bool {
+		return f.prices[i].Start.Before(f.prices[j].Start)
+	})
+}
+
+// Prices returns a copy of all known prices sorted by start.
+func (f *PriceFeed) Prices() []Price {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]Price(nil), f.prices...)
+}
+
+// At returns the price valid at t.
+func (f *PriceFeed) At(t time.Time) (Price, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, p := range f.prices {
+		if !t.Before(p.Start) && t.Before(p.End) {
+			return p, true
+		}
+	}
+	return Price{}, false
+}
+
+// DayAverage returns the mean price of the calendar day of t in the location
+// of t.
+func (f *PriceFeed) DayAverage(t time.Time) (float64, bool) {
+	var sum float64
+	var n int
+	for _, p := range f.day(t) {
+		sum += p.Value
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+// IsCheapest reports whether the price at t is among the n cheapest periods
+// of its calendar day.
+func (f *PriceFeed) IsCheapest(t time.Time, n int) bool {
+	cur, ok := f.At(t)
+	if !ok {
+		return false
+	}
+	day := f.day(t)
+	sort.SliceStable(day, func(i, j int) bool { return day[i].Value < day[j].Value })
+	for i := 0; i < n && i < len(day); i++ {
+		if day[i].Start.Equal(cur.Start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Map returns the price at t and the day average as BlockPrices. Unknown
+// prices are left out.
+func (f *PriceFeed) Map(t time.Time) DataTypeMap {
+	pm := DataTypeMap{}
+	if p, ok := f.At(t); ok {
+		pm[PriceCurrent] = newPriceValue("Price_current", p.Value)
+	}
+	if avg, ok := f.DayAverage(t); ok {
+		pm[PriceDayAverage] = newPriceValue("Price_day_average", avg)
+	}
+	return pm
+}
+
+// newPriceValue stores the signed price per kWh with three decimals, the
+// precision of FromHeatPump.
+func newPriceValue(name string, v float64) *Base {
+	b := &Base{Definition: &Definition{
+		returnType:    reflect.Float32,
+		name:          "price",
+		class:         "price",
+		luxtronikName: name,
+		factor:        0.001,
+		signed:        true,
+	}}
+	b.reading.Raw = uint32(int32(math.Round(v * 1e3)))
+	return b
+}
+
+func (f *PriceFeed) day(t time.Time) []Price {
+	y, m, d := t.Date()
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var day []Price
+	for _, p := range f.prices {
+		if py, pm, pd := p.Start.In(t.Location()).Date(); py == y && pm == m && pd == d {
+			day = append(day, p)
+		}
+	}
+	return day
+}
@@ -0,0 +1,74 @@
+package luxtronik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceFeed_Refresh(t *testing.T) {
+	y, m, d := time.Now().Date()
+	start := time.Date(y, m, d+1, 10, 0, 0, 0, time.Local)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		// aWATTar style response with EUR/MWh and millisecond timestamps
+		_, _ = w.Write([]byte(`{"data":[
+			{"start_timestamp":` + strconv.FormatInt(start.UnixMilli(), 10) + `,"marketprice":120.5},
+			{"start_timestamp":` + strconv.FormatInt(start.Add(time.Hour).UnixMilli(), 10) + `,"marketprice":80}
+		]}`))
+	}))
+	defer srv.Close()
+
+	f, err := NewPriceFeed(PriceFeedOptions{
+		URL:        srv.URL,
+		Header:     http.Header{"Authorization": {"Bearer secret"}},
+		Path:       "data",
+		StartField: "start_timestamp",
+		ValueField: "marketprice",
+		Scale:      0.001,
+	})
+	require.NoError(t, err)
+	require.NoError(t, f.Refresh(context.Background()))
+
+	p, ok := f.At(start.Add(90 * time.Minute))
+	require.True(t, ok)
+	assert.InDelta(t, 0.08, p.Value, 1e-9)
+	assert.True(t, f.IsCheapest(start.Add(time.Hour), 1))
+	assert.False(t, f.IsCheapest(start, 1))
+
+	_, ok = f.At(start.Add(3 * time.Hour))
+	assert.False(t, ok)
+
+	pm := f.Map(start.Add(90 * time.Minute))
+	require.Len(t, pm, 2)
+	assert.Equal(t, "Price_current", pm[PriceCurrent].Name())
+	assert.Equal(t, float32(0.08), pm[PriceCurrent].FromHeatPump())
+	assert.Equal(t, float32(0.1), pm[PriceDayAverage].FromHeatPump(), "0.10025 with three decimals")
+	assert.Empty(t, f.Map(start.Add(-48*time.Hour)))
+}
+
+func TestPriceFeed_Alert(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	f, err := NewPriceFeed(PriceFeedOptions{URL: "http://localhost"})
+	require.NoError(t, err)
+	f.merge([]Price{
+		{Start: start, End: start.Add(time.Hour), Value: 0.25},
+		{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), Value: -0.012},
+	})
+
+	rule, err := ParseAlertRule("negative price: Price_current < 0")
+	require.NoError(t, err)
+	var events []AlertEvent
+	a := NewAlertEngine(AlertOptions{Rules: []AlertRule{rule}, Notify: func(e AlertEvent) { events = append(events, e) }})
+	for _, ts := range []time.Time{start, start.Add(time.Hour)} {
+		require.NoError(t, a.Write(context.Background(), "hp", ts, BlockPrices, f.Map(ts)))
+	}
+	require.Len(t, events, 1)
+	assert.InDelta(t, -0.012, events[0].Value, 1e-6)
+}
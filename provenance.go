@@ -0,0 +1,59 @@
+package luxtronik
+
+import (
+	"reflect"
+	"time"
+)
+
+// Provenance describes where a value comes from and how it got converted,
+// so that a displayed number can be traced back to the wire.
+type Provenance struct {
+	Block string `json:"block"`
+	Index int    `json:"index"`
+	// Name is the name of the controller, e.g. ID_WEB_Temperatur_TVL.
+	Name string `json:"name"`
+	Raw  uint32 `json:"raw"`
+	// Signed is set for raw values in two's complement.
+	Signed bool `json:"signed,omitempty"`
+	// Conversion is one of code, custom, duration, time, factor or none.
+	Conversion string  `json:"conversion"`
+	Factor     float32 `json:"factor,omitempty"`
+	// Type is the Go type of the converted value.
+	Type  string `json:"type"`
+	Class string `json:"class"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// Provenance returns the origin of b at index idx of block.
+func (b *Base) Provenance(block string, idx int) Provenance {
+	p := Provenance{
+		Block:      block,
+		Index:      idx,
+		Name:       b.luxtronikName,
+		Raw:        b.reading.Raw,
+		Signed:     b.Signed(),
+		Conversion: "none",
+		Type:       reflect.TypeOf(b.FromHeatPump()).String(),
+		Class:      b.class,
+		Unit:       b.Unit(),
+	}
+	switch {
+	case b.codes != nil:
+		p.Conversion = "code"
+	case b.customFromHP != nil:
+		p.Conversion = "custom"
+	case b.class == classTime:
+		p.Conversion = "time"
+	case isDuration(b.FromHeatPump()):
+		p.Conversion = "duration"
+	case b.scaled():
+		p.Conversion = "factor"
+		p.Factor = b.factor
+	}
+	return p
+}
+
+func isDuration(v any) bool {
+	_, ok := v.(time.Duration)
+	return ok
+}
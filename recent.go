@@ -0,0 +1,113 @@
+package luxtronik
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recentValues keeps the last values of every value in ring buffers, see
+// PollerOptions.RecentSize. It is safe for concurrent use.
+type recentValues struct {
+	size int
+
+	mu    sync.Mutex
+	rings map[string]*recentRing // keyed by host/block/name
+}
+
+type recentRing struct {
+	host, block string
+	base        *Base // converts the raw values
+	times       []time.Time
+	raws        []uint32
+	next        int
+}
+
+func newRecentValues(size int) *recentValues {
+	return &recentValues{size: size, rings: map[string]*recentRing{}}
+}
+
+func (r *recentValues) add(host string, ts time.Time, block string, pm DataTypeMap) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range pm {
+		key := host + "/" + block + "/" + b.luxtronikName
+		ring, ok := r.rings[key]
+		if !ok {
+			ring = &recentRing{host: host, block: block, base: b}
+			r.rings[key] = ring
+		}
+		if len(ring.raws) < r.size {
+			ring.times = append(ring.times, ts)
+			ring.raws = append(ring.raws, b.reading.Raw)
+			continue
+		}
+		ring.times[ring.next], ring.raws[ring.next] = ts, b.reading.Raw
+		ring.next = (ring.next + 1) % r.size
+	}
+}
+
+// series returns the rings with the luxtronik name, oldest point first.
+func (r *recentValues) series(host, name string) []HistorySeries {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var res []HistorySeries
+	for _, ring := range r.rings {
+		if !strings.EqualFold(ring.base.luxtronikName, name) || (host != "" && ring.host != host) {
+			continue
+		}
+		s := HistorySeries{Host: ring.host, Block: ring.block, Name: ring.base.luxtronikName}
+		s.Points = make([]HistoryPoint, 0, len(ring.raws))
+		for i := range ring.raws {
+			j := (ring.next + i) % len(ring.raws)
+			raw := ring.raws[j]
+			s.Points = append(s.Points, HistoryPoint{Time: ring.times[j], Raw: raw, Value: ring.base.FromHeatPumpRaw(raw)})
+		}
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Host != res[j].Host {
+			return res[i].Host < res[j].Host
+		}
+		return res[i].Block < res[j].Block
+	})
+	return res
+}
+
+// Recent returns the values of the last polls of all series with the
+// luxtronik name, oldest first. The name is matched case-insensitive, an
+// empty host matches all heat pumps. Returns nil without
+// PollerOptions.RecentSize.
+func (p *Poller) Recent(host, name string) []HistorySeries {
+	if p.recent == nil {
+		return nil
+	}
+	return p.recent.series(host, name)
+}
+
+// RecentHandler serves Poller.Recent as JSON on GET with the query
+// parameters name and host, e.g. ?name=ID_WEB_Temperatur_TA, for sparklines.
+func RecentHandler(p *Poller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "missing name", http.StatusBadRequest)
+			return
+		}
+		res := p.Recent(r.URL.Query().Get("host"), name)
+		if len(res) == 0 {
+			http.Error(w, "unknown name "+name, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
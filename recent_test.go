@@ -0,0 +1,48 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoller_Recent(t *testing.T) {
+	hp := newMockHeatPump(t)
+	p := NewPoller(MustNewClient(hp.addr(), Options{}), PollerOptions{
+		Blocks:     []string{BlockCalculations},
+		RecentSize: 3,
+	})
+	defer p.Close()
+
+	for i := 1; i <= 5; i++ {
+		hp.mu.Lock()
+		hp.calculations[CalcOutdoorTemperature] = uint32(i * 10)
+		hp.mu.Unlock()
+		require.NoError(t, p.Poll(context.Background()))
+	}
+
+	series := p.Recent("", "id_web_temperatur_ta")
+	require.Len(t, series, 1)
+	var values []any
+	for _, pt := range series[0].Points {
+		values = append(values, pt.Value)
+	}
+	assert.Equal(t, []any{float32(3), float32(4), float32(5)}, values, "oldest first, the first polls dropped")
+
+	srv := httptest.NewServer(RecentHandler(p))
+	defer srv.Close()
+	res, err := http.Get(srv.URL + "?name=ID_WEB_Temperatur_TA")
+	require.NoError(t, err)
+	var got []HistorySeries
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	res.Body.Close()
+	require.Len(t, got, 1)
+	assert.Len(t, got[0].Points, 3)
+
+	assert.Nil(t, NewPoller(MustNewClient(hp.addr(), Options{}), PollerOptions{}).Recent("", "ID_WEB_Temperatur_TA"))
+}
@@ -0,0 +1,296 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// DailySchedule fires every day at fixed wall clock times of a location.
+type DailySchedule struct {
+	// Times are the offsets from local midnight, e.g. 7h for 07:00.
+	Times []time.Duration
+	// Location defaults to time.Local.
+	Location *time.Location
+}
+
+// ParseDailySchedule parses clock times separated by commas or spaces like
+// "07:00,19:30".
+func ParseDailySchedule(s string, loc *time.Location) (DailySchedule, error) {
+	ds := DailySchedule{Location: loc}
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	if len(parts) == 0 {
+		return DailySchedule{}, errors.New("ParseDailySchedule requires at least one time")
+	}
+	for _, part := range parts {
+		at, err := parseClock(part)
+		if err != nil {
+			return DailySchedule{}, fmt.Errorf("ParseDailySchedule invalid time %q: %w", part, err)
+		}
+		ds.Times = append(ds.Times, at)
+	}
+	sort.Slice(ds.Times, func(i, j int) bool { return ds.Times[i] < ds.Times[j] })
+	return ds, nil
+}
+
+// Next returns the first scheduled time after t. The times are resolved on
+// the wall clock of each day instead of adding 24 hours, so a 07:00 report
+// stays at 07:00 when daylight saving time starts or ends. Times which do not
+// exist on a day, e.g. 02:30 when the clocks go forward, are shifted by the
+// gap like time.Date does.
+func (s DailySchedule) Next(t time.Time) time.Time {
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	lt := t.In(loc)
+	var next time.Time
+	for day := 0; day < 2 && next.IsZero(); day++ {
+		for _, at := range s.Times {
+			c := time.Date(lt.Year(), lt.Month(), lt.Day()+day,
+				int(at/time.Hour), int(at%time.Hour/time.Minute), 0, 0, loc)
+			if c.After(t) && (next.IsZero() || c.Before(next)) {
+				next = c
+			}
+		}
+	}
+	return next
+}
+
+// Summary aggregates the calculations of one heat pump between two reports.
+type Summary struct {
+	Report           string
+	Host             string
+	From             time.Time
+	To               time.Time
+	Samples          int
+	MinOutdoorTemp   float64
+	MaxOutdoorTemp   float64
+	MeanOutdoorTemp  float64
+	HeatingEnergy    float64 // kWh
+	HotWaterEnergy   float64 // kWh
+	HeatingRuntime   time.Duration
+	CompressorStarts uint32
+}
+
+type reportLocale struct {
+	decimal    string
+	layout     string
+	title      string
+	outdoor    string
+	heating    string
+	hotWater   string
+	runtime    string
+	starts     string
+	noSamples  string
+	hoursShort string
+}
+
+var reportLocales = map[string]reportLocale{
+	"en": {
+		decimal:    ".",
+		layout:     "2006-01-02 15:04",
+		title:      "Summary %s to %s",
+		outdoor:    "Outdoor temperature: min %s °C, mean %s °C, max %s °C",
+		heating:    "Heating: %s kWh",
+		hotWater:   "Hot water: %s kWh",
+		runtime:    "Heating runtime: %s %s",
+		starts:     "Compressor starts: %d",
+		noSamples:  "No values received",
+		hoursShort: "h",
+	},
+	"de": {
+		decimal:    ",",
+		layout:     "02.01.2006 15:04",
+		title:      "Zusammenfassung %s bis %s",
+		outdoor:    "Außentemperatur: min. %s °C, Mittel %s °C, max. %s °C",
+		heating:    "Heizung: %s kWh",
+		hotWater:   "Warmwasser: %s kWh",
+		runtime:    "Laufzeit Heizung: %s %s",
+		starts:     "Verdichterstarts: %d",
+		noSamples:  "Keine Werte empfangen",
+		hoursShort: "Std.",
+	},
+}
+
+// Format renders the summary as text in the language of locale, e.g. "de"
+// or "de-AT". Unknown locales fall back to English. Times are shown in the
+// location of From and To.
+func (s Summary) Format(locale string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(locale, "_", "-")), "-")
+	l, ok := reportLocales[lang]
+	if !ok {
+		l = reportLocales["en"]
+	}
+	num := func(v float64) string {
+		return strings.Replace(fmt.Sprintf("%.1f", v), ".", l.decimal, 1)
+	}
+
+	var sb strings.Builder
+	if s.Host != "" {
+		sb.WriteString(s.Host + ": ")
+	}
+	fmt.Fprintf(&sb, l.title+"\n", s.From.Format(l.layout), s.To.Format(l.layout))
+	if s.Samples == 0 {
+		sb.WriteString(l.noSamples + "\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, l.outdoor+"\n", num(s.MinOutdoorTemp), num(s.MeanOutdoorTemp), num(s.MaxOutdoorTemp))
+	fmt.Fprintf(&sb, l.heating+"\n", num(s.HeatingEnergy))
+	fmt.Fprintf(&sb, l.hotWater+"\n", num(s.HotWaterEnergy))
+	fmt.Fprintf(&sb, l.runtime+"\n", num(s.HeatingRuntime.Hours()), l.hoursShort)
+	fmt.Fprintf(&sb, l.starts+"\n", s.CompressorStarts)
+	return sb.String()
+}
+
+// ReportOptions configures a Reporter.
+type ReportOptions struct {
+	// Name labels the report, e.g. morning.
+	Name     string
+	Schedule DailySchedule
+	// Locale selects language and number format of the text, see
+	// Summary.Format.
+	Locale string
+	// Deliver receives the summary of every heat pump at the scheduled
+	// times. Defaults to logging the text at info level.
+	Deliver func(ctx context.Context, s Summary, text string) error
+	Logger  *zap.Logger
+}
+
+// Reporter is a Sink which aggregates the calculations of each heat pump and
+// delivers a summary at the scheduled times. The poll time decides which
+// period a sample belongs to, the summary is delivered with the first poll
+// after the scheduled time.
+type Reporter struct {
+	opts ReportOptions
+
+	mu      sync.Mutex
+	periods map[string]*reportPeriod
+}
+
+type reportPeriod struct {
+	from    time.Time
+	due     time.Time
+	first   *SeasonSample // baseline of the counters
+	last    *SeasonSample
+	samples int
+	tempSum float64
+	minTemp float64
+	maxTemp float64
+}
+
+// NewReporter creates the reporter, it needs at least one scheduled time.
+func NewReporter(opts ReportOptions) (*Reporter, error) {
+	if len(opts.Schedule.Times) == 0 {
+		return nil, fmt.Errorf("NewReporter %q: schedule without times", opts.Name)
+	}
+	if opts.Schedule.Location == nil {
+		opts.Schedule.Location = time.Local
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Deliver == nil {
+		logger := opts.Logger
+		opts.Deliver = func(_ context.Context, s Summary, text string) error {
+			logger.Info("summary", zap.String("report", s.Report), zap.String("host", s.Host), zap.String("text", text))
+			return nil
+		}
+	}
+	return &Reporter{opts: opts, periods: map[string]*reportPeriod{}}, nil
+}
+
+func (r *Reporter) Write(ctx context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+	if block != BlockCalculations {
+		return nil
+	}
+	sample := SeasonSampleFromCalculations(ts, pm)
+
+	r.mu.Lock()
+	p, ok := r.periods[host]
+	if !ok {
+		p = r.newPeriod(ts, ts, nil)
+		r.periods[host] = p
+	}
+	var due *Summary
+	if !ts.Before(p.due) {
+		s := r.summarize(host, p)
+		due = &s
+		p = r.newPeriod(p.due, ts, p.last)
+		r.periods[host] = p
+	}
+	p.add(sample)
+	r.mu.Unlock()
+
+	if due == nil {
+		return nil
+	}
+	if err := r.opts.Deliver(ctx, *due, due.Format(r.opts.Locale)); err != nil {
+		return fmt.Errorf("Reporter %q failed to deliver summary of %s: %w", r.opts.Name, host, err)
+	}
+	return nil
+}
+
+// newPeriod starts a period at from which ends at the next scheduled time
+// after ts, so missed report times are merged into a single period. The
+// counters continue from baseline.
+func (r *Reporter) newPeriod(from, ts time.Time, baseline *SeasonSample) *reportPeriod {
+	return &reportPeriod{
+		from:    from,
+		due:     r.opts.Schedule.Next(ts),
+		first:   baseline,
+		minTemp: math.Inf(1),
+		maxTemp: math.Inf(-1),
+	}
+}
+
+func (p *reportPeriod) add(s SeasonSample) {
+	if p.first == nil {
+		p.first = &s
+	}
+	p.last = &s
+	p.samples++
+	p.tempSum += s.OutdoorTemp
+	p.minTemp = math.Min(p.minTemp, s.OutdoorTemp)
+	p.maxTemp = math.Max(p.maxTemp, s.OutdoorTemp)
+}
+
+func (r *Reporter) summarize(host string, p *reportPeriod) Summary {
+	loc := r.opts.Schedule.Location
+	s := Summary{
+		Report:  r.opts.Name,
+		Host:    host,
+		From:    p.from.In(loc),
+		To:      p.due.In(loc),
+		Samples: p.samples,
+	}
+	if p.samples == 0 {
+		return s
+	}
+	s.MinOutdoorTemp, s.MaxOutdoorTemp = p.minTemp, p.maxTemp
+	s.MeanOutdoorTemp = p.tempSum / float64(p.samples)
+	// counter resets, e.g. after a controller update, are ignored
+	if d := p.last.HeatingEnergy - p.first.HeatingEnergy; d > 0 {
+		s.HeatingEnergy = d
+	}
+	if d := p.last.HotWaterEnergy - p.first.HotWaterEnergy; d > 0 {
+		s.HotWaterEnergy = d
+	}
+	if d := p.last.HeatingRuntime - p.first.HeatingRuntime; d > 0 {
+		s.HeatingRuntime = d
+	}
+	if p.last.CompressorStarts > p.first.CompressorStarts {
+		s.CompressorStarts = p.last.CompressorStarts - p.first.CompressorStarts
+	}
+	return s
+}
+
+func (r *Reporter) Close() error { return nil }
@@ -0,0 +1,94 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailySchedule_Next(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	s, err := ParseDailySchedule("19:30, 07:00", berlin)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Hour, 19*time.Hour + 30*time.Minute}, s.Times)
+
+	tests := []struct {
+		after time.Time
+		want  time.Time
+	}{
+		{time.Date(2024, 3, 12, 6, 0, 0, 0, berlin), time.Date(2024, 3, 12, 7, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 12, 7, 0, 0, 0, berlin), time.Date(2024, 3, 12, 19, 30, 0, 0, berlin)},
+		// clocks go forward, the morning report comes 22.5 hours later
+		{time.Date(2024, 3, 30, 20, 0, 0, 0, berlin), time.Date(2024, 3, 31, 7, 0, 0, 0, berlin)},
+		// clocks go back
+		{time.Date(2024, 10, 26, 20, 0, 0, 0, berlin), time.Date(2024, 10, 27, 7, 0, 0, 0, berlin)},
+		// a UTC timestamp is resolved in the location of the schedule
+		{time.Date(2024, 7, 1, 5, 30, 0, 0, time.UTC), time.Date(2024, 7, 1, 17, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got := s.Next(tt.after)
+		assert.True(t, tt.want.Equal(got), "after %s: got %s want %s", tt.after, got, tt.want)
+		assert.Equal(t, tt.want.In(berlin).Format("15:04"), got.In(berlin).Format("15:04"))
+	}
+
+	_, err = ParseDailySchedule("7am", berlin)
+	assert.Error(t, err)
+}
+
+func TestReporter(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	schedule, err := ParseDailySchedule("07:00", berlin)
+	require.NoError(t, err)
+
+	var got []Summary
+	var texts []string
+	r, err := NewReporter(ReportOptions{
+		Name:     "morning",
+		Schedule: schedule,
+		Locale:   "de_DE",
+		Deliver: func(_ context.Context, s Summary, text string) error {
+			got = append(got, s)
+			texts = append(texts, text)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	pm := NewCalculationsMap()
+	ctx := context.Background()
+	// hourly polls across the start of daylight saving time
+	ts := time.Date(2024, 3, 30, 7, 0, 0, 0, berlin)
+	for i := 0; i < 48; i++ {
+		pm[CalcOutdoorTemperature].SetRaw(uint32(40 + i))
+		pm[CalcHeatQuantityHeating].SetRaw(uint32(1000 + 10*i))
+		pm[CalcCompressorStarts].SetRaw(uint32(i / 2))
+		require.NoError(t, r.Write(ctx, "cellar", ts, BlockCalculations, pm))
+		ts = ts.Add(time.Hour)
+	}
+	require.NoError(t, r.Write(ctx, "cellar", ts, BlockParameters, NewParameterMap()))
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "cellar", got[0].Host)
+	assert.Equal(t, "morning", got[0].Report)
+	assert.Equal(t, time.Date(2024, 3, 31, 7, 0, 0, 0, berlin), got[0].To)
+	assert.Equal(t, 23, got[0].Samples, "the night was one hour shorter")
+	assert.InDelta(t, 22.0, got[0].HeatingEnergy, 0.01)
+	assert.InDelta(t, 4.0, got[0].MinOutdoorTemp, 0.01)
+	assert.InDelta(t, 6.2, got[0].MaxOutdoorTemp, 0.01)
+	assert.Equal(t, got[0].To, got[1].From)
+	assert.InDelta(t, 24.0, got[1].HeatingEnergy, 0.01, "continues from the previous period")
+
+	assert.Equal(t, "cellar: Zusammenfassung 30.03.2024 07:00 bis 31.03.2024 07:00\n"+
+		"Außentemperatur: min. 4,0 °C, Mittel 5,1 °C, max. 6,2 °C\n"+
+		"Heizung: 22,0 kWh\n"+
+		"Warmwasser: 0,0 kWh\n"+
+		"Laufzeit Heizung: 0,0 Std.\n"+
+		"Verdichterstarts: 11\n", texts[0])
+	assert.Contains(t, got[0].Format("en"), "Summary 2024-03-30 07:00 to 2024-03-31 07:00")
+}
@@ -0,0 +1,191 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// ScheduleEntry writes Value to Parameter at the times of the cron
+// expression, e.g. a night setback of the heating curve offset.
+type ScheduleEntry struct {
+	// Cron has the five standard fields or a descriptor like @daily, a
+	// CRON_TZ= prefix selects the time zone.
+	Cron      string `yaml:"cron"`
+	Parameter string `yaml:"parameter"`
+	Value     string `yaml:"value"`
+}
+
+func (e ScheduleEntry) String() string {
+	return e.Cron + " " + e.Parameter + "=" + e.Value
+}
+
+// ParseScheduleEntry parses entries like "0 22 * * 1-5 ID_Einst_WK_akt=-2",
+// the cron expression followed by the assignment.
+func ParseScheduleEntry(s string) (ScheduleEntry, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return ScheduleEntry{}, fmt.Errorf("ParseScheduleEntry invalid entry %q, want cron name=value", s)
+	}
+	name, value, ok := strings.Cut(fields[len(fields)-1], "=")
+	if !ok || name == "" || value == "" {
+		return ScheduleEntry{}, fmt.Errorf("ParseScheduleEntry invalid assignment in %q, want name=value", s)
+	}
+	e := ScheduleEntry{Cron: strings.Join(fields[:len(fields)-1], " "), Parameter: name, Value: value}
+	if _, err := cron.ParseStandard(e.Cron); err != nil {
+		return ScheduleEntry{}, fmt.Errorf("ParseScheduleEntry invalid cron expression %q: %w", e.Cron, err)
+	}
+	return e, nil
+}
+
+// LoadSchedule reads a YAML list of entries.
+func LoadSchedule(path string) ([]ScheduleEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadSchedule: %w", err)
+	}
+	var entries []ScheduleEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("LoadSchedule %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+type SchedulerOptions struct {
+	Entries []ScheduleEntry
+	// Location of cron expressions without CRON_TZ, defaults to time.Local.
+	Location *time.Location
+	// DryRun logs the writes instead of executing them.
+	DryRun bool
+	Logger *zap.Logger
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type scheduleJob struct {
+	ScheduleEntry
+	schedule cron.Schedule
+	index    int
+	raw      uint32
+	next     time.Time
+}
+
+// Scheduler is a Sink which writes parameters at the times of cron
+// expressions, e.g. for night setbacks, weekend modes or silent hours. The
+// writes happen with the first poll of the parameters at or after the
+// scheduled time, so the poll interval limits the accuracy. A failed write
+// is retried with every poll until it succeeds. Occurrences missed while
+// the daemon was not running are not caught up. Parameters
+// which already have the value are not written again to spare the flash of
+// the controller. The Poller must read the parameters.
+type Scheduler struct {
+	client *Client
+	opts   SchedulerOptions
+
+	mu   sync.Mutex
+	jobs []*scheduleJob
+}
+
+// NewScheduler validates the entries against the parameter definitions:
+// unknown or read-only parameters and invalid values are rejected.
+func NewScheduler(c *Client, opts SchedulerOptions) (*Scheduler, error) {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	pm := NewParameterMap()
+	now := opts.Now().In(opts.Location)
+	s := &Scheduler{client: c, opts: opts}
+	for _, e := range opts.Entries {
+		sched, err := cron.ParseStandard(e.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("NewScheduler invalid cron expression %q: %w", e.Cron, err)
+		}
+		idx, b, ok := pm.Lookup(e.Parameter)
+		if !ok {
+			return nil, fmt.Errorf("NewScheduler %q: %w", e.Parameter, ErrUnknownIndex)
+		}
+		if !b.writeable {
+			return nil, fmt.Errorf("NewScheduler %q: %w", e.Parameter, ErrWritingNotAllowed)
+		}
+		raw, err := b.ToHeatPump(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("NewScheduler %q: %w", e.Parameter, err)
+		}
+		s.jobs = append(s.jobs, &scheduleJob{ScheduleEntry: e, schedule: sched, index: idx, raw: raw, next: sched.Next(now)})
+	}
+	return s, nil
+}
+
+func (s *Scheduler) Write(ctx context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+	if block != BlockParameters || (s.client != nil && host != s.client.Name()) {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.opts.Now().In(s.opts.Location)
+	var errs []error
+	for _, j := range s.jobs {
+		if now.Before(j.next) {
+			continue
+		}
+		due, next := j.next, j.schedule.Next(now)
+		log := s.opts.Logger.With(
+			zap.String("entry", j.String()),
+			zap.Time("due", due),
+			zap.Duration("delay", now.Sub(due)),
+			zap.Time("next", next))
+		if b, ok := pm[j.index]; ok && b.reading.Raw == j.raw {
+			log.Info("scheduled parameter already set")
+			j.next = next
+			continue
+		}
+		log.Info("scheduled parameter write", zap.Bool("dry_run", s.opts.DryRun))
+		if s.opts.DryRun {
+			j.next = next
+			continue
+		}
+		// a failed write stays due and is retried with the next poll
+		if err := s.client.WriteParameterRawContext(ctx, pm, j.index, j.raw); err != nil {
+			errs = append(errs, fmt.Errorf("Scheduler.Write %s: %w", j, err))
+			continue
+		}
+		j.next = next
+	}
+	return errors.Join(errs...)
+}
+
+// ScheduledWrite is the next execution of an entry.
+type ScheduledWrite struct {
+	ScheduleEntry
+	Next time.Time
+}
+
+// Next returns the next execution of all entries sorted by time.
+func (s *Scheduler) Next() []ScheduledWrite {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]ScheduledWrite, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		res = append(res, ScheduledWrite{ScheduleEntry: j.ScheduleEntry, Next: j.next})
+	}
+	slices.SortStableFunc(res, func(a, b ScheduledWrite) int { return a.Next.Compare(b.Next) })
+	return res
+}
+
+func (s *Scheduler) Close() error { return nil }
@@ -0,0 +1,118 @@
+package luxtronik
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleEntry(t *testing.T) {
+	e, err := ParseScheduleEntry("0 22 * * 1-5 ID_Einst_WK_akt=-2")
+	require.NoError(t, err)
+	assert.Equal(t, ScheduleEntry{Cron: "0 22 * * 1-5", Parameter: "ID_Einst_WK_akt", Value: "-2"}, e)
+	e, err = ParseScheduleEntry("@daily ID_Ba_Bw_akt=Automatic")
+	require.NoError(t, err)
+	assert.Equal(t, "@daily", e.Cron)
+
+	for _, s := range []string{"0 22 * * *", "0 22 * * * ID_Einst_WK_akt", "0 25 * * * ID_Einst_WK_akt=1"} {
+		_, err := ParseScheduleEntry(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestLoadSchedule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+- cron: "0 22 * * *"
+  parameter: ID_Einst_WK_akt
+  value: "-2"
+- cron: "0 6 * * *"
+  parameter: ID_Einst_WK_akt
+  value: "0"
+`), 0o600))
+	entries, err := LoadSchedule(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "0 6 * * * ID_Einst_WK_akt=0", entries[1].String())
+}
+
+func TestScheduler(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	now := time.Date(2024, 3, 1, 21, 0, 0, 0, time.UTC)
+	s, err := NewScheduler(c, SchedulerOptions{
+		Entries: []ScheduleEntry{
+			{Cron: "0 22 * * *", Parameter: "ID_Einst_WK_akt", Value: "-2"},
+			{Cron: "0 6 * * *", Parameter: "ID_Einst_WK_akt", Value: "0"},
+		},
+		Location: time.UTC,
+		Now:      func() time.Time { return now },
+	})
+	require.NoError(t, err)
+	next := s.Next()
+	require.Len(t, next, 2)
+	assert.Equal(t, time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC), next[0].Next)
+
+	params := NewParameterMap()
+	ctx := context.Background()
+	poll := func() {
+		t.Helper()
+		require.NoError(t, params.SetRawValues(m.parameters))
+		require.NoError(t, s.Write(ctx, c.Name(), now, BlockParameters, params))
+	}
+	poll()
+	assert.Equal(t, uint32(0), m.parameters[ParamHeatingOffset])
+
+	now = time.Date(2024, 3, 1, 22, 0, 30, 0, time.UTC)
+	poll()
+	assert.Equal(t, uint32(0xFFFFFFEC), m.parameters[ParamHeatingOffset], "-2.0 K")
+
+	// the write of the morning restores the offset
+	m.parameters[ParamHeatingOffset] = 7
+	now = time.Date(2024, 3, 2, 6, 1, 0, 0, time.UTC)
+	poll()
+	assert.Equal(t, uint32(0), m.parameters[ParamHeatingOffset])
+	assert.Equal(t, time.Date(2024, 3, 2, 22, 0, 0, 0, time.UTC), s.Next()[0].Next)
+
+	_, err = NewScheduler(c, SchedulerOptions{Entries: []ScheduleEntry{{Cron: "@daily", Parameter: "ID_WEB_Temperatur_TA", Value: "1"}}})
+	assert.ErrorIs(t, err, ErrUnknownIndex)
+	_, err = NewScheduler(c, SchedulerOptions{Entries: []ScheduleEntry{{Cron: "@daily", Parameter: "ID_Einst_BWS_akt", Value: "hot"}}})
+	assert.Error(t, err)
+}
+
+func TestScheduler_WriteFailed(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	defer c.Close()
+
+	now := time.Date(2024, 3, 1, 22, 0, 30, 0, time.UTC)
+	s, err := NewScheduler(c, SchedulerOptions{
+		Entries:  []ScheduleEntry{{Cron: "0 22 * * *", Parameter: "ID_Einst_WK_akt", Value: "-2"}},
+		Location: time.UTC,
+		Now:      func() time.Time { return now.Add(-time.Hour) },
+	})
+	require.NoError(t, err)
+	s.opts.Now = func() time.Time { return now }
+	params := NewParameterMap()
+	require.NoError(t, params.SetRawValues(m.parameters))
+
+	// not connected, the write fails
+	ctx := context.Background()
+	require.Error(t, s.Write(ctx, c.Name(), now, BlockParameters, params))
+	assert.Equal(t, uint32(0), m.parameters[ParamHeatingOffset])
+	assert.Equal(t, time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC), s.Next()[0].Next, "still due")
+
+	require.NoError(t, c.Connect())
+	now = now.Add(30 * time.Second)
+	require.NoError(t, s.Write(ctx, c.Name(), now, BlockParameters, params))
+	assert.Equal(t, uint32(0xFFFFFFEC), m.parameters[ParamHeatingOffset], "retried with the next poll")
+	assert.Equal(t, time.Date(2024, 3, 2, 22, 0, 0, 0, time.UTC), s.Next()[0].Next)
+}
@@ -0,0 +1,98 @@
+package luxtronik
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// SchemaEntry describes a value of the catalog so that generic frontends can
+// render it without knowing the data model of the controller.
+type SchemaEntry struct {
+	Block string `json:"block"`
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	// Type is the data type of the catalog, e.g. celsius or HeatingMode.
+	Type string `json:"type"`
+	// ValueType is the Go type of the converted value, e.g. float32.
+	ValueType string `json:"value_type"`
+	// Group is the class of the value, e.g. temperature or selection.
+	Group     string `json:"group"`
+	Unit      string `json:"unit,omitempty"`
+	Writeable bool   `json:"writeable"`
+	Signed    bool   `json:"signed,omitempty"`
+	// Codes are the allowed values of selections, the index is the raw value.
+	Codes []string `json:"codes,omitempty"`
+	// Min, Max and Step bound the written values, see Base.Range.
+	Min        *float64 `json:"min,omitempty"`
+	Max        *float64 `json:"max,omitempty"`
+	Step       *float64 `json:"step,omitempty"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Visibility string   `json:"visibility,omitempty"`
+}
+
+// Schema describes all values of the blocks, sorted by block and index.
+func Schema(blocks map[string]DataTypeMap) []SchemaEntry {
+	var entries []SchemaEntry
+	for block, pm := range blocks {
+		pm.IterateSorted(func(idx int, b *Base) {
+			e := SchemaEntry{
+				Block:      block,
+				Index:      idx,
+				Name:       b.luxtronikName,
+				Type:       b.name,
+				ValueType:  reflect.TypeOf(b.FromHeatPump()).String(),
+				Group:      b.class,
+				Unit:       b.unit,
+				Writeable:  b.writeable,
+				Signed:     b.Signed(),
+				Codes:      b.codes,
+				Aliases:    b.Aliases(),
+				Visibility: b.visibility,
+			}
+			if low, high, step, ok := b.Range(); ok {
+				e.Min, e.Max = &low, &high
+				if step > 0 {
+					e.Step = &step
+				}
+			}
+			entries = append(entries, e)
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Block != entries[j].Block {
+			return entries[i].Block < entries[j].Block
+		}
+		return entries[i].Index < entries[j].Index
+	})
+	return entries
+}
+
+// SchemaHandler serves the Schema of the blocks as JSON on GET. The query
+// parameter block restricts the result to one block.
+func SchemaHandler(blocks map[string]DataTypeMap) http.Handler {
+	schema := Schema(blocks)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		res := schema
+		if block := r.URL.Query().Get("block"); block != "" {
+			if _, ok := blocks[block]; !ok {
+				http.Error(w, "unknown block "+block, http.StatusNotFound)
+				return
+			}
+			res = []SchemaEntry{}
+			for _, e := range schema {
+				if e.Block == block {
+					res = append(res, e)
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
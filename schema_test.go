@@ -0,0 +1,51 @@
+package luxtronik
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaHandler(t *testing.T) {
+	srv := httptest.NewServer(SchemaHandler(map[string]DataTypeMap{
+		BlockParameters:   NewParameterMap(),
+		BlockCalculations: NewCalculationsMap(),
+	}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "?block=" + BlockParameters)
+	require.NoError(t, err)
+	var schema []SchemaEntry
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&schema))
+	res.Body.Close()
+	require.Len(t, schema, len(NewParameterMap()))
+
+	mode := schema[ParamHeatingMode]
+	assert.Equal(t, "ID_Ba_Hz_akt", mode.Name)
+	assert.True(t, mode.Writeable)
+	assert.Equal(t, "luxtronik.HeatingMode", mode.ValueType)
+	assert.Contains(t, mode.Codes, "Party")
+	assert.Equal(t, []string{"heating mode"}, mode.Aliases)
+
+	offset := schema[ParamHeatingOffset]
+	assert.Equal(t, "temperature", offset.Group)
+	assert.Equal(t, "°C", offset.Unit)
+	assert.True(t, offset.Signed)
+
+	res, err = http.Get(srv.URL)
+	require.NoError(t, err)
+	schema = nil
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&schema))
+	res.Body.Close()
+	assert.Len(t, schema, len(NewParameterMap())+len(NewCalculationsMap()))
+	assert.Equal(t, BlockCalculations, schema[0].Block, "sorted by block")
+
+	res, err = http.Get(srv.URL + "?block=nope")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,138 @@
+package luxtronik
+
+import (
+	"sort"
+	"strings"
+)
+
+// aliases maps luxtronik names to common english terms so that users find
+// the values without knowing the german abbreviations of the controller.
+var aliases = map[string][]string{
+	"ID_Einst_WK_akt":               {"heating curve offset", "temperature correction"},
+	"ID_Einst_BWS_akt":              {"hot water target", "dhw setpoint"},
+	"ID_Ba_Hz_akt":                  {"heating mode"},
+	"ID_Ba_Bw_akt":                  {"hot water mode", "dhw mode"},
+	"ID_Einst_HzHwHKE_akt":          {"heating curve end point"},
+	"ID_Einst_HzHKRANH_akt":         {"heating curve parallel shift"},
+	"ID_Einst_HzHKRABS_akt":         {"heating curve night setback"},
+	"ID_Einst_BWS_Hyst_akt":         {"hot water hysteresis"},
+	"ID_Einst_HRHyst_akt":           {"return temperature hysteresis"},
+	"ID_Soll_BWS_akt":               {"hot water target"},
+	"ID_Einst_Zugangscode":          {"access level", "password"},
+	"ID_Einst_BA_Kuehl_akt":         {"cooling mode"},
+	"ID_Ba_Sw_akt":                  {"pool mode", "swimming pool mode"},
+	"ID_WEB_Temperatur_TVL":         {"flow temperature", "supply temperature"},
+	"ID_WEB_Temperatur_TRL":         {"return temperature"},
+	"ID_WEB_Sollwert_TRL_HZ":        {"return temperature target"},
+	"ID_WEB_Temperatur_THG":         {"hot gas temperature"},
+	"ID_WEB_Temperatur_TA":          {"outdoor temperature", "outside temperature"},
+	"ID_WEB_Mitteltemperatur":       {"average outdoor temperature"},
+	"ID_WEB_Temperatur_TBW":         {"hot water temperature", "dhw temperature"},
+	"ID_WEB_Einst_BWS_akt":          {"hot water target"},
+	"ID_WEB_Temperatur_TWE":         {"heat source inlet temperature", "brine in"},
+	"ID_WEB_Temperatur_TWA":         {"heat source outlet temperature", "brine out"},
+	"ID_WEB_EVUin":                  {"utility lock", "evu"},
+	"ID_WEB_VD1out":                 {"compressor 1"},
+	"ID_WEB_VD2out":                 {"compressor 2"},
+	"ID_WEB_Zaehler_BetrZeitVD1":    {"compressor 1 operating hours"},
+	"ID_WEB_Zaehler_BetrZeitImpVD1": {"compressor 1 starts", "compressor impulses"},
+	"ID_WEB_Zaehler_BetrZeitWP":     {"heat pump operating hours"},
+	"ID_WEB_Zaehler_BetrZeitHz":     {"heating operating hours"},
+	"ID_WEB_Zaehler_BetrZeitBW":     {"hot water operating hours"},
+	"ID_WEB_Code_WP_akt":            {"heat pump type", "model"},
+	"ID_WEB_WP_BZ_akt":              {"operation mode", "state"},
+	"ID_WEB_AdresseIP_akt":          {"ip address"},
+	"ID_WEB_WMZ_Heizung":            {"heat quantity heating", "energy heating"},
+	"ID_WEB_WMZ_Brauchwasser":       {"heat quantity hot water", "energy hot water"},
+	"ID_WEB_WMZ_Durchfluss":         {"flow rate"},
+	"ID_WEB_LIN_HD":                 {"high pressure"},
+	"ID_WEB_LIN_ND":                 {"low pressure"},
+	"ID_WEB_Freq_VD":                {"compressor frequency"},
+	"Heat_Output":                   {"thermal power", "heat output"},
+}
+
+// Aliases returns the english alias names of the value.
+func (b *Base) Aliases() []string {
+	return aliases[b.luxtronikName]
+}
+
+// SearchMatch describes a single hit of Search.
+type SearchMatch struct {
+	Block string
+	Index int
+	Base  *Base
+	// Field names where the keyword has been found: name, alias, type, code
+	Field string
+	Score int
+}
+
+// Search performs a case-insensitive fuzzy search across names, aliases,
+// data types and code strings of all given blocks. Exact substring matches
+// rank higher than matches where only the characters appear in order. The
+// result is sorted by descending score.
+func Search(keyword string, blocks map[string]DataTypeMap) []SearchMatch {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if keyword == "" {
+		return nil
+	}
+
+	var matches []SearchMatch
+	for block, pm := range blocks {
+		pm.IterateSorted(func(idx int, b *Base) {
+			best := SearchMatch{Block: block, Index: idx, Base: b}
+			try := func(field, text string, weight int) {
+				if s := fuzzyScore(keyword, text) * weight; s > best.Score {
+					best.Score = s
+					best.Field = field
+				}
+			}
+			try("name", b.luxtronikName, 3)
+			for _, a := range b.Aliases() {
+				try("alias", a, 3)
+			}
+			try("type", b.name, 2)
+			for _, c := range b.codes {
+				try("code", c, 1)
+			}
+			if best.Score > 0 {
+				matches = append(matches, best)
+			}
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Block != matches[j].Block {
+			return matches[i].Block < matches[j].Block
+		}
+		return matches[i].Index < matches[j].Index
+	})
+	return matches
+}
+
+// fuzzyScore returns 0 if keyword does not match text. A substring match
+// scores 10, a match where all characters of keyword appear in order within
+// text scores 1.
+func fuzzyScore(keyword, text string) int {
+	text = strings.ToLower(text)
+	if text == "" {
+		return 0
+	}
+	if strings.Contains(text, keyword) {
+		return 10
+	}
+	// underscores and spaces are ignored so "hot water" finds "hotwater"
+	kr := []rune(strings.NewReplacer(" ", "", "_", "").Replace(keyword))
+	pos := 0
+	for _, r := range text {
+		if pos < len(kr) && kr[pos] == r {
+			pos++
+		}
+	}
+	if pos == len(kr) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	blocks := map[string]DataTypeMap{
+		BlockParameters:   NewParameterMap(),
+		BlockCalculations: NewCalculationsMap(),
+	}
+
+	t.Run("alias", func(t *testing.T) {
+		m := Search("Outdoor Temperature", blocks)
+		require.NotEmpty(t, m)
+		assert.Equal(t, BlockCalculations, m[0].Block)
+		assert.Equal(t, 15, m[0].Index)
+		assert.Equal(t, "alias", m[0].Field)
+	})
+	t.Run("code", func(t *testing.T) {
+		m := Search("evu lock", blocks)
+		require.NotEmpty(t, m)
+		assert.Equal(t, "code", m[0].Field)
+	})
+	t.Run("fuzzy name", func(t *testing.T) {
+		m := Search("tempTVL", blocks)
+		require.NotEmpty(t, m)
+		assert.Equal(t, "ID_WEB_Temperatur_TVL", m[0].Base.Name())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Search(" ", blocks))
+	})
+}
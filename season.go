@@ -0,0 +1,288 @@
+package luxtronik
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SeasonSample is a single reading of the counters needed to detect heating
+// seasons. Samples should be taken at least hourly, defrosts are only
+// counted when the sample rate is high enough to catch them.
+type SeasonSample struct {
+	Time             time.Time
+	OutdoorTemp      float64
+	HeatingRuntime   time.Duration
+	HeatingEnergy    float64 // kWh counter
+	HotWaterEnergy   float64 // kWh counter
+	ElectricalEnergy float64 // kWh counter of an external meter, optional
+	CompressorStarts uint32
+	Defrosting       bool
+}
+
+// SeasonSampleFromCalculations extracts a sample from a read calculations
+// block. The electrical energy is not known to the controller and must be set
+// by the caller for the COP.
+func SeasonSampleFromCalculations(ts time.Time, pm DataTypeMap) SeasonSample {
+	return SeasonSample{
+		Time:             ts,
+		OutdoorTemp:      float64(int32(pm[CalcOutdoorTemperature].reading.Raw)) * 0.1,
+		HeatingRuntime:   time.Duration(pm[CalcHeatingRuntime].reading.Raw) * time.Second,
+		HeatingEnergy:    float64(pm[CalcHeatQuantityHeating].reading.Raw) * 0.1,
+		HotWaterEnergy:   float64(pm[CalcHeatQuantityHotWater].reading.Raw) * 0.1,
+		CompressorStarts: pm[CalcCompressorStarts].reading.Raw,
+		Defrosting:       pm[CalcOperationMode].reading.Raw == 4,
+	}
+}
+
+type SeasonOptions struct {
+	// HeatingLimit is the daily mean outdoor temperature in °C below which a
+	// day can be a heating day. Defaults to 15 °C.
+	HeatingLimit float64
+	// MinRuntime is the daily heating runtime a heating day needs. Defaults
+	// to one hour.
+	MinRuntime time.Duration
+	// StartDays is the number of consecutive heating days which start a
+	// season. Defaults to 3.
+	StartDays int
+	// EndDays is the number of consecutive days without heating which end a
+	// season. Defaults to 7.
+	EndDays int
+	// RoomTemp is the base temperature of the degree days. Defaults to
+	// 20 °C as defined in VDI 3807.
+	RoomTemp float64
+	// Location defines the day boundaries, defaults to time.Local.
+	Location *time.Location
+}
+
+func (opts SeasonOptions) withDefaults() SeasonOptions {
+	if opts.HeatingLimit == 0 {
+		opts.HeatingLimit = 15
+	}
+	if opts.MinRuntime == 0 {
+		opts.MinRuntime = time.Hour
+	}
+	if opts.StartDays < 1 {
+		opts.StartDays = 3
+	}
+	if opts.EndDays < 1 {
+		opts.EndDays = 7
+	}
+	if opts.RoomTemp == 0 {
+		opts.RoomTemp = 20
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	return opts
+}
+
+// Season summarizes one heating season.
+type Season struct {
+	Start            time.Time
+	End              time.Time
+	Ongoing          bool // the samples end within the season
+	Days             int
+	MeanOutdoorTemp  float64
+	HeatingRuntime   time.Duration
+	HeatingEnergy    float64
+	HotWaterEnergy   float64
+	ElectricalEnergy float64
+	// COP is the ratio of heat quantity to electrical energy, zero without
+	// electrical energy.
+	COP              float64
+	CompressorStarts uint32
+	Defrosts         int
+	// DegreeDays is the sum of RoomTemp minus the daily mean outdoor
+	// temperature of all days below the heating limit (Gradtagzahl).
+	DegreeDays float64
+	// EnergyPerDegreeDay normalizes the heating energy by the degree days to
+	// compare seasons of different coldness.
+	EnergyPerDegreeDay float64
+}
+
+// Label returns the usual name of a season, e.g. 2023/24.
+func (s Season) Label() string {
+	return fmt.Sprintf("%d/%02d", s.Start.Year(), (s.Start.Year()+1)%100)
+}
+
+type seasonDay struct {
+	date       time.Time
+	tempSum    float64
+	tempCount  int
+	runtime    time.Duration
+	heating    float64
+	hotWater   float64
+	electrical float64
+	starts     uint32
+	defrosts   int
+	heatingDay bool
+	degreeDays float64
+}
+
+// DetectSeasons groups the samples into days and detects the heating seasons
+// from the daily mean outdoor temperature and the heating runtime. Counter
+// resets between two samples are ignored.
+func DetectSeasons(samples []SeasonSample, opts SeasonOptions) []Season {
+	opts = opts.withDefaults()
+	days := groupSeasonDays(samples, opts)
+
+	var (
+		seasons []Season
+		inside  bool
+		start   int
+		run     int // consecutive days contradicting the current state
+	)
+	for i, d := range days {
+		switch {
+		case !inside && d.heatingDay:
+			run++
+			if run == opts.StartDays {
+				inside, start, run = true, i-opts.StartDays+1, 0
+			}
+		case !inside:
+			run = 0
+		case inside && !d.heatingDay:
+			run++
+			if run == opts.EndDays {
+				seasons = append(seasons, summarizeSeason(days[start:i-opts.EndDays+1], false))
+				inside, run = false, 0
+			}
+		default:
+			run = 0
+		}
+	}
+	if inside {
+		seasons = append(seasons, summarizeSeason(days[start:], true))
+	}
+	return seasons
+}
+
+func groupSeasonDays(samples []SeasonSample, opts SeasonOptions) []*seasonDay {
+	samples = append([]SeasonSample(nil), samples...)
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	var days []*seasonDay
+	var cur *seasonDay
+	for i, s := range samples {
+		t := s.Time.In(opts.Location)
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, opts.Location)
+		if cur == nil || !cur.date.Equal(date) {
+			cur = &seasonDay{date: date}
+			days = append(days, cur)
+		}
+		cur.tempSum += s.OutdoorTemp
+		cur.tempCount++
+
+		if i == 0 {
+			continue
+		}
+		// deltas are accounted to the day of the later sample
+		prev := samples[i-1]
+		if d := s.HeatingRuntime - prev.HeatingRuntime; d > 0 {
+			cur.runtime += d
+		}
+		if d := s.HeatingEnergy - prev.HeatingEnergy; d > 0 {
+			cur.heating += d
+		}
+		if d := s.HotWaterEnergy - prev.HotWaterEnergy; d > 0 {
+			cur.hotWater += d
+		}
+		if d := s.ElectricalEnergy - prev.ElectricalEnergy; d > 0 {
+			cur.electrical += d
+		}
+		if s.CompressorStarts > prev.CompressorStarts {
+			cur.starts += s.CompressorStarts - prev.CompressorStarts
+		}
+		if s.Defrosting && !prev.Defrosting {
+			cur.defrosts++
+		}
+	}
+
+	for _, d := range days {
+		mean := d.tempSum / float64(d.tempCount)
+		d.heatingDay = mean < opts.HeatingLimit && d.runtime >= opts.MinRuntime
+		if mean < opts.HeatingLimit {
+			d.degreeDays = opts.RoomTemp - mean
+		}
+	}
+	return days
+}
+
+func summarizeSeason(days []*seasonDay, ongoing bool) Season {
+	s := Season{
+		Start:   days[0].date,
+		End:     days[len(days)-1].date,
+		Ongoing: ongoing,
+		Days:    len(days),
+	}
+	var tempSum float64
+	for _, d := range days {
+		tempSum += d.tempSum / float64(d.tempCount)
+		s.HeatingRuntime += d.runtime
+		s.HeatingEnergy += d.heating
+		s.HotWaterEnergy += d.hotWater
+		s.ElectricalEnergy += d.electrical
+		s.CompressorStarts += d.starts
+		s.Defrosts += d.defrosts
+		s.DegreeDays += d.degreeDays
+	}
+	s.MeanOutdoorTemp = tempSum / float64(len(days))
+	if s.ElectricalEnergy > 0 {
+		s.COP = (s.HeatingEnergy + s.HotWaterEnergy) / s.ElectricalEnergy
+	}
+	if s.DegreeDays > 0 {
+		s.EnergyPerDegreeDay = s.HeatingEnergy / s.DegreeDays
+	}
+	return s
+}
+
+// MonthStats normalizes the heating energy of a month by its degree days.
+type MonthStats struct {
+	Month              time.Time
+	Days               int
+	MeanOutdoorTemp    float64
+	DegreeDays         float64
+	HeatingEnergy      float64
+	HotWaterEnergy     float64
+	EnergyPerDegreeDay float64
+}
+
+// MonthlyStats returns the degree days and energy per calendar month, so
+// the consumption of months with different coldness can be compared.
+func MonthlyStats(samples []SeasonSample, opts SeasonOptions) []MonthStats {
+	opts = opts.withDefaults()
+
+	var (
+		months  []MonthStats
+		cur     *MonthStats
+		tempSum float64
+	)
+	finish := func() {
+		if cur == nil {
+			return
+		}
+		cur.MeanOutdoorTemp = tempSum / float64(cur.Days)
+		if cur.DegreeDays > 0 {
+			cur.EnergyPerDegreeDay = cur.HeatingEnergy / cur.DegreeDays
+		}
+	}
+	for _, d := range groupSeasonDays(samples, opts) {
+		month := time.Date(d.date.Year(), d.date.Month(), 1, 0, 0, 0, 0, opts.Location)
+		if cur == nil || !cur.Month.Equal(month) {
+			finish()
+			months = append(months, MonthStats{Month: month})
+			cur = &months[len(months)-1]
+			tempSum = 0
+		}
+		cur.Days++
+		tempSum += d.tempSum / float64(d.tempCount)
+		cur.DegreeDays += d.degreeDays
+		cur.HeatingEnergy += d.heating
+		cur.HotWaterEnergy += d.hotWater
+	}
+	finish()
+	return months
+}
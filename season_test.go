@@ -0,0 +1,84 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSeasons(t *testing.T) {
+	start := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	// 30 summer days, 100 heating days, 30 summer days sampled every 6 hours
+	var (
+		samples []SeasonSample
+		cur     SeasonSample
+	)
+	for day := 0; day < 160; day++ {
+		heating := day >= 30 && day < 130
+		for h := 0; h < 24; h += 6 {
+			cur.Time = start.AddDate(0, 0, day).Add(time.Duration(h) * time.Hour)
+			cur.OutdoorTemp = 20
+			cur.HotWaterEnergy += 0.5
+			cur.Defrosting = false
+			if heating {
+				cur.OutdoorTemp = 2
+				cur.HeatingRuntime += 2 * time.Hour
+				cur.HeatingEnergy += 10
+				cur.ElectricalEnergy += 3
+				cur.CompressorStarts++
+				cur.Defrosting = h == 12
+			}
+			samples = append(samples, cur)
+		}
+	}
+
+	seasons := DetectSeasons(samples, SeasonOptions{Location: time.UTC})
+	require.Len(t, seasons, 1)
+	s := seasons[0]
+	assert.Equal(t, "2023/24", s.Label())
+	assert.Equal(t, start.AddDate(0, 0, 30), s.Start)
+	assert.Equal(t, start.AddDate(0, 0, 129), s.End)
+	assert.False(t, s.Ongoing)
+	assert.Equal(t, 100, s.Days)
+	assert.Equal(t, 800*time.Hour, s.HeatingRuntime)
+	assert.InDelta(t, 4000, s.HeatingEnergy, 0.01)
+	assert.Equal(t, 100, s.Defrosts)
+	assert.InDelta(t, 2, s.MeanOutdoorTemp, 0.01)
+	assert.InDelta(t, (4000.0+200)/1200, s.COP, 0.01)
+
+	seasons = DetectSeasons(samples[:100*4], SeasonOptions{Location: time.UTC})
+	require.Len(t, seasons, 1)
+	assert.True(t, seasons[0].Ongoing)
+}
+
+func TestMonthlyStats(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		samples []SeasonSample
+		cur     SeasonSample
+	)
+	// January at 0 °C and February at 10 °C with the same energy per kelvin
+	for day := 0; day < 31+29; day++ {
+		ts := start.AddDate(0, 0, day)
+		cur.Time = ts
+		cur.OutdoorTemp = 0
+		cur.HeatingEnergy += 40
+		if ts.Month() == time.February {
+			cur.OutdoorTemp = 10
+			cur.HeatingEnergy -= 20
+		}
+		samples = append(samples, cur)
+	}
+
+	months := MonthlyStats(samples, SeasonOptions{Location: time.UTC})
+	require.Len(t, months, 2)
+	assert.Equal(t, 31, months[0].Days)
+	assert.InDelta(t, 31*20, months[0].DegreeDays, 0.01)
+	assert.InDelta(t, 29*10, months[1].DegreeDays, 0.01)
+	assert.InDelta(t, 2, months[1].EnergyPerDegreeDay, 0.01)
+	// the first sample has no delta
+	assert.InDelta(t, 30*40.0/(31*20), months[0].EnergyPerDegreeDay, 0.01)
+}
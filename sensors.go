@@ -0,0 +1,181 @@
+package luxtronik
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BlockSensors labels the readings of external room sensors which the
+// Poller forwards alongside the heat pump blocks.
+const BlockSensors = "sensors"
+
+// SensorReading is a single measurement of an external room sensor.
+// Temperature and Humidity are optional but at least one must be set.
+type SensorReading struct {
+	Sensor      string    `json:"sensor"`
+	Temperature *float64  `json:"temperature,omitempty"` // °C
+	Humidity    *float64  `json:"humidity,omitempty"`    // %
+	Time        time.Time `json:"time,omitempty"`
+}
+
+// RoomSensors collects the readings of external sensors, e.g. posted via
+// HTTP, and provides them as DataTypeMap so that all sinks can handle them
+// like heat pump values. Each sensor gets two consecutive indexes, the first
+// for the temperature and the second for the humidity.
+type RoomSensors struct {
+	maxAge time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	indexes map[string]int
+	pm      DataTypeMap
+	updated map[int]time.Time
+}
+
+// NewRoomSensors creates the store. Readings older than maxAge are dropped
+// from Map, zero keeps them forever.
+func NewRoomSensors(maxAge time.Duration) *RoomSensors {
+	return &RoomSensors{
+		maxAge:  maxAge,
+		now:     time.Now,
+		indexes: map[string]int{},
+		pm:      DataTypeMap{},
+		updated: map[int]time.Time{},
+	}
+}
+
+var sensorNameCleaner = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// sensorValueName derives the value name, e.g. Room_living_room_Temperature.
+func sensorValueName(sensor, quantity string) string {
+	return "Room_" + strings.Trim(sensorNameCleaner.ReplaceAllString(strings.ToLower(sensor), "_"), "_") + "_" + quantity
+}
+
+// newSensorValue stores signed values with one decimal like the controller
+// does for temperatures.
+func newSensorValue(name, class, unit string) *Base {
+	return &Base{Definition: &Definition{
+		returnType:    reflect.Float32,
+		name:          "sensor",
+		class:         class,
+		luxtronikName: name,
+		unit:          unit,
+		factor:        0.1,
+		signed:        true,
+	}}
+}
+
+// Update stores a reading. A zero Time is replaced by the current time.
+func (r *RoomSensors) Update(reading SensorReading) error {
+	if strings.TrimSpace(reading.Sensor) == "" {
+		return errors.New("RoomSensors.Update missing sensor name")
+	}
+	if reading.Temperature == nil && reading.Humidity == nil {
+		return fmt.Errorf("RoomSensors.Update sensor %q: missing temperature and humidity", reading.Sensor)
+	}
+	if reading.Time.IsZero() {
+		reading.Time = r.now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	idx, ok := r.indexes[reading.Sensor]
+	if !ok {
+		idx = len(r.indexes) * 2
+		r.indexes[reading.Sensor] = idx
+		r.pm[idx] = newSensorValue(sensorValueName(reading.Sensor, "Temperature"), classTemperature, "°C")
+		r.pm[idx+1] = newSensorValue(sensorValueName(reading.Sensor, "Humidity"), "percent", "%")
+	}
+	set := func(idx int, v float64) {
+		b := r.pm[idx]
+		b.reading.Prev = b.reading.Raw
+		b.reading.Raw = uint32(int32(math.Round(v * 10)))
+		r.updated[idx] = reading.Time
+	}
+	if reading.Temperature != nil {
+		set(idx, *reading.Temperature)
+	}
+	if reading.Humidity != nil {
+		set(idx+1, *reading.Humidity)
+	}
+	return nil
+}
+
+// Map returns a copy of all current readings. Values which have never been
+// received or are older than the max age are left out.
+func (r *RoomSensors) Map() DataTypeMap {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	pm := make(DataTypeMap, len(r.updated))
+	for idx, ts := range r.updated {
+		if r.maxAge > 0 && now.Sub(ts) > r.maxAge {
+			continue
+		}
+		b := *r.pm[idx]
+		pm[idx] = &b
+	}
+	return pm
+}
+
+// ServeHTTP accepts POST requests with a JSON encoded SensorReading or a
+// list of them.
+func (r *RoomSensors) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var raw json.RawMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 1<<20)).Decode(&raw); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := r.updateJSON(raw, ""); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleMQTT stores the JSON encoded SensorReading or list of them of an MQTT
+// message. Readings without sensor are named after the last topic level, so
+// that messages of e.g. zigbee2mqtt/living room like
+// {"temperature":19.5,"humidity":52} work as they are.
+func (r *RoomSensors) HandleMQTT(topic string, payload []byte) error {
+	sensor := topic[strings.LastIndexByte(topic, '/')+1:]
+	if err := r.updateJSON(payload, sensor); err != nil {
+		return fmt.Errorf("RoomSensors.HandleMQTT %s: %w", topic, err)
+	}
+	return nil
+}
+
+// updateJSON stores a SensorReading or a list of them, sensor is the
+// default name.
+func (r *RoomSensors) updateJSON(raw []byte, sensor string) error {
+	var readings []SensorReading
+	if err := json.Unmarshal(raw, &readings); err != nil {
+		var single SensorReading
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return err
+		}
+		readings = []SensorReading{single}
+	}
+	for _, reading := range readings {
+		if reading.Sensor == "" {
+			reading.Sensor = sensor
+		}
+		if err := r.Update(reading); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package luxtronik
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomSensors(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	rs := NewRoomSensors(time.Hour)
+	rs.now = func() time.Time { return now }
+
+	post := func(body string) int {
+		rec := httptest.NewRecorder()
+		rs.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sensors", strings.NewReader(body)))
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusNoContent, post(`{"sensor":"Living Room","temperature":19.46,"humidity":52}`))
+	assert.Equal(t, http.StatusNoContent, post(`[{"sensor":"garage","temperature":-3.2,"time":"2024-01-10T10:00:00Z"}]`))
+	assert.Equal(t, http.StatusBadRequest, post(`{"sensor":"attic"}`))
+
+	pm := rs.Map()
+	require.Len(t, pm, 2, "the garage reading is too old and has no humidity")
+	assert.Equal(t, "Room_living_room_Temperature", pm[0].Name())
+	assert.Equal(t, float32(19.5), pm[0].FromHeatPump())
+	assert.Equal(t, float32(52), pm[1].FromHeatPump())
+
+	now = now.Add(-90 * time.Minute)
+	assert.Equal(t, float32(-3.2), rs.Map()[2].FromHeatPump())
+}
+
+func TestRoomSensors_HandleMQTT(t *testing.T) {
+	rs := NewRoomSensors(0)
+	require.NoError(t, rs.HandleMQTT("zigbee2mqtt/Living Room", []byte(`{"temperature":19.5,"humidity":52,"linkquality":120}`)))
+	require.NoError(t, rs.HandleMQTT("sensors", []byte(`[{"sensor":"garage","temperature":-3.2}]`)))
+	assert.Error(t, rs.HandleMQTT("zigbee2mqtt/attic", []byte(`{"battery":80}`)))
+	assert.Error(t, rs.HandleMQTT("zigbee2mqtt/attic", []byte(`online`)))
+
+	pm := rs.Map()
+	require.Len(t, pm, 3)
+	assert.Equal(t, "Room_living_room_Temperature", pm[0].Name())
+	assert.Equal(t, float32(52), pm[1].FromHeatPump())
+	assert.Equal(t, "Room_garage_Temperature", pm[2].Name())
+}
@@ -0,0 +1,203 @@
+package luxtronik
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SerialBaud is the baud rate of the RS232 port of Luxtronik 1 controllers.
+const SerialBaud = 57600
+
+// lux1Temperatures is the command of Luxtronik 1 controllers which returns
+// the temperatures.
+const lux1Temperatures = "1100"
+
+// NewLux1CalculationsMap returns the calculations of Luxtronik 1
+// controllers, the temperatures in the order of their answer to command
+// 1100. Values with an equivalent in NewCalculationsMap share its names.
+func NewLux1CalculationsMap() DataTypeMap {
+	return DataTypeMap{
+		0:  NewCelsius("ID_WEB_Temperatur_TVL", false),
+		1:  NewCelsius("ID_WEB_Temperatur_TRL", false),
+		2:  NewCelsius("ID_WEB_Sollwert_TRL_HZ", false),
+		3:  NewCelsius("ID_WEB_Temperatur_THG", false),
+		4:  NewCelsius("ID_WEB_Temperatur_TA", false),
+		5:  NewCelsius("ID_WEB_Temperatur_TBW", false),
+		6:  NewCelsius("ID_WEB_Einst_BWS_akt", false),
+		7:  NewCelsius("ID_WEB_Temperatur_TWE", false),
+		8:  NewCelsius("Lux1_Temperatur_Kaeltekreis", false), // refrigerant circuit
+		9:  NewCelsius("ID_WEB_Temperatur_TFB1", false),
+		10: NewCelsius("ID_WEB_Sollwert_TVL_MK1", false),
+		11: NewCelsius("ID_WEB_Temperatur_RFV", false),
+	}
+}
+
+// SerialOptions configure a SerialSource.
+type SerialOptions struct {
+	// Alias names the heat pump in logs and sinks, defaults to the name of
+	// the port.
+	Alias string
+	// Baud defaults to SerialBaud.
+	Baud int
+	// Open opens the port. The default opens the device file as it is, so
+	// configure the port beforehand, e.g. stty -F /dev/ttyUSB0 57600 raw,
+	// or open it with a serial library like go.bug.st/serial.
+	Open    func(port string, baud int) (io.ReadWriteCloser, error)
+	Timeout time.Duration
+	Logger  *zap.Logger
+}
+
+// SerialSource reads Luxtronik 1 controllers over their RS232 port. Only
+// the calculations are available, see NewLux1CalculationsMap. It
+// implements MapSource, so the Poller decodes them with that map.
+type SerialSource struct {
+	port string
+	opts SerialOptions
+	log  *zap.Logger
+
+	mu   sync.Mutex
+	rw   io.ReadWriteCloser
+	r    *bufio.Reader
+	size int
+}
+
+var _ MapSource = (*SerialSource)(nil)
+
+// NewSerialSource opens the port lazily, e.g. /dev/ttyUSB0.
+func NewSerialSource(port string, opts SerialOptions) *SerialSource {
+	if opts.Baud == 0 {
+		opts.Baud = SerialBaud
+	}
+	if opts.Open == nil {
+		opts.Open = openSerialFile
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	log := opts.Logger
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return &SerialSource{port: port, opts: opts, log: log.With(zap.String("port", port)), size: len(NewLux1CalculationsMap())}
+}
+
+func openSerialFile(port string, _ int) (io.ReadWriteCloser, error) {
+	return os.OpenFile(port, os.O_RDWR, 0)
+}
+
+func (s *SerialSource) Name() string {
+	if s.opts.Alias != "" {
+		return s.opts.Alias
+	}
+	return filepath.Base(s.port)
+}
+
+// NewMap returns NewLux1CalculationsMap for the calculations, nil for the
+// other blocks.
+func (s *SerialSource) NewMap(block string) DataTypeMap {
+	if block == BlockCalculations {
+		return NewLux1CalculationsMap()
+	}
+	return nil
+}
+
+// ReadRaw reads the temperatures for the calculations, the other blocks
+// return errors.ErrUnsupported. A failed read closes the port.
+func (s *SerialSource) ReadRaw(ctx context.Context, cmd int32) ([]uint32, error) {
+	if cmd != CalculationsRead {
+		return nil, fmt.Errorf("SerialSource.ReadRaw command %d: %w", cmd, errors.ErrUnsupported)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := s.request(ctx, lux1Temperatures)
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("SerialSource.ReadRaw %s: %w", s.port, err)
+	}
+	return raw, nil
+}
+
+// request sends cmd and parses the answer, the line starting with cmd
+// followed by the number of values and the values, e.g.
+// 1100;12;254;257;... Other lines, e.g. the echo of cmd, are skipped.
+func (s *SerialSource) request(ctx context.Context, cmd string) ([]uint32, error) {
+	if s.rw == nil {
+		rw, err := s.opts.Open(s.port, s.opts.Baud)
+		if err != nil {
+			return nil, fmt.Errorf("open: %w", err)
+		}
+		s.rw, s.r = rw, bufio.NewReader(rw)
+		s.log.Debug("serial port opened", zap.Int("baud", s.opts.Baud))
+	}
+	if d, ok := s.rw.(interface{ SetDeadline(time.Time) error }); ok {
+		deadline := time.Now().Add(s.opts.Timeout)
+		if dl, ok := ctx.Deadline(); ok {
+			deadline = dl
+		}
+		_ = d.SetDeadline(deadline)
+	}
+	if _, err := io.WriteString(s.rw, cmd+"\r\n"); err != nil {
+		return nil, err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if fields := strings.Split(strings.TrimSpace(line), ";"); len(fields) > 2 && fields[0] == cmd {
+			return parseLux1Values(fields[1:], s.size)
+		}
+	}
+}
+
+// parseLux1Values parses the count and the values of an answer into raw
+// values of a map of size entries.
+func parseLux1Values(fields []string, size int) ([]uint32, error) {
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n != len(fields)-1 {
+		return nil, fmt.Errorf("%w: count %q does not match %d values", ErrLengthMismatch, fields[0], len(fields)-1)
+	}
+	raw := make([]uint32, size)
+	for i, f := range fields[1:] {
+		if i >= size {
+			break
+		}
+		v, err := strconv.ParseInt(f, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("value %d %q: %w", i, f, ErrInvalidValue)
+		}
+		raw[i] = uint32(int32(v))
+	}
+	return raw, nil
+}
+
+// Close closes the port, the next read opens it again.
+func (s *SerialSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.close()
+}
+
+func (s *SerialSource) close() error {
+	if s.rw == nil {
+		return nil
+	}
+	err := s.rw.Close()
+	s.rw, s.r = nil, nil
+	return err
+}
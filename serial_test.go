@@ -0,0 +1,77 @@
+package luxtronik
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockLux1 answers the temperature command like a Luxtronik 1
+// controller, echo included.
+func newMockLux1(t *testing.T, answer string) SerialOptions {
+	t.Helper()
+	return SerialOptions{Open: func(port string, baud int) (io.ReadWriteCloser, error) {
+		assert.Equal(t, "/dev/ttyUSB0", port)
+		assert.Equal(t, SerialBaud, baud)
+		ctrl, conn := net.Pipe()
+		go func() {
+			defer ctrl.Close()
+			r := bufio.NewReader(ctrl)
+			for {
+				cmd, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if _, err := io.WriteString(ctrl, cmd+answer+"\r\n"); err != nil {
+					return
+				}
+			}
+		}()
+		return conn, nil
+	}}
+}
+
+func TestSerialSource(t *testing.T) {
+	src := NewSerialSource("/dev/ttyUSB0", newMockLux1(t, "1100;12;254;231;240;650;-45;482;480;85;0;0;0;0"))
+	defer src.Close()
+	assert.Equal(t, "ttyUSB0", src.Name())
+	assert.Nil(t, src.NewMap(BlockParameters))
+
+	_, err := src.ReadRaw(context.Background(), ParametersRead)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	var got DataTypeMap
+	sink := SinkFunc(func(_ context.Context, host string, _ time.Time, block string, pm DataTypeMap) error {
+		assert.Equal(t, "ttyUSB0", host)
+		assert.Equal(t, BlockCalculations, block)
+		got = pm
+		return nil
+	})
+	p := NewPoller(src, PollerOptions{Blocks: []string{BlockCalculations, BlockParameters}}, sink)
+	require.NoError(t, p.Poll(context.Background()))
+	require.NotNil(t, got)
+	assert.Len(t, got, len(NewLux1CalculationsMap()))
+	assert.Equal(t, float32(25.4), got[0].FromHeatPump())
+	assert.Equal(t, float32(-4.5), got[4].FromHeatPump())
+	assert.Equal(t, "ID_WEB_Temperatur_TA", got[4].Name())
+}
+
+func TestSerialSource_Errors(t *testing.T) {
+	src := NewSerialSource("/dev/ttyUSB0", newMockLux1(t, "1100;12;254"))
+	defer src.Close()
+	_, err := src.ReadRaw(context.Background(), CalculationsRead)
+	assert.ErrorIs(t, err, ErrLengthMismatch)
+
+	src = NewSerialSource("/dev/ttyUSB0", SerialOptions{Open: func(string, int) (io.ReadWriteCloser, error) {
+		return nil, errors.New("no such device")
+	}})
+	_, err = src.ReadRaw(context.Background(), CalculationsRead)
+	assert.EqualError(t, err, "SerialSource.ReadRaw /dev/ttyUSB0: open: no such device")
+}
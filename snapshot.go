@@ -0,0 +1,81 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Snapshot holds the maps of all three blocks of a single read of a heat
+// pump. It is the unit handed to the sinks and the history, see Export.
+type Snapshot struct {
+	// Host names the heat pump, see Client.Name.
+	Host string
+	// Time is the start of the read.
+	Time time.Time
+	// Firmware of the heat pump as sent with the calculations.
+	Firmware     string
+	Parameters   DataTypeMap
+	Calculations DataTypeMap
+	Visibilities DataTypeMap
+}
+
+// Block returns the map of a block, nil for unknown blocks.
+func (s *Snapshot) Block(block string) DataTypeMap {
+	switch block {
+	case BlockParameters:
+		return s.Parameters
+	case BlockCalculations:
+		return s.Calculations
+	case BlockVisibilities:
+		return s.Visibilities
+	}
+	return nil
+}
+
+// Export writes all blocks of the snapshot to sink, calculations first. It
+// tries every block and returns the errors of all failed writes.
+func (s *Snapshot) Export(ctx context.Context, sink Sink) error {
+	var errs []error
+	for _, block := range deviceBlocks {
+		pm := s.Block(block)
+		if pm == nil {
+			continue
+		}
+		if err := sink.Write(ctx, s.Host, s.Time, block, pm); err != nil {
+			errs = append(errs, fmt.Errorf("Snapshot.Export %s: %w", block, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReadAll reads all three blocks into new maps. It connects if needed and
+// holds the client for the whole read, so that concurrent calls do not
+// interleave their blocks. The calculations are read first, their firmware
+// selects the maps of the other blocks, see SelectMaps.
+func (c *Client) ReadAll(ctx context.Context) (*Snapshot, error) {
+	c.snapshot.Lock()
+	defer c.snapshot.Unlock()
+
+	if err := c.connect(ctx); err != nil {
+		return nil, fmt.Errorf("Client.ReadAll: %w", err)
+	}
+	s := &Snapshot{Host: c.Name(), Time: time.Now(), Calculations: NewCalculationsMap()}
+	if err := c.readFromHeatPump(ctx, s.Calculations, CalculationsRead, 0); err != nil {
+		return nil, fmt.Errorf("Client.ReadAll %s: %w", BlockCalculations, err)
+	}
+	s.Firmware = c.firmware
+	maps, err := SelectMaps(s.Firmware)
+	if err != nil {
+		return nil, fmt.Errorf("Client.ReadAll: %w", err)
+	}
+	s.Parameters, s.Visibilities = maps[BlockParameters], maps[BlockVisibilities]
+	if err := c.readFromHeatPump(ctx, s.Parameters, ParametersRead, 0); err != nil {
+		return nil, fmt.Errorf("Client.ReadAll %s: %w", BlockParameters, err)
+	}
+	if err := c.readFromHeatPump(ctx, s.Visibilities, VisibilitiesRead, 0); err != nil {
+		return nil, fmt.Errorf("Client.ReadAll %s: %w", BlockVisibilities, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,43 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadAll(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[10] = 215
+	hp.parameters[ParamHotWaterTarget] = 485
+	hp.visibilities[0] = 1
+	c := MustNewClient(hp.addr(), Options{Alias: "cellar"})
+	defer c.Close()
+
+	s, err := c.ReadAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "cellar", s.Host)
+	assert.WithinDuration(t, time.Now(), s.Time, time.Minute)
+	assert.Equal(t, float32(21.5), s.Calculations[10].FromHeatPump())
+	assert.Equal(t, uint32(485), s.Parameters[ParamHotWaterTarget].RawValue())
+	assert.Equal(t, uint32(1), s.Visibilities[0].RawValue())
+	assert.Nil(t, s.Block("unknown"))
+
+	var blocks []string
+	err = s.Export(context.Background(), SinkFunc(func(_ context.Context, host string, ts time.Time, block string, pm DataTypeMap) error {
+		assert.Equal(t, "cellar", host)
+		assert.Equal(t, s.Time, ts)
+		assert.Same(t, s.Block(block)[0], pm[0])
+		blocks = append(blocks, block)
+		if block == BlockParameters {
+			return errors.New("disk full")
+		}
+		return nil
+	}))
+	assert.EqualError(t, err, "Snapshot.Export parameters: disk full")
+	assert.Equal(t, []string{BlockCalculations, BlockParameters, BlockVisibilities}, blocks)
+}
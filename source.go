@@ -0,0 +1,172 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DataSource delivers the raw values of the blocks of a heat pump. The
+// Client reads them from the controller, a ReplaySource from recorded dumps,
+// tests may use their own double.
+type DataSource interface {
+	// Name identifies the heat pump, e.g. in the values of the sinks.
+	Name() string
+	// ReadRaw returns the raw values of the block of cmd, one of
+	// ParametersRead, CalculationsRead or VisibilitiesRead.
+	ReadRaw(ctx context.Context, cmd int32) ([]uint32, error)
+	Close() error
+}
+
+var _ DataSource = (*Client)(nil)
+
+// MapSource is a DataSource with its own definitions of the blocks, e.g. the
+// SerialSource of Luxtronik 1 controllers. The Poller decodes its values
+// with these maps instead of the catalog.
+type MapSource interface {
+	DataSource
+	// NewMap returns the map of a block, nil if the source lacks it.
+	NewMap(block string) DataTypeMap
+}
+
+var blockCommands = map[string]int32{
+	BlockParameters:   ParametersRead,
+	BlockCalculations: CalculationsRead,
+	BlockVisibilities: VisibilitiesRead,
+}
+
+var catalogLengths = sync.OnceValue(func() map[int32]int {
+	return map[int32]int{
+		ParametersRead:   len(NewParameterMap()),
+		CalculationsRead: len(NewCalculationsMap()),
+		VisibilitiesRead: len(NewVisibilitiesMap()),
+	}
+})
+
+// newBlockMap returns the DataTypeMap of a block, nil for unknown blocks.
+func newBlockMap(block string) DataTypeMap {
+	switch block {
+	case BlockParameters:
+		return NewParameterMap()
+	case BlockCalculations:
+		return NewCalculationsMap()
+	case BlockVisibilities:
+		return NewVisibilitiesMap()
+	}
+	return nil
+}
+
+// catalogLength returns the number of values of the block of cmd known to
+// this package.
+func catalogLength(cmd int32) int {
+	return catalogLengths()[cmd]
+}
+
+// ReplaySource replays dumps, e.g. read by ReadDumps, as a DataSource. Each
+// block advances to the next dump containing it on every read, after the
+// last one ReadRaw returns io.EOF unless Loop is set. Indexes missing in a
+// dump read as zero.
+type ReplaySource struct {
+	// Loop starts over with the first dump after the last one.
+	Loop bool
+
+	name  string
+	dumps []*Dump
+
+	mu   sync.Mutex
+	next map[int32]int
+}
+
+// NewReplaySource replays the dumps in order. The name defaults to the host
+// of the first dump.
+func NewReplaySource(name string, dumps ...*Dump) *ReplaySource {
+	if name == "" && len(dumps) > 0 {
+		name = dumps[0].Host
+	}
+	return &ReplaySource{name: name, dumps: dumps, next: map[int32]int{}}
+}
+
+func (r *ReplaySource) Name() string {
+	return r.name
+}
+
+func (r *ReplaySource) ReadRaw(ctx context.Context, cmd int32) ([]uint32, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	block, ok := blockNames[cmd]
+	if !ok {
+		return nil, fmt.Errorf("ReplaySource.ReadRaw unknown command %d: %w", cmd, ErrInvalidCommand)
+	}
+
+	r.mu.Lock()
+	i := r.find(block, r.next[cmd], len(r.dumps))
+	if i < 0 && r.Loop {
+		i = r.find(block, 0, r.next[cmd])
+	}
+	if i < 0 {
+		r.mu.Unlock()
+		return nil, io.EOF
+	}
+	r.next[cmd] = i + 1
+	r.mu.Unlock()
+
+	raw := make([]uint32, catalogLength(cmd))
+	for _, e := range r.dumps[i].Entries {
+		if e.Block != block || e.Index < 0 {
+			continue
+		}
+		if e.Index >= len(raw) {
+			raw = append(raw, make([]uint32, e.Index+1-len(raw))...)
+		}
+		raw[e.Index] = e.Raw
+	}
+	return raw, nil
+}
+
+// find returns the index of the first dump from start to end which contains
+// the block or -1.
+func (r *ReplaySource) find(block string, start, end int) int {
+	for i := start; i < end; i++ {
+		for _, e := range r.dumps[i].Entries {
+			if e.Block == block {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Close does nothing, the dumps stay in memory.
+func (r *ReplaySource) Close() error {
+	return nil
+}
+
+// NewFileSource replays a file written by the dump command, e.g. to develop
+// dashboards without access to a heat pump. The extension selects the
+// format, see ReadDumps. The name defaults to the host of the first dump or
+// the file name.
+func NewFileSource(file string) (*ReplaySource, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("NewFileSource: %w", err)
+	}
+	defer f.Close()
+
+	dumps, err := ReadDumps(f, strings.TrimPrefix(filepath.Ext(file), "."))
+	if err != nil {
+		return nil, fmt.Errorf("NewFileSource %s: %w", file, err)
+	}
+	if len(dumps) == 0 {
+		return nil, fmt.Errorf("NewFileSource %s has no dumps: %w", file, io.EOF)
+	}
+	name := dumps[0].Host
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	return NewReplaySource(name, dumps...), nil
+}
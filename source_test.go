@@ -0,0 +1,105 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadRaw(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[10] = 215
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+
+	raw, err := c.ReadRaw(context.Background(), CalculationsRead)
+	require.NoError(t, err)
+	assert.Len(t, raw, len(NewCalculationsMap()))
+	assert.Equal(t, uint32(215), raw[10])
+
+	raw, err = c.ReadRaw(context.Background(), VisibilitiesRead)
+	require.NoError(t, err)
+	assert.Len(t, raw, len(NewVisibilitiesMap()))
+}
+
+func TestReplaySource(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	dump := func(raw uint32) *Dump {
+		return &Dump{Time: ts, Host: "hp1", Entries: []DumpEntry{
+			{Block: BlockCalculations, Index: 10, Raw: raw},
+		}}
+	}
+	src := NewReplaySource("", dump(215), dump(220))
+	assert.Equal(t, "hp1", src.Name())
+
+	var got []any
+	sink := SinkFunc(func(_ context.Context, host string, _ time.Time, _ string, pm DataTypeMap) error {
+		assert.Equal(t, "hp1", host)
+		got = append(got, pm[10].FromHeatPump())
+		return nil
+	})
+	p := NewPoller(src, PollerOptions{Blocks: []string{BlockCalculations}}, sink)
+	defer p.Close()
+
+	require.NoError(t, p.Poll(context.Background()))
+	require.NoError(t, p.Poll(context.Background()))
+	assert.Equal(t, []any{float32(21.5), float32(22)}, got)
+
+	err := p.Poll(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, PollerStats{Polls: 3, Failures: 1}, p.Stats())
+
+	_, err = src.ReadRaw(context.Background(), ParametersWrite)
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+}
+
+func TestNewFileSource(t *testing.T) {
+	dir := t.TempDir()
+	calcs := NewCalculationsMap()
+	calcs[10].SetRaw(215)
+	var buf bytes.Buffer
+	require.NoError(t, NewDump("hp1", time.Now(), map[string]DataTypeMap{BlockCalculations: calcs}).WriteJSON(&buf))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hp1.json"), buf.Bytes(), 0o600))
+
+	// two answers of the calculations as sent by the controller
+	raw := make([]uint32, len(calcs))
+	buf.Reset()
+	for _, v := range []uint32{220, 225} {
+		raw[10] = v
+		require.NoError(t, writeMockBlock(&buf, []uint32{CalculationsRead, 0}, raw))
+	}
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, []uint32{VisibilitiesRead, 2}))
+	buf.Write([]byte{1, 0})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "capture.raw"), buf.Bytes(), 0o600))
+
+	src, err := NewFileSource(filepath.Join(dir, "hp1.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "hp1", src.Name())
+	got, err := src.ReadRaw(context.Background(), CalculationsRead)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(215), got[10])
+
+	src, err = NewFileSource(filepath.Join(dir, "capture.raw"))
+	require.NoError(t, err)
+	assert.Equal(t, "capture", src.Name())
+	src.Loop = true
+	for _, want := range []uint32{220, 225, 220} {
+		got, err := src.ReadRaw(context.Background(), CalculationsRead)
+		require.NoError(t, err)
+		assert.Equal(t, want, got[10])
+	}
+	vis, err := src.ReadRaw(context.Background(), VisibilitiesRead)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 0}, vis[:2])
+
+	_, err = NewFileSource(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,189 @@
+package luxtronik
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const spoolFileExt = ".spool"
+
+type SpoolOptions struct {
+	Dir string
+	// MaxBytes caps the size of all spooled records. The oldest records get
+	// dropped once the cap is exceeded. Defaults to 64 MiB.
+	MaxBytes int64
+	Logger   *zap.Logger
+}
+
+// PermanentError marks an error of a send function of the Spool which a
+// retry does not fix, e.g. a payload the target rejects as invalid.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+type spoolRecord struct {
+	seq  uint64
+	size int64
+}
+
+// Spool is an on-disk FIFO queue used by push exporters to buffer payloads
+// while their target is unreachable. Every record is stored in its own file
+// so a crash never corrupts more than the record being written.
+type Spool struct {
+	opts SpoolOptions
+
+	mu      sync.Mutex
+	records []spoolRecord
+	size    int64
+	nextSeq uint64
+}
+
+// OpenSpool creates the spool directory if needed and loads the records left
+// over from a previous run.
+func OpenSpool(opts SpoolOptions) (*Spool, error) {
+	if opts.MaxBytes < 1 {
+		opts.MaxBytes = 64 << 20
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if err := os.MkdirAll(opts.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("OpenSpool failed to create %q: %w", opts.Dir, err)
+	}
+
+	entries, err := os.ReadDir(opts.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("OpenSpool failed to read %q: %w", opts.Dir, err)
+	}
+
+	s := &Spool{opts: opts}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, spoolFileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, spoolFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("OpenSpool failed to stat %q: %w", name, err)
+		}
+		s.records = append(s.records, spoolRecord{seq: seq, size: fi.Size()})
+		s.size += fi.Size()
+	}
+	sort.Slice(s.records, func(i, j int) bool {
+		return s.records[i].seq < s.records[j].seq
+	})
+	if n := len(s.records); n > 0 {
+		s.nextSeq = s.records[n-1].seq + 1
+	}
+	return s, nil
+}
+
+func (s *Spool) path(seq uint64) string {
+	return filepath.Join(s.opts.Dir, fmt.Sprintf("%020d%s", seq, spoolFileExt))
+}
+
+// Len returns the number of pending records.
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+// Append stores a record at the end of the queue.
+func (s *Spool) Append(record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seq := s.nextSeq
+	if err := os.WriteFile(s.path(seq), record, 0o640); err != nil {
+		return fmt.Errorf("Spool.Append failed to write record %d: %w", seq, err)
+	}
+	s.nextSeq++
+	s.records = append(s.records, spoolRecord{seq: seq, size: int64(len(record))})
+	s.size += int64(len(record))
+
+	for s.size > s.opts.MaxBytes && len(s.records) > 1 {
+		oldest := s.records[0]
+		if err := os.Remove(s.path(oldest.seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("Spool.Append failed to drop record %d: %w", oldest.seq, err)
+		}
+		s.records = s.records[1:]
+		s.size -= oldest.size
+		s.opts.Logger.Warn("spool size exceeded, dropped oldest record",
+			zap.String("dir", s.opts.Dir), zap.Uint64("seq", oldest.seq))
+	}
+	return nil
+}
+
+// Replay sends all pending records in order. A record gets removed once send
+// returns nil or a PermanentError, the latter is logged. Replay stops at the
+// first other error and keeps that record.
+func (s *Spool) Replay(send func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.records) > 0 {
+		rec := s.records[0]
+		data, err := os.ReadFile(s.path(rec.seq))
+		if err != nil {
+			return fmt.Errorf("Spool.Replay failed to read record %d: %w", rec.seq, err)
+		}
+		if err := send(data); err != nil {
+			var pErr *PermanentError
+			if !errors.As(err, &pErr) {
+				return err
+			}
+			s.opts.Logger.Error("spooled record rejected, dropped",
+				zap.String("dir", s.opts.Dir), zap.Uint64("seq", rec.seq), zap.Error(err))
+		}
+		if err := os.Remove(s.path(rec.seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("Spool.Replay failed to remove record %d: %w", rec.seq, err)
+		}
+		s.records = s.records[1:]
+		s.size -= rec.size
+	}
+	return nil
+}
+
+// Deliver sends the payload after all pending records have been replayed so
+// that the target receives everything in order. If the target is not
+// reachable the payload gets spooled and Deliver returns nil. A payload
+// rejected with a PermanentError is not spooled, Deliver returns the error.
+// A nil Spool just calls send.
+func (s *Spool) Deliver(payload []byte, send func([]byte) error) error {
+	if s == nil {
+		return send(payload)
+	}
+
+	err := s.Replay(send)
+	if err == nil {
+		err = send(payload)
+	}
+	var pErr *PermanentError
+	if err == nil || errors.As(err, &pErr) {
+		return err
+	}
+
+	if aErr := s.Append(payload); aErr != nil {
+		return errors.Join(err, aErr)
+	}
+	s.opts.Logger.Warn("target unreachable, payload spooled",
+		zap.String("dir", s.opts.Dir), zap.Int("pending", s.Len()), zap.Error(err))
+	return nil
+}
@@ -0,0 +1,57 @@
+package luxtronik
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpool(t *testing.T) {
+	dir := t.TempDir()
+	errOffline := errors.New("offline")
+
+	s, err := OpenSpool(SpoolOptions{Dir: dir, MaxBytes: 6})
+	require.NoError(t, err)
+
+	offline := func([]byte) error { return errOffline }
+	require.NoError(t, s.Deliver([]byte("aa"), offline))
+	require.NoError(t, s.Deliver([]byte("bb"), offline))
+	require.NoError(t, s.Deliver([]byte("cc"), offline))
+	require.NoError(t, s.Deliver([]byte("dd"), offline))
+	assert.Equal(t, 3, s.Len(), "oldest record must be dropped once MaxBytes is exceeded")
+
+	// reopening restores the pending records in order
+	s, err = OpenSpool(SpoolOptions{Dir: dir, MaxBytes: 6})
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.Len())
+
+	var got []string
+	require.NoError(t, s.Deliver([]byte("ee"), func(b []byte) error {
+		got = append(got, string(b))
+		return nil
+	}))
+	assert.Equal(t, []string{"bb", "cc", "dd", "ee"}, got)
+	assert.Equal(t, 0, s.Len())
+
+	// a rejected record is dropped instead of blocking the later ones
+	require.NoError(t, s.Deliver([]byte("ff"), offline))
+	require.NoError(t, s.Deliver([]byte("gg"), offline))
+	got = nil
+	require.NoError(t, s.Deliver([]byte("hh"), func(b []byte) error {
+		if string(b) == "ff" {
+			return &PermanentError{Err: errors.New("invalid")}
+		}
+		got = append(got, string(b))
+		return nil
+	}))
+	assert.Equal(t, []string{"gg", "hh"}, got)
+	assert.Equal(t, 0, s.Len())
+	err = s.Deliver([]byte("ii"), func([]byte) error { return &PermanentError{Err: errOffline} })
+	assert.ErrorIs(t, err, errOffline)
+	assert.Equal(t, 0, s.Len(), "rejected payloads are not spooled")
+
+	var nilSpool *Spool
+	assert.ErrorIs(t, nilSpool.Deliver([]byte("ff"), offline), errOffline)
+}
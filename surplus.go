@@ -0,0 +1,371 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaxSurplusHotWater is the highest hot water target in °C a
+// SurplusController may set, regardless of its options.
+const MaxSurplusHotWater = 65.0
+
+// SurplusSwitch sets a parameter to Value while the surplus lasts, e.g. the
+// hot water mode or an SG Ready input the controller exposes as writeable
+// parameter.
+type SurplusSwitch struct {
+	Name  string
+	Value string
+}
+
+// ParseSurplusSwitch parses switches like "ID_Ba_Bw_akt=Party".
+func ParseSurplusSwitch(s string) (SurplusSwitch, error) {
+	name, value, ok := strings.Cut(s, "=")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if !ok || name == "" || value == "" {
+		return SurplusSwitch{}, fmt.Errorf("ParseSurplusSwitch invalid switch %q, want name=value", s)
+	}
+	return SurplusSwitch{Name: name, Value: value}, nil
+}
+
+type SurplusOptions struct {
+	// On is the PV surplus in W from which the heat pump gets boosted, Off
+	// the surplus below which the boost ends. Defaults to 1500 and 500 W.
+	On  float64
+	Off float64
+	// MinOn and MinOff are the cool-down times: a boost lasts at least MinOn
+	// and the next boost starts at least MinOff after the last one ended.
+	// Both default to 15m to spare the compressor.
+	MinOn  time.Duration
+	MinOff time.Duration
+	// MaxAge of a surplus reading, older readings count as no surplus.
+	// Defaults to 5m.
+	MaxAge time.Duration
+
+	// HotWaterTarget in °C is set during a boost if the current target is
+	// lower, at most MaxSurplusHotWater. Zero keeps the target.
+	HotWaterTarget float64
+	// HeatingBoost in K raises the heating curve offset during a boost, at
+	// most MaxThermostatOffset.
+	HeatingBoost float64
+	// Switches are set during a boost and restored afterwards.
+	Switches []SurplusSwitch
+	// DryRun logs the boosts instead of writing them.
+	DryRun bool
+
+	// URL returns the surplus for Run, either a plain number or a JSON
+	// document. Path is the dot separated path to the surplus within the
+	// document, see PriceFeedOptions, and also applies to ServeHTTP.
+	URL        string
+	Path       string
+	HTTPClient *http.Client
+
+	Logger *zap.Logger
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type surplusSwitch struct {
+	SurplusSwitch
+	index int
+	raw   uint32
+	saved uint32
+}
+
+// SurplusController is a Sink which uses the surplus of a PV system. When
+// the surplus reaches On it raises the hot water target and the heating
+// curve offset and sets the switches, when it falls below Off or the
+// readings get stale it restores the previous values. Readings arrive via
+// Update, ServeHTTP or Run. Closing restores the previous values. The
+// Poller must read the parameters. Do not combine it with a TariffShifter or
+// a Thermostat, they change the same parameters.
+type SurplusController struct {
+	client *Client
+	opts   SurplusOptions
+
+	mu            sync.Mutex
+	surplus       float64
+	updated       time.Time
+	parameters    DataTypeMap
+	switches      []surplusSwitch
+	active        bool
+	changed       time.Time
+	savedHotWater uint32
+	savedOffset   uint32
+}
+
+func NewSurplusController(c *Client, opts SurplusOptions) (*SurplusController, error) {
+	if opts.On == 0 && opts.Off == 0 {
+		opts.On, opts.Off = 1500, 500
+	}
+	if opts.Off >= opts.On {
+		return nil, fmt.Errorf("NewSurplusController off threshold %.0f W must be below the on threshold %.0f W", opts.Off, opts.On)
+	}
+	if opts.HotWaterTarget > MaxSurplusHotWater {
+		return nil, fmt.Errorf("NewSurplusController hot water target %.1f °C above %.0f °C", opts.HotWaterTarget, MaxSurplusHotWater)
+	}
+	if opts.HeatingBoost < 0 || opts.HeatingBoost > MaxThermostatOffset {
+		return nil, fmt.Errorf("NewSurplusController heating boost %.1f K out of range 0-%.0f K", opts.HeatingBoost, MaxThermostatOffset)
+	}
+	if opts.URL != "" {
+		if _, err := url.ParseRequestURI(opts.URL); err != nil {
+			return nil, fmt.Errorf("NewSurplusController failed to parse URL %q: %w", opts.URL, err)
+		}
+	}
+	pm := NewParameterMap()
+	switches := make([]surplusSwitch, 0, len(opts.Switches))
+	for _, sw := range opts.Switches {
+		idx, b, ok := pm.Lookup(sw.Name)
+		if !ok {
+			return nil, fmt.Errorf("NewSurplusController switch %q: %w", sw.Name, ErrUnknownIndex)
+		}
+		if !b.writeable {
+			return nil, fmt.Errorf("NewSurplusController switch %q: %w", sw.Name, ErrWritingNotAllowed)
+		}
+		raw, err := b.ToHeatPump(sw.Value)
+		if err != nil {
+			return nil, fmt.Errorf("NewSurplusController switch %q: %w", sw.Name, err)
+		}
+		switches = append(switches, surplusSwitch{SurplusSwitch: sw, index: idx, raw: raw})
+	}
+	if opts.MinOn <= 0 {
+		opts.MinOn = 15 * time.Minute
+	}
+	if opts.MinOff <= 0 {
+		opts.MinOff = 15 * time.Minute
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = 5 * time.Minute
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &SurplusController{client: c, opts: opts, switches: switches}, nil
+}
+
+// ParseSurplus reads the surplus in W from a plain number or from the JSON
+// document at the dot separated path.
+func ParseSurplus(payload []byte, path string) (float64, error) {
+	payload = bytes.TrimSpace(payload)
+	if v, err := strconv.ParseFloat(string(payload), 64); err == nil && path == "" {
+		return v, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return 0, fmt.Errorf("ParseSurplus failed to decode JSON: %w", err)
+	}
+	v, err := jsonPath(doc, path)
+	if err != nil {
+		return 0, fmt.Errorf("ParseSurplus: %w", err)
+	}
+	var f float64
+	switch n := v.(type) {
+	case json.Number:
+		f, err = n.Float64()
+	case string:
+		f, err = strconv.ParseFloat(n, 64)
+	default:
+		err = errors.New("not a number")
+	}
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("ParseSurplus path %q: %w", path, ErrInvalidValue)
+	}
+	return f, nil
+}
+
+// Update stores the current surplus in W, negative values mean grid
+// consumption.
+func (s *SurplusController) Update(watts float64) {
+	s.mu.Lock()
+	s.surplus = watts
+	s.updated = s.opts.Now()
+	s.mu.Unlock()
+}
+
+// ServeHTTP accepts the surplus via POST, see ParseSurplus.
+func (s *SurplusController) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, 1<<16))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	v, err := ParseSurplus(body, s.opts.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.Update(v)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Fetch reads the surplus from the URL.
+func (s *SurplusController) Fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.opts.URL, nil)
+	if err != nil {
+		return fmt.Errorf("SurplusController.Fetch failed to create request: %w", err)
+	}
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("SurplusController.Fetch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return fmt.Errorf("SurplusController.Fetch failed to read body: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("SurplusController.Fetch received status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	v, err := ParseSurplus(body, s.opts.Path)
+	if err != nil {
+		return fmt.Errorf("SurplusController.Fetch: %w", err)
+	}
+	s.Update(v)
+	return nil
+}
+
+// Run fetches the surplus in the given interval until the context gets
+// cancelled. Errors are logged.
+func (s *SurplusController) Run(ctx context.Context, interval time.Duration) {
+	tkr := time.NewTicker(interval)
+	defer tkr.Stop()
+	for {
+		if err := s.Fetch(ctx); err != nil && ctx.Err() == nil {
+			s.opts.Logger.Error("surplus fetch failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tkr.C:
+		}
+	}
+}
+
+func (s *SurplusController) Write(ctx context.Context, _ string, _ time.Time, block string, pm DataTypeMap) error {
+	if block != BlockParameters {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.parameters = pm
+
+	now := s.opts.Now()
+	fresh := !s.updated.IsZero() && now.Sub(s.updated) <= s.opts.MaxAge
+	switch {
+	case !s.active && fresh && s.surplus >= s.opts.On:
+		if !s.changed.IsZero() && now.Sub(s.changed) < s.opts.MinOff {
+			return nil
+		}
+		return s.switchBoost(ctx, true, now)
+	case s.active && (!fresh || s.surplus < s.opts.Off):
+		if now.Sub(s.changed) < s.opts.MinOn {
+			return nil
+		}
+		return s.switchBoost(ctx, false, now)
+	}
+	return nil
+}
+
+func (s *SurplusController) switchBoost(ctx context.Context, on bool, now time.Time) error {
+	hotWater := s.parameters[ParamHotWaterTarget]
+	offset := s.parameters[ParamHeatingOffset]
+	if on {
+		s.savedHotWater = hotWater.reading.Raw
+		s.savedOffset = offset.reading.Raw
+		for i := range s.switches {
+			s.switches[i].saved = s.parameters[s.switches[i].index].reading.Raw
+		}
+	}
+
+	type write struct {
+		name string
+		idx  int
+		raw  uint32
+	}
+	writes := []write{
+		{"hot water target", ParamHotWaterTarget, s.savedHotWater},
+		{"heating offset", ParamHeatingOffset, s.savedOffset},
+	}
+	if on {
+		if raw, err := hotWater.canonical().ToHeatPump(s.opts.HotWaterTarget); err == nil && s.opts.HotWaterTarget > 0 && raw > s.savedHotWater {
+			writes[0].raw = raw
+		}
+		// raw arithmetic keeps negative offsets in two's complement intact
+		saved := float64(int32(s.savedOffset)) * float64(offset.factor)
+		boosted := math.Max(saved, math.Min(saved+s.opts.HeatingBoost, MaxThermostatOffset))
+		writes[1].raw = uint32(int32(math.Round(boosted / float64(offset.factor))))
+	}
+	for _, sw := range s.switches {
+		raw := sw.saved
+		if on {
+			raw = sw.raw
+		}
+		writes = append(writes, write{sw.Name, sw.index, raw})
+	}
+
+	s.opts.Logger.Info("pv surplus boost changed",
+		zap.Bool("on", on),
+		zap.Float64("surplus_w", s.surplus),
+		zap.Bool("dry_run", s.opts.DryRun))
+	if !s.opts.DryRun {
+		for _, w := range writes {
+			// unchanged values are not written to spare the controller's flash
+			if w.raw == s.parameters[w.idx].reading.Raw {
+				continue
+			}
+			if err := s.client.WriteParameterRawContext(ctx, s.parameters, w.idx, w.raw); err != nil {
+				return fmt.Errorf("SurplusController.switchBoost %s: %w", w.name, err)
+			}
+		}
+	}
+	s.active = on
+	s.changed = now
+	return nil
+}
+
+// Active reports whether a boost is running.
+func (s *SurplusController) Active() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
+// Close restores the previous values if a boost is running.
+func (s *SurplusController) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.active {
+		return nil
+	}
+	if !s.opts.DryRun {
+		if err := s.client.Connect(); err != nil {
+			return fmt.Errorf("SurplusController.Close failed to restore targets: %w", err)
+		}
+	}
+	return s.switchBoost(context.Background(), false, s.opts.Now())
+}
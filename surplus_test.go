@@ -0,0 +1,97 @@
+package luxtronik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSurplus(t *testing.T) {
+	v, err := ParseSurplus([]byte(" 1234.5\n"), "")
+	require.NoError(t, err)
+	assert.Equal(t, 1234.5, v)
+	v, err = ParseSurplus([]byte(`{"site":{"grid":[{"export":"-200"}]}}`), "site.grid.0.export")
+	require.NoError(t, err)
+	assert.Equal(t, -200.0, v)
+
+	_, err = ParseSurplus([]byte(`{"export":true}`), "export")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	_, err = ParseSurplus([]byte(`sunny`), "")
+	assert.Error(t, err)
+}
+
+func TestSurplusController(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, err := NewSurplusController(c, SurplusOptions{
+		HotWaterTarget: 55,
+		HeatingBoost:   1,
+		Switches:       []SurplusSwitch{{Name: "ID_Ba_Bw_akt", Value: "Party"}},
+		Now:            func() time.Time { return now },
+	})
+	require.NoError(t, err)
+
+	m.parameters[ParamHeatingOffset] = uint32(0xFFFFFFFB) // -0.5 K
+	m.parameters[ParamHotWaterTarget] = 480
+	params := NewParameterMap()
+	ctx := context.Background()
+	poll := func() {
+		t.Helper()
+		require.NoError(t, params.SetRawValues(m.parameters))
+		require.NoError(t, s.Write(ctx, "hp", now, BlockParameters, params))
+	}
+
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	res, err := http.Post(srv.URL, "text/plain", strings.NewReader("2000"))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	poll()
+	assert.True(t, s.Active())
+	assert.Equal(t, uint32(550), m.parameters[ParamHotWaterTarget])
+	assert.Equal(t, uint32(5), m.parameters[ParamHeatingOffset], "-0.5 K raised by 1 K")
+	assert.Equal(t, uint32(2), m.parameters[ParamHotWaterMode], "party")
+
+	// the boost lasts at least MinOn
+	now = now.Add(10 * time.Minute)
+	s.Update(100)
+	poll()
+	assert.True(t, s.Active())
+
+	now = now.Add(5 * time.Minute)
+	poll()
+	assert.False(t, s.Active())
+	assert.Equal(t, uint32(480), m.parameters[ParamHotWaterTarget])
+	assert.Equal(t, uint32(0xFFFFFFFB), m.parameters[ParamHeatingOffset])
+	assert.Equal(t, uint32(0), m.parameters[ParamHotWaterMode])
+
+	// cool-down after the boost, then stale readings end it
+	now = now.Add(time.Minute)
+	s.Update(3000)
+	poll()
+	assert.False(t, s.Active())
+	now = now.Add(15 * time.Minute)
+	s.Update(3000)
+	poll()
+	assert.True(t, s.Active())
+	now = now.Add(20 * time.Minute)
+	poll()
+	assert.False(t, s.Active(), "stale reading")
+
+	_, err = NewSurplusController(c, SurplusOptions{Switches: []SurplusSwitch{{Name: "ID_WEB_Temperatur_TA", Value: "1"}}})
+	assert.ErrorIs(t, err, ErrUnknownIndex, "calculations are no parameters")
+	_, err = NewSurplusController(c, SurplusOptions{HotWaterTarget: 70})
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SwitchoffEntry is a stored reason why the heat pump stopped.
+type SwitchoffEntry struct {
+	Time   time.Time
+	Code   uint32
+	Reason string
+}
+
+// SwitchoffHistory decodes the last five switch-off reasons from the
+// calculations, the latest first. Empty slots are left out.
+func SwitchoffHistory(calculations DataTypeMap) []SwitchoffEntry {
+	var entries []SwitchoffEntry
+	for i := 0; i < 5; i++ {
+		ts, okTime := calculations[CalcSwitchoffFileTime0+i]
+		code, okCode := calculations[CalcSwitchoffFileNr0+i]
+		if !okTime || !okCode || ts.reading.Raw == 0 {
+			continue
+		}
+		reason := fmt.Sprint(code.FromHeatPump())
+		if reason == "" {
+			reason = fmt.Sprintf("unknown code: %d", code.reading.Raw)
+		}
+		entries = append(entries, SwitchoffEntry{
+			Time:   time.Unix(int64(ts.reading.Raw), 0),
+			Code:   code.reading.Raw,
+			Reason: reason,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.After(entries[j].Time) })
+	return entries
+}
+
+// ReadSwitchoffHistory reads the calculations and returns the switch-off
+// reasons, see SwitchoffHistory.
+func (c *Client) ReadSwitchoffHistory() ([]SwitchoffEntry, error) {
+	return c.ReadSwitchoffHistoryContext(context.Background())
+}
+
+// ReadSwitchoffHistoryContext is ReadSwitchoffHistory with a context, see
+// ReadParametersContext.
+func (c *Client) ReadSwitchoffHistoryContext(ctx context.Context) ([]SwitchoffEntry, error) {
+	pm := NewCalculationsMap()
+	if err := c.ReadCalculationsContext(ctx, pm); err != nil {
+		return nil, fmt.Errorf("ReadSwitchoffHistory: %w", err)
+	}
+	return SwitchoffHistory(pm), nil
+}
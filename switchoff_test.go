@@ -0,0 +1,30 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadSwitchoffHistory(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.calculations[CalcSwitchoffFileTime0] = 1700000000
+	hp.calculations[CalcSwitchoffFileNr0] = 3
+	hp.calculations[CalcSwitchoffFileTime2] = 1710000000
+	hp.calculations[CalcSwitchoffFileNr2] = 12
+	hp.calculations[CalcSwitchoffFileTime4] = 1690000000
+	hp.calculations[CalcSwitchoffFileNr4] = 20
+
+	c := MustNewClient(hp.addr(), Options{})
+	defer c.Close()
+	require.NoError(t, c.Connect())
+	entries, err := c.ReadSwitchoffHistory()
+	require.NoError(t, err)
+	assert.Equal(t, []SwitchoffEntry{
+		{Time: time.Unix(1710000000, 0), Code: 12, Reason: "unknown code: 12"},
+		{Time: time.Unix(1700000000, 0), Code: 3, Reason: "evu lock"},
+		{Time: time.Unix(1690000000, 0), Code: 20, Reason: "unknown code: 20"},
+	}, entries)
+}
@@ -0,0 +1,317 @@
+package luxtronik
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TariffWindow is a daily period with a cheap (NT) electricity tariff. End
+// may be before Start for windows spanning midnight. Empty Weekdays means
+// every day, the weekday refers to the start of the window.
+type TariffWindow struct {
+	Start    time.Duration // offset since midnight
+	End      time.Duration
+	Weekdays []time.Weekday
+}
+
+// ParseTariffWindow parses windows like "22:00-06:00".
+func ParseTariffWindow(s string) (TariffWindow, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return TariffWindow{}, fmt.Errorf("ParseTariffWindow invalid window %q, want HH:MM-HH:MM", s)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return TariffWindow{}, fmt.Errorf("ParseTariffWindow invalid start %q: %w", s, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return TariffWindow{}, fmt.Errorf("ParseTariffWindow invalid end %q: %w", s, err)
+	}
+	return TariffWindow{Start: start, End: end}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t lies within the window.
+func (w TariffWindow) Contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	since := t.Sub(midnight)
+	if w.Start <= w.End {
+		return since >= w.Start && since < w.End && w.onDay(t.Weekday())
+	}
+	// spans midnight: the evening part belongs to today, the morning part
+	// to the window started yesterday
+	if since >= w.Start {
+		return w.onDay(t.Weekday())
+	}
+	return since < w.End && w.onDay(t.AddDate(0, 0, -1).Weekday())
+}
+
+func (w TariffWindow) onDay(d time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, wd := range w.Weekdays {
+		if wd == d {
+			return true
+		}
+	}
+	return false
+}
+
+type TariffOptions struct {
+	// CheapWindows are the periods of the cheap tariff.
+	CheapWindows []TariffWindow
+	// HighPrice and LowPrice per kWh of electrical energy.
+	HighPrice float64
+	LowPrice  float64
+
+	// Prices replaces the fixed windows with dynamic prices. A period is
+	// cheap if its price is at most MaxPrice or if it is one of the
+	// CheapestHours of its day. The savings are computed against the daily
+	// average price.
+	Prices        *PriceFeed
+	MaxPrice      float64
+	CheapestHours int
+
+	// HotWaterTarget in °C is set during cheap periods if the current target
+	// is lower.
+	HotWaterTarget float64
+	// HeatingBoost in K raises the heating curve offset during cheap periods
+	// to load the buffer.
+	HeatingBoost float64
+
+	// Setback in K lowers the heating curve offset during expensive periods,
+	// zero disables it. The hot water target is lowered to HotWaterFloor if
+	// that is set. Below OutdoorFloor in °C no setback is applied.
+	Setback       float64
+	HotWaterFloor float64
+	OutdoorFloor  float64
+
+	// COP estimates the electrical energy from the heat quantity for the
+	// savings report. Defaults to 3.5.
+	COP    float64
+	Logger *zap.Logger
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// TariffReport summarizes the heat produced per tariff and the savings
+// compared to producing the heat of the cheap periods at the high price.
+type TariffReport struct {
+	CheapHeat     float64 // kWh heat quantity
+	ExpensiveHeat float64 // kWh heat quantity
+	Shifts        int
+	Savings       float64
+}
+
+type tariffMode int
+
+const (
+	tariffNeutral tariffMode = iota
+	tariffBoost
+	tariffSetback
+)
+
+func (m tariffMode) String() string {
+	return [...]string{"neutral", "boost", "setback"}[m]
+}
+
+// TariffShifter is a Sink which shifts hot water preparation and loads the
+// buffer during cheap tariff periods. It raises the hot water target and the
+// heating curve offset when a cheap period starts, optionally lowers them
+// during expensive periods and restores the previous values otherwise or
+// when the shifter gets closed. The Poller must read parameters and
+// calculations.
+type TariffShifter struct {
+	client *Client
+	opts   TariffOptions
+
+	mu            sync.Mutex
+	parameters    DataTypeMap
+	mode          tariffMode
+	savedHotWater uint32
+	savedOffset   uint32
+	lastHeat      float64
+	lastTime      time.Time
+	lastCheap     bool
+	report        TariffReport
+}
+
+func NewTariffShifter(c *Client, opts TariffOptions) *TariffShifter {
+	if opts.COP <= 0 {
+		opts.COP = 3.5
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &TariffShifter{client: c, opts: opts}
+}
+
+// IsCheap reports whether t lies within one of the cheap windows or, with
+// dynamic prices, whether the price at t is cheap.
+func (s *TariffShifter) IsCheap(t time.Time) bool {
+	if s.opts.Prices != nil {
+		if p, ok := s.opts.Prices.At(t); ok && p.Value <= s.opts.MaxPrice {
+			return true
+		}
+		return s.opts.CheapestHours > 0 && s.opts.Prices.IsCheapest(t, s.opts.CheapestHours)
+	}
+	for _, w := range s.opts.CheapWindows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TariffShifter) Write(_ context.Context, _ string, _ time.Time, block string, pm DataTypeMap) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch block {
+	case BlockParameters:
+		s.parameters = pm
+		return nil
+	case BlockCalculations:
+	default:
+		return nil
+	}
+
+	now := s.opts.Now()
+	cheap := s.IsCheap(now)
+	s.account(now, pm, cheap)
+
+	if s.parameters == nil {
+		return nil
+	}
+	want := tariffNeutral
+	switch {
+	case cheap:
+		want = tariffBoost
+	case s.opts.Setback > 0 && float64(int32(pm[CalcOutdoorTemperature].reading.Raw))*0.1 >= s.opts.OutdoorFloor:
+		want = tariffSetback
+	}
+	if want == s.mode {
+		return nil
+	}
+	return s.switchMode(want)
+}
+
+// account attributes the heat quantity produced since the last poll to the
+// tariff which was valid at the last poll.
+func (s *TariffShifter) account(now time.Time, pm DataTypeMap, cheap bool) {
+	heat := float64(pm[CalcHeatQuantityHeating].reading.Raw+pm[CalcHeatQuantityHotWater].reading.Raw) * 0.1
+	if delta := heat - s.lastHeat; s.lastHeat > 0 && delta > 0 {
+		if s.lastCheap {
+			s.report.CheapHeat += delta
+			s.report.Savings += delta / s.opts.COP * s.priceSpread(s.lastTime)
+		} else {
+			s.report.ExpensiveHeat += delta
+		}
+	}
+	s.lastHeat = heat
+	s.lastTime = now
+	s.lastCheap = cheap
+}
+
+// priceSpread returns the price difference per kWh saved by consuming at t.
+func (s *TariffShifter) priceSpread(t time.Time) float64 {
+	if s.opts.Prices == nil {
+		return s.opts.HighPrice - s.opts.LowPrice
+	}
+	p, ok := s.opts.Prices.At(t)
+	avg, ok2 := s.opts.Prices.DayAverage(t)
+	if !ok || !ok2 {
+		return 0
+	}
+	return avg - p.Value
+}
+
+func (s *TariffShifter) switchMode(mode tariffMode) error {
+	hotWater := s.parameters[ParamHotWaterTarget]
+	offset := s.parameters[ParamHeatingOffset]
+	if s.mode == tariffNeutral {
+		s.savedHotWater = hotWater.reading.Raw
+		s.savedOffset = offset.reading.Raw
+	}
+
+	// raw arithmetic keeps negative offsets in two's complement intact
+	kelvin := func(k float64) uint32 {
+		return uint32(int32(math.Round(k / float64(offset.factor))))
+	}
+	hw, off := s.savedHotWater, s.savedOffset
+	switch mode {
+	case tariffBoost:
+		if raw, err := hotWater.canonical().ToHeatPump(s.opts.HotWaterTarget); err == nil && raw > hw {
+			hw = raw
+		}
+		off += kelvin(s.opts.HeatingBoost)
+	case tariffSetback:
+		if raw, err := hotWater.canonical().ToHeatPump(s.opts.HotWaterFloor); err == nil && s.opts.HotWaterFloor > 0 && raw < hw {
+			hw = raw
+		}
+		off -= kelvin(s.opts.Setback)
+	}
+
+	// a boost on top of a high offset must not leave the range
+	hw, off = hotWater.clampRaw(hw), offset.clampRaw(off)
+
+	// unchanged values are not written to spare the controller's flash
+	if hw != hotWater.reading.Raw {
+		if err := s.client.WriteParameterRaw(s.parameters, ParamHotWaterTarget, hw); err != nil {
+			return fmt.Errorf("TariffShifter.switchMode %s hot water target: %w", mode, err)
+		}
+	}
+	if off != offset.reading.Raw {
+		if err := s.client.WriteParameterRaw(s.parameters, ParamHeatingOffset, off); err != nil {
+			return fmt.Errorf("TariffShifter.switchMode %s heating offset: %w", mode, err)
+		}
+	}
+	if mode == tariffBoost {
+		s.report.Shifts++
+	}
+	s.opts.Logger.Info("tariff mode changed",
+		zap.Stringer("from", s.mode),
+		zap.Stringer("to", mode),
+		zap.Float64("cheap_heat_kwh", s.report.CheapHeat),
+		zap.Float64("expensive_heat_kwh", s.report.ExpensiveHeat),
+		zap.Float64("savings", s.report.Savings))
+	s.mode = mode
+	return nil
+}
+
+func (s *TariffShifter) Report() TariffReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.report
+}
+
+// Close restores the original targets if they have been changed.
+func (s *TariffShifter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mode == tariffNeutral {
+		return nil
+	}
+	if err := s.client.Connect(); err != nil {
+		return fmt.Errorf("TariffShifter.Close failed to restore targets: %w", err)
+	}
+	return s.switchMode(tariffNeutral)
+}
@@ -0,0 +1,157 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTariffWindow_Contains(t *testing.T) {
+	w, err := ParseTariffWindow("22:00-06:00")
+	require.NoError(t, err)
+	w.Weekdays = []time.Weekday{time.Friday}
+
+	at := func(day int, clock string) time.Time {
+		c, _ := time.Parse("15:04", clock)
+		// 2024-03-01 is a Friday
+		return time.Date(2024, 3, day, c.Hour(), c.Minute(), 0, 0, time.UTC)
+	}
+	assert.False(t, w.Contains(at(1, "21:59")))
+	assert.True(t, w.Contains(at(1, "22:00")))
+	assert.True(t, w.Contains(at(2, "05:59")), "morning after the friday window")
+	assert.False(t, w.Contains(at(2, "06:00")))
+	assert.False(t, w.Contains(at(2, "23:00")), "saturday evening")
+
+	_, err = ParseTariffWindow("22:00")
+	assert.Error(t, err)
+}
+
+func TestTariffShifter(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	now := time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)
+	w, err := ParseTariffWindow("22:00-06:00")
+	require.NoError(t, err)
+	s := NewTariffShifter(c, TariffOptions{
+		CheapWindows:   []TariffWindow{w},
+		HighPrice:      0.40,
+		LowPrice:       0.25,
+		HotWaterTarget: 50,
+		HeatingBoost:   2,
+		COP:            3,
+		Now:            func() time.Time { return now },
+	})
+
+	params := NewParameterMap()
+	params[ParamHeatingOffset].reading.Raw = uint32(0xFFFFFFF6) // -1.0 K
+	params[ParamHotWaterTarget].reading.Raw = 450
+	calcs := NewCalculationsMap()
+	calcs[CalcHeatQuantityHeating].reading.Raw = 1000
+
+	ctx := context.Background()
+	require.NoError(t, s.Write(ctx, "hp", now, BlockParameters, params))
+	require.NoError(t, s.Write(ctx, "hp", now, BlockCalculations, calcs))
+	assert.Equal(t, uint32(500), m.parameters[ParamHotWaterTarget])
+	assert.Equal(t, uint32(10), m.parameters[ParamHeatingOffset], "-1.0 K raised by 2 K")
+
+	// 9 kWh heat during the cheap window, 3 kWh after it ended
+	now = now.Add(7 * time.Hour)
+	require.NoError(t, params.SetRawValues(m.parameters))
+	calcs[CalcHeatQuantityHeating].reading.Raw = 1090
+	require.NoError(t, s.Write(ctx, "hp", now, BlockParameters, params))
+	require.NoError(t, s.Write(ctx, "hp", now, BlockCalculations, calcs))
+	assert.Equal(t, uint32(450), m.parameters[ParamHotWaterTarget])
+	assert.Equal(t, uint32(0xFFFFFFF6), m.parameters[ParamHeatingOffset])
+
+	calcs[CalcHeatQuantityHeating].reading.Raw = 1120
+	require.NoError(t, s.Write(ctx, "hp", now, BlockCalculations, calcs))
+
+	r := s.Report()
+	assert.Equal(t, 1, r.Shifts)
+	assert.InDelta(t, 9, r.CheapHeat, 0.001)
+	assert.InDelta(t, 3, r.ExpensiveHeat, 0.001)
+	assert.InDelta(t, 0.45, r.Savings, 0.001)
+}
+
+func TestTariffShifter_BoostRange(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	now := time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)
+	w, err := ParseTariffWindow("22:00-06:00")
+	require.NoError(t, err)
+	s := NewTariffShifter(c, TariffOptions{
+		CheapWindows: []TariffWindow{w},
+		HeatingBoost: 5,
+		Setback:      9,
+		Now:          func() time.Time { return now },
+	})
+
+	params := NewParameterMap()
+	params[ParamHeatingOffset].reading.Raw = 30 // +3 K
+	ctx := context.Background()
+	require.NoError(t, s.Write(ctx, "hp", now, BlockParameters, params))
+	require.NoError(t, s.Write(ctx, "hp", now, BlockCalculations, NewCalculationsMap()))
+	assert.Equal(t, uint32(50), m.parameters[ParamHeatingOffset], "+3 K boosted by 5 K ends at the maximum of 5 K")
+
+	// raw writes outside the range are rejected before they reach the
+	// controller, which would clamp them silently
+	err = c.WriteParameterRaw(params, ParamHeatingOffset, 80)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestTariffShifter_Prices(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	feed, err := NewPriceFeed(PriceFeedOptions{URL: "http://localhost/prices"})
+	require.NoError(t, err)
+	var prices []Price
+	for h, v := range []float64{0.30, 0.10, 0.20, 0.40} {
+		start := day.Add(time.Duration(h) * time.Hour)
+		prices = append(prices, Price{Start: start, End: start.Add(time.Hour), Value: v})
+	}
+	feed.merge(prices)
+
+	now := day.Add(3 * time.Hour)
+	s := NewTariffShifter(c, TariffOptions{
+		Prices:        feed,
+		CheapestHours: 1,
+		Setback:       1,
+		HotWaterFloor: 42,
+		OutdoorFloor:  -5,
+		Now:           func() time.Time { return now },
+	})
+	assert.True(t, s.IsCheap(day.Add(time.Hour+30*time.Minute)))
+	assert.False(t, s.IsCheap(day.Add(2*time.Hour)))
+
+	params := NewParameterMap()
+	params[ParamHotWaterTarget].reading.Raw = 480
+	calcs := NewCalculationsMap()
+	calcs[CalcOutdoorTemperature].reading.Raw = 20
+
+	ctx := context.Background()
+	require.NoError(t, s.Write(ctx, "hp", now, BlockParameters, params))
+	require.NoError(t, s.Write(ctx, "hp", now, BlockCalculations, calcs))
+	assert.Equal(t, uint32(420), m.parameters[ParamHotWaterTarget])
+	assert.Equal(t, uint32(0xFFFFFFF6), m.parameters[ParamHeatingOffset], "setback by 1 K")
+
+	// the outdoor floor ends the setback
+	require.NoError(t, params.SetRawValues(m.parameters))
+	calcs[CalcOutdoorTemperature].reading.Raw = uint32(0xFFFFFFC4) // -6.0 °C
+	require.NoError(t, s.Write(ctx, "hp", now, BlockParameters, params))
+	require.NoError(t, s.Write(ctx, "hp", now, BlockCalculations, calcs))
+	assert.Equal(t, uint32(480), m.parameters[ParamHotWaterTarget])
+	assert.Equal(t, uint32(0), m.parameters[ParamHeatingOffset])
+}
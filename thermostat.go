@@ -0,0 +1,206 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaxThermostatOffset is the largest heating curve offset in K a Thermostat
+// may set in either direction, regardless of its options.
+const MaxThermostatOffset = 5.0
+
+// DefaultThermostatInterval is the default minimum time between two writes
+// of a Thermostat.
+const DefaultThermostatInterval = 15 * time.Minute
+
+type ThermostatOptions struct {
+	// Sensor names the room sensor of the RoomSensors which the Poller
+	// forwards as BlockSensors.
+	Sensor string
+	// Target room temperature in °C.
+	Target float64
+	// Kp is the offset in K per K of control error, Ki the offset in K per
+	// K of control error and hour.
+	Kp float64
+	Ki float64
+
+	// MinOffset and MaxOffset clamp the heating curve offset in K, both
+	// must lie within ±MaxThermostatOffset. Defaults to ±2 K.
+	MinOffset float64
+	MaxOffset float64
+	// MaxStep limits the change of the offset per write in K, defaults to
+	// 0.5 K.
+	MaxStep float64
+	// MinInterval is the minimum time between two writes to spare the flash
+	// of the controller, defaults to DefaultThermostatInterval.
+	MinInterval time.Duration
+	// DryRun logs the offsets instead of writing them.
+	DryRun bool
+
+	Logger *zap.Logger
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Thermostat is a Sink which closes the loop between an external room
+// sensor and the heating curve offset with a PI controller, for houses where
+// the room influence of the controller is insufficient. The offset starts
+// from the value found at the first poll, gets clamped to the safety limits
+// and changes at most MaxStep per MinInterval. Without a current reading of
+// the sensor the offset is kept. Closing restores the original offset. The
+// Poller must read the parameters and forward the room sensors. Do not
+// combine it with a TariffShifter, both change the offset.
+type Thermostat struct {
+	client *Client
+	opts   ThermostatOptions
+	name   string
+
+	mu         sync.Mutex
+	parameters DataTypeMap
+	saved      uint32
+	base       float64 // K, the original offset
+	offset     float64 // K, last written or, in a dry run, logged
+	started    bool
+	integral   float64 // K*h
+	lastTime   time.Time
+	lastWrite  time.Time
+}
+
+func NewThermostat(c *Client, opts ThermostatOptions) (*Thermostat, error) {
+	if opts.Sensor == "" {
+		return nil, errors.New("NewThermostat missing sensor")
+	}
+	if opts.Target < 5 || opts.Target > 30 {
+		return nil, fmt.Errorf("NewThermostat target %.1f °C out of range 5-30 °C", opts.Target)
+	}
+	if opts.Kp < 0 || opts.Ki < 0 {
+		return nil, errors.New("NewThermostat negative gains")
+	}
+	if opts.MinOffset == 0 && opts.MaxOffset == 0 {
+		opts.MinOffset, opts.MaxOffset = -2, 2
+	}
+	if opts.MinOffset < -MaxThermostatOffset || opts.MaxOffset > MaxThermostatOffset || opts.MinOffset >= opts.MaxOffset {
+		return nil, fmt.Errorf("NewThermostat offset limits %.1f to %.1f K must lie within ±%.0f K", opts.MinOffset, opts.MaxOffset, MaxThermostatOffset)
+	}
+	if opts.MaxStep <= 0 {
+		opts.MaxStep = 0.5
+	}
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = DefaultThermostatInterval
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Thermostat{client: c, opts: opts, name: sensorValueName(opts.Sensor, "Temperature")}, nil
+}
+
+func (t *Thermostat) Write(_ context.Context, _ string, _ time.Time, block string, pm DataTypeMap) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch block {
+	case BlockParameters:
+		t.parameters = pm
+		if !t.started {
+			b := pm[ParamHeatingOffset]
+			t.saved = b.reading.Raw
+			t.base = math.Round(b.number(b.reading.Raw)*float64(b.factor)*10) / 10
+			t.offset = t.base
+			t.started = true
+		}
+		return nil
+	case BlockSensors:
+	default:
+		return nil
+	}
+	if !t.started {
+		return nil
+	}
+	_, b, ok := pm.Lookup(t.name)
+	if !ok {
+		return nil
+	}
+	room, ok := b.canonical().FromHeatPump().(float32)
+	if !ok {
+		return nil
+	}
+	return t.control(float64(room))
+}
+
+// control runs one cycle of the PI controller.
+func (t *Thermostat) control(room float64) error {
+	now := t.opts.Now()
+	e := t.opts.Target - room
+	if !t.lastTime.IsZero() {
+		// a gap, e.g. of a stale sensor, must not wind up the integral
+		dt := min(now.Sub(t.lastTime), time.Hour).Hours()
+		t.integral += e * dt
+	}
+	t.lastTime = now
+	// anti-windup: the integral alone never exceeds the limits
+	if t.opts.Ki > 0 {
+		t.integral = clamp(t.integral, t.opts.MinOffset/t.opts.Ki, t.opts.MaxOffset/t.opts.Ki)
+	}
+
+	want := clamp(t.base+t.opts.Kp*e+t.opts.Ki*t.integral, t.opts.MinOffset, t.opts.MaxOffset)
+	if !t.lastWrite.IsZero() && now.Sub(t.lastWrite) < t.opts.MinInterval {
+		return nil
+	}
+	next := clamp(want, t.offset-t.opts.MaxStep, t.offset+t.opts.MaxStep)
+	next = math.Round(next*10) / 10
+	if next == t.offset {
+		return nil
+	}
+
+	t.opts.Logger.Info("thermostat adjusts the heating offset",
+		zap.Float64("room", room),
+		zap.Float64("target", t.opts.Target),
+		zap.Float64("from", t.offset),
+		zap.Float64("to", next),
+		zap.Bool("dry_run", t.opts.DryRun))
+	if !t.opts.DryRun {
+		if err := t.client.WriteParameter(t.parameters, ParamHeatingOffset, next); err != nil {
+			return fmt.Errorf("Thermostat.control heating offset %.1f: %w", next, err)
+		}
+	}
+	t.offset = next
+	t.lastWrite = now
+	return nil
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// Offset returns the heating curve offset in K set by the thermostat.
+func (t *Thermostat) Offset() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.offset
+}
+
+// Close restores the original heating curve offset if it has been changed.
+func (t *Thermostat) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lastWrite.IsZero() || t.opts.DryRun {
+		return nil
+	}
+	if err := t.client.Connect(); err != nil {
+		return fmt.Errorf("Thermostat.Close failed to restore the offset: %w", err)
+	}
+	if err := t.client.WriteParameterRaw(t.parameters, ParamHeatingOffset, t.saved); err != nil {
+		return fmt.Errorf("Thermostat.Close failed to restore the offset: %w", err)
+	}
+	t.lastWrite = time.Time{}
+	return nil
+}
@@ -0,0 +1,75 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThermostat(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	now := time.Date(2024, 1, 10, 6, 0, 0, 0, time.UTC)
+	th, err := NewThermostat(c, ThermostatOptions{
+		Sensor: "Living Room",
+		Target: 21,
+		Kp:     1,
+		Ki:     0.5,
+		Now:    func() time.Time { return now },
+	})
+	require.NoError(t, err)
+
+	sensors := NewRoomSensors(0)
+	room := func(v float64) DataTypeMap {
+		require.NoError(t, sensors.Update(SensorReading{Sensor: "living room", Temperature: &v, Time: now}))
+		return sensors.Map()
+	}
+	params := NewParameterMap()
+	params[ParamHeatingOffset].reading.Raw = uint32(0xFFFFFFFB) // -0.5 K
+
+	ctx := context.Background()
+	require.NoError(t, th.Write(ctx, "hp", now, BlockParameters, params))
+	require.NoError(t, th.Write(ctx, "hp", now, BlockSensors, room(19)))
+	assert.Equal(t, uint32(0), m.parameters[ParamHeatingOffset], "-0.5 K raised by one step")
+	assert.Equal(t, 0.0, th.Offset())
+
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, th.Write(ctx, "hp", now, BlockSensors, room(19)))
+	assert.Equal(t, 0.0, th.Offset(), "rate limited")
+
+	for i := 0; i < 20; i++ {
+		now = now.Add(15 * time.Minute)
+		require.NoError(t, th.Write(ctx, "hp", now, BlockSensors, room(17)))
+	}
+	assert.Equal(t, 2.0, th.Offset(), "clamped to the safety limit")
+	assert.Equal(t, uint32(20), m.parameters[ParamHeatingOffset])
+
+	require.NoError(t, th.Close())
+	assert.Equal(t, uint32(0xFFFFFFFB), m.parameters[ParamHeatingOffset], "restored")
+
+	_, err = NewThermostat(c, ThermostatOptions{Sensor: "x", Target: 21, MaxOffset: 8})
+	assert.Error(t, err)
+}
+
+func TestThermostat_DryRun(t *testing.T) {
+	m := newMockHeatPump(t)
+	c := MustNewClient(m.addr(), Options{})
+	th, err := NewThermostat(c, ThermostatOptions{Sensor: "hall", Target: 20, Kp: 2, DryRun: true})
+	require.NoError(t, err)
+
+	sensors := NewRoomSensors(0)
+	v := 22.0
+	require.NoError(t, sensors.Update(SensorReading{Sensor: "hall", Temperature: &v}))
+	ctx := context.Background()
+	require.NoError(t, th.Write(ctx, "hp", time.Now(), BlockParameters, NewParameterMap()))
+	require.NoError(t, th.Write(ctx, "hp", time.Now(), BlockSensors, sensors.Map()))
+	assert.Equal(t, -0.5, th.Offset())
+	assert.Zero(t, m.parameters[ParamHeatingOffset], "nothing written")
+	require.NoError(t, th.Close())
+}
@@ -0,0 +1,40 @@
+package luxtronik
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/SchumacherFM/luxtronik"
+
+// span attributes in addition to the semantic conventions
+const (
+	attrHost    = attribute.Key("luxtronik.host")
+	attrCommand = attribute.Key("luxtronik.command")
+	attrLength  = attribute.Key("luxtronik.length")
+	attrIndex   = attribute.Key("luxtronik.index")
+)
+
+// startSpan starts a client span carrying the address of the heat pump.
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	port, _ := strconv.Atoi(c.port)
+	attrs = append(attrs,
+		attrHost.String(c.Name()),
+		attribute.String("server.address", c.host),
+		attribute.Int("server.port", port),
+	)
+	return c.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan records err and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
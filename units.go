@@ -0,0 +1,112 @@
+package luxtronik
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Units selects the unit system of converted values, see Options.Units.
+type Units int
+
+const (
+	// UnitsMetric returns the values in the units of the controller.
+	UnitsMetric Units = iota
+	// UnitsImperial converts °C to °F, kWh to BTU, l/h to gpm (US gallons)
+	// and bar to psi. Temperature differences in K are not converted.
+	UnitsImperial
+)
+
+func (u Units) String() string {
+	if u == UnitsImperial {
+		return "imperial"
+	}
+	return "metric"
+}
+
+// ParseUnits parses metric or imperial.
+func ParseUnits(s string) (Units, error) {
+	switch strings.ToLower(s) {
+	case "", "metric":
+		return UnitsMetric, nil
+	case "imperial":
+		return UnitsImperial, nil
+	}
+	return UnitsMetric, fmt.Errorf("ParseUnits %q, want metric or imperial: %w", s, ErrInvalidValue)
+}
+
+const (
+	btuPerKWh      = 3412.14163
+	litresPerGal   = 3.785411784
+	psiPerBar      = 14.5037738
+	fahrenheitZero = 32
+)
+
+type unitConversion struct {
+	unit string
+	from func(float64) float64
+	to   func(float64) float64
+}
+
+// imperialUnits maps the units of the controller to their imperial
+// counterpart.
+var imperialUnits = map[string]unitConversion{
+	"°C": {
+		unit: "°F",
+		from: func(v float64) float64 { return v*9/5 + fahrenheitZero },
+		to:   func(v float64) float64 { return (v - fahrenheitZero) * 5 / 9 },
+	},
+	"kWh": {
+		unit: "BTU",
+		from: func(v float64) float64 { return v * btuPerKWh },
+		to:   func(v float64) float64 { return v / btuPerKWh },
+	},
+	"l/h": {
+		unit: "gpm",
+		from: func(v float64) float64 { return v / 60 / litresPerGal },
+		to:   func(v float64) float64 { return v * 60 * litresPerGal },
+	},
+	"bar": {
+		unit: "psi",
+		from: func(v float64) float64 { return v * psiPerBar },
+		to:   func(v float64) float64 { return v / psiPerBar },
+	},
+}
+
+// SetUnits sets the unit system of FromHeatPump, ToHeatPump and Unit.
+func (b *Base) SetUnits(u Units) {
+	b.units = u
+}
+
+// SetUnits sets the unit system of all values, see Options.Units.
+func (pm DataTypeMap) SetUnits(u Units) {
+	for _, b := range pm {
+		b.units = u
+	}
+}
+
+// conversion returns the conversion of the unit of b if it applies.
+func (b *Base) conversion() (unitConversion, bool) {
+	if b.units != UnitsImperial || b.codes != nil || b.customFromHP != nil || b.returnType != reflect.Float32 {
+		return unitConversion{}, false
+	}
+	conv, ok := imperialUnits[b.unit]
+	return conv, ok
+}
+
+// canonical returns a copy of b without unit conversion and translation for
+// the typed APIs, which work in the units and codes of the controller.
+func (b *Base) canonical() *Base {
+	c := *b
+	c.units = UnitsMetric
+	c.lang = ""
+	return &c
+}
+
+// fromMetric converts v from the unit of the controller into the unit of b.
+func (b *Base) fromMetric(v float64) float64 {
+	if conv, ok := b.conversion(); ok {
+		return conv.from(v)
+	}
+	return v
+}
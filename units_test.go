@@ -0,0 +1,65 @@
+package luxtronik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase_SetUnits(t *testing.T) {
+	tests := []struct {
+		b     *Base
+		raw   uint32
+		value float32
+		unit  string
+	}{
+		{NewCelsius("ID_WEB_Temperatur_TVL", false), 215, 70.7, "°F"},
+		{NewCelsius("ID_WEB_Temperatur_TA", false), uint32(0xFFFFFFCE), 23, "°F"}, // -5 °C
+		{NewEnergy("ID_WEB_WMZ_Heizung"), 10, 3412.142, "BTU"},
+		{NewFlow("ID_WEB_Durchfluss_WMZ"), 1200, 5.283, "gpm"},
+		{NewPressure("ID_WEB_LIN_HD"), 150, 21.756, "psi"},
+		{NewKelvin("ID_Einst_HRM_Hyst", true), 20, 2, "K"},
+	}
+	for _, tt := range tests {
+		tt.b.SetRaw(tt.raw)
+		metric := tt.b.FromHeatPump()
+		tt.b.SetUnits(UnitsImperial)
+		assert.Equal(t, tt.value, tt.b.FromHeatPump(), tt.b.Name())
+		assert.Equal(t, tt.unit, tt.b.Unit(), tt.b.Name())
+		assert.Equal(t, metric, tt.b.canonical().FromHeatPump(), tt.b.Name())
+	}
+
+	b := NewCelsius("ID_Einst_WK_akt", true)
+	b.SetUnits(UnitsImperial)
+	raw, err := b.ToHeatPump(113.9)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(455), raw)
+	raw, err = b.canonical().ToHeatPump(45.5)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(455), raw)
+}
+
+func TestParseUnits(t *testing.T) {
+	u, err := ParseUnits("Imperial")
+	require.NoError(t, err)
+	assert.Equal(t, UnitsImperial, u)
+	u, err = ParseUnits("")
+	require.NoError(t, err)
+	assert.Equal(t, UnitsMetric, u)
+	_, err = ParseUnits("nautical")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestClient_Units(t *testing.T) {
+	m := newMockHeatPump(t)
+	m.calculations[CalcOutdoorTemperature] = 100
+	c := MustNewClient(m.addr(), Options{Units: UnitsImperial})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	pm := NewCalculationsMap()
+	require.NoError(t, c.ReadCalculations(pm))
+	assert.Equal(t, float32(50), pm[CalcOutdoorTemperature].FromHeatPump())
+	assert.Equal(t, "°F", pm[CalcOutdoorTemperature].Unit())
+}
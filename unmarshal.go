@@ -0,0 +1,164 @@
+package luxtronik
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Unmarshal fills the fields of the struct pointed to by v with the values
+// of pm. Fields select their value with the lux tag, either by index or by
+// luxtronik name, the option raw assigns the raw value:
+//
+//	type Status struct {
+//		Flow    float32       `lux:"10"`
+//		Outdoor float64       `lux:"name=ID_WEB_Temperatur_TA"`
+//		Mode    string        `lux:"80"`
+//		ModeRaw uint32        `lux:"80,raw"`
+//		Runtime time.Duration `lux:"64"`
+//	}
+//
+// The converted value gets cast to the type of the field, fields without tag
+// are skipped.
+func Unmarshal(pm DataTypeMap, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Unmarshal requires a pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs []error
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, ok := f.Tag.Lookup("lux")
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+		b, raw, err := luxField(pm, tag)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Unmarshal field %s: %w", f.Name, err))
+			continue
+		}
+		val := b.FromHeatPump()
+		if raw {
+			val = b.reading.Raw
+		}
+		if err := setField(rv.Field(i), val); err != nil {
+			errs = append(errs, fmt.Errorf("Unmarshal field %s from %s: %w", f.Name, b.luxtronikName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// luxField resolves the lux tag of a field.
+func luxField(pm DataTypeMap, tag string) (b *Base, raw bool, err error) {
+	key, opts, _ := strings.Cut(tag, ",")
+	switch opts {
+	case "":
+	case "raw":
+		raw = true
+	default:
+		return nil, false, fmt.Errorf("unknown option %q", opts)
+	}
+
+	if name, ok := strings.CutPrefix(key, "name="); ok {
+		if _, b, ok := pm.Lookup(name); ok {
+			return b, raw, nil
+		}
+		return nil, false, fmt.Errorf("name %q: %w", name, ErrUnknownIndex)
+	}
+	idx, err := strconv.Atoi(key)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid tag %q, want an index or name=", tag)
+	}
+	if b, ok := pm[idx]; ok {
+		return b, raw, nil
+	}
+	return nil, false, fmt.Errorf("index %d: %w", idx, ErrUnknownIndex)
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+func setField(f reflect.Value, val any) error {
+	// codes into fields of their enum type, e.g. HeatingMode
+	if rv := reflect.ValueOf(val); rv.IsValid() && rv.Type() == f.Type() {
+		f.Set(rv)
+		return nil
+	}
+	if f.Type() == durationType {
+		d, ok := val.(time.Duration)
+		if !ok {
+			return fmt.Errorf("%w: %T is no duration", ErrInvalidValue, val)
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+	if f.Type() == timeType {
+		t, ok := val.(time.Time)
+		if !ok {
+			return fmt.Errorf("%w: %T is no time", ErrInvalidValue, val)
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if t, ok := val.(time.Time); ok && f.Kind() == reflect.String {
+		f.SetString(FormatValue(t))
+		return nil
+	}
+
+	var err error
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(cast.ToString(val))
+	case reflect.Bool:
+		var b bool
+		if b, err = cast.ToBoolE(val); err == nil {
+			f.SetBool(b)
+		}
+	case reflect.Float32, reflect.Float64:
+		// keeps 21.3 from becoming 21.299999237060547 in a float64
+		if v, ok := val.(float32); ok {
+			val = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		}
+		var n float64
+		if n, err = cast.ToFloat64E(val); err == nil {
+			f.SetFloat(n)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = cast.ToInt64E(val); err == nil {
+			if f.OverflowInt(n) {
+				return fmt.Errorf("%w: %d overflows %s", ErrInvalidValue, n, f.Type())
+			}
+			f.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = cast.ToUint64E(val); err == nil {
+			if f.OverflowUint(n) {
+				return fmt.Errorf("%w: %d overflows %s", ErrInvalidValue, n, f.Type())
+			}
+			f.SetUint(n)
+		}
+	case reflect.Interface:
+		if f.NumMethod() != 0 {
+			return fmt.Errorf("%w: unsupported field type %s", ErrInvalidValue, f.Type())
+		}
+		f.Set(reflect.ValueOf(val))
+	default:
+		return fmt.Errorf("%w: unsupported field type %s", ErrInvalidValue, f.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+	return nil
+}
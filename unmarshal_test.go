@@ -0,0 +1,49 @@
+package luxtronik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshal(t *testing.T) {
+	pm := NewCalculationsMap()
+	pm[CalcFlowTemperature].SetRaw(213)
+	pm[CalcOutdoorTemperature].SetRaw(45)
+	pm[CalcOperationMode].SetRaw(1)
+	pm[CalcHeatingRuntime].SetRaw(7200)
+	pm[CalcCompressorStarts].SetRaw(300)
+
+	var s struct {
+		Flow    float32       `lux:"10"`
+		Outdoor float64       `lux:"name=id_web_temperatur_ta"`
+		Mode    string        `lux:"80"`
+		ModeRaw uint32        `lux:"80,raw"`
+		Runtime time.Duration `lux:"64"`
+		Starts  int           `lux:"57"`
+		Any     any           `lux:"10"`
+		Skipped string
+	}
+	require.NoError(t, Unmarshal(pm, &s))
+	assert.Equal(t, float32(21.3), s.Flow)
+	assert.Equal(t, 4.5, s.Outdoor)
+	assert.Equal(t, "hot water", s.Mode)
+	assert.Equal(t, uint32(1), s.ModeRaw)
+	assert.Equal(t, 2*time.Hour, s.Runtime)
+	assert.Equal(t, 300, s.Starts)
+	assert.Equal(t, float32(21.3), s.Any)
+
+	var bad struct {
+		Missing float32       `lux:"name=ID_Nope"`
+		Small   int8          `lux:"57"`
+		Runtime time.Duration `lux:"10"`
+	}
+	err := Unmarshal(pm, &bad)
+	assert.ErrorIs(t, err, ErrUnknownIndex)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	assert.ErrorContains(t, err, "Unmarshal field Small from ID_WEB_Zaehler_BetrZeitImpVD1: invalid value: 300 overflows int8")
+
+	assert.Error(t, Unmarshal(pm, s))
+}
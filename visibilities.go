@@ -1,7 +1,11 @@
+// Code generated by genmaps from data/visibilities.csv; DO NOT EDIT.
+
 package luxtronik
 
-func NewVisibilitiesMap() DataTypeMap {
+func visibilitiesCatalog() DataTypeMap {
 	return DataTypeMap{
+		// the index number is really important because it assigns a value from
+		// the heat pump to the Base object.
 		0:   NewUnknown("ID_Visi_NieAnzeigen"),
 		1:   NewUnknown("ID_Visi_ImmerAnzeigen"),
 		2:   NewUnknown("ID_Visi_Heizung"),
@@ -384,3 +388,332 @@ func NewVisibilitiesMap() DataTypeMap {
 		379: NewUnknown("Unknown_Visibility_379"),
 	}
 }
+
+// Indexes of visibilitiesCatalog.
+const (
+	VisNieAnzeigen                          = 0   // ID_Visi_NieAnzeigen
+	VisImmerAnzeigen                        = 1   // ID_Visi_ImmerAnzeigen
+	VisHeizung                              = 2   // ID_Visi_Heizung
+	VisBrauwasser                           = 3   // ID_Visi_Brauwasser
+	VisSchwimmbad                           = 4   // ID_Visi_Schwimmbad
+	VisKuhlung                              = 5   // ID_Visi_Kuhlung
+	VisLueftung                             = 6   // ID_Visi_Lueftung
+	VisMK1                                  = 7   // ID_Visi_MK1
+	VisMK2                                  = 8   // ID_Visi_MK2
+	VisThermDesinfekt                       = 9   // ID_Visi_ThermDesinfekt
+	VisZirkulation                          = 10  // ID_Visi_Zirkulation
+	VisKuhlTempSolltempMK1                  = 11  // ID_Visi_KuhlTemp_SolltempMK1
+	VisKuhlTempSolltempMK2                  = 12  // ID_Visi_KuhlTemp_SolltempMK2
+	VisKuhlTempATDiffMK1                    = 13  // ID_Visi_KuhlTemp_ATDiffMK1
+	VisKuhlTempATDiffMK2                    = 14  // ID_Visi_KuhlTemp_ATDiffMK2
+	VisServiceInformation                   = 15  // ID_Visi_Service_Information
+	VisServiceEinstellung                   = 16  // ID_Visi_Service_Einstellung
+	VisServiceSprache                       = 17  // ID_Visi_Service_Sprache
+	VisServiceDatumUhrzeit                  = 18  // ID_Visi_Service_DatumUhrzeit
+	VisServiceAusheiz                       = 19  // ID_Visi_Service_Ausheiz
+	VisServiceAnlagenkonfiguration          = 20  // ID_Visi_Service_Anlagenkonfiguration
+	VisServiceIBNAssistant                  = 21  // ID_Visi_Service_IBNAssistant
+	VisServiceParameterIBNZuruck            = 22  // ID_Visi_Service_ParameterIBNZuruck
+	VisTempVorlauf                          = 23  // ID_Visi_Temp_Vorlauf
+	VisTempRucklauf                         = 24  // ID_Visi_Temp_Rucklauf
+	VisTempRLSoll                           = 25  // ID_Visi_Temp_RL_Soll
+	VisTempRuecklext                        = 26  // ID_Visi_Temp_Ruecklext
+	VisTempHeissgas                         = 27  // ID_Visi_Temp_Heissgas
+	VisTempAussent                          = 28  // ID_Visi_Temp_Aussent
+	VisTempBWIst                            = 29  // ID_Visi_Temp_BW_Ist
+	VisTempBWSoll                           = 30  // ID_Visi_Temp_BW_Soll
+	VisTempWQEin                            = 31  // ID_Visi_Temp_WQ_Ein
+	VisTempKaltekreis                       = 32  // ID_Visi_Temp_Kaltekreis
+	VisTempMK1Vorlauf                       = 33  // ID_Visi_Temp_MK1_Vorlauf
+	VisTempMK1VLSoll                        = 34  // ID_Visi_Temp_MK1VL_Soll
+	VisTempRaumstation                      = 35  // ID_Visi_Temp_Raumstation
+	VisTempMK2Vorlauf                       = 36  // ID_Visi_Temp_MK2_Vorlauf
+	VisTempMK2VLSoll                        = 37  // ID_Visi_Temp_MK2VL_Soll
+	VisTempSolarkoll                        = 38  // ID_Visi_Temp_Solarkoll
+	VisTempSolarsp                          = 39  // ID_Visi_Temp_Solarsp
+	VisTempExtEnerg                         = 40  // ID_Visi_Temp_Ext_Energ
+	VisINASD                                = 41  // ID_Visi_IN_ASD
+	VisINBWT                                = 42  // ID_Visi_IN_BWT
+	VisINEVU                                = 43  // ID_Visi_IN_EVU
+	VisINHD                                 = 44  // ID_Visi_IN_HD
+	VisINMOT                                = 45  // ID_Visi_IN_MOT
+	VisINND                                 = 46  // ID_Visi_IN_ND
+	VisINPEX                                = 47  // ID_Visi_IN_PEX
+	VisINSWT                                = 48  // ID_Visi_IN_SWT
+	VisOUTAbtauventil                       = 49  // ID_Visi_OUT_Abtauventil
+	VisOUTBUP                               = 50  // ID_Visi_OUT_BUP
+	VisOUTFUP1                              = 51  // ID_Visi_OUT_FUP1
+	VisOUTHUP                               = 52  // ID_Visi_OUT_HUP
+	VisOUTMischer1Auf                       = 53  // ID_Visi_OUT_Mischer1Auf
+	VisOUTMischer1Zu                        = 54  // ID_Visi_OUT_Mischer1Zu
+	VisOUTVentilation                       = 55  // ID_Visi_OUT_Ventilation
+	VisOUTVentilBOSUP                       = 56  // ID_Visi_OUT_Ventil_BOSUP
+	VisOUTVerdichter1                       = 57  // ID_Visi_OUT_Verdichter1
+	VisOUTVerdichter2                       = 58  // ID_Visi_OUT_Verdichter2
+	VisOUTZIP                               = 59  // ID_Visi_OUT_ZIP
+	VisOUTZUP                               = 60  // ID_Visi_OUT_ZUP
+	VisOUTZWE1                              = 61  // ID_Visi_OUT_ZWE1
+	VisOUTZWE2SST                           = 62  // ID_Visi_OUT_ZWE2_SST
+	VisOUTZWE3                              = 63  // ID_Visi_OUT_ZWE3
+	VisOUTFUP2                              = 64  // ID_Visi_OUT_FUP2
+	VisOUTSLP                               = 65  // ID_Visi_OUT_SLP
+	VisOUTSUP                               = 66  // ID_Visi_OUT_SUP
+	VisOUTMischer2Auf                       = 67  // ID_Visi_OUT_Mischer2Auf
+	VisOUTMischer2Zu                        = 68  // ID_Visi_OUT_Mischer2Zu
+	VisAblaufZWPSeit                        = 69  // ID_Visi_AblaufZ_WP_Seit
+	VisAblaufZZWE1Seit                      = 70  // ID_Visi_AblaufZ_ZWE1_seit
+	VisAblaufZZWE2Seit                      = 71  // ID_Visi_AblaufZ_ZWE2_seit
+	VisAblaufZZWE3Seit                      = 72  // ID_Visi_AblaufZ_ZWE3_seit
+	VisAblaufZNetzeinv                      = 73  // ID_Visi_AblaufZ_Netzeinv
+	VisAblaufZSSPZeit1                      = 74  // ID_Visi_AblaufZ_SSP_Zeit1
+	VisAblaufZVDStand                       = 75  // ID_Visi_AblaufZ_VD_Stand
+	VisAblaufZHRMZeit                       = 76  // ID_Visi_AblaufZ_HRM_Zeit
+	VisAblaufZHRWZeit                       = 77  // ID_Visi_AblaufZ_HRW_Zeit
+	VisAblaufZTDISeit                       = 78  // ID_Visi_AblaufZ_TDI_seit
+	VisAblaufZSperreBW                      = 79  // ID_Visi_AblaufZ_Sperre_BW
+	VisBstBStdVD1                           = 80  // ID_Visi_Bst_BStdVD1
+	VisBstImpVD1                            = 81  // ID_Visi_Bst_ImpVD1
+	VisBstDEZVD1                            = 82  // ID_Visi_Bst_dEZVD1
+	VisBstBStdVD2                           = 83  // ID_Visi_Bst_BStdVD2
+	VisBstImpVD2                            = 84  // ID_Visi_Bst_ImpVD2
+	VisBstDEZVD2                            = 85  // ID_Visi_Bst_dEZVD2
+	VisBstBStdZWE1                          = 86  // ID_Visi_Bst_BStdZWE1
+	VisBstBStdZWE2                          = 87  // ID_Visi_Bst_BStdZWE2
+	VisBstBStdZWE3                          = 88  // ID_Visi_Bst_BStdZWE3
+	VisBstBStdWP                            = 89  // ID_Visi_Bst_BStdWP
+	VisTextKurzprogramme                    = 90  // ID_Visi_Text_Kurzprogramme
+	VisTextZwangsheizung                    = 91  // ID_Visi_Text_Zwangsheizung
+	VisTextZwangsbrauchwasser               = 92  // ID_Visi_Text_Zwangsbrauchwasser
+	VisTextAbtauen                          = 93  // ID_Visi_Text_Abtauen
+	VisEinstTempRucklBegr                   = 94  // ID_Visi_EinstTemp_RucklBegr
+	VisEinstTempHystereseHR                 = 95  // ID_Visi_EinstTemp_HystereseHR
+	VisEinstTempTRErhmax                    = 96  // ID_Visi_EinstTemp_TRErhmax
+	VisEinstTempFreig2VD                    = 97  // ID_Visi_EinstTemp_Freig2VD
+	VisEinstTempFreigZWE                    = 98  // ID_Visi_EinstTemp_FreigZWE
+	VisEinstTempTluftabt                    = 99  // ID_Visi_EinstTemp_Tluftabt
+	VisEinstTempTDISolltemp                 = 100 // ID_Visi_EinstTemp_TDISolltemp
+	VisEinstTempHystereseBW                 = 101 // ID_Visi_EinstTemp_HystereseBW
+	VisEinstTempVorl2VDBW                   = 102 // ID_Visi_EinstTemp_Vorl2VDBW
+	VisEinstTempTAussenmax                  = 103 // ID_Visi_EinstTemp_TAussenmax
+	VisEinstTempTAussenmin                  = 104 // ID_Visi_EinstTemp_TAussenmin
+	VisEinstTempTWQmin                      = 105 // ID_Visi_EinstTemp_TWQmin
+	VisEinstTempTHGmax                      = 106 // ID_Visi_EinstTemp_THGmax
+	VisEinstTempTLABTEnde                   = 107 // ID_Visi_EinstTemp_TLABTEnde
+	VisEinstTempAbsenkbis                   = 108 // ID_Visi_EinstTemp_Absenkbis
+	VisEinstTempVorlaufmax                  = 109 // ID_Visi_EinstTemp_Vorlaufmax
+	VisEinstTempTDiffEin                    = 110 // ID_Visi_EinstTemp_TDiffEin
+	VisEinstTempTDiffAus                    = 111 // ID_Visi_EinstTemp_TDiffAus
+	VisEinstTempTDiffmax                    = 112 // ID_Visi_EinstTemp_TDiffmax
+	VisEinstTempTEEHeizung                  = 113 // ID_Visi_EinstTemp_TEEHeizung
+	VisEinstTempTEEBrauchw                  = 114 // ID_Visi_EinstTemp_TEEBrauchw
+	VisEinstTempVorl2VDSW                   = 115 // ID_Visi_EinstTemp_Vorl2VDSW
+	VisEinstTempVLMaxMk1                    = 116 // ID_Visi_EinstTemp_VLMaxMk1
+	VisEinstTempVLMaxMk2                    = 117 // ID_Visi_EinstTemp_VLMaxMk2
+	VisPrioriBrauchwasser                   = 118 // ID_Visi_Priori_Brauchwasser
+	VisPrioriHeizung                        = 119 // ID_Visi_Priori_Heizung
+	VisPrioriSchwimmbad                     = 120 // ID_Visi_Priori_Schwimmbad
+	VisSysEinEVUSperre                      = 121 // ID_Visi_SysEin_EVUSperre
+	VisSysEinRaumstation                    = 122 // ID_Visi_SysEin_Raumstation
+	VisSysEinEinbindung                     = 123 // ID_Visi_SysEin_Einbindung
+	VisSysEinMischkreis1                    = 124 // ID_Visi_SysEin_Mischkreis1
+	VisSysEinMischkreis2                    = 125 // ID_Visi_SysEin_Mischkreis2
+	VisSysEinZWE1Art                        = 126 // ID_Visi_SysEin_ZWE1Art
+	VisSysEinZWE1Fkt                        = 127 // ID_Visi_SysEin_ZWE1Fkt
+	VisSysEinZWE2Art                        = 128 // ID_Visi_SysEin_ZWE2Art
+	VisSysEinZWE2Fkt                        = 129 // ID_Visi_SysEin_ZWE2Fkt
+	VisSysEinZWE3Art                        = 130 // ID_Visi_SysEin_ZWE3Art
+	VisSysEinZWE3Fkt                        = 131 // ID_Visi_SysEin_ZWE3Fkt
+	VisSysEinStoerung                       = 132 // ID_Visi_SysEin_Stoerung
+	VisSysEinBrauchwasser1                  = 133 // ID_Visi_SysEin_Brauchwasser1
+	VisSysEinBrauchwasser2                  = 134 // ID_Visi_SysEin_Brauchwasser2
+	VisSysEinBrauchwasser3                  = 135 // ID_Visi_SysEin_Brauchwasser3
+	VisSysEinBrauchwasser4                  = 136 // ID_Visi_SysEin_Brauchwasser4
+	VisSysEinBrauchwasser5                  = 137 // ID_Visi_SysEin_Brauchwasser5
+	VisSysEinBWWPmax                        = 138 // ID_Visi_SysEin_BWWPmax
+	VisSysEinAbtzykmax                      = 139 // ID_Visi_SysEin_Abtzykmax
+	VisSysEinLuftabt                        = 140 // ID_Visi_SysEin_Luftabt
+	VisSysEinLuftAbtmax                     = 141 // ID_Visi_SysEin_LuftAbtmax
+	VisSysEinAbtauen1                       = 142 // ID_Visi_SysEin_Abtauen1
+	VisSysEinAbtauen2                       = 143 // ID_Visi_SysEin_Abtauen2
+	VisSysEinPumpenoptim                    = 144 // ID_Visi_SysEin_Pumpenoptim
+	VisSysEinZusatzpumpe                    = 145 // ID_Visi_SysEin_Zusatzpumpe
+	VisSysEinZugang                         = 146 // ID_Visi_SysEin_Zugang
+	VisSysEinSoledrDurchf                   = 147 // ID_Visi_SysEin_SoledrDurchf
+	VisSysEinUberwachungVD                  = 148 // ID_Visi_SysEin_UberwachungVD
+	VisSysEinRegelungHK                     = 149 // ID_Visi_SysEin_RegelungHK
+	VisSysEinRegelungMK1                    = 150 // ID_Visi_SysEin_RegelungMK1
+	VisSysEinRegelungMK2                    = 151 // ID_Visi_SysEin_RegelungMK2
+	VisSysEinKuhlung                        = 152 // ID_Visi_SysEin_Kuhlung
+	VisSysEinAusheizen                      = 153 // ID_Visi_SysEin_Ausheizen
+	VisSysEinElektrAnode                    = 154 // ID_Visi_SysEin_ElektrAnode
+	VisSysEinSWBBer                         = 155 // ID_Visi_SysEin_SWBBer
+	VisSysEinSWBMin                         = 156 // ID_Visi_SysEin_SWBMin
+	VisSysEinHeizung                        = 157 // ID_Visi_SysEin_Heizung
+	VisSysEinPeriodeMk1                     = 158 // ID_Visi_SysEin_PeriodeMk1
+	VisSysEinLaufzeitMk1                    = 159 // ID_Visi_SysEin_LaufzeitMk1
+	VisSysEinPeriodeMk2                     = 160 // ID_Visi_SysEin_PeriodeMk2
+	VisSysEinLaufzeitMk2                    = 161 // ID_Visi_SysEin_LaufzeitMk2
+	VisSysEinHeizgrenze                     = 162 // ID_Visi_SysEin_Heizgrenze
+	VisEnltHUP                              = 163 // ID_Visi_Enlt_HUP
+	VisEnltZUP                              = 164 // ID_Visi_Enlt_ZUP
+	VisEnltBUP                              = 165 // ID_Visi_Enlt_BUP
+	VisEnltVentilatorBOSUP                  = 166 // ID_Visi_Enlt_Ventilator_BOSUP
+	VisEnltMA1                              = 167 // ID_Visi_Enlt_MA1
+	VisEnltMZ1                              = 168 // ID_Visi_Enlt_MZ1
+	VisEnltZIP                              = 169 // ID_Visi_Enlt_ZIP
+	VisEnltMA2                              = 170 // ID_Visi_Enlt_MA2
+	VisEnltMZ2                              = 171 // ID_Visi_Enlt_MZ2
+	VisEnltSUP                              = 172 // ID_Visi_Enlt_SUP
+	VisEnltSLP                              = 173 // ID_Visi_Enlt_SLP
+	VisEnltFP2                              = 174 // ID_Visi_Enlt_FP2
+	VisEnltLaufzeit                         = 175 // ID_Visi_Enlt_Laufzeit
+	VisAnlgkonfHeizung                      = 176 // ID_Visi_Anlgkonf_Heizung
+	VisAnlgkonfBrauchwarmwasser             = 177 // ID_Visi_Anlgkonf_Brauchwarmwasser
+	VisAnlgkonfSchwimmbad                   = 178 // ID_Visi_Anlgkonf_Schwimmbad
+	VisHeizungBetriebsart                   = 179 // ID_Visi_Heizung_Betriebsart
+	VisHeizungTemperaturPlusMinus           = 180 // ID_Visi_Heizung_TemperaturPlusMinus
+	VisHeizungHeizkurven                    = 181 // ID_Visi_Heizung_Heizkurven
+	VisHeizungZeitschaltprogramm            = 182 // ID_Visi_Heizung_Zeitschaltprogramm
+	VisHeizungHeizgrenze                    = 183 // ID_Visi_Heizung_Heizgrenze
+	VisMitteltemperatur                     = 184 // ID_Visi_Mitteltemperatur
+	VisDataenlogger                         = 185 // ID_Visi_Dataenlogger
+	VisSprachenDEUTSCH                      = 186 // ID_Visi_Sprachen_DEUTSCH
+	VisSprachenENGLISH                      = 187 // ID_Visi_Sprachen_ENGLISH
+	VisSprachenFRANCAIS                     = 188 // ID_Visi_Sprachen_FRANCAIS
+	VisSprachenNORWAY                       = 189 // ID_Visi_Sprachen_NORWAY
+	VisSprachenTCHECH                       = 190 // ID_Visi_Sprachen_TCHECH
+	VisSprachenITALIANO                     = 191 // ID_Visi_Sprachen_ITALIANO
+	VisSprachenNEDERLANDS                   = 192 // ID_Visi_Sprachen_NEDERLANDS
+	VisSprachenSVENSKA                      = 193 // ID_Visi_Sprachen_SVENSKA
+	VisSprachenPOLSKI                       = 194 // ID_Visi_Sprachen_POLSKI
+	VisSprachenMAGYARUL                     = 195 // ID_Visi_Sprachen_MAGYARUL
+	VisErrorUSBspeichern                    = 196 // ID_Visi_ErrorUSBspeichern
+	VisBstBStdHz                            = 197 // ID_Visi_Bst_BStdHz
+	VisBstBStdBW                            = 198 // ID_Visi_Bst_BStdBW
+	VisBstBStdKue                           = 199 // ID_Visi_Bst_BStdKue
+	VisServiceSystemsteuerung               = 200 // ID_Visi_Service_Systemsteuerung
+	VisServiceSystemsteuerungContrast       = 201 // ID_Visi_Service_Systemsteuerung_Contrast
+	VisServiceSystemsteuerungWebserver      = 202 // ID_Visi_Service_Systemsteuerung_Webserver
+	VisServiceSystemsteuerungIPAdresse      = 203 // ID_Visi_Service_Systemsteuerung_IPAdresse
+	VisServiceSystemsteuerungFernwartung    = 204 // ID_Visi_Service_Systemsteuerung_Fernwartung
+	VisParalleleschaltung                   = 205 // ID_Visi_Paralleleschaltung
+	VisSysEinParalleleschaltung             = 206 // ID_Visi_SysEin_Paralleleschaltung
+	VisSprachenDANSK                        = 207 // ID_Visi_Sprachen_DANSK
+	VisSprachenPORTUGES                     = 208 // ID_Visi_Sprachen_PORTUGES
+	VisHeizkurveHeizung                     = 209 // ID_Visi_Heizkurve_Heizung
+	VisSysEinMischkreis3                    = 210 // ID_Visi_SysEin_Mischkreis3
+	VisMK3                                  = 211 // ID_Visi_MK3
+	VisTempMK3Vorlauf                       = 212 // ID_Visi_Temp_MK3_Vorlauf
+	VisTempMK3VLSoll                        = 213 // ID_Visi_Temp_MK3VL_Soll
+	VisOUTMischer3Auf                       = 214 // ID_Visi_OUT_Mischer3Auf
+	VisOUTMischer3Zu                        = 215 // ID_Visi_OUT_Mischer3Zu
+	VisSysEinRegelungMK3                    = 216 // ID_Visi_SysEin_RegelungMK3
+	VisSysEinPeriodeMk3                     = 217 // ID_Visi_SysEin_PeriodeMk3
+	VisSysEinLaufzeitMk3                    = 218 // ID_Visi_SysEin_LaufzeitMk3
+	VisSysEinKuhlZeitEin                    = 219 // ID_Visi_SysEin_Kuhl_Zeit_Ein
+	VisSysEinKuhlZeitAus                    = 220 // ID_Visi_SysEin_Kuhl_Zeit_Aus
+	VisAblaufZAbtauIn                       = 221 // ID_Visi_AblaufZ_AbtauIn
+	VisWaermemengeWS                        = 222 // ID_Visi_Waermemenge_WS
+	VisWaermemengeWQ                        = 223 // ID_Visi_Waermemenge_WQ
+	VisEnltMA3                              = 224 // ID_Visi_Enlt_MA3
+	VisEnltMZ3                              = 225 // ID_Visi_Enlt_MZ3
+	VisEnltFP3                              = 226 // ID_Visi_Enlt_FP3
+	VisOUTFUP3                              = 227 // ID_Visi_OUT_FUP3
+	VisTempRaumstation2                     = 228 // ID_Visi_Temp_Raumstation2
+	VisTempRaumstation3                     = 229 // ID_Visi_Temp_Raumstation3
+	VisBstBStdSW                            = 230 // ID_Visi_Bst_BStdSW
+	VisSprachenLITAUISCH                    = 231 // ID_Visi_Sprachen_LITAUISCH
+	VisSprachenESTNICH                      = 232 // ID_Visi_Sprachen_ESTNICH
+	VisSysEinFernwartung                    = 233 // ID_Visi_SysEin_Fernwartung
+	VisSprachenSLOVENISCH                   = 234 // ID_Visi_Sprachen_SLOVENISCH
+	VisEinstTempTAEG                        = 235 // ID_Visi_EinstTemp_TA_EG
+	VisEinstTVLmaxEG                        = 236 // ID_Visi_Einst_TVLmax_EG
+	VisSysEinPoptNachlauf                   = 237 // ID_Visi_SysEin_PoptNachlauf
+	VisRFVKKuehlin                          = 238 // ID_Visi_RFV_K_Kuehlin
+	VisSysEinEffizienzpumpeNom              = 239 // ID_Visi_SysEin_EffizienzpumpeNom
+	VisSysEinEffizienzpumpeMin              = 240 // ID_Visi_SysEin_EffizienzpumpeMin
+	VisSysEinEffizienzpumpe                 = 241 // ID_Visi_SysEin_Effizienzpumpe
+	VisSysEinWaermemenge                    = 242 // ID_Visi_SysEin_Waermemenge
+	VisServiceWMZEffizienz                  = 243 // ID_Visi_Service_WMZ_Effizienz
+	VisSysEinWmVersorgungKorrektur          = 244 // ID_Visi_SysEin_Wm_Versorgung_Korrektur
+	VisSysEinWmAuswertungKorrektur          = 245 // ID_Visi_SysEin_Wm_Auswertung_Korrektur
+	VisINAnalogIn                           = 246 // ID_Visi_IN_AnalogIn
+	VisEinsSNEingabe                        = 247 // ID_Visi_Eins_SN_Eingabe
+	VisOUTAnalog1                           = 248 // ID_Visi_OUT_Analog_1
+	VisOUTAnalog2                           = 249 // ID_Visi_OUT_Analog_2
+	VisSolar                                = 250 // ID_Visi_Solar
+	VisSysEinSolar                          = 251 // ID_Visi_SysEin_Solar
+	VisEinstTempTDiffKollmax                = 252 // ID_Visi_EinstTemp_TDiffKollmax
+	VisAblaufZHGSperre                      = 253 // ID_Visi_AblaufZ_HG_Sperre
+	VisSysEinAktKuehlung                    = 254 // ID_Visi_SysEin_Akt_Kuehlung
+	VisSysEinVorlaufVBO                     = 255 // ID_Visi_SysEin_Vorlauf_VBO
+	VisEinstKRHyst                          = 256 // ID_Visi_Einst_KRHyst
+	VisEinstAktKuehlSpeicherMin             = 257 // ID_Visi_Einst_Akt_Kuehl_Speicher_min
+	VisEinstAktKuehlFreigWQE                = 258 // ID_Visi_Einst_Akt_Kuehl_Freig_WQE
+	VisSysEinAbtZykMin                      = 259 // ID_Visi_SysEin_AbtZykMin
+	VisSysEinVD2ZeitMin                     = 260 // ID_Visi_SysEin_VD2_Zeit_Min
+	VisEinstTempHystereseHRVerkuerzt        = 261 // ID_Visi_EinstTemp_Hysterese_HR_verkuerzt
+	VisEinstLufFeuchteschutzAkt             = 262 // ID_Visi_Einst_Luf_Feuchteschutz_akt
+	VisEinstLufReduziertAkt                 = 263 // ID_Visi_Einst_Luf_Reduziert_akt
+	VisEinstLufNennlueftungAkt              = 264 // ID_Visi_Einst_Luf_Nennlueftung_akt
+	VisEinstLufIntensivlueftungAkt          = 265 // ID_Visi_Einst_Luf_Intensivlueftung_akt
+	VisTemperaturLueftungZuluft             = 266 // ID_Visi_Temperatur_Lueftung_Zuluft
+	VisTemperaturLueftungAbluft             = 267 // ID_Visi_Temperatur_Lueftung_Abluft
+	VisOUTAnalog3                           = 268 // ID_Visi_OUT_Analog_3
+	VisOUTAnalog4                           = 269 // ID_Visi_OUT_Analog_4
+	VisINAnalog2                            = 270 // ID_Visi_IN_Analog_2
+	VisINAnalog3                            = 271 // ID_Visi_IN_Analog_3
+	VisINSAX                                = 272 // ID_Visi_IN_SAX
+	VisOUTVZU                               = 273 // ID_Visi_OUT_VZU
+	VisOUTVAB                               = 274 // ID_Visi_OUT_VAB
+	VisOUTVSK                               = 275 // ID_Visi_OUT_VSK
+	VisOUTFRH                               = 276 // ID_Visi_OUT_FRH
+	VisKuhlTempSolltempMK3                  = 277 // ID_Visi_KuhlTemp_SolltempMK3
+	VisKuhlTempATDiffMK3                    = 278 // ID_Visi_KuhlTemp_ATDiffMK3
+	VisINSPL                                = 279 // ID_Visi_IN_SPL
+	VisSysEinLueftungsstufen                = 280 // ID_Visi_SysEin_Lueftungsstufen
+	VisSysEinMeldungTDI                     = 281 // ID_Visi_SysEin_Meldung_TDI
+	VisSysEinTypWZW                         = 282 // ID_Visi_SysEin_Typ_WZW
+	VisBACnet                               = 283 // ID_Visi_BACnet
+	VisSprachenSLOWAKISCH                   = 284 // ID_Visi_Sprachen_SLOWAKISCH
+	VisSprachenLETTISCH                     = 285 // ID_Visi_Sprachen_LETTISCH
+	VisSprachenFINNISCH                     = 286 // ID_Visi_Sprachen_FINNISCH
+	VisKalibrierungLWD                      = 287 // ID_Visi_Kalibrierung_LWD
+	VisINDurchfluss                         = 288 // ID_Visi_IN_Durchfluss
+	VisLINANSAUGVERDICHTER                  = 289 // ID_Visi_LIN_ANSAUG_VERDICHTER
+	VisLINVDH                               = 290 // ID_Visi_LIN_VDH
+	VisLINUH                                = 291 // ID_Visi_LIN_UH
+	VisLINDruck                             = 292 // ID_Visi_LIN_Druck
+	VisEinstSollwertTRLKuehlen              = 293 // ID_Visi_Einst_Sollwert_TRL_Kuehlen
+	VisEntlExVentil                         = 294 // ID_Visi_Entl_ExVentil
+	VisEinstMediumWaermequelle              = 295 // ID_Visi_Einst_Medium_Waermequelle
+	VisEinstMultispeicher                   = 296 // ID_Visi_Einst_Multispeicher
+	VisEinstMinimaleRuecklaufsolltemperatur = 297 // ID_Visi_Einst_Minimale_Ruecklaufsolltemperatur
+	VisEinstPKuehlTime                      = 298 // ID_Visi_Einst_PKuehlTime
+	VisSprachenTUERKISCH                    = 299 // ID_Visi_Sprachen_TUERKISCH
+	VisRBE                                  = 300 // ID_Visi_RBE
+	VisEinstLufStufenFaktor                 = 301 // ID_Visi_Einst_Luf_Stufen_Faktor
+	VisFreigabeZeitZWE                      = 302 // ID_Visi_Freigabe_Zeit_ZWE
+	VisEinstMinVLKuehl                      = 303 // ID_Visi_Einst_min_VL_Kuehl
+	VisZWE1                                 = 304 // ID_Visi_ZWE1
+	VisZWE2                                 = 305 // ID_Visi_ZWE2
+	VisZWE3                                 = 306 // ID_Visi_ZWE3
+	VisSEC                                  = 307 // ID_Visi_SEC
+	VisHZIO                                 = 308 // ID_Visi_HZIO
+	VisWPIO                                 = 309 // ID_Visi_WPIO
+	VisLINANSAUGVERDAMPFER                  = 310 // ID_Visi_LIN_ANSAUG_VERDAMPFER
+	VisLINMULTI1                            = 311 // ID_Visi_LIN_MULTI1
+	VisLINMULTI2                            = 312 // ID_Visi_LIN_MULTI2
+	VisEinstLeistungZWE                     = 313 // ID_Visi_Einst_Leistung_ZWE
+	VisSprachenESPANOL                      = 314 // ID_Visi_Sprachen_ESPANOL
+	VisTempBWOben                           = 315 // ID_Visi_Temp_BW_oben
+	VisMAXIO                                = 316 // ID_Visi_MAXIO
+	VisOUTAbtauwunsch                       = 317 // ID_Visi_OUT_Abtauwunsch
+	VisSmartGrid                            = 318 // ID_Visi_SmartGrid
+	VisDrehzahlgeregelt                     = 319 // ID_Visi_Drehzahlgeregelt
+	VisP155Inverter                         = 320 // ID_Visi_P155_Inverter
+	VisLeistungsfreigabe                    = 321 // ID_Visi_Leistungsfreigabe
+	VisEinstVorlAktKuehl                    = 322 // ID_Visi_Einst_Vorl_akt_Kuehl
+	VisEinstAbtauenImWarmwasser             = 323 // ID_Visi_Einst_Abtauen_im_Warmwasser
+	VisWaermemengeZWE                       = 324 // ID_Visi_Waermemenge_ZWE
+)
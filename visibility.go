@@ -0,0 +1,58 @@
+package luxtronik
+
+import "strings"
+
+// WithVisibility sets the luxtronik name of the visibility which tells
+// whether the controller shows the value, e.g. ID_Visi_Temp_Vorlauf. It
+// returns b for use in map definitions. The definition is copied like by
+// WithRange.
+func (b *Base) WithVisibility(name string) *Base {
+	d := *b.Definition
+	d.visibility = name
+	b.Definition = &d
+	return b
+}
+
+// Visibility returns the luxtronik name of the visibility of the value,
+// empty if the value has none.
+func (d *Definition) Visibility() string {
+	return d.visibility
+}
+
+// Hidden reports whether the controller declared the value invisible with
+// the last ApplyVisibilities, e.g. the sensor of a missing solar collector.
+func (b *Base) Hidden() bool {
+	return b.hidden
+}
+
+// ApplyVisibilities marks the entries of pm as hidden whose visibility is
+// zero in vis, read with ReadVisibilities. Entries without a visibility or
+// whose visibility is unknown stay visible. It returns the number of hidden
+// entries.
+func (pm DataTypeMap) ApplyVisibilities(vis DataTypeMap) int {
+	visible := make(map[string]bool, len(vis))
+	for _, v := range vis {
+		visible[strings.ToLower(v.luxtronikName)] = v.reading.Raw != 0
+	}
+	n := 0
+	for _, b := range pm {
+		shown, known := visible[strings.ToLower(b.visibility)]
+		b.hidden = b.visibility != "" && known && !shown
+		if b.hidden {
+			n++
+		}
+	}
+	return n
+}
+
+// Visible returns a map of the entries which are not hidden. The entries are
+// shared with pm.
+func (pm DataTypeMap) Visible() DataTypeMap {
+	v := make(DataTypeMap, len(pm))
+	for idx, b := range pm {
+		if !b.hidden {
+			v[idx] = b
+		}
+	}
+	return v
+}
@@ -0,0 +1,48 @@
+package luxtronik
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataTypeMap_ApplyVisibilities(t *testing.T) {
+	vis := NewVisibilitiesMap()
+	vis[VisTempVorlauf].SetRaw(1)
+	vis[VisTempSolarkoll].SetRaw(0)
+
+	pm := NewCalculationsMap()
+	assert.Equal(t, "ID_Visi_Temp_Solarkoll", pm[CalcTemperaturTSK].Visibility())
+	n := pm.ApplyVisibilities(vis)
+	assert.Positive(t, n)
+	assert.False(t, pm[CalcFlowTemperature].Hidden())
+	assert.True(t, pm[CalcTemperaturTSK].Hidden())
+	assert.False(t, pm[CalcHeatQuantityHeating].Hidden(), "values without visibility stay visible")
+
+	visible := pm.Visible()
+	assert.Len(t, visible, len(pm)-n)
+	assert.NotContains(t, visible, CalcTemperaturTSK)
+}
+
+func TestPoller_HideInvisible(t *testing.T) {
+	hp := newMockHeatPump(t)
+	hp.visibilities[VisTempVorlauf] = 1
+
+	var got DataTypeMap
+	p := NewPoller(MustNewClient(hp.addr(), Options{}), PollerOptions{
+		Blocks:        []string{BlockCalculations},
+		HideInvisible: true,
+	}, SinkFunc(func(_ context.Context, _ string, _ time.Time, block string, pm DataTypeMap) error {
+		assert.Equal(t, BlockCalculations, block, "the visibilities are read but not forwarded")
+		got = pm
+		return nil
+	}))
+	defer p.Close()
+
+	require.NoError(t, p.Poll(context.Background()))
+	assert.Contains(t, got, CalcFlowTemperature)
+	assert.NotContains(t, got, CalcTemperaturTSK)
+}
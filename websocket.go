@@ -0,0 +1,272 @@
+package luxtronik
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// WebSocketPort is the port of the web interface of Luxtronik 2.1
+// controllers.
+const WebSocketPort = "8214"
+
+// webSocketProtocol is the subprotocol the controller insists on.
+const webSocketProtocol = "Lux_WS"
+
+// WebItem is a value shown on a page of the web interface.
+type WebItem struct {
+	// Path names the value by the pages leading to it, e.g.
+	// Informationen/Temperaturen/Vorlauf.
+	Path string
+	// ID is the id of the value on the controller, e.g. 0x4816ac.
+	ID string
+	// Value is the text as shown, e.g. 31.2°C.
+	Value string
+}
+
+// WebField locates a value of the web interface in a block.
+type WebField struct {
+	Block string
+	Index int
+}
+
+// DefaultWebFields maps the paths of the German web interface to the
+// calculations.
+var DefaultWebFields = map[string]WebField{
+	"Informationen/Temperaturen/Vorlauf":          {BlockCalculations, CalcFlowTemperature},
+	"Informationen/Temperaturen/Rücklauf":         {BlockCalculations, CalcReturnTemperature},
+	"Informationen/Temperaturen/Rückl.-Soll":      {BlockCalculations, CalcSollwertTRLHZ},
+	"Informationen/Temperaturen/Heissgas":         {BlockCalculations, CalcTemperaturTHG},
+	"Informationen/Temperaturen/Außentemperatur":  {BlockCalculations, CalcOutdoorTemperature},
+	"Informationen/Temperaturen/Mitteltemperatur": {BlockCalculations, CalcMitteltemperatur},
+	"Informationen/Temperaturen/Warmwasser-Ist":   {BlockCalculations, CalcHotWaterTemperature},
+	"Informationen/Temperaturen/Warmwasser-Soll":  {BlockCalculations, CalcHotWaterTarget},
+	"Informationen/Temperaturen/Wärmequelle-Ein":  {BlockCalculations, CalcSourceInTemperature},
+	"Informationen/Temperaturen/Wärmequelle-Aus":  {BlockCalculations, CalcTemperaturTWA},
+}
+
+// WebSocketOptions configure a WebSocketSource.
+type WebSocketOptions struct {
+	// Alias names the heat pump in logs and sinks, defaults to the host.
+	Alias string
+	// Password of the web interface as set on the controller.
+	Password string
+	// Fields maps the paths of the values to the blocks, nil means
+	// DefaultWebFields. Values without a field are only returned by Items.
+	Fields      map[string]WebField
+	DialTimeout time.Duration
+	Logger      *zap.Logger
+}
+
+// WebSocketSource reads the values from the web interface of Luxtronik 2.1
+// controllers, which also shows values not available on DefaultPort. It
+// implements DataSource for the values mapped by WebSocketOptions.Fields,
+// the others are returned by Items.
+type WebSocketSource struct {
+	addr string
+	opts WebSocketOptions
+	log  *zap.Logger
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	// pages are the ids and names of the top level pages
+	pages []webItem
+}
+
+var _ DataSource = (*WebSocketSource)(nil)
+
+// NewWebSocketSource connects lazily to the web interface at addr, which
+// defaults to WebSocketPort.
+func NewWebSocketSource(addr string, opts WebSocketOptions) (*WebSocketSource, error) {
+	addr, err := normalizeAddr(addr, WebSocketPort)
+	if err != nil {
+		return nil, fmt.Errorf("NewWebSocketSource: %w", err)
+	}
+	if opts.Fields == nil {
+		opts.Fields = DefaultWebFields
+	}
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	log := opts.Logger
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return &WebSocketSource{addr: addr, opts: opts, log: log.With(zap.String("addr", addr))}, nil
+}
+
+func (w *WebSocketSource) Name() string {
+	if w.opts.Alias != "" {
+		return w.opts.Alias
+	}
+	host, _, _ := net.SplitHostPort(w.addr)
+	return host
+}
+
+// Items returns all values of all pages. It connects and logs in if needed,
+// a failed request closes the connection.
+func (w *WebSocketSource) Items(ctx context.Context) ([]WebItem, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	items, err := w.items(ctx)
+	if err != nil {
+		w.close()
+		return nil, fmt.Errorf("WebSocketSource.Items: %w", err)
+	}
+	return items, nil
+}
+
+func (w *WebSocketSource) items(ctx context.Context) ([]WebItem, error) {
+	if err := w.connect(ctx); err != nil {
+		return nil, err
+	}
+	var items []WebItem
+	for _, page := range w.pages {
+		var content webItem
+		if err := w.request(ctx, "GET;"+page.ID, &content); err != nil {
+			return nil, fmt.Errorf("page %s: %w", page.Name, err)
+		}
+		items = content.flatten(page.Name, items)
+	}
+	return items, nil
+}
+
+// ReadRaw converts the values mapped to the block of cmd into raw values,
+// see WebSocketOptions.Fields. Unmapped indexes read as zero. The web
+// interface has no visibilities, they return errors.ErrUnsupported.
+func (w *WebSocketSource) ReadRaw(ctx context.Context, cmd int32) ([]uint32, error) {
+	block, ok := blockNames[cmd]
+	if !ok {
+		return nil, fmt.Errorf("WebSocketSource.ReadRaw unknown command %d: %w", cmd, ErrInvalidCommand)
+	}
+	if cmd == VisibilitiesRead {
+		return nil, fmt.Errorf("WebSocketSource.ReadRaw %s: %w", block, errors.ErrUnsupported)
+	}
+	items, err := w.Items(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := newBlockMap(block)
+	raw := make([]uint32, len(pm))
+	for _, item := range items {
+		f, ok := w.opts.Fields[item.Path]
+		if !ok || f.Block != block {
+			continue
+		}
+		b, ok := pm[f.Index]
+		if !ok {
+			continue
+		}
+		v, err := b.toRaw(parseWebValue(item.Value))
+		if err != nil {
+			w.log.Debug("web value not converted", zap.String("path", item.Path), zap.String("value", item.Value), zap.Error(err))
+			continue
+		}
+		raw[f.Index] = v
+	}
+	return raw, nil
+}
+
+// Close closes the connection, the next read connects again.
+func (w *WebSocketSource) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.close()
+}
+
+func (w *WebSocketSource) close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn, w.pages = nil, nil
+	return err
+}
+
+// connect dials and logs in, the answer to the login lists the pages.
+func (w *WebSocketSource) connect(ctx context.Context) error {
+	if w.conn != nil {
+		return nil
+	}
+	d := websocket.Dialer{HandshakeTimeout: w.opts.DialTimeout, Subprotocols: []string{webSocketProtocol}}
+	conn, _, err := d.DialContext(ctx, "ws://"+w.addr+"/", nil)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	w.conn = conn
+	var nav webItem
+	if err := w.request(ctx, "LOGIN;"+w.opts.Password, &nav); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	w.pages = nav.Items
+	w.log.Debug("logged in to web interface", zap.Int("pages", len(w.pages)))
+	return nil
+}
+
+// request sends cmd and decodes the XML answer into v.
+func (w *WebSocketSource) request(ctx context.Context, cmd string, v any) error {
+	deadline := time.Now().Add(w.opts.DialTimeout)
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	}
+	_ = w.conn.SetWriteDeadline(deadline)
+	_ = w.conn.SetReadDeadline(deadline)
+	if err := w.conn.WriteMessage(websocket.TextMessage, []byte(cmd)); err != nil {
+		return err
+	}
+	_, msg, err := w.conn.ReadMessage()
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(msg, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+	return nil
+}
+
+// webItem is an entry of the XML pages, either a page with items or a value.
+type webItem struct {
+	ID    string    `xml:"id,attr"`
+	Name  string    `xml:"name"`
+	Value *string   `xml:"value"`
+	Items []webItem `xml:"item"`
+}
+
+// flatten appends the values below i with their paths to items.
+func (i webItem) flatten(path string, items []WebItem) []WebItem {
+	for _, c := range i.Items {
+		p := path + "/" + c.Name
+		if c.Value != nil {
+			items = append(items, WebItem{Path: p, ID: c.ID, Value: *c.Value})
+		}
+		items = c.flatten(p, items)
+	}
+	return items
+}
+
+// parseWebValue returns the number of a value like 31.2°C or 4,5 K, other
+// values as they are.
+func parseWebValue(s string) any {
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == ',' || r == '-' || r == '+')
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(s[:end], ",", "."), 64); err == nil {
+		return f
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package luxtronik
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockWebInterface serves the web interface of a controller with the
+// password secret.
+func newMockWebInterface(t *testing.T) string {
+	t.Helper()
+	pages := map[string]string{
+		"0x1": `<Content><item id='0x10'><name>Temperaturen</name>` +
+			`<item id='0x11'><name>Vorlauf</name><value>31.2°C</value></item>` +
+			`<item id='0x12'><name>Außentemperatur</name><value>-4,5°C</value></item>` +
+			`<item id='0x13'><name>Warmwasser-Ist</name><value>---</value></item>` +
+			`</item></Content>`,
+		"0x2": `<Content><item id='0x20'><name>Anlage</name>` +
+			`<item id='0x21'><name>Solltemperatur</name><value>48.0°C</value></item>` +
+			`</item></Content>`,
+	}
+	upgrader := websocket.Upgrader{Subprotocols: []string{webSocketProtocol}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			cmd, arg, _ := strings.Cut(string(msg), ";")
+			var answer string
+			switch {
+			case cmd == "LOGIN" && arg == "secret":
+				answer = `<Navigation id='0x0'><item id='0x1'><name>Informationen</name></item>` +
+					`<item id='0x2'><name>Einstellungen</name></item></Navigation>`
+			case cmd == "GET":
+				answer = pages[arg]
+			}
+			if answer == "" {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(answer)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestWebSocketSource(t *testing.T) {
+	addr := newMockWebInterface(t)
+	src, err := NewWebSocketSource(addr, WebSocketOptions{Password: "secret"})
+	require.NoError(t, err)
+	defer src.Close()
+	assert.Equal(t, "127.0.0.1", src.Name())
+
+	items, err := src.Items(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []WebItem{
+		{Path: "Informationen/Temperaturen/Vorlauf", ID: "0x11", Value: "31.2°C"},
+		{Path: "Informationen/Temperaturen/Außentemperatur", ID: "0x12", Value: "-4,5°C"},
+		{Path: "Informationen/Temperaturen/Warmwasser-Ist", ID: "0x13", Value: "---"},
+		{Path: "Einstellungen/Anlage/Solltemperatur", ID: "0x21", Value: "48.0°C"},
+	}, items)
+
+	raw, err := src.ReadRaw(context.Background(), CalculationsRead)
+	require.NoError(t, err)
+	pm := NewCalculationsMap()
+	require.NoError(t, pm.SetRawValues(raw))
+	assert.Equal(t, float32(31.2), pm[CalcFlowTemperature].FromHeatPump())
+	assert.Equal(t, float32(-4.5), pm[CalcOutdoorTemperature].FromHeatPump())
+	assert.Zero(t, pm[CalcHotWaterTemperature].RawValue())
+
+	_, err = src.ReadRaw(context.Background(), VisibilitiesRead)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	bad, err := NewWebSocketSource(addr, WebSocketOptions{Password: "wrong"})
+	require.NoError(t, err)
+	_, err = bad.Items(context.Background())
+	assert.ErrorContains(t, err, "login")
+}
+
+func TestParseWebValue(t *testing.T) {
+	assert.Equal(t, 31.2, parseWebValue("31.2°C"))
+	assert.Equal(t, 4.5, parseWebValue(" 4,5 K"))
+	assert.Equal(t, "Automatik", parseWebValue("Automatik"))
+}
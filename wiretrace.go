@@ -0,0 +1,27 @@
+package luxtronik
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const (
+	traceSent     = ">"
+	traceReceived = "<"
+)
+
+// traceWire writes b to Options.TraceWriter, received chunks with the time
+// since the request. The dump is a single write so that the traces of
+// several clients sharing the writer do not interleave.
+func (c *Client) traceWire(dir string, b []byte) {
+	w := c.opts.TraceWriter
+	if w == nil {
+		return
+	}
+	head := fmt.Sprintf("%s %s %s %d bytes", time.Now().Format("15:04:05.000000"), c.Name(), dir, len(b))
+	if dir == traceReceived && !c.requested.IsZero() {
+		head += fmt.Sprintf(" after %s", time.Since(c.requested).Round(time.Microsecond))
+	}
+	_, _ = fmt.Fprintf(w, "%s\n%s", head, hex.Dump(b))
+}
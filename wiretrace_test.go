@@ -0,0 +1,25 @@
+package luxtronik
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TraceWriter(t *testing.T) {
+	hp := newMockHeatPump(t)
+	var buf bytes.Buffer
+	c := MustNewClient(hp.addr(), Options{Alias: "cellar", TraceWriter: &buf})
+	defer c.Close()
+
+	_, err := c.ReadRaw(context.Background(), VisibilitiesRead)
+	require.NoError(t, err)
+
+	trace := buf.String()
+	assert.Contains(t, trace, " cellar > 8 bytes\n00000000  00 00 0b bd 00 00 00 00 ")
+	assert.Regexp(t, ` cellar < 4 bytes after \S+\n00000000  00 00 0b bd `, trace)
+	assert.Regexp(t, ` cellar < \d+ bytes after \S+\n00000000  00 00 00 00`, trace)
+}